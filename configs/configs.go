@@ -3,10 +3,16 @@ package configs
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+const (
+	DefaultAccessExpiration  = 15 * time.Minute
+	DefaultRefreshExpiration = 30 * 24 * time.Hour
+)
+
 type Configs struct {
 	PostgreSQL PostgreSQL
 	JWT        JWT
@@ -31,7 +37,9 @@ type PostgreSQL struct {
 }
 
 type JWT struct {
-	Secret string
+	Secret            string
+	AccessExpiration  time.Duration
+	RefreshExpiration time.Duration
 }
 
 type Supabase struct {
@@ -71,7 +79,9 @@ func LoadConfigs() *Configs {
 			Port: os.Getenv("APP_PORT"),
 		},
 		JWT: JWT{
-			Secret: os.Getenv("JWT_SECRET"),
+			Secret:            os.Getenv("JWT_SECRET"),
+			AccessExpiration:  getDuration("JWT_ACCESS_EXPIRATION", DefaultAccessExpiration),
+			RefreshExpiration: getDuration("JWT_REFRESH_EXPIRATION", DefaultRefreshExpiration),
 		},
 		Supabase: Supabase{
 			URL:    os.Getenv("SUPABASE_URL"),
@@ -89,3 +99,18 @@ func LoadConfigs() *Configs {
 		},
 	}
 }
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration for %s, using default %s", key, fallback)
+		return fallback
+	}
+
+	return duration
+}
@@ -3,9 +3,13 @@ package main
 import (
 	"log"
 	"math"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/XzerozZ/Kasian_Phrom_BE/configs"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/servers"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/socket"
 	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/database"
 	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
 
@@ -22,6 +26,20 @@ func main() {
 	utils.StartScheduler()
 	servers.SetupRoutes(app, config.JWT, config.Supabase, config.Mail, config.Recommend)
 	serverAddress := config.App.Host + ":" + config.App.Port
+	go func() {
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+		<-quit
+
+		log.Println("Shutting down server...")
+		socket.Shutdown()
+		if err := app.Shutdown(); err != nil {
+			log.Printf("Error shutting down server: %v", err)
+		}
+	}()
+
 	log.Printf("Server is running on %s", serverAddress)
-	log.Fatal(app.Listen(serverAddress))
+	if err := app.Listen(serverAddress); err != nil {
+		log.Fatal(err)
+	}
 }
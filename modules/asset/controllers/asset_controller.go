@@ -2,7 +2,6 @@ package controllers
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/asset/usecases"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
@@ -69,7 +68,17 @@ func (c *AssetController) CreateAssetHandler(ctx *fiber.Ctx) error {
 
 func (c *AssetController) GetAssetByIDHandler(ctx *fiber.Ctx) error {
 	id := ctx.Params("id")
-	data, err := c.assetusecase.GetAssetByID(id)
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	data, err := c.assetusecase.GetAssetByID(id, userID)
 	if err != nil {
 		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
 			"status":      fiber.ErrNotFound.Message,
@@ -212,12 +221,12 @@ func (c *AssetController) DeleteAssetByIDHandler(ctx *fiber.Ctx) error {
 
 	transfers = make([]entities.TransferRequest, 0, len(types))
 	for i := 0; i < len(types); i++ {
-		amount, err := strconv.ParseFloat(amounts[i], 64)
+		amount, err := entities.ParseMoney(amounts[i])
 		if err != nil {
 			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"status":      "Error",
 				"status_code": fiber.StatusBadRequest,
-				"message":     "Invalid amount format, must be a valid float64",
+				"message":     "Invalid amount format, must be a valid number",
 				"result":      nil,
 			})
 		}
@@ -226,7 +235,7 @@ func (c *AssetController) DeleteAssetByIDHandler(ctx *fiber.Ctx) error {
 			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"status":      "Error",
 				"status_code": fiber.StatusBadRequest,
-				"message":     fmt.Sprintf("Amount cannot be negative at index %d: %f", i, amount),
+				"message":     fmt.Sprintf("Amount cannot be negative at index %d: %s", i, amount),
 				"result":      nil,
 			})
 		}
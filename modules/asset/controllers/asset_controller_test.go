@@ -24,7 +24,7 @@ func TestAssetHandlers(t *testing.T) {
 		asset := entities.Asset{
 			Name:      "House",
 			Type:      "Car",
-			TotalCost: 10000,
+			TotalCost: entities.Baht(10000),
 			EndYear:   "2026",
 		}
 
@@ -33,7 +33,7 @@ func TestAssetHandlers(t *testing.T) {
 			ID:        "ASSET001",
 			Name:      "House",
 			Type:      "Car",
-			TotalCost: 10000,
+			TotalCost: entities.Baht(10000),
 			EndYear:   "2026",
 			UserID:    "user123",
 		}
@@ -70,7 +70,7 @@ func TestAssetHandlers(t *testing.T) {
 		asset := entities.Asset{
 			Name:      "House",
 			Type:      "Car",
-			TotalCost: 10000,
+			TotalCost: entities.Baht(10000),
 			EndYear:   "2026",
 		}
 
@@ -94,7 +94,7 @@ func TestAssetHandlers(t *testing.T) {
 
 	t.Run("CreateAssetHandler - Missing Name", func(t *testing.T) {
 		asset := entities.Asset{
-			TotalCost: 10000,
+			TotalCost: entities.Baht(10000),
 			Type:      "Car",
 			EndYear:   "2026",
 		}
@@ -124,7 +124,7 @@ func TestAssetHandlers(t *testing.T) {
 		asset := entities.Asset{
 			Name:      "House",
 			Type:      "Car",
-			TotalCost: 10000,
+			TotalCost: entities.Baht(10000),
 			EndYear:   "2026",
 		}
 
@@ -160,14 +160,14 @@ func TestAssetHandlers(t *testing.T) {
 				ID:        "ASSET001",
 				Name:      "House",
 				Type:      "Car",
-				TotalCost: 10000,
+				TotalCost: entities.Baht(10000),
 				EndYear:   "2026",
 				UserID:    userID,
 			},
 			{
 				ID:        "ASSET002",
 				Name:      "Car",
-				TotalCost: 5000,
+				TotalCost: entities.Baht(5000),
 				EndYear:   "2024",
 				UserID:    userID,
 			},
@@ -232,15 +232,18 @@ func TestAssetHandlers(t *testing.T) {
 			ID:        assetID,
 			Name:      "House",
 			Type:      "Car",
-			TotalCost: 10000,
+			TotalCost: entities.Baht(10000),
 			EndYear:   "2026",
 			UserID:    "user123",
 		}
 
-		mockUseCase.On("GetAssetByID", assetID).Return(expectedAsset, nil).Once()
+		mockUseCase.On("GetAssetByID", assetID, "user123").Return(expectedAsset, nil).Once()
 
 		app := fiber.New()
-		app.Get("/assets/:id", controller.GetAssetByIDHandler)
+		app.Get("/assets/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.GetAssetByIDHandler(c)
+		})
 
 		req := httptest.NewRequest("GET", "/assets/"+assetID, nil)
 		resp, err := app.Test(req, -1)
@@ -259,10 +262,13 @@ func TestAssetHandlers(t *testing.T) {
 	t.Run("GetAssetByIDHandler - Not Found", func(t *testing.T) {
 		assetID := "NONEXISTENT"
 
-		mockUseCase.On("GetAssetByID", assetID).Return(nil, errors.New("not found")).Once()
+		mockUseCase.On("GetAssetByID", assetID, "user123").Return(nil, errors.New("not found")).Once()
 
 		app := fiber.New()
-		app.Get("/assets/:id", controller.GetAssetByIDHandler)
+		app.Get("/assets/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.GetAssetByIDHandler(c)
+		})
 
 		req := httptest.NewRequest("GET", "/assets/"+assetID, nil)
 		resp, err := app.Test(req, -1)
@@ -278,7 +284,7 @@ func TestAssetHandlers(t *testing.T) {
 		asset := entities.Asset{
 			Name:      "Updated House",
 			Type:      "Car",
-			TotalCost: 12000,
+			TotalCost: entities.Baht(12000),
 			EndYear:   "2027",
 		}
 
@@ -287,7 +293,7 @@ func TestAssetHandlers(t *testing.T) {
 			ID:        assetID,
 			Name:      "Updated House",
 			Type:      "Car",
-			TotalCost: 12000,
+			TotalCost: entities.Baht(12000),
 			EndYear:   "2027",
 			UserID:    "user123",
 		}
@@ -325,7 +331,7 @@ func TestAssetHandlers(t *testing.T) {
 		asset := entities.Asset{
 			Name:      "Updated House",
 			Type:      "Car",
-			TotalCost: 12000,
+			TotalCost: entities.Baht(12000),
 			EndYear:   "2027",
 		}
 
@@ -347,7 +353,7 @@ func TestAssetHandlers(t *testing.T) {
 		asset := entities.Asset{
 			Name:      "Updated House",
 			Type:      "Car",
-			TotalCost: 12000,
+			TotalCost: entities.Baht(12000),
 			EndYear:   "2027",
 		}
 
@@ -412,7 +418,7 @@ func TestAssetHandlers(t *testing.T) {
 		userID := "user123"
 
 		mockUseCase.On("DeleteAssetByID", assetID, userID, mock.MatchedBy(func(t []entities.TransferRequest) bool {
-			return len(t) == 1 && t[0].Type == "asset" && t[0].Name == "Investment" && t[0].Amount == 500
+			return len(t) == 1 && t[0].Type == "asset" && t[0].Name == "Investment" && t[0].Amount == entities.Baht(500)
 		})).Return(nil).Once()
 
 		app := fiber.New()
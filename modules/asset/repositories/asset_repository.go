@@ -7,6 +7,7 @@ import (
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GormAssetRepository struct {
@@ -20,9 +21,11 @@ func NewGormAssetRepository(db *gorm.DB) *GormAssetRepository {
 type AssetRepository interface {
 	CreateAsset(asset *entities.Asset) (*entities.Asset, error)
 	GetAssetByID(id string) (*entities.Asset, error)
+	GetAssetByIDForUpdate(id string) (*entities.Asset, error)
 	GetAssetByUserID(userID string) ([]entities.Asset, error)
 	GetAssetNextID() (string, error)
 	FindAssetByNameandUserID(name, userID string) (*entities.Asset, error)
+	FindAssetByNameandUserIDForUpdate(name, userID string) (*entities.Asset, error)
 	UpdateAssetByID(asset *entities.Asset) (*entities.Asset, error)
 	DeleteAssetByID(id string) error
 }
@@ -44,6 +47,15 @@ func (r *GormAssetRepository) GetAssetByID(id string) (*entities.Asset, error) {
 	return &asset, nil
 }
 
+func (r *GormAssetRepository) GetAssetByIDForUpdate(id string) (*entities.Asset, error) {
+	var asset entities.Asset
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&asset, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return &asset, nil
+}
+
 func (r *GormAssetRepository) GetAssetByUserID(userID string) ([]entities.Asset, error) {
 	var assets []entities.Asset
 	if err := r.db.Where("user_id = ?", userID).Find(&assets).Error; err != nil {
@@ -78,6 +90,15 @@ func (r *GormAssetRepository) FindAssetByNameandUserID(name, userID string) (*en
 	return &asset, nil
 }
 
+func (r *GormAssetRepository) FindAssetByNameandUserIDForUpdate(name, userID string) (*entities.Asset, error) {
+	var asset entities.Asset
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ? AND user_id = ?", name, userID).First(&asset).Error; err != nil {
+		return nil, err
+	}
+
+	return &asset, nil
+}
+
 func (r *GormAssetRepository) UpdateAssetByID(asset *entities.Asset) (*entities.Asset, error) {
 	if err := r.db.Save(&asset).Error; err != nil {
 		return nil, err
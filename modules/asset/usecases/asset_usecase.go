@@ -7,10 +7,11 @@ import (
 
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/asset/repositories"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
-	notiRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/notification/repositories"
+	ledger "github.com/XzerozZ/Kasian_Phrom_BE/modules/ledger/usecases"
+	notiUsecase "github.com/XzerozZ/Kasian_Phrom_BE/modules/notification/usecases"
 	nhRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/nursing_house/repositories"
 	retirementRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/retirement_plan/repositories"
-	"github.com/XzerozZ/Kasian_Phrom_BE/modules/socket"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/unitofwork"
 	userRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/repositories"
 	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
 	"github.com/google/uuid"
@@ -18,7 +19,7 @@ import (
 
 type AssetUseCase interface {
 	CreateAsset(asset entities.Asset) (*entities.Asset, error)
-	GetAssetByID(id string) (*entities.Asset, error)
+	GetAssetByID(id, userID string) (*entities.Asset, error)
 	GetAssetByUserID(userID string) ([]entities.Asset, error)
 	UpdateAssetByID(id string, asset entities.Asset) (*entities.Asset, error)
 	DeleteAssetByID(id string, userID string, transfers []entities.TransferRequest) error
@@ -29,16 +30,18 @@ type AssetUseCaseImpl struct {
 	userrepo       userRepo.UserRepository
 	nhrepo         nhRepo.NhRepository
 	retirementrepo retirementRepo.RetirementRepository
-	notirepo       notiRepo.NotiRepository
+	dispatcher     notiUsecase.NotiDispatcher
+	uow            unitofwork.UnitOfWork
 }
 
-func NewAssetUseCase(assetrepo repositories.AssetRepository, userrepo userRepo.UserRepository, nhrepo nhRepo.NhRepository, retirementrepo retirementRepo.RetirementRepository, notirepo notiRepo.NotiRepository) *AssetUseCaseImpl {
+func NewAssetUseCase(assetrepo repositories.AssetRepository, userrepo userRepo.UserRepository, nhrepo nhRepo.NhRepository, retirementrepo retirementRepo.RetirementRepository, dispatcher notiUsecase.NotiDispatcher, uow unitofwork.UnitOfWork) *AssetUseCaseImpl {
 	return &AssetUseCaseImpl{
 		assetrepo:      assetrepo,
 		userrepo:       userrepo,
 		nhrepo:         nhrepo,
 		retirementrepo: retirementrepo,
-		notirepo:       notirepo,
+		dispatcher:     dispatcher,
+		uow:            uow,
 	}
 }
 
@@ -53,8 +56,7 @@ func (u *AssetUseCaseImpl) UpdateAssetStatus(asset *entities.Asset, currentYear
 		asset.LastCalculatedMonth = 0
 		asset.MonthlyExpenses = 0
 		notification := utils.AlertNoti("asset", asset.UserID, asset.Name, asset.ID, asset.TotalCost)
-		_ = u.notirepo.CreateNotification(notification)
-		socket.SendNotificationToUser(asset.UserID, *notification)
+		_ = u.dispatcher.Dispatch(notification)
 		return nil
 	}
 
@@ -101,8 +103,17 @@ func (u *AssetUseCaseImpl) CreateAsset(asset entities.Asset) (*entities.Asset, e
 	return u.assetrepo.CreateAsset(&asset)
 }
 
-func (u *AssetUseCaseImpl) GetAssetByID(id string) (*entities.Asset, error) {
-	return u.assetrepo.GetAssetByID(id)
+func (u *AssetUseCaseImpl) GetAssetByID(id, userID string) (*entities.Asset, error) {
+	asset, err := u.assetrepo.GetAssetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if asset.UserID != userID {
+		return nil, errors.New("asset not found")
+	}
+
+	return asset, nil
 }
 
 func (u *AssetUseCaseImpl) GetAssetByUserID(userID string) ([]entities.Asset, error) {
@@ -135,6 +146,10 @@ func (u *AssetUseCaseImpl) UpdateAssetByID(id string, asset entities.Asset) (*en
 		return nil, err
 	}
 
+	if existingAsset.UserID != asset.UserID {
+		return nil, errors.New("asset not found")
+	}
+
 	if asset.TotalCost <= 0 {
 		return nil, errors.New("totalcost must be greater than zero")
 	}
@@ -165,170 +180,193 @@ func (u *AssetUseCaseImpl) UpdateAssetByID(id string, asset entities.Asset) (*en
 }
 
 func (u *AssetUseCaseImpl) DeleteAssetByID(id string, userID string, transfers []entities.TransferRequest) error {
-	asset, err := u.assetrepo.GetAssetByID(id)
-	if err != nil {
-		return err
-	}
+	var notifications []*entities.Notification
+	err := u.uow.Do(func(repos unitofwork.Repositories) error {
+		asset, err := repos.Assets.GetAssetByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
 
-	user, err := u.userrepo.GetUserByID(userID)
-	if err != nil {
-		return err
-	}
+		if asset.UserID != userID {
+			return errors.New("asset not found")
+		}
 
-	totalTransfer := 0.0
-	for _, transfer := range transfers {
-		totalTransfer += transfer.Amount
-	}
+		user, err := repos.Users.GetUserByID(userID)
+		if err != nil {
+			return err
+		}
 
-	if totalTransfer > asset.CurrentMoney {
-		return errors.New("transfer amount exceeds asset's current money")
-	}
+		var totalTransfer entities.Money
+		for _, transfer := range transfers {
+			totalTransfer += transfer.Amount
+		}
 
-	for _, transfer := range transfers {
-		switch transfer.Type {
-		case "asset":
-			selectedItem, err := u.assetrepo.FindAssetByNameandUserID(transfer.Name, userID)
-			if err != nil {
-				return err
-			}
+		if totalTransfer > asset.CurrentMoney {
+			return errors.New("transfer amount exceeds asset's current money")
+		}
 
-			if selectedItem.Status == "In_Progress" {
-				selectedItem.CurrentMoney += transfer.Amount
-				his := entities.History{
-					ID:           uuid.New().String(),
-					Method:       "deposit",
-					Type:         "saving_money",
-					Category:     "asset",
-					Name:         selectedItem.Name,
-					Money:        transfer.Amount,
-					TransferFrom: asset.Name,
-					UserID:       userID,
-					TrackDate:    time.Now(),
-				}
+		legs := []ledger.Leg{{Kind: entities.LedgerAccountAsset, RefID: asset.ID, Name: asset.Name, Amount: -asset.CurrentMoney}}
 
-				_, err = u.userrepo.CreateHistory(&his)
+		for _, transfer := range transfers {
+			switch transfer.Type {
+			case "asset":
+				selectedItem, err := repos.Assets.FindAssetByNameandUserIDForUpdate(transfer.Name, userID)
 				if err != nil {
 					return err
 				}
 
-				if selectedItem.CurrentMoney >= selectedItem.TotalCost {
-					selectedItem.Status = "Completed"
-					selectedItem.MonthlyExpenses = 0
-					selectedItem.LastCalculatedMonth = 0
-					notification := utils.SuccessNotification("asset", user.ID, selectedItem.Name, selectedItem.ID, selectedItem.CurrentMoney)
-					_ = u.notirepo.CreateNotification(notification)
-					socket.SendNotificationToUser(userID, *notification)
+				if selectedItem.Status == "In_Progress" {
+					selectedItem.CurrentMoney += transfer.Amount
+					his := entities.History{
+						ID:           uuid.New().String(),
+						Method:       "deposit",
+						Type:         "saving_money",
+						Category:     "asset",
+						Name:         selectedItem.Name,
+						Money:        transfer.Amount,
+						TransferFrom: asset.Name,
+						UserID:       userID,
+						TrackDate:    time.Now(),
+					}
+
+					_, err = repos.Users.CreateHistory(&his)
+					if err != nil {
+						return err
+					}
+
+					if selectedItem.CurrentMoney >= selectedItem.TotalCost {
+						selectedItem.Status = "Completed"
+						selectedItem.MonthlyExpenses = 0
+						selectedItem.LastCalculatedMonth = 0
+						notifications = append(notifications, utils.SuccessNotification("asset", user.ID, selectedItem.Name, selectedItem.ID, selectedItem.CurrentMoney))
+					}
+
+					_, err = repos.Assets.UpdateAssetByID(selectedItem)
+					if err != nil {
+						return err
+					}
+
+					legs = append(legs, ledger.Leg{Kind: entities.LedgerAccountAsset, RefID: selectedItem.ID, Name: selectedItem.Name, Amount: transfer.Amount, Balance: selectedItem.CurrentMoney})
+				} else {
+					return errors.New("cannot update completed or paused asset")
 				}
 
-				_, err = u.assetrepo.UpdateAssetByID(selectedItem)
+			case "house":
+				house, err := repos.Users.GetSelectedHouseForUpdate(userID)
 				if err != nil {
 					return err
 				}
-			} else {
-				return errors.New("cannot update completed or paused asset")
-			}
 
-		case "house":
-			house, err := u.userrepo.GetSelectedHouse(userID)
-			if err != nil {
-				return err
-			}
+				if house.NursingHouseID != "00001" || house.Status != "Completed" {
+					house.CurrentMoney += transfer.Amount
+					his := entities.History{
+						ID:           uuid.New().String(),
+						Method:       "deposit",
+						Type:         "saving_money",
+						Category:     "house",
+						Name:         house.NursingHouse.Name,
+						Money:        transfer.Amount,
+						TransferFrom: asset.Name,
+						UserID:       userID,
+						TrackDate:    time.Now(),
+					}
+
+					_, err = repos.Users.CreateHistory(&his)
+					if err != nil {
+						return err
+					}
+
+					requiredMoney := house.NursingHouse.Price.Mul((user.RetirementPlan.ExpectLifespan - user.RetirementPlan.RetirementAge) * 12)
+					if house.CurrentMoney >= requiredMoney {
+						house.Status = "Completed"
+						house.MonthlyExpenses = 0
+						house.LastCalculatedMonth = 0
+						notifications = append(notifications, utils.SuccessNotification("house", user.ID, house.NursingHouse.Name, house.NursingHouseID, house.CurrentMoney))
+					}
+
+					_, err := repos.Users.UpdateSelectedHouse(house)
+					if err != nil {
+						return err
+					}
+
+					legs = append(legs, ledger.Leg{Kind: entities.LedgerAccountHouse, RefID: userID, Name: house.NursingHouse.Name, Amount: transfer.Amount, Balance: house.CurrentMoney})
+				} else {
+					return errors.New("cannot update completed nursing house")
+				}
 
-			if house.NursingHouseID != "00001" || house.Status != "Completed" {
-				house.CurrentMoney += transfer.Amount
+			case "retirementplan":
+				retirement, err := repos.Retirements.GetRetirementByUserIDForUpdate(userID)
+				if err != nil {
+					return err
+				}
+
+				retirement.CurrentSavings += transfer.Amount
 				his := entities.History{
 					ID:           uuid.New().String(),
 					Method:       "deposit",
 					Type:         "saving_money",
-					Category:     "house",
-					Name:         house.NursingHouse.Name,
+					Category:     "retirementplan",
+					Name:         retirement.PlanName,
 					Money:        transfer.Amount,
 					TransferFrom: asset.Name,
 					UserID:       userID,
 					TrackDate:    time.Now(),
 				}
 
-				_, err = u.userrepo.CreateHistory(&his)
+				_, err = repos.Users.CreateHistory(&his)
 				if err != nil {
 					return err
 				}
 
-				requiredMoney := (user.RetirementPlan.ExpectLifespan - user.RetirementPlan.RetirementAge) * 12 * house.NursingHouse.Price
-				if house.CurrentMoney >= float64(requiredMoney) {
-					house.Status = "Completed"
-					house.MonthlyExpenses = 0
-					house.LastCalculatedMonth = 0
-					notification := utils.SuccessNotification("house", user.ID, house.NursingHouse.Name, house.NursingHouseID, house.CurrentMoney)
-					_ = u.notirepo.CreateNotification(notification)
-					socket.SendNotificationToUser(userID, *notification)
+				allMoney := retirement.CurrentSavings + retirement.CurrentTotalInvestment
+				if allMoney >= retirement.LastRequiredFunds {
+					retirement.Status = "Completed"
+					retirement.LastMonthlyExpenses = 0
+					retirement.LastMonthlyExpenses = 0
+					notifications = append(notifications, utils.SuccessNotification("retirementplan", user.ID, retirement.PlanName, retirement.ID, allMoney))
 				}
 
-				_, err := u.userrepo.UpdateSelectedHouse(house)
+				_, err = repos.Retirements.UpdateRetirementPlan(retirement)
 				if err != nil {
 					return err
 				}
-			} else {
-				return errors.New("cannot update completed nursing house")
-			}
 
-		case "retirementplan":
-			retirement, err := u.retirementrepo.GetRetirementByUserID(userID)
-			if err != nil {
-				return err
-			}
-
-			retirement.CurrentSavings += transfer.Amount
-			his := entities.History{
-				ID:           uuid.New().String(),
-				Method:       "deposit",
-				Type:         "saving_money",
-				Category:     "retirementplan",
-				Name:         retirement.PlanName,
-				Money:        transfer.Amount,
-				TransferFrom: asset.Name,
-				UserID:       userID,
-				TrackDate:    time.Now(),
+				legs = append(legs, ledger.Leg{Kind: entities.LedgerAccountRetirementSavings, RefID: userID, Name: retirement.PlanName, Amount: transfer.Amount, Balance: retirement.CurrentSavings})
+			default:
+				continue
 			}
+		}
 
-			_, err = u.userrepo.CreateHistory(&his)
-			if err != nil {
-				return err
-			}
+		his := entities.History{
+			ID:        uuid.New().String(),
+			Method:    "withdraw",
+			Type:      "saving_money",
+			Category:  "asset",
+			Name:      asset.Name,
+			Money:     asset.CurrentMoney - totalTransfer,
+			UserID:    userID,
+			TrackDate: time.Now(),
+		}
 
-			allMoney := retirement.CurrentSavings + retirement.CurrentTotalInvestment
-			if allMoney >= retirement.LastRequiredFunds {
-				retirement.Status = "Completed"
-				retirement.LastMonthlyExpenses = 0
-				retirement.LastMonthlyExpenses = 0
-				notification := utils.SuccessNotification("retirementplan", user.ID, retirement.PlanName, retirement.ID, allMoney)
-				_ = u.notirepo.CreateNotification(notification)
-				socket.SendNotificationToUser(userID, *notification)
-			}
+		_, err = repos.Users.CreateHistory(&his)
+		if err != nil {
+			return err
+		}
 
-			_, err = u.retirementrepo.UpdateRetirementPlan(retirement)
-			if err != nil {
-				return err
-			}
-		default:
-			continue
+		if _, err := ledger.PostEntry(repos.Ledger, userID, "Delete asset "+asset.Name, time.Now(), legs...); err != nil {
+			return err
 		}
-	}
 
-	his := entities.History{
-		ID:        uuid.New().String(),
-		Method:    "withdraw",
-		Type:      "saving_money",
-		Category:  "asset",
-		Name:      asset.Name,
-		Money:     asset.CurrentMoney - totalTransfer,
-		UserID:    userID,
-		TrackDate: time.Now(),
-	}
+		return repos.Assets.DeleteAssetByID(asset.ID)
+	})
 
-	_, err = u.userrepo.CreateHistory(&his)
 	if err != nil {
 		return err
 	}
 
-	return u.assetrepo.DeleteAssetByID(asset.ID)
+	for _, notification := range notifications {
+		_ = u.dispatcher.Dispatch(notification)
+	}
+
+	return nil
 }
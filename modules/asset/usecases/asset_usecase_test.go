@@ -9,6 +9,7 @@ import (
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/asset/usecases"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"github.com/XzerozZ/Kasian_Phrom_BE/testing/repositories/mocks"
+	usecaseMocks "github.com/XzerozZ/Kasian_Phrom_BE/testing/usecases/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -18,9 +19,10 @@ func TestCreateAsset_Success(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	currentYear := time.Now().Year()
 	nextYear := strconv.Itoa(currentYear + 1)
@@ -28,7 +30,7 @@ func TestCreateAsset_Success(t *testing.T) {
 	asset := entities.Asset{
 		Name:      "Test Asset",
 		Type:      "Property",
-		TotalCost: 100000.0,
+		TotalCost: entities.Baht(100000),
 		EndYear:   nextYear,
 		UserID:    "user123",
 	}
@@ -36,7 +38,7 @@ func TestCreateAsset_Success(t *testing.T) {
 	expectedAsset := asset
 	expectedAsset.ID = "ASSET001"
 	expectedAsset.Status = "In_Progress"
-	expectedAsset.MonthlyExpenses = 100000.0 / float64((currentYear+1-currentYear)*12) // Simple calculation
+	expectedAsset.MonthlyExpenses = entities.Baht(100000).DivRound((currentYear+1-currentYear)*12, entities.OneBaht) // Simple calculation
 	expectedAsset.LastCalculatedMonth = int(time.Now().Month())
 
 	mockAssetRepo.On("GetAssetNextID").Return("ASSET001", nil)
@@ -57,9 +59,10 @@ func TestCreateAsset_InvalidTotalCost(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	currentYear := time.Now().Year()
 	nextYear := strconv.Itoa(currentYear + 1)
@@ -85,9 +88,10 @@ func TestCreateAsset_InvalidEndYear(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	currentYear := time.Now().Year()
 	lastYear := strconv.Itoa(currentYear - 1)
@@ -95,7 +99,7 @@ func TestCreateAsset_InvalidEndYear(t *testing.T) {
 	asset := entities.Asset{
 		Name:      "Test Asset",
 		Type:      "Property",
-		TotalCost: 100000.0,
+		TotalCost: entities.Baht(100000),
 		EndYear:   lastYear,
 		UserID:    "user123",
 	}
@@ -113,16 +117,17 @@ func TestGetAssetByID_Success(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	expectedAsset := &entities.Asset{
 		ID:           "ASSET001",
 		Name:         "Test Asset",
 		Type:         "Property",
-		TotalCost:    100000.0,
-		CurrentMoney: 10000.0,
+		TotalCost:    entities.Baht(100000),
+		CurrentMoney: entities.Baht(10000),
 		Status:       "In_Progress",
 		EndYear:      "2026",
 		UserID:       "user123",
@@ -130,7 +135,7 @@ func TestGetAssetByID_Success(t *testing.T) {
 
 	mockAssetRepo.On("GetAssetByID", "ASSET001").Return(expectedAsset, nil)
 
-	result, err := assetUseCase.GetAssetByID("ASSET001")
+	result, err := assetUseCase.GetAssetByID("ASSET001", "user123")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -143,13 +148,34 @@ func TestGetAssetByID_NotFound(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	mockAssetRepo.On("GetAssetByID", "ASSET999").Return(nil, errors.New("asset not found"))
 
-	result, err := assetUseCase.GetAssetByID("ASSET999")
+	result, err := assetUseCase.GetAssetByID("ASSET999", "user123")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "asset not found", err.Error())
+	mockAssetRepo.AssertExpectations(t)
+}
+
+func TestGetAssetByID_NotOwner(t *testing.T) {
+	mockAssetRepo := new(mocks.MockAssetRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockNhRepo := new(mocks.MockNhRepository)
+	mockRetirementRepo := new(mocks.MockRetirementRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
+
+	mockAssetRepo.On("GetAssetByID", "ASSET001").Return(&entities.Asset{ID: "ASSET001", UserID: "user123"}, nil)
+
+	result, err := assetUseCase.GetAssetByID("ASSET001", "user456")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -162,9 +188,10 @@ func TestGetAssetByUserID_Success(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	currentYear := time.Now().Year()
 	nextYear := strconv.Itoa(currentYear + 1)
@@ -175,8 +202,8 @@ func TestGetAssetByUserID_Success(t *testing.T) {
 			ID:                  "ASSET001",
 			Name:                "Test Asset 1",
 			Type:                "Property",
-			TotalCost:           100000.0,
-			CurrentMoney:        10000.0,
+			TotalCost:           entities.Baht(100000),
+			CurrentMoney:        entities.Baht(10000),
 			Status:              "In_Progress",
 			EndYear:             nextYear,
 			LastCalculatedMonth: currentMonth - 1,
@@ -186,8 +213,8 @@ func TestGetAssetByUserID_Success(t *testing.T) {
 			ID:                  "ASSET002",
 			Name:                "Test Asset 2",
 			Type:                "Vehicle",
-			TotalCost:           50000.0,
-			CurrentMoney:        5000.0,
+			TotalCost:           entities.Baht(50000),
+			CurrentMoney:        entities.Baht(5000),
 			Status:              "In_Progress",
 			EndYear:             nextYear,
 			LastCalculatedMonth: currentMonth,
@@ -197,7 +224,7 @@ func TestGetAssetByUserID_Success(t *testing.T) {
 
 	updatedAsset := assets[0]
 	updatedAsset.LastCalculatedMonth = currentMonth
-	updatedAsset.MonthlyExpenses = 100000.0 / float64((currentYear+1-currentYear)*12)
+	updatedAsset.MonthlyExpenses = entities.Baht(100000).DivRound((currentYear+1-currentYear)*12, entities.OneBaht)
 
 	mockAssetRepo.On("GetAssetByUserID", "user123").Return(assets, nil)
 	mockAssetRepo.On("UpdateAssetByID", mock.MatchedBy(func(a *entities.Asset) bool {
@@ -217,9 +244,10 @@ func TestUpdateAssetByID_Success(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	currentYear := time.Now().Year()
 	nextYear := strconv.Itoa(currentYear + 1)
@@ -229,19 +257,20 @@ func TestUpdateAssetByID_Success(t *testing.T) {
 		ID:                  "ASSET001",
 		Name:                "Test Asset",
 		Type:                "Property",
-		TotalCost:           100000.0,
-		CurrentMoney:        10000.0,
+		TotalCost:           entities.Baht(100000),
+		CurrentMoney:        entities.Baht(10000),
 		Status:              "In_Progress",
 		EndYear:             nextYear,
-		MonthlyExpenses:     8333.33,
+		MonthlyExpenses:     entities.MoneyFromFloat(8333.33),
 		LastCalculatedMonth: currentMonth - 1,
 		UserID:              "user123",
 	}
 
 	updateRequest := entities.Asset{
+		UserID:    "user123",
 		Name:      "Updated Asset",
 		Type:      "Investment",
-		TotalCost: 150000.0,
+		TotalCost: entities.Baht(150000),
 		EndYear:   nextYear,
 		Status:    "In_Progress",
 	}
@@ -250,11 +279,11 @@ func TestUpdateAssetByID_Success(t *testing.T) {
 		ID:                  "ASSET001",
 		Name:                "Updated Asset",
 		Type:                "Investment",
-		TotalCost:           150000.0,
-		CurrentMoney:        10000.0,
+		TotalCost:           entities.Baht(150000),
+		CurrentMoney:        entities.Baht(10000),
 		Status:              "In_Progress",
 		EndYear:             nextYear,
-		MonthlyExpenses:     12500.0,
+		MonthlyExpenses:     entities.Baht(12500),
 		LastCalculatedMonth: currentMonth,
 		UserID:              "user123",
 	}
@@ -270,7 +299,7 @@ func TestUpdateAssetByID_Success(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, "Updated Asset", result.Name)
 	assert.Equal(t, "Investment", result.Type)
-	assert.Equal(t, 150000.0, result.TotalCost)
+	assert.Equal(t, entities.Baht(150000), result.TotalCost)
 	assert.Equal(t, currentMonth, result.LastCalculatedMonth)
 	mockAssetRepo.AssertExpectations(t)
 }
@@ -280,9 +309,10 @@ func TestUpdateAssetByID_InvalidTotalCost(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	currentYear := time.Now().Year()
 	nextYear := strconv.Itoa(currentYear + 1)
@@ -291,14 +321,15 @@ func TestUpdateAssetByID_InvalidTotalCost(t *testing.T) {
 		ID:           "ASSET001",
 		Name:         "Test Asset",
 		Type:         "Property",
-		TotalCost:    100000.0,
-		CurrentMoney: 10000.0,
+		TotalCost:    entities.Baht(100000),
+		CurrentMoney: entities.Baht(10000),
 		Status:       "In_Progress",
 		EndYear:      nextYear,
 		UserID:       "user123",
 	}
 
 	updateRequest := entities.Asset{
+		UserID:    "user123",
 		Name:      "Updated Asset",
 		Type:      "Investment",
 		TotalCost: 0.0,
@@ -320,9 +351,10 @@ func TestUpdateAssetByID_PausedStatus(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	currentYear := time.Now().Year()
 	nextYear := strconv.Itoa(currentYear + 1)
@@ -331,19 +363,20 @@ func TestUpdateAssetByID_PausedStatus(t *testing.T) {
 		ID:                  "ASSET001",
 		Name:                "Test Asset",
 		Type:                "Property",
-		TotalCost:           100000.0,
-		CurrentMoney:        10000.0,
+		TotalCost:           entities.Baht(100000),
+		CurrentMoney:        entities.Baht(10000),
 		Status:              "In_Progress",
 		EndYear:             nextYear,
-		MonthlyExpenses:     8333.33,
+		MonthlyExpenses:     entities.MoneyFromFloat(8333.33),
 		LastCalculatedMonth: int(time.Now().Month()),
 		UserID:              "user123",
 	}
 
 	updateRequest := entities.Asset{
+		UserID:    "user123",
 		Name:      "Updated Asset",
 		Type:      "Investment",
-		TotalCost: 150000.0,
+		TotalCost: entities.Baht(150000),
 		EndYear:   nextYear,
 		Status:    "Paused",
 	}
@@ -352,8 +385,8 @@ func TestUpdateAssetByID_PausedStatus(t *testing.T) {
 		ID:                  "ASSET001",
 		Name:                "Updated Asset",
 		Type:                "Investment",
-		TotalCost:           150000.0,
-		CurrentMoney:        10000.0,
+		TotalCost:           entities.Baht(150000),
+		CurrentMoney:        entities.Baht(10000),
 		Status:              "Paused",
 		EndYear:             nextYear,
 		MonthlyExpenses:     0.0,
@@ -371,7 +404,7 @@ func TestUpdateAssetByID_PausedStatus(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "Paused", result.Status)
-	assert.Equal(t, 0.0, result.MonthlyExpenses)
+	assert.Equal(t, entities.Money(0), result.MonthlyExpenses)
 	assert.Equal(t, 0, result.LastCalculatedMonth)
 	mockAssetRepo.AssertExpectations(t)
 }
@@ -381,16 +414,17 @@ func TestDeleteAssetByID_Success(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	asset := &entities.Asset{
 		ID:           "ASSET001",
 		Name:         "Test Asset",
 		Type:         "Property",
-		TotalCost:    100000.0,
-		CurrentMoney: 10000.0,
+		TotalCost:    entities.Baht(100000),
+		CurrentMoney: entities.Baht(10000),
 		Status:       "In_Progress",
 		EndYear:      "2026",
 		UserID:       "user123",
@@ -408,12 +442,12 @@ func TestDeleteAssetByID_Success(t *testing.T) {
 		{
 			Type:   "retirementplan",
 			Name:   "My Retirement",
-			Amount: 5000.0,
+			Amount: entities.Baht(5000),
 		},
 		{
 			Type:   "asset",
 			Name:   "Second Asset",
-			Amount: 3000.0,
+			Amount: entities.Baht(3000),
 		},
 	}
 
@@ -421,40 +455,42 @@ func TestDeleteAssetByID_Success(t *testing.T) {
 		ID:           "ASSET002",
 		Name:         "Second Asset",
 		Type:         "Investment",
-		TotalCost:    50000.0,
-		CurrentMoney: 2000.0,
+		TotalCost:    entities.Baht(50000),
+		CurrentMoney: entities.Baht(2000),
 		Status:       "In_Progress",
 		EndYear:      "2026",
 		UserID:       "user123",
 	}
 
 	updatedTargetAsset := *targetAsset
-	updatedTargetAsset.CurrentMoney = 5000.0
+	updatedTargetAsset.CurrentMoney = entities.Baht(5000)
 
 	retirementPlan := &entities.RetirementPlan{
 		ID:                     "RET001",
 		PlanName:               "My Retirement",
-		CurrentSavings:         20000.0,
+		CurrentSavings:         entities.Baht(20000),
 		UserID:                 "user123",
-		CurrentTotalInvestment: 30000.0,
-		LastRequiredFunds:      100000.0,
+		CurrentTotalInvestment: entities.Baht(30000),
+		LastRequiredFunds:      entities.Baht(100000),
 		Status:                 "In_Progress",
 	}
 
 	updatedRetirementPlan := *retirementPlan
-	updatedRetirementPlan.CurrentSavings = 25000.0
+	updatedRetirementPlan.CurrentSavings = entities.Baht(25000)
 
-	mockAssetRepo.On("GetAssetByID", "ASSET001").Return(asset, nil)
+	mockAssetRepo.On("GetAssetByIDForUpdate", "ASSET001").Return(asset, nil)
 	mockUserRepo.On("GetUserByID", "user123").Return(user, nil)
 	mockUserRepo.On("CreateHistory", mock.AnythingOfType("*entities.History")).Return(&entities.History{}, nil).Times(3)
-	mockAssetRepo.On("FindAssetByNameandUserID", "Second Asset", "user123").Return(targetAsset, nil)
+	mockAssetRepo.On("FindAssetByNameandUserIDForUpdate", "Second Asset", "user123").Return(targetAsset, nil)
 	mockAssetRepo.On("UpdateAssetByID", mock.MatchedBy(func(a *entities.Asset) bool {
-		return a.ID == "ASSET002" && a.CurrentMoney == 5000.0
+		return a.ID == "ASSET002" && a.CurrentMoney == entities.Baht(5000)
 	})).Return(&updatedTargetAsset, nil)
-	mockRetirementRepo.On("GetRetirementByUserID", "user123").Return(retirementPlan, nil)
+	mockRetirementRepo.On("GetRetirementByUserIDForUpdate", "user123").Return(retirementPlan, nil)
 	mockRetirementRepo.On("UpdateRetirementPlan", mock.MatchedBy(func(p *entities.RetirementPlan) bool {
-		return p.ID == "RET001" && p.CurrentSavings == 25000.0
+		return p.ID == "RET001" && p.CurrentSavings == entities.Baht(25000)
 	})).Return(&updatedRetirementPlan, nil)
+	mockLedgerRepo.On("GetAccount", "user123", mock.Anything, mock.Anything).Return(&entities.LedgerAccount{ID: "account-1"}, nil)
+	mockLedgerRepo.On("CreateEntry", mock.AnythingOfType("*entities.LedgerEntry")).Return(&entities.LedgerEntry{}, nil)
 	mockAssetRepo.On("DeleteAssetByID", "ASSET001").Return(nil)
 
 	err := assetUseCase.DeleteAssetByID("ASSET001", "user123", transferRequests)
@@ -470,16 +506,17 @@ func TestDeleteAssetByID_TransferAmountExceedsCurrentMoney(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	asset := &entities.Asset{
 		ID:           "ASSET001",
 		Name:         "Test Asset",
 		Type:         "Property",
-		TotalCost:    100000.0,
-		CurrentMoney: 10000.0,
+		TotalCost:    entities.Baht(100000),
+		CurrentMoney: entities.Baht(10000),
 		Status:       "In_Progress",
 		EndYear:      "2026",
 		UserID:       "user123",
@@ -497,16 +534,16 @@ func TestDeleteAssetByID_TransferAmountExceedsCurrentMoney(t *testing.T) {
 		{
 			Type:   "retirementplan",
 			Name:   "My Retirement",
-			Amount: 8000.0,
+			Amount: entities.Baht(8000),
 		},
 		{
 			Type:   "asset",
 			Name:   "Second Asset",
-			Amount: 5000.0,
+			Amount: entities.Baht(5000),
 		},
 	}
 
-	mockAssetRepo.On("GetAssetByID", "ASSET001").Return(asset, nil)
+	mockAssetRepo.On("GetAssetByIDForUpdate", "ASSET001").Return(asset, nil)
 	mockUserRepo.On("GetUserByID", "user123").Return(user, nil)
 
 	err := assetUseCase.DeleteAssetByID("ASSET001", "user123", transferRequests)
@@ -521,16 +558,17 @@ func TestDeleteAssetByID_TransferToCompletedAsset(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	asset := &entities.Asset{
 		ID:           "ASSET001",
 		Name:         "Test Asset",
 		Type:         "Property",
-		TotalCost:    100000.0,
-		CurrentMoney: 10000.0,
+		TotalCost:    entities.Baht(100000),
+		CurrentMoney: entities.Baht(10000),
 		Status:       "In_Progress",
 		EndYear:      "2026",
 		UserID:       "user123",
@@ -548,7 +586,7 @@ func TestDeleteAssetByID_TransferToCompletedAsset(t *testing.T) {
 		{
 			Type:   "asset",
 			Name:   "Completed Asset",
-			Amount: 5000.0,
+			Amount: entities.Baht(5000),
 		},
 	}
 
@@ -556,16 +594,16 @@ func TestDeleteAssetByID_TransferToCompletedAsset(t *testing.T) {
 		ID:           "ASSET002",
 		Name:         "Completed Asset",
 		Type:         "Investment",
-		TotalCost:    50000.0,
-		CurrentMoney: 50000.0,
+		TotalCost:    entities.Baht(50000),
+		CurrentMoney: entities.Baht(50000),
 		Status:       "Completed",
 		EndYear:      "2026",
 		UserID:       "user123",
 	}
 
-	mockAssetRepo.On("GetAssetByID", "ASSET001").Return(asset, nil)
+	mockAssetRepo.On("GetAssetByIDForUpdate", "ASSET001").Return(asset, nil)
 	mockUserRepo.On("GetUserByID", "user123").Return(user, nil)
-	mockAssetRepo.On("FindAssetByNameandUserID", "Completed Asset", "user123").Return(completedAsset, nil)
+	mockAssetRepo.On("FindAssetByNameandUserIDForUpdate", "Completed Asset", "user123").Return(completedAsset, nil)
 
 	err := assetUseCase.DeleteAssetByID("ASSET001", "user123", transferRequests)
 
@@ -579,9 +617,10 @@ func TestUpdateAssetStatus_InProgress(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	currentYear := time.Now().Year()
 	nextYear := strconv.Itoa(currentYear + 1)
@@ -590,8 +629,8 @@ func TestUpdateAssetStatus_InProgress(t *testing.T) {
 		ID:           "ASSET001",
 		Name:         "Test Asset",
 		Type:         "Property",
-		TotalCost:    100000.0,
-		CurrentMoney: 50000.0,
+		TotalCost:    entities.Baht(100000),
+		CurrentMoney: entities.Baht(50000),
 		Status:       "In_Progress",
 		EndYear:      nextYear,
 		UserID:       "user123",
@@ -608,9 +647,10 @@ func TestUpdateAssetStatus_Completed(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	currentYear := time.Now().Year()
 	nextYear := strconv.Itoa(currentYear + 1)
@@ -619,8 +659,8 @@ func TestUpdateAssetStatus_Completed(t *testing.T) {
 		ID:           "ASSET001",
 		Name:         "Test Asset",
 		Type:         "Property",
-		TotalCost:    100000.0,
-		CurrentMoney: 100000.0,
+		TotalCost:    entities.Baht(100000),
+		CurrentMoney: entities.Baht(100000),
 		Status:       "In_Progress",
 		EndYear:      nextYear,
 		UserID:       "user123",
@@ -637,9 +677,10 @@ func TestUpdateAssetStatus_Paused(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	currentYear := time.Now().Year()
 
@@ -647,24 +688,24 @@ func TestUpdateAssetStatus_Paused(t *testing.T) {
 		ID:                  "ASSET001",
 		Name:                "Test Asset",
 		Type:                "Property",
-		TotalCost:           100000.0,
-		CurrentMoney:        50000.0,
+		TotalCost:           entities.Baht(100000),
+		CurrentMoney:        entities.Baht(50000),
 		Status:              "In_Progress",
 		EndYear:             strconv.Itoa(currentYear),
-		MonthlyExpenses:     5000.0,
+		MonthlyExpenses:     entities.Baht(5000),
 		LastCalculatedMonth: 5,
 		UserID:              "user123",
 	}
 
-	mockNotiRepo.On("CreateNotification", mock.AnythingOfType("*entities.Notification")).Return(nil)
+	mockDispatcher.On("Dispatch", mock.AnythingOfType("*entities.Notification")).Return(nil)
 
 	err := assetUseCase.UpdateAssetStatus(asset, currentYear)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "Paused", asset.Status)
 	assert.Equal(t, 0, asset.LastCalculatedMonth)
-	assert.Equal(t, 0.0, asset.MonthlyExpenses)
-	mockNotiRepo.AssertExpectations(t)
+	assert.Equal(t, entities.Money(0), asset.MonthlyExpenses)
+	mockDispatcher.AssertExpectations(t)
 }
 
 func TestUpdateAssetStatus_AlreadyPaused(t *testing.T) {
@@ -672,9 +713,10 @@ func TestUpdateAssetStatus_AlreadyPaused(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	currentYear := time.Now().Year()
 	nextYear := strconv.Itoa(currentYear + 1)
@@ -683,11 +725,11 @@ func TestUpdateAssetStatus_AlreadyPaused(t *testing.T) {
 		ID:                  "ASSET001",
 		Name:                "Test Asset",
 		Type:                "Property",
-		TotalCost:           100000.0,
-		CurrentMoney:        50000.0,
+		TotalCost:           entities.Baht(100000),
+		CurrentMoney:        entities.Baht(50000),
 		Status:              "Paused",
 		EndYear:             nextYear,
-		MonthlyExpenses:     5000.0,
+		MonthlyExpenses:     entities.Baht(5000),
 		LastCalculatedMonth: 5,
 		UserID:              "user123",
 	}
@@ -697,7 +739,7 @@ func TestUpdateAssetStatus_AlreadyPaused(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "Paused", asset.Status)
 	assert.Equal(t, 0, asset.LastCalculatedMonth)
-	assert.Equal(t, 0.0, asset.MonthlyExpenses)
+	assert.Equal(t, entities.Money(0), asset.MonthlyExpenses)
 }
 
 func TestDeleteAssetByID_TransferToHouse(t *testing.T) {
@@ -705,16 +747,17 @@ func TestDeleteAssetByID_TransferToHouse(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	asset := &entities.Asset{
 		ID:           "ASSET001",
 		Name:         "Test Asset",
 		Type:         "Property",
-		TotalCost:    100000.0,
-		CurrentMoney: 10000.0,
+		TotalCost:    entities.Baht(100000),
+		CurrentMoney: entities.Baht(10000),
 		Status:       "In_Progress",
 		EndYear:      "2026",
 		UserID:       "user123",
@@ -732,7 +775,7 @@ func TestDeleteAssetByID_TransferToHouse(t *testing.T) {
 		{
 			Type:   "house",
 			Name:   "Nursing Home",
-			Amount: 5000.0,
+			Amount: entities.Baht(5000),
 		},
 	}
 
@@ -740,24 +783,26 @@ func TestDeleteAssetByID_TransferToHouse(t *testing.T) {
 		NursingHouseID: "NH001",
 		UserID:         "user123",
 		Status:         "In_Progress",
-		CurrentMoney:   20000.0,
+		CurrentMoney:   entities.Baht(20000),
 		NursingHouse: entities.NursingHouse{
 			ID:    "NH001",
 			Name:  "Nursing Home",
-			Price: 1000.0,
+			Price: entities.Baht(1000),
 		},
 	}
 
 	updatedSelectedHouse := *selectedHouse
-	updatedSelectedHouse.CurrentMoney = 25000.0
+	updatedSelectedHouse.CurrentMoney = entities.Baht(25000)
 
-	mockAssetRepo.On("GetAssetByID", "ASSET001").Return(asset, nil)
+	mockAssetRepo.On("GetAssetByIDForUpdate", "ASSET001").Return(asset, nil)
 	mockUserRepo.On("GetUserByID", "user123").Return(user, nil)
-	mockUserRepo.On("GetSelectedHouse", "user123").Return(selectedHouse, nil)
+	mockUserRepo.On("GetSelectedHouseForUpdate", "user123").Return(selectedHouse, nil)
 	mockUserRepo.On("CreateHistory", mock.AnythingOfType("*entities.History")).Return(&entities.History{}, nil).Times(2)
 	mockUserRepo.On("UpdateSelectedHouse", mock.MatchedBy(func(h *entities.SelectedHouse) bool {
-		return h.CurrentMoney == 25000.0
+		return h.CurrentMoney == entities.Baht(25000)
 	})).Return(&updatedSelectedHouse, nil)
+	mockLedgerRepo.On("GetAccount", "user123", mock.Anything, mock.Anything).Return(&entities.LedgerAccount{ID: "account-1"}, nil)
+	mockLedgerRepo.On("CreateEntry", mock.AnythingOfType("*entities.LedgerEntry")).Return(&entities.LedgerEntry{}, nil)
 	mockAssetRepo.On("DeleteAssetByID", "ASSET001").Return(nil)
 
 	err := assetUseCase.DeleteAssetByID("ASSET001", "user123", transferRequests)
@@ -772,16 +817,17 @@ func TestDeleteAssetByID_TransferToCompletedHouse(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	asset := &entities.Asset{
 		ID:           "ASSET001",
 		Name:         "Test Asset",
 		Type:         "Property",
-		TotalCost:    100000.0,
-		CurrentMoney: 10000.0,
+		TotalCost:    entities.Baht(100000),
+		CurrentMoney: entities.Baht(10000),
 		Status:       "In_Progress",
 		EndYear:      "2026",
 		UserID:       "user123",
@@ -799,7 +845,7 @@ func TestDeleteAssetByID_TransferToCompletedHouse(t *testing.T) {
 		{
 			Type:   "house",
 			Name:   "Completed House",
-			Amount: 5000.0,
+			Amount: entities.Baht(5000),
 		},
 	}
 
@@ -807,17 +853,17 @@ func TestDeleteAssetByID_TransferToCompletedHouse(t *testing.T) {
 		NursingHouseID: "00001",
 		UserID:         "user123",
 		Status:         "Completed",
-		CurrentMoney:   50000.0,
+		CurrentMoney:   entities.Baht(50000),
 		NursingHouse: entities.NursingHouse{
 			ID:    "00001",
 			Name:  "Completed House",
-			Price: 1000.0,
+			Price: entities.Baht(1000),
 		},
 	}
 
-	mockAssetRepo.On("GetAssetByID", "ASSET001").Return(asset, nil)
+	mockAssetRepo.On("GetAssetByIDForUpdate", "ASSET001").Return(asset, nil)
 	mockUserRepo.On("GetUserByID", "user123").Return(user, nil)
-	mockUserRepo.On("GetSelectedHouse", "user123").Return(completedHouse, nil)
+	mockUserRepo.On("GetSelectedHouseForUpdate", "user123").Return(completedHouse, nil)
 
 	err := assetUseCase.DeleteAssetByID("ASSET001", "user123", transferRequests)
 
@@ -831,16 +877,17 @@ func TestDeleteAssetByID_HouseCompletedAfterTransfer(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	asset := &entities.Asset{
 		ID:           "ASSET001",
 		Name:         "Test Asset",
 		Type:         "Property",
-		TotalCost:    100000.0,
-		CurrentMoney: 10000.0,
+		TotalCost:    entities.Baht(100000),
+		CurrentMoney: entities.Baht(10000),
 		Status:       "In_Progress",
 		EndYear:      "2026",
 		UserID:       "user123",
@@ -858,7 +905,7 @@ func TestDeleteAssetByID_HouseCompletedAfterTransfer(t *testing.T) {
 		{
 			Type:   "house",
 			Name:   "Almost Complete House",
-			Amount: 5000.0,
+			Amount: entities.Baht(5000),
 		},
 	}
 
@@ -866,28 +913,30 @@ func TestDeleteAssetByID_HouseCompletedAfterTransfer(t *testing.T) {
 		NursingHouseID: "NH001",
 		UserID:         "user123",
 		Status:         "In_Progress",
-		CurrentMoney:   295000.0,
+		CurrentMoney:   entities.Baht(295000),
 		NursingHouse: entities.NursingHouse{
 			ID:    "NH001",
 			Name:  "Almost Complete House",
-			Price: 1000.0,
+			Price: entities.Baht(1000),
 		},
 	}
 
 	updatedSelectedHouse := *selectedHouse
-	updatedSelectedHouse.CurrentMoney = 300000.0
+	updatedSelectedHouse.CurrentMoney = entities.Baht(300000)
 	updatedSelectedHouse.Status = "Completed"
 	updatedSelectedHouse.MonthlyExpenses = 0
 	updatedSelectedHouse.LastCalculatedMonth = 0
 
-	mockAssetRepo.On("GetAssetByID", "ASSET001").Return(asset, nil)
+	mockAssetRepo.On("GetAssetByIDForUpdate", "ASSET001").Return(asset, nil)
 	mockUserRepo.On("GetUserByID", "user123").Return(user, nil)
-	mockUserRepo.On("GetSelectedHouse", "user123").Return(selectedHouse, nil)
+	mockUserRepo.On("GetSelectedHouseForUpdate", "user123").Return(selectedHouse, nil)
 	mockUserRepo.On("CreateHistory", mock.AnythingOfType("*entities.History")).Return(&entities.History{}, nil).Times(2)
 	mockUserRepo.On("UpdateSelectedHouse", mock.MatchedBy(func(h *entities.SelectedHouse) bool {
-		return h.CurrentMoney == 300000.0 && h.Status == "Completed"
+		return h.CurrentMoney == entities.Baht(300000) && h.Status == "Completed"
 	})).Return(&updatedSelectedHouse, nil)
-	mockNotiRepo.On("CreateNotification", mock.AnythingOfType("*entities.Notification")).Return(nil)
+	mockDispatcher.On("Dispatch", mock.AnythingOfType("*entities.Notification")).Return(nil)
+	mockLedgerRepo.On("GetAccount", "user123", mock.Anything, mock.Anything).Return(&entities.LedgerAccount{ID: "account-1"}, nil)
+	mockLedgerRepo.On("CreateEntry", mock.AnythingOfType("*entities.LedgerEntry")).Return(&entities.LedgerEntry{}, nil)
 	mockAssetRepo.On("DeleteAssetByID", "ASSET001").Return(nil)
 
 	err := assetUseCase.DeleteAssetByID("ASSET001", "user123", transferRequests)
@@ -895,7 +944,7 @@ func TestDeleteAssetByID_HouseCompletedAfterTransfer(t *testing.T) {
 	assert.NoError(t, err)
 	mockAssetRepo.AssertExpectations(t)
 	mockUserRepo.AssertExpectations(t)
-	mockNotiRepo.AssertExpectations(t)
+	mockDispatcher.AssertExpectations(t)
 }
 
 func TestDeleteAssetByID_RetirementPlanCompletedAfterTransfer(t *testing.T) {
@@ -903,16 +952,17 @@ func TestDeleteAssetByID_RetirementPlanCompletedAfterTransfer(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	asset := &entities.Asset{
 		ID:           "ASSET001",
 		Name:         "Test Asset",
 		Type:         "Property",
-		TotalCost:    100000.0,
-		CurrentMoney: 10000.0,
+		TotalCost:    entities.Baht(100000),
+		CurrentMoney: entities.Baht(10000),
 		Status:       "In_Progress",
 		EndYear:      "2026",
 		UserID:       "user123",
@@ -930,33 +980,35 @@ func TestDeleteAssetByID_RetirementPlanCompletedAfterTransfer(t *testing.T) {
 		{
 			Type:   "retirementplan",
 			Name:   "Almost Complete Retirement",
-			Amount: 5000.0,
+			Amount: entities.Baht(5000),
 		},
 	}
 
 	retirementPlan := &entities.RetirementPlan{
 		ID:                     "RET001",
 		PlanName:               "Almost Complete Retirement",
-		CurrentSavings:         45000.0,
-		CurrentTotalInvestment: 50000.0,
-		LastRequiredFunds:      100000.0,
+		CurrentSavings:         entities.Baht(45000),
+		CurrentTotalInvestment: entities.Baht(50000),
+		LastRequiredFunds:      entities.Baht(100000),
 		Status:                 "In_Progress",
 		UserID:                 "user123",
 	}
 
 	updatedRetirementPlan := *retirementPlan
-	updatedRetirementPlan.CurrentSavings = 50000.0
+	updatedRetirementPlan.CurrentSavings = entities.Baht(50000)
 	updatedRetirementPlan.Status = "Completed"
 	updatedRetirementPlan.LastMonthlyExpenses = 0
 
-	mockAssetRepo.On("GetAssetByID", "ASSET001").Return(asset, nil)
+	mockAssetRepo.On("GetAssetByIDForUpdate", "ASSET001").Return(asset, nil)
 	mockUserRepo.On("GetUserByID", "user123").Return(user, nil)
 	mockUserRepo.On("CreateHistory", mock.AnythingOfType("*entities.History")).Return(&entities.History{}, nil).Times(2)
-	mockRetirementRepo.On("GetRetirementByUserID", "user123").Return(retirementPlan, nil)
+	mockRetirementRepo.On("GetRetirementByUserIDForUpdate", "user123").Return(retirementPlan, nil)
 	mockRetirementRepo.On("UpdateRetirementPlan", mock.MatchedBy(func(r *entities.RetirementPlan) bool {
-		return r.CurrentSavings == 50000.0 && r.Status == "Completed"
+		return r.CurrentSavings == entities.Baht(50000) && r.Status == "Completed"
 	})).Return(&updatedRetirementPlan, nil)
-	mockNotiRepo.On("CreateNotification", mock.AnythingOfType("*entities.Notification")).Return(nil)
+	mockDispatcher.On("Dispatch", mock.AnythingOfType("*entities.Notification")).Return(nil)
+	mockLedgerRepo.On("GetAccount", "user123", mock.Anything, mock.Anything).Return(&entities.LedgerAccount{ID: "account-1"}, nil)
+	mockLedgerRepo.On("CreateEntry", mock.AnythingOfType("*entities.LedgerEntry")).Return(&entities.LedgerEntry{}, nil)
 	mockAssetRepo.On("DeleteAssetByID", "ASSET001").Return(nil)
 
 	err := assetUseCase.DeleteAssetByID("ASSET001", "user123", transferRequests)
@@ -964,7 +1016,55 @@ func TestDeleteAssetByID_RetirementPlanCompletedAfterTransfer(t *testing.T) {
 	assert.NoError(t, err)
 	mockAssetRepo.AssertExpectations(t)
 	mockRetirementRepo.AssertExpectations(t)
-	mockNotiRepo.AssertExpectations(t)
+	mockDispatcher.AssertExpectations(t)
+}
+
+func TestDeleteAssetByID_RollsBackWhenDeleteFails(t *testing.T) {
+	mockAssetRepo := new(mocks.MockAssetRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockNhRepo := new(mocks.MockNhRepository)
+	mockRetirementRepo := new(mocks.MockRetirementRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
+	uow := mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo)
+
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, uow)
+
+	asset := &entities.Asset{
+		ID:           "ASSET001",
+		Name:         "Test Asset",
+		CurrentMoney: entities.Baht(10000),
+		UserID:       "user123",
+	}
+
+	retirementPlan := &entities.RetirementPlan{
+		ID:                     "RET001",
+		PlanName:               "Almost Complete Retirement",
+		CurrentSavings:         entities.Baht(45000),
+		CurrentTotalInvestment: entities.Baht(50000),
+		LastRequiredFunds:      entities.Baht(100000),
+		Status:                 "In_Progress",
+		UserID:                 "user123",
+	}
+
+	mockAssetRepo.On("GetAssetByIDForUpdate", "ASSET001").Return(asset, nil)
+	mockUserRepo.On("GetUserByID", "user123").Return(&entities.User{ID: "user123"}, nil)
+	mockUserRepo.On("CreateHistory", mock.AnythingOfType("*entities.History")).Return(&entities.History{}, nil).Times(2)
+	mockRetirementRepo.On("GetRetirementByUserIDForUpdate", "user123").Return(retirementPlan, nil)
+	mockRetirementRepo.On("UpdateRetirementPlan", mock.AnythingOfType("*entities.RetirementPlan")).Return(retirementPlan, nil)
+	mockLedgerRepo.On("GetAccount", "user123", mock.Anything, mock.Anything).Return(&entities.LedgerAccount{ID: "account-1"}, nil)
+	mockLedgerRepo.On("CreateEntry", mock.AnythingOfType("*entities.LedgerEntry")).Return(&entities.LedgerEntry{}, nil)
+	mockAssetRepo.On("DeleteAssetByID", "ASSET001").Return(errors.New("database error"))
+
+	err := assetUseCase.DeleteAssetByID("ASSET001", "user123", []entities.TransferRequest{
+		{Type: "retirementplan", Name: "Almost Complete Retirement", Amount: entities.Baht(5000)},
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "database error", err.Error())
+	assert.Equal(t, 1, uow.RolledBack)
+	assert.Equal(t, 0, uow.Committed)
+	mockDispatcher.AssertNotCalled(t, "Dispatch", mock.Anything)
 }
 
 func TestDeleteAssetByID_InvalidTransferType(t *testing.T) {
@@ -972,16 +1072,17 @@ func TestDeleteAssetByID_InvalidTransferType(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	asset := &entities.Asset{
 		ID:           "ASSET001",
 		Name:         "Test Asset",
 		Type:         "Property",
-		TotalCost:    100000.0,
-		CurrentMoney: 10000.0,
+		TotalCost:    entities.Baht(100000),
+		CurrentMoney: entities.Baht(10000),
 		Status:       "In_Progress",
 		EndYear:      "2026",
 		UserID:       "user123",
@@ -999,13 +1100,15 @@ func TestDeleteAssetByID_InvalidTransferType(t *testing.T) {
 		{
 			Type:   "invalid_type",
 			Name:   "Some Entity",
-			Amount: 5000.0,
+			Amount: entities.Baht(5000),
 		},
 	}
 
-	mockAssetRepo.On("GetAssetByID", "ASSET001").Return(asset, nil)
+	mockAssetRepo.On("GetAssetByIDForUpdate", "ASSET001").Return(asset, nil)
 	mockUserRepo.On("GetUserByID", "user123").Return(user, nil)
 	mockUserRepo.On("CreateHistory", mock.AnythingOfType("*entities.History")).Return(&entities.History{}, nil)
+	mockLedgerRepo.On("GetAccount", "user123", mock.Anything, mock.Anything).Return(&entities.LedgerAccount{ID: "account-1"}, nil)
+	mockLedgerRepo.On("CreateEntry", mock.AnythingOfType("*entities.LedgerEntry")).Return(&entities.LedgerEntry{}, nil)
 	mockAssetRepo.On("DeleteAssetByID", "ASSET001").Return(nil)
 
 	err := assetUseCase.DeleteAssetByID("ASSET001", "user123", transferRequests)
@@ -1020,13 +1123,14 @@ func TestAssetUseCaseImpl_GetAssetByID_NotFound(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	mockAssetRepo.On("GetAssetByID", "NOTFOUND").Return(nil, errors.New("asset not found"))
 
-	result, err := assetUseCase.GetAssetByID("NOTFOUND")
+	result, err := assetUseCase.GetAssetByID("NOTFOUND", "user123")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -1038,14 +1142,16 @@ func TestUpdateAssetByID_AssetNotFound(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	updateRequest := entities.Asset{
+		UserID:    "user123",
 		Name:      "Updated Asset",
 		Type:      "Investment",
-		TotalCost: 150000.0,
+		TotalCost: entities.Baht(150000),
 		EndYear:   "2026",
 	}
 
@@ -1063,11 +1169,12 @@ func TestDeleteAssetByID_AssetNotFound(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
-	mockAssetRepo.On("GetAssetByID", "NOTFOUND").Return(nil, errors.New("asset not found"))
+	mockAssetRepo.On("GetAssetByIDForUpdate", "NOTFOUND").Return(nil, errors.New("asset not found"))
 
 	err := assetUseCase.DeleteAssetByID("NOTFOUND", "user123", []entities.TransferRequest{})
 
@@ -1080,22 +1187,23 @@ func TestDeleteAssetByID_UserNotFound(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	asset := &entities.Asset{
 		ID:           "ASSET001",
 		Name:         "Test Asset",
 		Type:         "Property",
-		TotalCost:    100000.0,
-		CurrentMoney: 10000.0,
+		TotalCost:    entities.Baht(100000),
+		CurrentMoney: entities.Baht(10000),
 		Status:       "In_Progress",
 		EndYear:      "2026",
-		UserID:       "user123",
+		UserID:       "NOTFOUND",
 	}
 
-	mockAssetRepo.On("GetAssetByID", "ASSET001").Return(asset, nil)
+	mockAssetRepo.On("GetAssetByIDForUpdate", "ASSET001").Return(asset, nil)
 	mockUserRepo.On("GetUserByID", "NOTFOUND").Return((*entities.User)(nil), errors.New("user not found"))
 
 	err := assetUseCase.DeleteAssetByID("ASSET001", "NOTFOUND", []entities.TransferRequest{})
@@ -1104,14 +1212,35 @@ func TestDeleteAssetByID_UserNotFound(t *testing.T) {
 	assert.Equal(t, "user not found", err.Error())
 }
 
+func TestDeleteAssetByID_NotOwner(t *testing.T) {
+	mockAssetRepo := new(mocks.MockAssetRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockNhRepo := new(mocks.MockNhRepository)
+	mockRetirementRepo := new(mocks.MockRetirementRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
+
+	mockAssetRepo.On("GetAssetByIDForUpdate", "ASSET001").Return(&entities.Asset{ID: "ASSET001", UserID: "user123"}, nil)
+
+	err := assetUseCase.DeleteAssetByID("ASSET001", "user456", []entities.TransferRequest{})
+
+	assert.Error(t, err)
+	assert.Equal(t, "asset not found", err.Error())
+	mockUserRepo.AssertNotCalled(t, "GetUserByID", "user456")
+	mockAssetRepo.AssertNotCalled(t, "DeleteAssetByID", "ASSET001")
+}
+
 func TestAssetUseCaseImpl_CreateAsset_FailedToGetNextID(t *testing.T) {
 	mockAssetRepo := new(mocks.MockAssetRepository)
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockNhRepo := new(mocks.MockNhRepository)
 	mockRetirementRepo := new(mocks.MockRetirementRepository)
-	mockNotiRepo := new(mocks.MockNotiRepository)
+	mockLedgerRepo := new(mocks.MockLedgerRepository)
+	mockDispatcher := new(usecaseMocks.MockNotiDispatcher)
 
-	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockNotiRepo)
+	assetUseCase := usecases.NewAssetUseCase(mockAssetRepo, mockUserRepo, mockNhRepo, mockRetirementRepo, mockDispatcher, mocks.NewMockUnitOfWork(mockUserRepo, mockAssetRepo, mockRetirementRepo, mockLedgerRepo))
 
 	currentYear := time.Now().Year()
 	nextYear := strconv.Itoa(currentYear + 1)
@@ -1119,7 +1248,7 @@ func TestAssetUseCaseImpl_CreateAsset_FailedToGetNextID(t *testing.T) {
 	asset := entities.Asset{
 		Name:      "Test Asset",
 		Type:      "Property",
-		TotalCost: 100000.0,
+		TotalCost: entities.Baht(100000),
 		EndYear:   nextYear,
 		UserID:    "user123",
 	}
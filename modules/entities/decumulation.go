@@ -0,0 +1,52 @@
+package entities
+
+import "time"
+
+const (
+	WithdrawalStrategyFixedAmount       = "fixed_amount"
+	WithdrawalStrategyFixedPercentage   = "fixed_percentage"
+	WithdrawalStrategyInflationAdjusted = "inflation_adjusted"
+)
+
+type DecumulationOptions struct {
+	WithdrawalRate float64
+	AnnualReturn   *float64
+}
+
+type DecumulationYear struct {
+	Year         int   `json:"year"`
+	Age          int   `json:"age"`
+	StartBalance Money `json:"start_balance"`
+	Growth       Money `json:"growth"`
+	Withdrawal   Money `json:"withdrawal"`
+	Needs        Money `json:"needs"`
+	Shortfall    Money `json:"shortfall"`
+	EndBalance   Money `json:"end_balance"`
+}
+
+type DecumulationStrategy struct {
+	Strategy                     string             `json:"strategy"`
+	FirstMonthWithdrawal         Money              `json:"first_month_withdrawal"`
+	SustainableMonthlyWithdrawal Money              `json:"sustainable_monthly_withdrawal"`
+	TotalWithdrawn               Money              `json:"total_withdrawn"`
+	TotalShortfall               Money              `json:"total_shortfall"`
+	EndingBalance                Money              `json:"ending_balance"`
+	CoversNeeds                  bool               `json:"covers_needs"`
+	DepletionYear                *int               `json:"depletion_year"`
+	DepletionAge                 *int               `json:"depletion_age"`
+	Years                        []DecumulationYear `json:"years"`
+}
+
+type DecumulationPlan struct {
+	StartMonth          time.Time              `json:"start_month"`
+	EndMonth            time.Time              `json:"end_month"`
+	Months              int                    `json:"months"`
+	StartingBalance     Money                  `json:"starting_balance"`
+	AnnualReturn        float64                `json:"annual_return"`
+	WithdrawalRate      float64                `json:"withdrawal_rate"`
+	ExpectedInflation   float64                `json:"expected_inflation"`
+	LivingExpenses      Money                  `json:"living_expenses"`
+	NursingHouseMonthly Money                  `json:"nursing_house_monthly"`
+	MonthlyNeeds        Money                  `json:"monthly_needs"`
+	Strategies          []DecumulationStrategy `json:"strategies"`
+}
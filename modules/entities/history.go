@@ -8,7 +8,7 @@ type History struct {
 	Type         string    `json:"type" gorm:"not null"`
 	Name         string    `json:"name" gorm:"not null"`
 	Category     string    `json:"category" gorm:"not null"`
-	Money        float64   `json:"money" gorm:"not null"`
+	Money        Money     `json:"money" gorm:"type:numeric(14,2);not null"`
 	UserID       string    `json:"-" gorm:"not null"`
 	TransferFrom string    `jsom:"transfer-from"`
 	TrackDate    time.Time `json:"track_at"`
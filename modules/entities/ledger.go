@@ -0,0 +1,58 @@
+package entities
+
+import "time"
+
+const (
+	LedgerAccountExternal             = "external"
+	LedgerAccountAsset                = "asset"
+	LedgerAccountHouse                = "house"
+	LedgerAccountRetirementSavings    = "retirement_savings"
+	LedgerAccountRetirementInvestment = "retirement_investment"
+	LedgerAccountVehicle              = "vehicle"
+)
+
+type LedgerAccount struct {
+	ID        string    `json:"account_id" gorm:"primaryKey"`
+	UserID    string    `json:"-" gorm:"not null;uniqueIndex:idx_ledger_account"`
+	Kind      string    `json:"kind" gorm:"not null;uniqueIndex:idx_ledger_account"`
+	RefID     string    `json:"ref_id" gorm:"not null;uniqueIndex:idx_ledger_account"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LedgerEntry struct {
+	ID          string          `json:"entry_id" gorm:"primaryKey"`
+	UserID      string          `json:"-" gorm:"not null;index"`
+	Description string          `json:"description" gorm:"not null"`
+	PostedAt    time.Time       `json:"posted_at" gorm:"not null;index"`
+	PostedBy    string          `json:"posted_by,omitempty"`
+	Reason      string          `json:"reason,omitempty"`
+	Postings    []LedgerPosting `json:"postings" gorm:"foreignKey:EntryID"`
+}
+
+type LedgerPosting struct {
+	ID        string `json:"posting_id" gorm:"primaryKey"`
+	EntryID   string `json:"-" gorm:"not null;index"`
+	AccountID string `json:"account_id" gorm:"not null;index"`
+	Amount    Money  `json:"amount" gorm:"type:numeric(14,2);not null"`
+}
+
+type LedgerBalance struct {
+	Account LedgerAccount `json:"account"`
+	Balance Money         `json:"balance"`
+	AsOf    time.Time     `json:"as_of"`
+}
+
+type LedgerDrift struct {
+	Kind          string `json:"kind"`
+	RefID         string `json:"ref_id"`
+	Name          string `json:"name"`
+	LedgerBalance Money  `json:"ledger_balance"`
+	GoalBalance   Money  `json:"goal_balance"`
+	Difference    Money  `json:"difference"`
+}
+
+type LedgerStatement struct {
+	LedgerBalance
+	Entries []LedgerEntry `json:"entries"`
+}
@@ -2,15 +2,128 @@ package entities
 
 import "time"
 
+const (
+	LoanRateFixed    = "fixed"
+	LoanRateFloating = "floating"
+	LoanRateFlat     = "flat"
+
+	LoanPaymentInstallment = "installment"
+	LoanPaymentPartial     = "partial"
+	LoanPaymentPrepayment  = "prepayment"
+	LoanPaymentRefinance   = "refinance"
+
+	PrepaymentShortenTerm       = "shorten_term"
+	PrepaymentReduceInstallment = "reduce_installment"
+)
+
 type Loan struct {
-	ID              string  `json:"loan_id" gorm:"primaryKey"`
-	Name            string  `json:"name" gorm:"not null"`
-	Type            string  `json:"type" gorm:"not null"`
-	MonthlyExpenses float64 `json:"monthly_expenses" gorm:"not null"`
-	RemainingMonths int     `json:"remaining_months" gorm:"not null"`
-	Installment     bool    `json:"installment" gorm:"not null"`
-	Status          string  `json:"status" gorm:"not null"`
-	UserID          string  `json:"-" gorm:"not null"`
+	ID              string    `json:"loan_id" gorm:"primaryKey"`
+	Name            string    `json:"name" gorm:"not null"`
+	Type            string    `json:"type" gorm:"not null"`
+	MonthlyExpenses Money     `json:"monthly_expenses" gorm:"type:numeric(14,2);not null"`
+	RemainingMonths int       `json:"remaining_months" gorm:"not null"`
+	Installment     bool      `json:"installment" gorm:"not null"`
+	Status          string    `json:"status" gorm:"not null"`
+	Principal       Money     `json:"principal" gorm:"type:numeric(14,2);not null;default:0"`
+	InterestRate    float64   `json:"interest_rate" gorm:"not null;default:0"`
+	RateType        string    `json:"rate_type"`
+	StartDate       time.Time `json:"start_date"`
+	TermMonths      int       `json:"term_months" gorm:"not null;default:0"`
+	Balance         Money     `json:"balance" gorm:"type:numeric(14,2);not null;default:0"`
+	RefinancedFrom  string    `json:"refinanced_from,omitempty"`
+	UserID          string    `json:"-" gorm:"not null"`
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
+
+type LoanPayment struct {
+	ID            string    `json:"payment_id" gorm:"primaryKey"`
+	Kind          string    `json:"kind" gorm:"not null"`
+	Amount        Money     `json:"amount" gorm:"type:numeric(14,2);not null"`
+	Interest      Money     `json:"interest" gorm:"type:numeric(14,2);not null;default:0"`
+	Principal     Money     `json:"principal" gorm:"type:numeric(14,2);not null;default:0"`
+	Balance       Money     `json:"balance" gorm:"type:numeric(14,2);not null;default:0"`
+	TransactionID string    `json:"transaction_id,omitempty"`
+	LoanID        string    `json:"loan_id" gorm:"not null;index"`
+	UserID        string    `json:"-" gorm:"not null"`
+	PaidAt        time.Time `json:"paid_at"`
+}
+
+type Prepayment struct {
+	Amount Money  `json:"amount"`
+	Mode   string `json:"mode"`
+}
+
+type LoanInstallment struct {
+	Number    int       `json:"number"`
+	DueDate   time.Time `json:"due_date"`
+	Payment   Money     `json:"payment"`
+	Interest  Money     `json:"interest"`
+	Principal Money     `json:"principal"`
+	Balance   Money     `json:"balance"`
+}
+
+type LoanSchedule struct {
+	LoanID        string            `json:"loan_id"`
+	Balance       Money             `json:"balance"`
+	TotalPayment  Money             `json:"total_payment"`
+	TotalInterest Money             `json:"total_interest"`
+	PayoffDate    time.Time         `json:"payoff_date"`
+	Installments  []LoanInstallment `json:"installments"`
+}
+
+const (
+	PayoffStrategyAvalanche = "avalanche"
+	PayoffStrategySnowball  = "snowball"
+	PayoffStrategyCustom    = "custom"
+)
+
+type PayoffOptions struct {
+	ExtraBudget Money
+	Order       []string
+}
+
+type PayoffPayment struct {
+	LoanID    string `json:"loan_id"`
+	Payment   Money  `json:"payment"`
+	Interest  Money  `json:"interest"`
+	Principal Money  `json:"principal"`
+	Balance   Money  `json:"balance"`
+}
+
+type PayoffMonth struct {
+	Month    int             `json:"month"`
+	Date     time.Time       `json:"date"`
+	Payment  Money           `json:"payment"`
+	Balance  Money           `json:"balance"`
+	Payments []PayoffPayment `json:"payments"`
+}
+
+type LoanPayoff struct {
+	LoanID     string    `json:"loan_id"`
+	Name       string    `json:"name"`
+	Months     int       `json:"months"`
+	PayoffDate time.Time `json:"payoff_date"`
+	Interest   Money     `json:"interest"`
+}
+
+type PayoffStrategy struct {
+	Strategy      string        `json:"strategy"`
+	Order         []string      `json:"order"`
+	Months        int           `json:"months"`
+	PayoffDate    time.Time     `json:"payoff_date"`
+	TotalPayment  Money         `json:"total_payment"`
+	TotalInterest Money         `json:"total_interest"`
+	InterestSaved Money         `json:"interest_saved"`
+	Loans         []LoanPayoff  `json:"loans"`
+	Schedule      []PayoffMonth `json:"schedule"`
+}
+
+type PayoffPlan struct {
+	ExtraBudget     Money            `json:"extra_budget"`
+	MonthlyBudget   Money            `json:"monthly_budget"`
+	Balance         Money            `json:"balance"`
+	MinimumMonths   int              `json:"minimum_months"`
+	MinimumInterest Money            `json:"minimum_interest"`
+	Strategies      []PayoffStrategy `json:"strategies"`
+}
@@ -0,0 +1,188 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+type Money int64
+
+const (
+	Satang  Money = 1
+	OneBaht Money = 100
+)
+
+var ErrInvalidMoney = errors.New("invalid money amount")
+
+var moneyPattern = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+func Baht(baht int64) Money {
+	return Money(baht) * OneBaht
+}
+
+func MoneyFromFloat(baht float64) Money {
+	return Money(math.Round(baht * float64(OneBaht)))
+}
+
+func ParseMoney(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	if !moneyPattern.MatchString(value) {
+		return 0, ErrInvalidMoney
+	}
+
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return 0, ErrInvalidMoney
+	}
+
+	satang := rat.Mul(rat, big.NewRat(int64(OneBaht), 1))
+	num, denom := satang.Num(), satang.Denom()
+	quo, rem := new(big.Int).QuoRem(num, denom, new(big.Int))
+	if rem.Sign() != 0 && new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(denom) >= 0 {
+		quo.Add(quo, big.NewInt(int64(num.Sign())))
+	}
+
+	if !quo.IsInt64() {
+		return 0, ErrInvalidMoney
+	}
+
+	return Money(quo.Int64()), nil
+}
+
+func divRound(a, b int64) int64 {
+	if b < 0 {
+		a, b = -a, -b
+	}
+
+	quo, rem := a/b, a%b
+	if rem < 0 {
+		rem = -rem
+	}
+
+	if rem*2 >= b {
+		if a < 0 {
+			quo--
+		} else {
+			quo++
+		}
+	}
+
+	return quo
+}
+
+func (m Money) Float64() float64 {
+	return float64(m) / float64(OneBaht)
+}
+
+func (m Money) String() string {
+	sign := ""
+	value := int64(m)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, value/int64(OneBaht), value%int64(OneBaht))
+}
+
+func (m Money) Mul(n int) Money {
+	return m * Money(n)
+}
+
+func (m Money) MulRate(rate float64) Money {
+	return Money(math.Round(float64(m) * rate))
+}
+
+func (m Money) Div(n int) Money {
+	return m.DivRound(n, Satang)
+}
+
+func (m Money) DivRound(n int, unit Money) Money {
+	if n == 0 || unit <= 0 {
+		return 0
+	}
+
+	return Money(divRound(int64(m), int64(n)*int64(unit))) * unit
+}
+
+func (m Money) Round(unit Money) Money {
+	return m.DivRound(1, unit)
+}
+
+func (m Money) Split(n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+
+	parts := make([]Money, n)
+	quo, rem := m/Money(n), m%Money(n)
+	step := Satang
+	if rem < 0 {
+		step, rem = -Satang, -rem
+	}
+
+	for i := range parts {
+		parts[i] = quo
+		if Money(i) < rem {
+			parts[i] += step
+		}
+	}
+
+	return parts
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "null" {
+		return nil
+	}
+
+	return m.UnmarshalText([]byte(value))
+}
+
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalText(data []byte) error {
+	parsed, err := ParseMoney(string(data))
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case int64:
+		*m = Baht(v)
+		return nil
+	case float64:
+		*m = MoneyFromFloat(v)
+		return nil
+	case []byte:
+		return m.UnmarshalText(v)
+	case string:
+		return m.UnmarshalText([]byte(v))
+	}
+
+	return ErrInvalidMoney
+}
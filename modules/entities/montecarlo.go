@@ -0,0 +1,34 @@
+package entities
+
+import "time"
+
+type MonteCarloOptions struct {
+	Paths            int
+	TargetConfidence float64
+	Seed             *uint64
+}
+
+type BalanceBand struct {
+	Month time.Time `json:"month"`
+	Age   int       `json:"age"`
+	P10   Money     `json:"p10"`
+	P25   Money     `json:"p25"`
+	P50   Money     `json:"p50"`
+	P75   Money     `json:"p75"`
+	P90   Money     `json:"p90"`
+}
+
+type MonteCarloResult struct {
+	Paths                 int           `json:"paths"`
+	Seed                  uint64        `json:"seed,string"`
+	RiskLevel             int           `json:"risk_level"`
+	ReturnMean            float64       `json:"return_mean"`
+	ReturnStdDev          float64       `json:"return_std_dev"`
+	InflationMean         float64       `json:"inflation_mean"`
+	InflationStdDev       float64       `json:"inflation_std_dev"`
+	MonthlyContribution   Money         `json:"monthly_contribution"`
+	SuccessProbability    float64       `json:"success_probability"`
+	TargetConfidence      float64       `json:"target_confidence"`
+	RequiredMonthlySaving *Money        `json:"required_monthly_saving"`
+	Bands                 []BalanceBand `json:"bands"`
+}
@@ -1,14 +1,75 @@
 package entities
 
-import "time"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
 
 type Notification struct {
-	ID        string    `json:"id" gorm:"primaryKey"`
-	UserID    string    `json:"user_id"`
-	Message   string    `json:"message" gorm:"not null"`
-	Type      string    `json:"type" gorm:"not null"`
-	Balance   float64   `json:"balance"`
-	IsRead    bool      `json:"is_read" gorm:"default:false"`
-	ObjectID  string    `json:"object_id" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at"`
+	ID              string             `json:"id" gorm:"primaryKey"`
+	UserID          string             `json:"user_id"`
+	Message         string             `json:"message" gorm:"not null"`
+	Type            string             `json:"type" gorm:"not null"`
+	TemplateKey     string             `json:"template_key"`
+	TemplateVariant int                `json:"template_variant" gorm:"default:0"`
+	TemplateParams  NotificationParams `json:"template_params" gorm:"type:jsonb"`
+	Balance         Money              `json:"balance" gorm:"type:numeric(14,2)"`
+	IsRead          bool               `json:"is_read" gorm:"default:false"`
+	IsArchived      bool               `json:"is_archived" gorm:"default:false"`
+	ObjectID        string             `json:"object_id" gorm:"not null"`
+	CreatedAt       time.Time          `json:"created_at"`
+}
+
+type QueuedEmail struct {
+	ID              string             `json:"id" gorm:"primaryKey"`
+	UserID          string             `json:"user_id" gorm:"not null;index"`
+	NotificationID  string             `json:"notification_id" gorm:"not null"`
+	Message         string             `json:"message" gorm:"not null"`
+	TemplateKey     string             `json:"template_key"`
+	TemplateVariant int                `json:"template_variant" gorm:"default:0"`
+	TemplateParams  NotificationParams `json:"template_params" gorm:"type:jsonb"`
+	CreatedAt       time.Time          `json:"created_at"`
+}
+
+type NotificationParams map[string]string
+
+func (p NotificationParams) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(p)
+}
+
+func (p *NotificationParams) Scan(value interface{}) error {
+	if value == nil {
+		*p = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("invalid notification params")
+	}
+
+	return json.Unmarshal(data, p)
+}
+
+type NotificationFilter struct {
+	Type            string
+	BeforeCreatedAt time.Time
+	BeforeID        string
+	Limit           int
+}
+
+type NotificationPage struct {
+	Notifications []Notification `json:"notifications"`
+	NextCursor    string         `json:"next_cursor"`
 }
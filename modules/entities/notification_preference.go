@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+type NotificationPreference struct {
+	UserID                string    `json:"-" gorm:"primaryKey"`
+	AssetEnabled          bool      `json:"asset" gorm:"not null"`
+	HouseEnabled          bool      `json:"house" gorm:"not null"`
+	LoanEnabled           bool      `json:"loan" gorm:"not null"`
+	RetirementPlanEnabled bool      `json:"retirementplan" gorm:"not null"`
+	InApp                 bool      `json:"in_app" gorm:"not null"`
+	Email                 bool      `json:"email" gorm:"not null"`
+	WeeklyDigest          bool      `json:"weekly_digest" gorm:"not null"`
+	QuietHoursStart       string    `json:"quiet_hours_start"`
+	QuietHoursEnd         string    `json:"quiet_hours_end"`
+	User                  User      `json:"-" gorm:"foreignKey:UserID"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
@@ -2,12 +2,14 @@ package entities
 
 import "time"
 
+const DefaultNursingHouseID = "00001"
+
 type NursingHouse struct {
 	ID           string  `json:"nh_id" gorm:"primaryKey"`
 	Name         string  `json:"name" gorm:"unique" `
 	Province     string  `json:"province"`
 	Address      string  `json:"address"`
-	Price        int     `json:"price" gorm:"not null"`
+	Price        Money   `json:"price" gorm:"type:numeric(14,2);not null"`
 	Google_map   string  `json:"map"`
 	Phone_number string  `json:"phone_number"`
 	Web_site     string  `json:"site"`
@@ -0,0 +1,85 @@
+package entities
+
+import "time"
+
+const (
+	HoldingKindFund    = "fund"
+	HoldingKindStock   = "stock"
+	HoldingKindBond    = "bond"
+	HoldingKindDeposit = "deposit"
+
+	AssetClassEquity      = "equity"
+	AssetClassFixedIncome = "fixed_income"
+	AssetClassCash        = "cash"
+)
+
+type Holding struct {
+	ID         string    `json:"holding_id" gorm:"primaryKey"`
+	Name       string    `json:"name" gorm:"not null"`
+	Symbol     string    `json:"symbol"`
+	Kind       string    `json:"kind" gorm:"not null"`
+	AssetClass string    `json:"asset_class" gorm:"not null"`
+	Quantity   float64   `json:"quantity" gorm:"not null"`
+	CostBasis  Money     `json:"cost_basis" gorm:"type:numeric(14,2);not null"`
+	Price      float64   `json:"price" gorm:"not null"`
+	PricedAt   time.Time `json:"priced_at"`
+	UserID     string    `json:"-" gorm:"not null;index"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type AllocationTarget struct {
+	AssetClass string  `json:"asset_class"`
+	Percent    float64 `json:"percent"`
+}
+
+type HoldingValue struct {
+	Holding
+	MarketValue Money   `json:"market_value"`
+	Gain        Money   `json:"gain"`
+	GainPercent float64 `json:"gain_percent"`
+}
+
+type AllocationSlice struct {
+	AssetClass  string  `json:"asset_class"`
+	MarketValue Money   `json:"market_value"`
+	Percent     float64 `json:"percent"`
+	Target      float64 `json:"target"`
+	Drift       float64 `json:"drift"`
+	Alert       bool    `json:"alert"`
+}
+
+type PortfolioSummary struct {
+	Risk           *Risk             `json:"risk"`
+	Holdings       []HoldingValue    `json:"holdings"`
+	MarketValue    Money             `json:"market_value"`
+	CostBasis      Money             `json:"cost_basis"`
+	Gain           Money             `json:"gain"`
+	GainPercent    float64           `json:"gain_percent"`
+	PlanInvestment Money             `json:"plan_investment"`
+	Allocation     []AllocationSlice `json:"allocation"`
+	DriftThreshold float64           `json:"drift_threshold"`
+	Drifted        bool              `json:"drifted"`
+}
+
+type PriceQuote struct {
+	ID        string    `json:"quote_id" gorm:"primaryKey"`
+	Symbol    string    `json:"symbol" gorm:"not null;uniqueIndex:idx_price_quotes_symbol_date"`
+	Price     float64   `json:"price" gorm:"not null"`
+	PricedAt  time.Time `json:"priced_at" gorm:"type:date;not null;uniqueIndex:idx_price_quotes_symbol_date"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PriceImportResult struct {
+	Imported        int   `json:"imported"`
+	UpdatedHoldings int64 `json:"updated_holdings"`
+}
+
+type PortfolioSnapshot struct {
+	ID          string    `json:"snapshot_id" gorm:"primaryKey"`
+	UserID      string    `json:"-" gorm:"not null;uniqueIndex:idx_portfolio_snapshots_user_date"`
+	Date        time.Time `json:"date" gorm:"type:date;not null;uniqueIndex:idx_portfolio_snapshots_user_date"`
+	MarketValue Money     `json:"market_value" gorm:"type:numeric(14,2);not null"`
+	CostBasis   Money     `json:"cost_basis" gorm:"type:numeric(14,2);not null"`
+	CreatedAt   time.Time `json:"created_at"`
+}
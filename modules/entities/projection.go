@@ -0,0 +1,35 @@
+package entities
+
+import "time"
+
+const (
+	ProjectionPhaseAccumulation = "accumulation"
+	ProjectionPhaseRetirement   = "retirement"
+)
+
+type ProjectionPoint struct {
+	Month         time.Time `json:"month"`
+	Age           int       `json:"age"`
+	Phase         string    `json:"phase"`
+	Contribution  Money     `json:"contribution"`
+	Expense       Money     `json:"expense"`
+	VehicleInflow Money     `json:"vehicle_inflow"`
+	Savings       Money     `json:"savings"`
+	Investment    Money     `json:"investment"`
+	Balance       Money     `json:"balance"`
+	RequiredFunds Money     `json:"required_funds"`
+	Shortfall     Money     `json:"shortfall"`
+}
+
+type RetirementProjection struct {
+	StartMonth            time.Time           `json:"start_month"`
+	RetirementMonth       time.Time           `json:"retirement_month"`
+	EndMonth              time.Time           `json:"end_month"`
+	MonthlyContribution   Money               `json:"monthly_contribution"`
+	BalanceAtRetirement   Money               `json:"balance_at_retirement"`
+	RequiredAtRetirement  Money               `json:"required_at_retirement"`
+	ShortfallAtRetirement Money               `json:"shortfall_at_retirement"`
+	DepletionMonth        *time.Time          `json:"depletion_month"`
+	Vehicles              []VehicleProjection `json:"vehicles"`
+	Points                []ProjectionPoint   `json:"points"`
+}
@@ -0,0 +1,73 @@
+package entities
+
+import "time"
+
+const (
+	ReadinessGoalRetirement   = "retirement"
+	ReadinessGoalNursingHouse = "nursing_house"
+	ReadinessGoalAsset        = "asset"
+	ReadinessGoalLoan         = "loan"
+)
+
+type ReadinessGap struct {
+	Goal            string    `json:"goal"`
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Status          string    `json:"status"`
+	Deadline        time.Time `json:"deadline"`
+	MonthsRemaining int       `json:"months_remaining"`
+	Target          Money     `json:"target"`
+	Current         Money     `json:"current"`
+	Remaining       Money     `json:"remaining"`
+	Expected        Money     `json:"expected"`
+	BehindBy        Money     `json:"behind_by"`
+	BehindSchedule  bool      `json:"behind_schedule"`
+	MonthlyRequired Money     `json:"monthly_required"`
+}
+
+type SurplusAllocation struct {
+	Goal     string `json:"goal"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Required Money  `json:"required"`
+	Amount   Money  `json:"amount"`
+}
+
+type ReadinessReport struct {
+	Score              int                 `json:"score"`
+	FundedRatio        float64             `json:"funded_ratio"`
+	Affordability      float64             `json:"affordability"`
+	RequiredFunds      Money               `json:"required_funds"`
+	CurrentMoney       Money               `json:"current_money"`
+	OutstandingLoans   Money               `json:"outstanding_loans"`
+	MonthlyIncome      Money               `json:"monthly_income"`
+	MonthlyExpenses    Money               `json:"monthly_expenses"`
+	MonthlySurplus     Money               `json:"monthly_surplus"`
+	LoanPayments       Money               `json:"loan_payments"`
+	MonthlyRequired    Money               `json:"monthly_required"`
+	MonthlyShortfall   Money               `json:"monthly_shortfall"`
+	UnallocatedSurplus Money               `json:"unallocated_surplus"`
+	Gaps               []ReadinessGap      `json:"gaps"`
+	Allocations        []SurplusAllocation `json:"allocations"`
+}
+
+type RetirementSummary struct {
+	PlanName               string           `json:"plan_name"`
+	AllRequiredFund        Money            `json:"allRequiredFund"`      //จำนวนเงินที่ต้องการทั้งหมด
+	StillNeed              Money            `json:"stillneed"`            //ขาดอีก
+	AllRetirementFund      Money            `json:"allretirementfund"`    //เงินเกษียณที่ต้องการทั้งหมด
+	MonthlyExpenses        Money            `json:"monthly_expenses"`     //เงินที่ต้องผ่อนเดือนนี้ทั้งหมด - เงินที่เก็บเดือนนี้ทั้งหมด
+	PlanSaving             Money            `json:"plan_saving"`          //เงินออมของแผน
+	AllMoney               Money            `json:"all_money"`            //เงินสุทธิ
+	Saving                 Money            `json:"saving"`               //เงินออมทั้งหมด
+	Investment             Money            `json:"investment"`           //เงินลงทุน
+	AllAssetsExpense       Money            `json:"all_assets_expense"`   //ราคาของทรัพย์สินที่ต้องผ่อนต่อเดือนทั้งหมด
+	NursingHouseExpense    Money            `json:"nursingHouse_expense"` //ราคาบ้านพักต่อเดือน
+	PlanExpense            Money            `json:"plan_expense"`
+	AnnualSavingsReturn    float64          `json:"annual_savings_return"`
+	AnnualInvestmentReturn float64          `json:"annual_investment_return"`
+	VehicleSavings         Money            `json:"vehicle_savings"` //เงินในกองทุนลดหย่อนภาษีและประกันสังคม
+	VehiclePayout          Money            `json:"vehicle_payout"`  //เงินที่คาดว่าจะได้รับจากกองทุนเมื่อเกษียณ
+	VehiclePension         Money            `json:"vehicle_pension"` //บำนาญรายเดือนที่คาดว่าจะได้รับ
+	Readiness              *ReadinessReport `json:"readiness"`
+}
@@ -2,28 +2,61 @@ package entities
 
 import "time"
 
+const (
+	StatusInProgress = "In_Progress"
+	StatusCompleted  = "Completed"
+)
+
 type RetirementPlan struct {
 	ID                      string    `json:"financial_id" gorm:"primaryKey"`
 	PlanName                string    `json:"planName" gorm:"not null"`
 	BirthDate               string    `json:"birth_date" gorm:"not null"`
 	RetirementAge           int       `json:"retirement_age" gorm:"not null"`
 	ExpectLifespan          int       `json:"expect_lifespan" gorm:"not null"`
-	CurrentSavings          float64   `json:"current_savings" gorm:"not null"`
+	CurrentSavings          Money     `json:"current_savings" gorm:"type:numeric(14,2);not null"`
 	CurrentSavingsReturns   float64   `json:"current_savings_returns" gorm:"not null"`
-	MonthlyIncome           float64   `json:"monthly_income" gorm:"not null"`
-	MonthlyExpenses         float64   `json:"monthly_expenses" gorm:"not null"`
-	CurrentTotalInvestment  float64   `json:"current_total_investment" gorm:"not null"`
+	MonthlyIncome           Money     `json:"monthly_income" gorm:"type:numeric(14,2);not null"`
+	MonthlyExpenses         Money     `json:"monthly_expenses" gorm:"type:numeric(14,2);not null"`
+	CurrentTotalInvestment  Money     `json:"current_total_investment" gorm:"type:numeric(14,2);not null"`
 	InvestmentReturn        float64   `json:"investment_return" gorm:"not null"`
-	ExpectedMonthlyExpenses float64   `json:"expected_monthly_expenses"`
+	ExpectedMonthlyExpenses Money     `json:"expected_monthly_expenses" gorm:"type:numeric(14,2)"`
 	ExpectedInflation       float64   `json:"expected_inflation" gorm:"not null"`
 	AnnualExpenseIncrease   float64   `json:"annual_expense_increase" gorm:"not null"`
 	AnnualSavingsReturn     float64   `json:"annual_savings_return" gorm:"not null"`
 	AnnualInvestmentReturn  float64   `json:"annual_investment_return" gorm:"not null"`
-	LastRequiredFunds       float64   `json:"last_required_funds" gorm:"default:0.0"`
-	LastMonthlyExpenses     float64   `json:"last_monthly_expenses" gorm:"default:0.0"`
+	LastRequiredFunds       Money     `json:"last_required_funds" gorm:"type:numeric(14,2);default:0"`
+	LastMonthlyExpenses     Money     `json:"last_monthly_expenses" gorm:"type:numeric(14,2);default:0"`
 	LastCalculatedMonth     int       `json:"last_calculated_month" gorm:"default:0"`
 	Status                  string    `json:"status" gorm:"not null"`
-	UserID                  string    `json:"user_id" gorm:"unique;foreignKey:UserID"`
+	IsActive                bool      `json:"is_active" gorm:"default:false"`
+	UserID                  string    `json:"user_id" gorm:"not null;index;uniqueIndex:idx_retirement_plans_active_user,where:is_active"`
 	CreatedAt               time.Time `json:"created_at"`
 	UpdatedAt               time.Time `json:"updated_at"`
 }
+
+type RetirementPlanRevision struct {
+	ID                      string    `json:"revision_id" gorm:"primaryKey"`
+	PlanID                  string    `json:"financial_id" gorm:"not null;uniqueIndex:idx_retirement_plan_revisions_plan_revision"`
+	Revision                int       `json:"revision" gorm:"not null;uniqueIndex:idx_retirement_plan_revisions_plan_revision"`
+	UserID                  string    `json:"-" gorm:"not null;index"`
+	PlanName                string    `json:"planName"`
+	BirthDate               string    `json:"birth_date"`
+	RetirementAge           int       `json:"retirement_age"`
+	ExpectLifespan          int       `json:"expect_lifespan"`
+	CurrentSavings          Money     `json:"current_savings" gorm:"type:numeric(14,2)"`
+	CurrentSavingsReturns   float64   `json:"current_savings_returns"`
+	MonthlyIncome           Money     `json:"monthly_income" gorm:"type:numeric(14,2)"`
+	MonthlyExpenses         Money     `json:"monthly_expenses" gorm:"type:numeric(14,2)"`
+	CurrentTotalInvestment  Money     `json:"current_total_investment" gorm:"type:numeric(14,2)"`
+	InvestmentReturn        float64   `json:"investment_return"`
+	ExpectedMonthlyExpenses Money     `json:"expected_monthly_expenses" gorm:"type:numeric(14,2)"`
+	ExpectedInflation       float64   `json:"expected_inflation"`
+	AnnualExpenseIncrease   float64   `json:"annual_expense_increase"`
+	AnnualSavingsReturn     float64   `json:"annual_savings_return"`
+	AnnualInvestmentReturn  float64   `json:"annual_investment_return"`
+	LastRequiredFunds       Money     `json:"last_required_funds" gorm:"type:numeric(14,2)"`
+	LastMonthlyExpenses     Money     `json:"last_monthly_expenses" gorm:"type:numeric(14,2)"`
+	Status                  string    `json:"status"`
+	IsActive                bool      `json:"is_active"`
+	CreatedAt               time.Time `json:"created_at"`
+}
@@ -0,0 +1,72 @@
+package entities
+
+import "time"
+
+type Scenario struct {
+	ID                      string          `json:"scenario_id" gorm:"primaryKey"`
+	UserID                  string          `json:"-" gorm:"not null;index"`
+	Name                    string          `json:"name" gorm:"not null"`
+	BirthDate               string          `json:"birth_date" gorm:"not null"`
+	RetirementAge           int             `json:"retirement_age" gorm:"not null"`
+	ExpectLifespan          int             `json:"expect_lifespan" gorm:"not null"`
+	CurrentSavings          Money           `json:"current_savings" gorm:"type:numeric(14,2);not null"`
+	CurrentSavingsReturns   float64         `json:"current_savings_returns" gorm:"not null"`
+	CurrentTotalInvestment  Money           `json:"current_total_investment" gorm:"type:numeric(14,2);not null"`
+	InvestmentReturn        float64         `json:"investment_return" gorm:"not null"`
+	ExpectedMonthlyExpenses Money           `json:"expected_monthly_expenses" gorm:"type:numeric(14,2)"`
+	ExpectedInflation       float64         `json:"expected_inflation" gorm:"not null"`
+	AnnualExpenseIncrease   float64         `json:"annual_expense_increase" gorm:"not null"`
+	AnnualSavingsReturn     float64         `json:"annual_savings_return" gorm:"not null"`
+	AnnualInvestmentReturn  float64         `json:"annual_investment_return" gorm:"not null"`
+	NursingHouseID          string          `json:"-"`
+	HouseCurrentMoney       Money           `json:"house_current_money" gorm:"type:numeric(14,2);default:0"`
+	NursingHouse            NursingHouse    `json:"nursing_house" gorm:"foreignKey:NursingHouseID"`
+	Assets                  []ScenarioAsset `json:"assets" gorm:"foreignKey:ScenarioID;constraint:OnDelete:CASCADE"`
+	CreatedAt               time.Time       `json:"created_at"`
+	UpdatedAt               time.Time       `json:"updated_at"`
+}
+
+type ScenarioAsset struct {
+	ID           string `json:"id" gorm:"primaryKey"`
+	ScenarioID   string `json:"-" gorm:"not null;index"`
+	AssetID      string `json:"asset_id"`
+	Name         string `json:"name" gorm:"not null"`
+	Type         string `json:"type"`
+	TotalCost    Money  `json:"total_cost" gorm:"type:numeric(14,2);not null"`
+	CurrentMoney Money  `json:"current_money" gorm:"type:numeric(14,2);default:0"`
+	Status       string `json:"status"`
+	EndYear      string `json:"end_year"`
+}
+
+type ScenarioRequest struct {
+	Name                    *string  `json:"name"`
+	RetirementAge           *int     `json:"retirement_age"`
+	ExpectLifespan          *int     `json:"expect_lifespan"`
+	CurrentSavingsReturns   *float64 `json:"current_savings_returns"`
+	InvestmentReturn        *float64 `json:"investment_return"`
+	ExpectedMonthlyExpenses *Money   `json:"expected_monthly_expenses"`
+	ExpectedInflation       *float64 `json:"expected_inflation"`
+	AnnualExpenseIncrease   *float64 `json:"annual_expense_increase"`
+	AnnualSavingsReturn     *float64 `json:"annual_savings_return"`
+	AnnualInvestmentReturn  *float64 `json:"annual_investment_return"`
+	NursingHouseID          *string  `json:"nh_id"`
+}
+
+type ScenarioSummary struct {
+	ScenarioID          string `json:"scenario_id"`
+	Name                string `json:"name"`
+	Active              bool   `json:"active"`
+	RetirementAge       int    `json:"retirement_age"`
+	ExpectLifespan      int    `json:"expect_lifespan"`
+	NursingHouseID      string `json:"nh_id"`
+	RetirementFunds     Money  `json:"retirement_funds"`
+	NursingHouseCost    Money  `json:"nursing_house_cost"`
+	AssetsCost          Money  `json:"assets_cost"`
+	RequiredFunds       Money  `json:"required_funds"`
+	CurrentMoney        Money  `json:"current_money"`
+	Shortfall           Money  `json:"shortfall"`
+	PlanMonthlySaving   Money  `json:"plan_monthly_saving"`
+	NursingHouseMonthly Money  `json:"nursing_house_monthly"`
+	AssetsMonthly       Money  `json:"assets_monthly"`
+	MonthlySaving       Money  `json:"monthly_saving"`
+}
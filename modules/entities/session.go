@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+type Session struct {
+	ID           string     `json:"session_id" gorm:"primaryKey"`
+	UserID       string     `json:"-" gorm:"not null;index"`
+	RefreshToken string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	User         User       `json:"-" gorm:"foreignKey:UserID;references:ID"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type AuthToken struct {
+	AccessToken  string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
@@ -0,0 +1,71 @@
+package entities
+
+import "time"
+
+type TaxTable struct {
+	ID                string       `json:"tax_table_id" gorm:"primaryKey"`
+	TaxYear           int          `json:"tax_year" gorm:"not null;uniqueIndex:idx_tax_tables_year_version"`
+	Version           int          `json:"version" gorm:"not null;uniqueIndex:idx_tax_tables_year_version"`
+	ExpenseRate       float64      `json:"expense_rate" gorm:"not null"`
+	ExpenseCap        Money        `json:"expense_cap" gorm:"type:numeric(14,2);not null"`
+	PersonalAllowance Money        `json:"personal_allowance" gorm:"type:numeric(14,2);not null"`
+	Brackets          []TaxBracket `json:"brackets" gorm:"foreignKey:TableID;constraint:OnDelete:CASCADE"`
+	CreatedAt         time.Time    `json:"created_at"`
+}
+
+type TaxBracket struct {
+	ID        string  `json:"-" gorm:"primaryKey"`
+	TableID   string  `json:"-" gorm:"not null;index"`
+	Threshold Money   `json:"threshold" gorm:"type:numeric(14,2);not null"`
+	Rate      float64 `json:"rate" gorm:"not null"`
+}
+
+type TaxDeduction struct {
+	ID        string    `json:"deduction_id" gorm:"primaryKey"`
+	UserID    string    `json:"-" gorm:"not null;index:idx_tax_deductions_user_year"`
+	TaxYear   int       `json:"tax_year" gorm:"not null;index:idx_tax_deductions_user_year"`
+	Name      string    `json:"name" gorm:"not null"`
+	Amount    Money     `json:"amount" gorm:"type:numeric(14,2);not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TaxEstimate struct {
+	TaxYear           int     `json:"tax_year"`
+	TableYear         int     `json:"table_year"`
+	TableVersion      int     `json:"table_version"`
+	AnnualIncome      Money   `json:"annual_income"`
+	ExpenseDeduction  Money   `json:"expense_deduction"`
+	PersonalAllowance Money   `json:"personal_allowance"`
+	Deductions        Money   `json:"deductions"`
+	VehicleDeductions Money   `json:"vehicle_deductions"`
+	TaxableIncome     Money   `json:"taxable_income"`
+	Tax               Money   `json:"tax"`
+	EffectiveRate     float64 `json:"effective_rate"`
+	MarginalRate      float64 `json:"marginal_rate"`
+}
+
+type TaxSaving struct {
+	VehicleType  string `json:"vehicle_type"`
+	Contributed  Money  `json:"contributed"`
+	Room         Money  `json:"room"`
+	Contribution Money  `json:"contribution"`
+	TaxSaving    Money  `json:"tax_saving"`
+}
+
+type TaxRedirect struct {
+	MonthlySaving             Money   `json:"monthly_saving"`
+	MonthsToRetirement        int     `json:"months_to_retirement"`
+	ProjectedValue            Money   `json:"projected_value"`
+	RetirementRemaining       Money   `json:"retirement_remaining"`
+	RetirementMonthlyRequired Money   `json:"retirement_monthly_required"`
+	CoveredRatio              float64 `json:"covered_ratio"`
+}
+
+type TaxOptimization struct {
+	Estimate          TaxEstimate `json:"estimate"`
+	Suggestions       []TaxSaving `json:"suggestions"`
+	TotalContribution Money       `json:"total_contribution"`
+	OptimizedTax      Money       `json:"optimized_tax"`
+	TotalSaving       Money       `json:"total_saving"`
+	Redirect          TaxRedirect `json:"redirect"`
+}
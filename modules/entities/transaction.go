@@ -2,11 +2,38 @@ package entities
 
 import "time"
 
+type TransactionStatus string
+
+const (
+	TransactionDue     TransactionStatus = "DUE"
+	TransactionOverdue TransactionStatus = "OVERDUE"
+	TransactionPaid    TransactionStatus = "PAID"
+	TransactionPaused  TransactionStatus = "PAUSED"
+)
+
 type Transaction struct {
-	ID        string    `json:"trans_id" gorm:"primaryKey"`
-	Status    string    `json:"status" gorm:"not null"`
-	UserID    string    `json:"-" gorm:"not null"`
-	LoanID    string    `json:"-" gorm:"not null"`
-	Loan      Loan      `gorm:"foreignKey:LoanID;references:ID"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            string            `json:"trans_id" gorm:"primaryKey"`
+	Status        TransactionStatus `json:"status" gorm:"not null"`
+	InstallmentNo int               `json:"installment_no" gorm:"not null;default:0"`
+	DueDate       time.Time         `json:"due_date"`
+	Amount        Money             `json:"amount" gorm:"type:numeric(14,2);not null;default:0"`
+	Interest      Money             `json:"interest" gorm:"type:numeric(14,2);not null;default:0"`
+	Principal     Money             `json:"principal" gorm:"type:numeric(14,2);not null;default:0"`
+	PaidAmount    Money             `json:"paid_amount" gorm:"type:numeric(14,2);not null;default:0"`
+	PaidAt        *time.Time        `json:"paid_at"`
+	UserID        string            `json:"-" gorm:"not null"`
+	LoanID        string            `json:"-" gorm:"not null"`
+	Loan          Loan              `gorm:"foreignKey:LoanID;references:ID"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+type PaymentStats struct {
+	Paid            int     `json:"paid"`
+	OnTime          int     `json:"on_time"`
+	Late            int     `json:"late"`
+	Due             int     `json:"due"`
+	Overdue         int     `json:"overdue"`
+	OnTimeRate      float64 `json:"on_time_rate"`
+	AverageDaysLate float64 `json:"average_days_late"`
+	TotalPaid       Money   `json:"total_paid"`
 }
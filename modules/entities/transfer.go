@@ -1,7 +1,7 @@
 package entities
 
 type TransferRequest struct {
-	Type   string  `json:"type"`
-	Name   string  `json:"name"`
-	Amount float64 `json:"amount"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Amount Money  `json:"amount"`
 }
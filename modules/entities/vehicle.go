@@ -0,0 +1,59 @@
+package entities
+
+import "time"
+
+const (
+	VehicleTypeRMF            = "rmf"
+	VehicleTypeSSF            = "ssf"
+	VehicleTypeThaiESG        = "thai_esg"
+	VehicleTypeProvidentFund  = "provident_fund"
+	VehicleTypeSocialSecurity = "social_security"
+
+	HistoryTypeVehicle = "vehicle"
+)
+
+type SavingsVehicle struct {
+	ID                  string    `json:"vehicle_id" gorm:"primaryKey"`
+	Name                string    `json:"name" gorm:"not null"`
+	Type                string    `json:"type" gorm:"not null"`
+	CurrentMoney        Money     `json:"current_money" gorm:"type:numeric(14,2);default:0"`
+	MonthlyContribution Money     `json:"monthly_contribution" gorm:"type:numeric(14,2);default:0"`
+	ExpectedReturn      float64   `json:"expected_return" gorm:"default:0"`
+	ContributionMonths  int       `json:"contribution_months" gorm:"default:0"`
+	AverageWage         Money     `json:"average_wage" gorm:"type:numeric(14,2);default:0"`
+	OpenedAt            time.Time `json:"opened_at"`
+	UserID              string    `json:"-" gorm:"not null;index"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type VehicleRule struct {
+	Type                string  `json:"type"`
+	IncomeRate          float64 `json:"income_rate"`
+	AnnualCap           Money   `json:"annual_cap"`
+	SharesRetirementCap bool    `json:"shares_retirement_cap"`
+	LockYears           int     `json:"lock_years"`
+	UnlockAge           int     `json:"unlock_age"`
+	DefaultReturn       float64 `json:"default_return"`
+}
+
+type VehicleProjection struct {
+	VehicleID        string    `json:"vehicle_id"`
+	Type             string    `json:"type"`
+	Name             string    `json:"name"`
+	PayoutMonth      time.Time `json:"payout_month"`
+	CurrentMoney     Money     `json:"current_money"`
+	ProjectedBalance Money     `json:"projected_balance"`
+	MonthlyPension   Money     `json:"monthly_pension"`
+	TotalPayout      Money     `json:"total_payout"`
+}
+
+type VehicleSummary struct {
+	SavingsVehicle
+	AnnualLimit         Money             `json:"annual_limit"`
+	ContributedThisYear Money             `json:"contributed_this_year"`
+	RemainingLimit      Money             `json:"remaining_limit"`
+	UnlockDate          time.Time         `json:"unlock_date"`
+	Withdrawable        Money             `json:"withdrawable"`
+	Projection          VehicleProjection `json:"projection"`
+}
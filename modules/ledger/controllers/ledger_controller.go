@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/ledger/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LedgerController struct {
+	ledgerusecase usecases.LedgerUseCase
+}
+
+func NewLedgerController(ledgerusecase usecases.LedgerUseCase) *LedgerController {
+	return &LedgerController{ledgerusecase: ledgerusecase}
+}
+
+func parseAsOf(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Now(), true
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date.AddDate(0, 0, 1).Add(-time.Nanosecond), true
+	}
+
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, true
+	}
+
+	return time.Time{}, false
+}
+
+func (c *LedgerController) GetBalancesHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	asOf, ok := parseAsOf(ctx.Query("at"))
+	if !ok {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Bad Request",
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Invalid date format, use YYYY-MM-DD or RFC3339",
+			"result":      nil,
+		})
+	}
+
+	balances, err := c.ledgerusecase.GetBalances(userID, asOf)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusInternalServerError,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Ledger balances retrieved successfully",
+		"result":      balances,
+	})
+}
+
+func (c *LedgerController) GetStatementHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	asOf, ok := parseAsOf(ctx.Query("at"))
+	if !ok {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Bad Request",
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Invalid date format, use YYYY-MM-DD or RFC3339",
+			"result":      nil,
+		})
+	}
+
+	statement, err := c.ledgerusecase.GetStatement(userID, id, asOf)
+	if err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Ledger statement retrieved successfully",
+		"result":      statement,
+	})
+}
+
+func (c *LedgerController) ReconcileHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	report, err := c.ledgerusecase.Reconcile(userID)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusInternalServerError,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Ledger reconciled successfully",
+		"result":      report,
+	})
+}
+
+func (c *LedgerController) AdjustBalancesHandler(ctx *fiber.Ctx) error {
+	userID := ctx.Params("id")
+	adminID, ok := ctx.Locals("user_id").(string)
+	if !ok || adminID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Bad Request",
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Invalid request body",
+			"result":      nil,
+		})
+	}
+
+	adjustments, err := c.ledgerusecase.AdjustBalances(adminID, userID, req.Reason)
+	if err != nil {
+		if err.Error() == "reason is required" {
+			return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"status":      fiber.ErrBadRequest.Message,
+				"status_code": fiber.ErrBadRequest.Code,
+				"message":     err.Error(),
+				"result":      nil,
+			})
+		}
+
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusInternalServerError,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Ledger balances adjusted successfully",
+		"result":      adjustments,
+	})
+}
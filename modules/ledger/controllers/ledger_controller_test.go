@@ -0,0 +1,182 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/ledger/controllers"
+	"github.com/XzerozZ/Kasian_Phrom_BE/testing/usecases/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTest(_ *testing.T) (*controllers.LedgerController, *mocks.MockLedgerUseCase, *fiber.App) {
+	mockUseCase := new(mocks.MockLedgerUseCase)
+	controller := controllers.NewLedgerController(mockUseCase)
+	app := fiber.New()
+	return controller, mockUseCase, app
+}
+
+func TestGetBalancesHandler(t *testing.T) {
+	t.Run("Success with date", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/ledger/accounts", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetBalancesHandler(c)
+		})
+
+		mockUseCase.On("GetBalances", "user-123", mock.MatchedBy(func(asOf time.Time) bool {
+			return asOf.Format("2006-01-02 15:04:05") == "2024-03-31 23:59:59"
+		})).Return([]entities.LedgerBalance{{Account: entities.LedgerAccount{ID: "acc-1"}, Balance: entities.Baht(1500)}}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/ledger/accounts?at=2024-03-31", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "Ledger balances retrieved successfully", responseMap["message"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Invalid date", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/ledger/accounts", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetBalancesHandler(c)
+		})
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/ledger/accounts?at=31-03-2024", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "Invalid date format, use YYYY-MM-DD or RFC3339", responseMap["message"])
+		mockUseCase.AssertNotCalled(t, "GetBalances", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		controller, _, app := setupTest(t)
+		app.Get("/ledger/accounts", controller.GetBalancesHandler)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/ledger/accounts", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestGetStatementHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/ledger/accounts/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetStatementHandler(c)
+		})
+
+		mockUseCase.On("GetStatement", "user-123", "acc-1", mock.AnythingOfType("time.Time")).Return(&entities.LedgerStatement{
+			LedgerBalance: entities.LedgerBalance{Account: entities.LedgerAccount{ID: "acc-1"}, Balance: entities.Baht(250)},
+		}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/ledger/accounts/acc-1", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		result := responseMap["result"].(map[string]interface{})
+		assert.Equal(t, float64(250), result["balance"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/ledger/accounts/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetStatementHandler(c)
+		})
+
+		mockUseCase.On("GetStatement", "user-123", "acc-9", mock.AnythingOfType("time.Time")).Return(nil, errors.New("ledger account not found")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/ledger/accounts/acc-9", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "ledger account not found", responseMap["message"])
+	})
+}
+
+func TestReconcileHandler(t *testing.T) {
+	controller, mockUseCase, app := setupTest(t)
+	app.Get("/ledger/reconcile", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return controller.ReconcileHandler(c)
+	})
+
+	mockUseCase.On("Reconcile", "user-123").Return([]entities.LedgerDrift{{Kind: entities.LedgerAccountHouse, Difference: entities.Baht(250)}}, nil).Once()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/ledger/reconcile", nil), -1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var responseMap map[string]interface{}
+	responseBody, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(responseBody, &responseMap)
+	assert.Len(t, responseMap["result"], 1)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestAdjustBalancesHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/ledger/users/:id/adjustments", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.AdjustBalancesHandler(c)
+		})
+
+		mockUseCase.On("AdjustBalances", "user-123", "user-9", "bank statement").Return([]entities.LedgerEntry{{ID: "entry-1", PostedBy: "user-123", Reason: "bank statement"}}, nil).Once()
+
+		req := httptest.NewRequest("POST", "/ledger/users/user-9/adjustments", strings.NewReader(`{"reason":"bank statement"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Len(t, responseMap["result"], 1)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Missing Reason", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/ledger/users/:id/adjustments", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.AdjustBalancesHandler(c)
+		})
+
+		mockUseCase.On("AdjustBalances", "user-123", "user-9", "").Return(nil, errors.New("reason is required")).Once()
+
+		req := httptest.NewRequest("POST", "/ledger/users/user-9/adjustments", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
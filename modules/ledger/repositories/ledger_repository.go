@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+
+	"gorm.io/gorm"
+)
+
+type GormLedgerRepository struct {
+	db *gorm.DB
+}
+
+func NewGormLedgerRepository(db *gorm.DB) *GormLedgerRepository {
+	return &GormLedgerRepository{db: db}
+}
+
+type LedgerRepository interface {
+	CreateAccount(account *entities.LedgerAccount) (*entities.LedgerAccount, error)
+	GetAccount(userID, kind, refID string) (*entities.LedgerAccount, error)
+	GetAccountByID(id string) (*entities.LedgerAccount, error)
+	GetAccountsByUserID(userID string) ([]entities.LedgerAccount, error)
+	CreateEntry(entry *entities.LedgerEntry) (*entities.LedgerEntry, error)
+	GetEntriesByAccountID(accountID string, asOf time.Time) ([]entities.LedgerEntry, error)
+	GetBalance(accountID string, asOf time.Time) (entities.Money, error)
+}
+
+func (r *GormLedgerRepository) CreateAccount(account *entities.LedgerAccount) (*entities.LedgerAccount, error) {
+	if err := r.db.Create(&account).Error; err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (r *GormLedgerRepository) GetAccount(userID, kind, refID string) (*entities.LedgerAccount, error) {
+	var account entities.LedgerAccount
+	if err := r.db.Where("user_id = ? AND kind = ? AND ref_id = ?", userID, kind, refID).First(&account).Error; err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (r *GormLedgerRepository) GetAccountByID(id string) (*entities.LedgerAccount, error) {
+	var account entities.LedgerAccount
+	if err := r.db.First(&account, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (r *GormLedgerRepository) GetAccountsByUserID(userID string) ([]entities.LedgerAccount, error) {
+	var accounts []entities.LedgerAccount
+	if err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (r *GormLedgerRepository) CreateEntry(entry *entities.LedgerEntry) (*entities.LedgerEntry, error) {
+	if err := r.db.Create(&entry).Error; err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (r *GormLedgerRepository) GetEntriesByAccountID(accountID string, asOf time.Time) ([]entities.LedgerEntry, error) {
+	var entries []entities.LedgerEntry
+	err := r.db.Preload("Postings").
+		Where("posted_at <= ? AND id IN (?)", asOf, r.db.Model(&entities.LedgerPosting{}).Select("entry_id").Where("account_id = ?", accountID)).
+		Order("posted_at ASC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *GormLedgerRepository) GetBalance(accountID string, asOf time.Time) (entities.Money, error) {
+	var balance entities.Money
+	err := r.db.Model(&entities.LedgerPosting{}).
+		Select("COALESCE(SUM(ledger_postings.amount), 0)").
+		Joins("JOIN ledger_entries ON ledger_entries.id = ledger_postings.entry_id").
+		Where("ledger_postings.account_id = ? AND ledger_entries.posted_at <= ?", accountID, asOf).
+		Scan(&balance).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return balance, nil
+}
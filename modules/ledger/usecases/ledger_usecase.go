@@ -0,0 +1,301 @@
+package usecases
+
+import (
+	"errors"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/ledger/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/unitofwork"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Leg struct {
+	Kind    string
+	RefID   string
+	Name    string
+	Amount  entities.Money
+	Balance entities.Money
+}
+
+type LedgerUseCase interface {
+	GetBalances(userID string, asOf time.Time) ([]entities.LedgerBalance, error)
+	GetStatement(userID, accountID string, asOf time.Time) (*entities.LedgerStatement, error)
+	Reconcile(userID string) ([]entities.LedgerDrift, error)
+	AdjustBalances(adminID, userID, reason string) ([]entities.LedgerEntry, error)
+}
+
+type LedgerUseCaseImpl struct {
+	ledgerrepo repositories.LedgerRepository
+	uow        unitofwork.UnitOfWork
+}
+
+func NewLedgerUseCase(ledgerrepo repositories.LedgerRepository, uow unitofwork.UnitOfWork) *LedgerUseCaseImpl {
+	return &LedgerUseCaseImpl{
+		ledgerrepo: ledgerrepo,
+		uow:        uow,
+	}
+}
+
+func mergeLegs(legs []Leg) []Leg {
+	merged := make([]Leg, 0, len(legs))
+	index := make(map[string]int)
+	for _, leg := range legs {
+		key := leg.Kind + "|" + leg.RefID
+		if i, ok := index[key]; ok {
+			merged[i].Amount += leg.Amount
+			merged[i].Balance = leg.Balance
+			continue
+		}
+
+		index[key] = len(merged)
+		merged = append(merged, leg)
+	}
+
+	return merged
+}
+
+func openAccount(repo repositories.LedgerRepository, userID string, leg Leg, postedAt time.Time) (*entities.LedgerAccount, error) {
+	account, err := repo.GetAccount(userID, leg.Kind, leg.RefID)
+	if err == nil {
+		return account, nil
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	account, err = repo.CreateAccount(&entities.LedgerAccount{
+		ID:     uuid.New().String(),
+		UserID: userID,
+		Kind:   leg.Kind,
+		RefID:  leg.RefID,
+		Name:   leg.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if opening := leg.Balance - leg.Amount; opening != 0 {
+		if _, err := PostEntry(repo, userID, "Opening balance", postedAt, Leg{Kind: leg.Kind, RefID: leg.RefID, Name: leg.Name, Amount: opening, Balance: opening}); err != nil {
+			return nil, err
+		}
+	}
+
+	return account, nil
+}
+
+func PostEntry(repo repositories.LedgerRepository, userID, description string, postedAt time.Time, legs ...Leg) (*entities.LedgerEntry, error) {
+	return postEntry(repo, &entities.LedgerEntry{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Description: description,
+		PostedAt:    postedAt,
+	}, legs...)
+}
+
+func postEntry(repo repositories.LedgerRepository, entry *entities.LedgerEntry, legs ...Leg) (*entities.LedgerEntry, error) {
+	userID, postedAt := entry.UserID, entry.PostedAt
+	var total entities.Money
+	for _, leg := range mergeLegs(legs) {
+		if leg.Amount == 0 {
+			continue
+		}
+
+		account, err := openAccount(repo, userID, leg, postedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Postings = append(entry.Postings, entities.LedgerPosting{
+			ID:        uuid.New().String(),
+			EntryID:   entry.ID,
+			AccountID: account.ID,
+			Amount:    leg.Amount,
+		})
+		total += leg.Amount
+	}
+
+	if total != 0 {
+		external, err := openAccount(repo, userID, Leg{Kind: entities.LedgerAccountExternal, RefID: userID, Name: "External"}, postedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Postings = append(entry.Postings, entities.LedgerPosting{
+			ID:        uuid.New().String(),
+			EntryID:   entry.ID,
+			AccountID: external.ID,
+			Amount:    -total,
+		})
+	}
+
+	if len(entry.Postings) == 0 {
+		return nil, nil
+	}
+
+	return repo.CreateEntry(entry)
+}
+
+func GoalLegs(user *entities.User) []Leg {
+	legs := make([]Leg, 0, len(user.Assets)+len(user.Vehicles)+3)
+	for _, asset := range user.Assets {
+		legs = append(legs, Leg{Kind: entities.LedgerAccountAsset, RefID: asset.ID, Name: asset.Name, Balance: asset.CurrentMoney})
+	}
+
+	for _, vehicle := range user.Vehicles {
+		legs = append(legs, Leg{Kind: entities.LedgerAccountVehicle, RefID: vehicle.ID, Name: vehicle.Name, Balance: vehicle.CurrentMoney})
+	}
+
+	legs = append(legs, Leg{Kind: entities.LedgerAccountHouse, RefID: user.ID, Name: user.House.NursingHouse.Name, Balance: user.House.CurrentMoney})
+	if user.RetirementPlan.ID != "" {
+		legs = append(legs,
+			Leg{Kind: entities.LedgerAccountRetirementSavings, RefID: user.ID, Name: user.RetirementPlan.PlanName, Balance: user.RetirementPlan.CurrentSavings},
+			Leg{Kind: entities.LedgerAccountRetirementInvestment, RefID: user.ID, Name: user.RetirementPlan.PlanName, Balance: user.RetirementPlan.CurrentTotalInvestment},
+		)
+	}
+
+	return legs
+}
+
+func (u *LedgerUseCaseImpl) GetBalances(userID string, asOf time.Time) ([]entities.LedgerBalance, error) {
+	accounts, err := u.ledgerrepo.GetAccountsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]entities.LedgerBalance, 0, len(accounts))
+	for _, account := range accounts {
+		balance, err := u.ledgerrepo.GetBalance(account.ID, asOf)
+		if err != nil {
+			return nil, err
+		}
+
+		balances = append(balances, entities.LedgerBalance{
+			Account: account,
+			Balance: balance,
+			AsOf:    asOf,
+		})
+	}
+
+	return balances, nil
+}
+
+func (u *LedgerUseCaseImpl) GetStatement(userID, accountID string, asOf time.Time) (*entities.LedgerStatement, error) {
+	account, err := u.ledgerrepo.GetAccountByID(accountID)
+	if err != nil || account.UserID != userID {
+		return nil, errors.New("ledger account not found")
+	}
+
+	balance, err := u.ledgerrepo.GetBalance(account.ID, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := u.ledgerrepo.GetEntriesByAccountID(account.ID, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.LedgerStatement{
+		LedgerBalance: entities.LedgerBalance{
+			Account: *account,
+			Balance: balance,
+			AsOf:    asOf,
+		},
+		Entries: entries,
+	}, nil
+}
+
+func drifts(repos unitofwork.Repositories, userID string, asOf time.Time) ([]entities.LedgerDrift, error) {
+	user, err := repos.Users.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []entities.LedgerDrift
+	for _, leg := range GoalLegs(user) {
+		var derived entities.Money
+		account, err := repos.Ledger.GetAccount(userID, leg.Kind, leg.RefID)
+		if err == nil {
+			if derived, err = repos.Ledger.GetBalance(account.ID, asOf); err != nil {
+				return nil, err
+			}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		if leg.Balance == derived {
+			continue
+		}
+
+		drifts = append(drifts, entities.LedgerDrift{
+			Kind:          leg.Kind,
+			RefID:         leg.RefID,
+			Name:          leg.Name,
+			LedgerBalance: derived,
+			GoalBalance:   leg.Balance,
+			Difference:    leg.Balance - derived,
+		})
+	}
+
+	return drifts, nil
+}
+
+func (u *LedgerUseCaseImpl) Reconcile(userID string) ([]entities.LedgerDrift, error) {
+	var report []entities.LedgerDrift
+	err := u.uow.Do(func(repos unitofwork.Repositories) error {
+		var err error
+		report, err = drifts(repos, userID, time.Now())
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (u *LedgerUseCaseImpl) AdjustBalances(adminID, userID, reason string) ([]entities.LedgerEntry, error) {
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	var adjustments []entities.LedgerEntry
+	err := u.uow.Do(func(repos unitofwork.Repositories) error {
+		now := time.Now()
+		report, err := drifts(repos, userID, now)
+		if err != nil {
+			return err
+		}
+
+		for _, drift := range report {
+			entry, err := postEntry(repos.Ledger, &entities.LedgerEntry{
+				ID:          uuid.New().String(),
+				UserID:      userID,
+				Description: "Balance adjustment",
+				PostedAt:    now,
+				PostedBy:    adminID,
+				Reason:      reason,
+			}, Leg{Kind: drift.Kind, RefID: drift.RefID, Name: drift.Name, Amount: drift.Difference, Balance: drift.GoalBalance})
+			if err != nil {
+				return err
+			}
+
+			if entry != nil {
+				adjustments = append(adjustments, *entry)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return adjustments, nil
+}
@@ -0,0 +1,219 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/ledger/usecases"
+	"github.com/XzerozZ/Kasian_Phrom_BE/testing/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func createdEntries(repo *mocks.MockLedgerRepository) []*entities.LedgerEntry {
+	var entries []*entities.LedgerEntry
+	for _, call := range repo.Calls {
+		if call.Method == "CreateEntry" {
+			entries = append(entries, call.Arguments.Get(0).(*entities.LedgerEntry))
+		}
+	}
+
+	return entries
+}
+
+func TestPostEntry(t *testing.T) {
+	postedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Balances with external account", func(t *testing.T) {
+		ledgerRepo := new(mocks.MockLedgerRepository)
+		ledgerRepo.On("GetAccount", "user-1", entities.LedgerAccountRetirementSavings, "user-1").Return(&entities.LedgerAccount{ID: "savings"}, nil).Once()
+		ledgerRepo.On("GetAccount", "user-1", entities.LedgerAccountExternal, "user-1").Return(&entities.LedgerAccount{ID: "external"}, nil).Once()
+		ledgerRepo.On("CreateEntry", mock.AnythingOfType("*entities.LedgerEntry")).Return(&entities.LedgerEntry{ID: "entry-1"}, nil).Once()
+
+		entry, err := usecases.PostEntry(ledgerRepo, "user-1", "deposit saving_money", postedAt, usecases.Leg{
+			Kind: entities.LedgerAccountRetirementSavings, RefID: "user-1", Amount: entities.MoneyFromFloat(1200.01), Balance: entities.MoneyFromFloat(1200.01),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "entry-1", entry.ID)
+		entry = createdEntries(ledgerRepo)[0]
+		assert.Len(t, entry.Postings, 2)
+		assert.Equal(t, "savings", entry.Postings[0].AccountID)
+		assert.Equal(t, entities.MoneyFromFloat(1200.01), entry.Postings[0].Amount)
+		assert.Equal(t, "external", entry.Postings[1].AccountID)
+		assert.Equal(t, entities.MoneyFromFloat(-1200.01), entry.Postings[1].Amount)
+		ledgerRepo.AssertExpectations(t)
+	})
+
+	t.Run("Internal transfer needs no external leg", func(t *testing.T) {
+		ledgerRepo := new(mocks.MockLedgerRepository)
+		ledgerRepo.On("GetAccount", "user-1", entities.LedgerAccountAsset, "asset-1").Return(&entities.LedgerAccount{ID: "asset"}, nil).Once()
+		ledgerRepo.On("GetAccount", "user-1", entities.LedgerAccountHouse, "user-1").Return(&entities.LedgerAccount{ID: "house"}, nil).Once()
+		ledgerRepo.On("CreateEntry", mock.AnythingOfType("*entities.LedgerEntry")).Return(&entities.LedgerEntry{ID: "entry-1"}, nil).Once()
+
+		entry, err := usecases.PostEntry(ledgerRepo, "user-1", "Delete asset Car", postedAt,
+			usecases.Leg{Kind: entities.LedgerAccountAsset, RefID: "asset-1", Amount: entities.Baht(-500)},
+			usecases.Leg{Kind: entities.LedgerAccountHouse, RefID: "user-1", Amount: entities.Baht(300), Balance: entities.Baht(300)},
+			usecases.Leg{Kind: entities.LedgerAccountHouse, RefID: "user-1", Amount: entities.Baht(200), Balance: entities.Baht(500)},
+		)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, entry)
+		entry = createdEntries(ledgerRepo)[0]
+		assert.Len(t, entry.Postings, 2)
+		assert.Equal(t, entities.Baht(500), entry.Postings[1].Amount)
+		ledgerRepo.AssertNotCalled(t, "GetAccount", "user-1", entities.LedgerAccountExternal, "user-1")
+	})
+
+	t.Run("Opens new account with opening balance", func(t *testing.T) {
+		ledgerRepo := new(mocks.MockLedgerRepository)
+		ledgerRepo.On("GetAccount", "user-1", entities.LedgerAccountHouse, "user-1").Return(nil, gorm.ErrRecordNotFound).Once()
+		ledgerRepo.On("CreateAccount", mock.AnythingOfType("*entities.LedgerAccount")).Return(&entities.LedgerAccount{ID: "house"}, nil).Once()
+		ledgerRepo.On("GetAccount", "user-1", entities.LedgerAccountHouse, "user-1").Return(&entities.LedgerAccount{ID: "house"}, nil)
+		ledgerRepo.On("GetAccount", "user-1", entities.LedgerAccountExternal, "user-1").Return(&entities.LedgerAccount{ID: "external"}, nil)
+		ledgerRepo.On("CreateEntry", mock.AnythingOfType("*entities.LedgerEntry")).Return(&entities.LedgerEntry{ID: "entry-1"}, nil).Twice()
+
+		entry, err := usecases.PostEntry(ledgerRepo, "user-1", "deposit house", postedAt, usecases.Leg{
+			Kind: entities.LedgerAccountHouse, RefID: "user-1", Amount: entities.Baht(100), Balance: entities.Baht(400),
+		})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, entry)
+		entries := createdEntries(ledgerRepo)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, "Opening balance", entries[0].Description)
+		assert.Equal(t, entities.Baht(300), entries[0].Postings[0].Amount)
+		assert.Equal(t, "deposit house", entries[1].Description)
+		assert.Equal(t, entities.Baht(100), entries[1].Postings[0].Amount)
+		ledgerRepo.AssertExpectations(t)
+	})
+
+	t.Run("No postings", func(t *testing.T) {
+		ledgerRepo := new(mocks.MockLedgerRepository)
+
+		entry, err := usecases.PostEntry(ledgerRepo, "user-1", "noop", postedAt, usecases.Leg{Kind: entities.LedgerAccountHouse, RefID: "user-1", Amount: entities.MoneyFromFloat(0.001)})
+
+		assert.NoError(t, err)
+		assert.Nil(t, entry)
+		ledgerRepo.AssertNotCalled(t, "CreateEntry", mock.Anything)
+	})
+}
+
+func TestGetStatement(t *testing.T) {
+	asOf := time.Now()
+
+	t.Run("Success", func(t *testing.T) {
+		ledgerRepo := new(mocks.MockLedgerRepository)
+		ledgerUseCase := usecases.NewLedgerUseCase(ledgerRepo, mocks.NewMockUnitOfWork(nil, nil, nil, ledgerRepo))
+		ledgerRepo.On("GetAccountByID", "acc-1").Return(&entities.LedgerAccount{ID: "acc-1", UserID: "user-1"}, nil).Once()
+		ledgerRepo.On("GetBalance", "acc-1", asOf).Return(entities.Baht(1500), nil).Once()
+		ledgerRepo.On("GetEntriesByAccountID", "acc-1", asOf).Return([]entities.LedgerEntry{{ID: "entry-1"}}, nil).Once()
+
+		statement, err := ledgerUseCase.GetStatement("user-1", "acc-1", asOf)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(1500), statement.Balance)
+		assert.Len(t, statement.Entries, 1)
+	})
+
+	t.Run("Other user's account", func(t *testing.T) {
+		ledgerRepo := new(mocks.MockLedgerRepository)
+		ledgerUseCase := usecases.NewLedgerUseCase(ledgerRepo, mocks.NewMockUnitOfWork(nil, nil, nil, ledgerRepo))
+		ledgerRepo.On("GetAccountByID", "acc-1").Return(&entities.LedgerAccount{ID: "acc-1", UserID: "user-2"}, nil).Once()
+
+		statement, err := ledgerUseCase.GetStatement("user-1", "acc-1", asOf)
+
+		assert.Nil(t, statement)
+		assert.EqualError(t, err, "ledger account not found")
+		ledgerRepo.AssertNotCalled(t, "GetBalance", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReconcile(t *testing.T) {
+	t.Run("Reports drift without posting", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		ledgerRepo := new(mocks.MockLedgerRepository)
+		uow := mocks.NewMockUnitOfWork(userRepo, nil, nil, ledgerRepo)
+		ledgerUseCase := usecases.NewLedgerUseCase(ledgerRepo, uow)
+
+		userRepo.On("GetUserByID", "user-1").Return(&entities.User{
+			ID:    "user-1",
+			House: entities.SelectedHouse{CurrentMoney: entities.Baht(750)},
+		}, nil).Once()
+		ledgerRepo.On("GetAccount", "user-1", entities.LedgerAccountHouse, "user-1").Return(&entities.LedgerAccount{ID: "house"}, nil)
+		ledgerRepo.On("GetBalance", "house", mock.AnythingOfType("time.Time")).Return(entities.Baht(500), nil).Once()
+
+		report, err := ledgerUseCase.Reconcile("user-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.LedgerDrift{{
+			Kind:          entities.LedgerAccountHouse,
+			RefID:         "user-1",
+			LedgerBalance: entities.Baht(500),
+			GoalBalance:   entities.Baht(750),
+			Difference:    entities.Baht(250),
+		}}, report)
+		ledgerRepo.AssertNotCalled(t, "CreateEntry", mock.Anything)
+	})
+
+	t.Run("Rolls back on error", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		ledgerRepo := new(mocks.MockLedgerRepository)
+		uow := mocks.NewMockUnitOfWork(userRepo, nil, nil, ledgerRepo)
+		ledgerUseCase := usecases.NewLedgerUseCase(ledgerRepo, uow)
+
+		userRepo.On("GetUserByID", "user-1").Return((*entities.User)(nil), errors.New("user not found")).Once()
+
+		report, err := ledgerUseCase.Reconcile("user-1")
+
+		assert.Nil(t, report)
+		assert.EqualError(t, err, "user not found")
+		assert.Equal(t, 1, uow.RolledBack)
+	})
+}
+
+func TestAdjustBalances(t *testing.T) {
+	t.Run("Posts audited adjustment for drift", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		ledgerRepo := new(mocks.MockLedgerRepository)
+		uow := mocks.NewMockUnitOfWork(userRepo, nil, nil, ledgerRepo)
+		ledgerUseCase := usecases.NewLedgerUseCase(ledgerRepo, uow)
+
+		userRepo.On("GetUserByID", "user-1").Return(&entities.User{
+			ID:    "user-1",
+			House: entities.SelectedHouse{CurrentMoney: entities.Baht(750)},
+		}, nil).Once()
+		ledgerRepo.On("GetAccount", "user-1", entities.LedgerAccountHouse, "user-1").Return(&entities.LedgerAccount{ID: "house"}, nil)
+		ledgerRepo.On("GetAccount", "user-1", entities.LedgerAccountExternal, "user-1").Return(&entities.LedgerAccount{ID: "external"}, nil)
+		ledgerRepo.On("GetBalance", "house", mock.AnythingOfType("time.Time")).Return(entities.Baht(500), nil).Once()
+		ledgerRepo.On("CreateEntry", mock.AnythingOfType("*entities.LedgerEntry")).Return(&entities.LedgerEntry{ID: "entry-1"}, nil).Once()
+
+		adjustments, err := ledgerUseCase.AdjustBalances("admin-1", "user-1", "bank statement")
+
+		assert.NoError(t, err)
+		assert.Len(t, adjustments, 1)
+		entry := createdEntries(ledgerRepo)[0]
+		assert.Equal(t, "Balance adjustment", entry.Description)
+		assert.Equal(t, "admin-1", entry.PostedBy)
+		assert.Equal(t, "bank statement", entry.Reason)
+		assert.Equal(t, entities.Baht(250), entry.Postings[0].Amount)
+		assert.Equal(t, entities.Baht(-250), entry.Postings[1].Amount)
+		assert.Equal(t, 1, uow.Committed)
+	})
+
+	t.Run("Requires reason", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		ledgerRepo := new(mocks.MockLedgerRepository)
+		uow := mocks.NewMockUnitOfWork(userRepo, nil, nil, ledgerRepo)
+		ledgerUseCase := usecases.NewLedgerUseCase(ledgerRepo, uow)
+
+		adjustments, err := ledgerUseCase.AdjustBalances("admin-1", "user-1", "")
+
+		assert.Nil(t, adjustments)
+		assert.EqualError(t, err, "reason is required")
+		userRepo.AssertNotCalled(t, "GetUserByID", mock.Anything)
+	})
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"strings"
+
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/loan/usecases"
 	transUsecases "github.com/XzerozZ/Kasian_Phrom_BE/modules/transaction/usecases"
@@ -50,6 +52,7 @@ func (c *LoanController) CreateLoanHandler(ctx *fiber.Ctx) error {
 	}
 
 	loan.UserID = userID
+	loan.RefinancedFrom = ""
 	createdLoan, err := c.loanusecase.CreateLoan(loan)
 	if err != nil {
 		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
@@ -70,7 +73,17 @@ func (c *LoanController) CreateLoanHandler(ctx *fiber.Ctx) error {
 
 func (c *LoanController) GetLoanByIDHandler(ctx *fiber.Ctx) error {
 	id := ctx.Params("id")
-	data, err := c.loanusecase.GetLoanByID(id)
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	data, err := c.loanusecase.GetLoanByID(id, userID)
 	if err != nil {
 		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
 			"status":      fiber.ErrNotFound.Message,
@@ -118,6 +131,11 @@ func (c *LoanController) GetLoanByUserIDHandler(ctx *fiber.Ctx) error {
 			"userID":           loan.UserID,
 			"remaining_months": loan.RemainingMonths,
 			"monthly_expenses": loan.MonthlyExpenses,
+			"principal":        loan.Principal,
+			"interest_rate":    loan.InterestRate,
+			"rate_type":        loan.RateType,
+			"term_months":      loan.TermMonths,
+			"balance":          loan.Balance,
 		})
 	}
 
@@ -185,7 +203,16 @@ func (c *LoanController) DeleteLoanHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	if err := c.loanusecase.DeleteLoanByID(id); err != nil {
+	if err := c.loanusecase.DeleteLoanByID(id, userID); err != nil {
+		if err.Error() == "loan has payment history" || err.Error() == "loan is part of a refinance" {
+			return ctx.Status(fiber.ErrConflict.Code).JSON(fiber.Map{
+				"status":      fiber.ErrConflict.Message,
+				"status_code": fiber.ErrConflict.Code,
+				"message":     err.Error(),
+				"result":      nil,
+			})
+		}
+
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":      "Error",
 			"status_code": fiber.StatusInternalServerError,
@@ -201,3 +228,275 @@ func (c *LoanController) DeleteLoanHandler(ctx *fiber.Ctx) error {
 		"result":      nil,
 	})
 }
+
+func (c *LoanController) GetLoanScheduleHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	schedule, err := c.loanusecase.GetLoanSchedule(id, userID)
+	if err != nil {
+		if err.Error() == "loan has no principal" {
+			return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"status":      fiber.ErrBadRequest.Message,
+				"status_code": fiber.ErrBadRequest.Code,
+				"message":     err.Error(),
+				"result":      nil,
+			})
+		}
+
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Loan schedule retrieved successfully",
+		"result":      schedule,
+	})
+}
+
+func (c *LoanController) PlanDebtPayoffHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	var options entities.PayoffOptions
+	if value := ctx.Query("extra_budget"); value != "" {
+		extraBudget, err := entities.ParseMoney(value)
+		if err != nil {
+			return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"status":      fiber.ErrBadRequest.Message,
+				"status_code": fiber.ErrBadRequest.Code,
+				"message":     "Invalid extra budget, expected a number",
+				"result":      nil,
+			})
+		}
+
+		options.ExtraBudget = extraBudget
+	}
+
+	for _, id := range strings.Split(ctx.Query("order"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			options.Order = append(options.Order, id)
+		}
+	}
+
+	plan, err := c.loanusecase.PlanDebtPayoff(userID, options)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Debt payoff plan retrieved successfully",
+		"result":      plan,
+	})
+}
+
+func (c *LoanController) PrepayLoanHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	var prepayment entities.Prepayment
+	if err := ctx.BodyParser(&prepayment); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	loan, err := c.loanusecase.PrepayLoan(id, userID, prepayment)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Prepayment recorded successfully",
+		"result":      loan,
+	})
+}
+
+func (c *LoanController) ChangeLoanRateHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	var req struct {
+		InterestRate float64 `json:"interest_rate"`
+	}
+
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	loan, err := c.loanusecase.ChangeLoanRate(id, userID, req.InterestRate)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Loan rate changed successfully",
+		"result":      loan,
+	})
+}
+
+func (c *LoanController) RefinanceLoanHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	var terms entities.Loan
+	if err := ctx.BodyParser(&terms); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	loan, err := c.loanusecase.RefinanceLoan(id, userID, terms)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusCreated,
+		"message":     "Loan refinanced successfully",
+		"result":      loan,
+	})
+}
+
+func (c *LoanController) GetLoanPaymentsHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	payments, err := c.loanusecase.GetLoanPayments(id, userID)
+	if err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Loan payments retrieved successfully",
+		"result":      payments,
+	})
+}
+
+func (c *LoanController) GetLoanTransactionsHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	transactions, err := c.loanusecase.GetLoanTransactions(id, userID)
+	if err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Loan transactions retrieved successfully",
+		"result":      transactions,
+	})
+}
@@ -156,7 +156,10 @@ func TestLoanHandlers(t *testing.T) {
 
 	t.Run("GetLoanByIDHandler - Success", func(t *testing.T) {
 		app := fiber.New()
-		app.Get("/loans/:id", controller.GetLoanByIDHandler)
+		app.Get("/loans/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.GetLoanByIDHandler(c)
+		})
 
 		mockLoanUseCase.ExpectedCalls = nil
 		mockLoanUseCase.Calls = nil
@@ -168,7 +171,7 @@ func TestLoanHandlers(t *testing.T) {
 			UserID: "user123",
 		}
 
-		mockLoanUseCase.On("GetLoanByID", "loan123").Return(mockLoan, nil).Once()
+		mockLoanUseCase.On("GetLoanByID", "loan123", "user123").Return(mockLoan, nil).Once()
 
 		req := httptest.NewRequest("GET", "/loans/loan123", nil)
 		resp, err := app.Test(req, -1)
@@ -190,12 +193,15 @@ func TestLoanHandlers(t *testing.T) {
 
 	t.Run("GetLoanByIDHandler - Not Found", func(t *testing.T) {
 		app := fiber.New()
-		app.Get("/loans/:id", controller.GetLoanByIDHandler)
+		app.Get("/loans/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.GetLoanByIDHandler(c)
+		})
 
 		mockLoanUseCase.ExpectedCalls = nil
 		mockLoanUseCase.Calls = nil
 
-		mockLoanUseCase.On("GetLoanByID", "nonexistent").Return(nil, errors.New("loan not found")).Once()
+		mockLoanUseCase.On("GetLoanByID", "nonexistent", "user123").Return(nil, errors.New("loan not found")).Once()
 
 		req := httptest.NewRequest("GET", "/loans/nonexistent", nil)
 		resp, err := app.Test(req, -1)
@@ -215,6 +221,341 @@ func TestLoanHandlers(t *testing.T) {
 		mockLoanUseCase.AssertExpectations(t)
 	})
 
+	t.Run("GetLoanScheduleHandler - Success", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/loans/:id/schedule", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.GetLoanScheduleHandler(c)
+		})
+
+		mockLoanUseCase.ExpectedCalls = nil
+		mockLoanUseCase.Calls = nil
+
+		mockSchedule := &entities.LoanSchedule{
+			LoanID:       "loan123",
+			Balance:      entities.Baht(100000),
+			Installments: []entities.LoanInstallment{{Number: 1, Payment: entities.MoneyFromFloat(8884.88)}},
+		}
+
+		mockLoanUseCase.On("GetLoanSchedule", "loan123", "user123").Return(mockSchedule, nil).Once()
+
+		req := httptest.NewRequest("GET", "/loans/loan123/schedule", nil)
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		assert.Equal(t, "Success", responseMap["status"])
+		assert.Equal(t, float64(fiber.StatusOK), responseMap["status_code"])
+		assert.Equal(t, "Loan schedule retrieved successfully", responseMap["message"])
+		assert.NotNil(t, responseMap["result"])
+
+		mockLoanUseCase.AssertExpectations(t)
+	})
+
+	t.Run("GetLoanScheduleHandler - No Principal", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/loans/:id/schedule", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.GetLoanScheduleHandler(c)
+		})
+
+		mockLoanUseCase.ExpectedCalls = nil
+		mockLoanUseCase.Calls = nil
+
+		mockLoanUseCase.On("GetLoanSchedule", "loan123", "user123").Return(nil, errors.New("loan has no principal")).Once()
+
+		req := httptest.NewRequest("GET", "/loans/loan123/schedule", nil)
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		assert.Equal(t, "Bad Request", responseMap["status"])
+		assert.Equal(t, "loan has no principal", responseMap["message"])
+		assert.Nil(t, responseMap["result"])
+
+		mockLoanUseCase.AssertExpectations(t)
+	})
+
+	t.Run("PlanDebtPayoffHandler - Success", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/loans/payoff", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.PlanDebtPayoffHandler(c)
+		})
+
+		mockLoanUseCase.ExpectedCalls = nil
+		mockLoanUseCase.Calls = nil
+
+		options := entities.PayoffOptions{ExtraBudget: entities.MoneyFromFloat(1500.50), Order: []string{"loan123", "loan456"}}
+		mockPlan := &entities.PayoffPlan{ExtraBudget: options.ExtraBudget, Strategies: []entities.PayoffStrategy{}}
+
+		mockLoanUseCase.On("PlanDebtPayoff", "user123", options).Return(mockPlan, nil).Once()
+
+		req := httptest.NewRequest("GET", "/loans/payoff?extra_budget=1500.50&order=loan123,%20loan456", nil)
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		assert.Equal(t, "Success", responseMap["status"])
+		assert.Equal(t, "Debt payoff plan retrieved successfully", responseMap["message"])
+		assert.NotNil(t, responseMap["result"])
+
+		mockLoanUseCase.AssertExpectations(t)
+	})
+
+	t.Run("PlanDebtPayoffHandler - Invalid Extra Budget", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/loans/payoff", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.PlanDebtPayoffHandler(c)
+		})
+
+		mockLoanUseCase.ExpectedCalls = nil
+		mockLoanUseCase.Calls = nil
+
+		req := httptest.NewRequest("GET", "/loans/payoff?extra_budget=abc", nil)
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		assert.Equal(t, "Invalid extra budget, expected a number", responseMap["message"])
+		mockLoanUseCase.AssertNotCalled(t, "PlanDebtPayoff", mock.Anything, mock.Anything)
+	})
+
+	t.Run("PlanDebtPayoffHandler - Error", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/loans/payoff", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.PlanDebtPayoffHandler(c)
+		})
+
+		mockLoanUseCase.ExpectedCalls = nil
+		mockLoanUseCase.Calls = nil
+
+		mockLoanUseCase.On("PlanDebtPayoff", "user123", entities.PayoffOptions{}).Return(nil, errors.New("no active loans")).Once()
+
+		req := httptest.NewRequest("GET", "/loans/payoff", nil)
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		assert.Equal(t, "Bad Request", responseMap["status"])
+		assert.Equal(t, "no active loans", responseMap["message"])
+		assert.Nil(t, responseMap["result"])
+
+		mockLoanUseCase.AssertExpectations(t)
+	})
+
+	t.Run("PrepayLoanHandler - Success", func(t *testing.T) {
+		app := fiber.New()
+		app.Post("/loans/:id/prepayments", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.PrepayLoanHandler(c)
+		})
+
+		mockLoanUseCase.ExpectedCalls = nil
+		mockLoanUseCase.Calls = nil
+
+		prepayment := entities.Prepayment{Amount: entities.Baht(20000), Mode: entities.PrepaymentShortenTerm}
+		mockLoanUseCase.On("PrepayLoan", "loan123", "user123", prepayment).Return(&entities.Loan{ID: "loan123", Balance: entities.Baht(80000)}, nil).Once()
+
+		body, _ := json.Marshal(prepayment)
+		req := httptest.NewRequest("POST", "/loans/loan123/prepayments", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		assert.Equal(t, "Prepayment recorded successfully", responseMap["message"])
+		mockLoanUseCase.AssertExpectations(t)
+	})
+
+	t.Run("PrepayLoanHandler - Error", func(t *testing.T) {
+		app := fiber.New()
+		app.Post("/loans/:id/prepayments", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.PrepayLoanHandler(c)
+		})
+
+		mockLoanUseCase.ExpectedCalls = nil
+		mockLoanUseCase.Calls = nil
+
+		prepayment := entities.Prepayment{Amount: entities.Baht(20000), Mode: "skip"}
+		mockLoanUseCase.On("PrepayLoan", "loan123", "user123", prepayment).Return(nil, errors.New("invalid prepayment mode")).Once()
+
+		body, _ := json.Marshal(prepayment)
+		req := httptest.NewRequest("POST", "/loans/loan123/prepayments", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockLoanUseCase.AssertExpectations(t)
+	})
+
+	t.Run("ChangeLoanRateHandler - Success", func(t *testing.T) {
+		app := fiber.New()
+		app.Put("/loans/:id/rate", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.ChangeLoanRateHandler(c)
+		})
+
+		mockLoanUseCase.ExpectedCalls = nil
+		mockLoanUseCase.Calls = nil
+
+		mockLoanUseCase.On("ChangeLoanRate", "loan123", "user123", 5.5).Return(&entities.Loan{ID: "loan123", InterestRate: 5.5}, nil).Once()
+
+		req := httptest.NewRequest("PUT", "/loans/loan123/rate", bytes.NewReader([]byte(`{"interest_rate":5.5}`)))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		assert.Equal(t, "Loan rate changed successfully", responseMap["message"])
+		mockLoanUseCase.AssertExpectations(t)
+	})
+
+	t.Run("ChangeLoanRateHandler - Error", func(t *testing.T) {
+		app := fiber.New()
+		app.Put("/loans/:id/rate", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.ChangeLoanRateHandler(c)
+		})
+
+		mockLoanUseCase.ExpectedCalls = nil
+		mockLoanUseCase.Calls = nil
+
+		mockLoanUseCase.On("ChangeLoanRate", "loan123", "user123", 5.5).Return(nil, errors.New("loan rate is not floating")).Once()
+
+		req := httptest.NewRequest("PUT", "/loans/loan123/rate", bytes.NewReader([]byte(`{"interest_rate":5.5}`)))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockLoanUseCase.AssertExpectations(t)
+	})
+
+	t.Run("RefinanceLoanHandler - Success", func(t *testing.T) {
+		app := fiber.New()
+		app.Post("/loans/:id/refinance", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.RefinanceLoanHandler(c)
+		})
+
+		mockLoanUseCase.ExpectedCalls = nil
+		mockLoanUseCase.Calls = nil
+
+		mockLoanUseCase.On("RefinanceLoan", "loan123", "user123", mock.MatchedBy(func(terms entities.Loan) bool {
+			return terms.InterestRate == 5 && terms.TermMonths == 36
+		})).Return(&entities.Loan{ID: "loan456", RefinancedFrom: "loan123"}, nil).Once()
+
+		req := httptest.NewRequest("POST", "/loans/loan123/refinance", bytes.NewReader([]byte(`{"interest_rate": 5, "rate_type": "fixed", "term_months": 36}`)))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		assert.Equal(t, "Loan refinanced successfully", responseMap["message"])
+		mockLoanUseCase.AssertExpectations(t)
+	})
+
+	t.Run("GetLoanPaymentsHandler - Success", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/loans/:id/payments", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.GetLoanPaymentsHandler(c)
+		})
+
+		mockLoanUseCase.ExpectedCalls = nil
+		mockLoanUseCase.Calls = nil
+
+		payments := []entities.LoanPayment{{ID: "payment1", Kind: entities.LoanPaymentInstallment, LoanID: "loan123"}}
+		mockLoanUseCase.On("GetLoanPayments", "loan123", "user123").Return(payments, nil).Once()
+
+		req := httptest.NewRequest("GET", "/loans/loan123/payments", nil)
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		assert.Equal(t, "Loan payments retrieved successfully", responseMap["message"])
+		assert.Len(t, responseMap["result"], 1)
+		mockLoanUseCase.AssertExpectations(t)
+	})
+
+	t.Run("GetLoanTransactionsHandler - Success", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/loans/:id/transactions", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.GetLoanTransactionsHandler(c)
+		})
+
+		mockLoanUseCase.ExpectedCalls = nil
+		mockLoanUseCase.Calls = nil
+
+		transactions := []entities.Transaction{{ID: "trans1", Status: entities.TransactionPaid, LoanID: "loan123"}, {ID: "trans2", Status: entities.TransactionDue, LoanID: "loan123"}}
+		mockLoanUseCase.On("GetLoanTransactions", "loan123", "user123").Return(transactions, nil).Once()
+
+		req := httptest.NewRequest("GET", "/loans/loan123/transactions", nil)
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		assert.Equal(t, "Loan transactions retrieved successfully", responseMap["message"])
+		assert.Len(t, responseMap["result"], 2)
+		mockLoanUseCase.AssertExpectations(t)
+	})
+
 	t.Run("GetLoanByUserIDHandler - Success", func(t *testing.T) {
 		app := fiber.New()
 		app.Get("/user/loans", func(c *fiber.Ctx) error {
@@ -466,7 +807,7 @@ func TestLoanHandlers(t *testing.T) {
 		mockLoanUseCase.ExpectedCalls = nil
 		mockLoanUseCase.Calls = nil
 
-		mockLoanUseCase.On("DeleteLoanByID", "loan123").Return(nil).Once()
+		mockLoanUseCase.On("DeleteLoanByID", "loan123", "user123").Return(nil).Once()
 
 		req := httptest.NewRequest("DELETE", "/loans/loan123", nil)
 		resp, err := app.Test(req, -1)
@@ -486,6 +827,26 @@ func TestLoanHandlers(t *testing.T) {
 		mockLoanUseCase.AssertExpectations(t)
 	})
 
+	t.Run("DeleteLoanHandler - Conflict when loan has payment history", func(t *testing.T) {
+		app := fiber.New()
+		app.Delete("/loans/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return controller.DeleteLoanHandler(c)
+		})
+
+		mockLoanUseCase.ExpectedCalls = nil
+		mockLoanUseCase.Calls = nil
+
+		mockLoanUseCase.On("DeleteLoanByID", "loan123", "user123").Return(errors.New("loan has payment history")).Once()
+
+		req := httptest.NewRequest("DELETE", "/loans/loan123", nil)
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+		mockLoanUseCase.AssertExpectations(t)
+	})
+
 	t.Run("DeleteLoanHandler - Unauthorized", func(t *testing.T) {
 		app := fiber.New()
 		app.Delete("/loans/:id", controller.DeleteLoanHandler)
@@ -516,7 +877,7 @@ func TestLoanHandlers(t *testing.T) {
 		mockLoanUseCase.ExpectedCalls = nil
 		mockLoanUseCase.Calls = nil
 
-		mockLoanUseCase.On("DeleteLoanByID", "loan123").Return(errors.New("database error")).Once()
+		mockLoanUseCase.On("DeleteLoanByID", "loan123", "user123").Return(errors.New("database error")).Once()
 
 		req := httptest.NewRequest("DELETE", "/loans/loan123", nil)
 		resp, err := app.Test(req, -1)
@@ -3,6 +3,7 @@ package repositories
 import (
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GormLoanRepository struct {
@@ -16,6 +17,7 @@ func NewGormLoanRepository(db *gorm.DB) *GormLoanRepository {
 type LoanRepository interface {
 	CreateLoan(loan *entities.Loan) (*entities.Loan, error)
 	GetLoanByID(id string) (*entities.Loan, error)
+	GetLoanByIDForUpdate(id string) (*entities.Loan, error)
 	GetLoanByUserID(userID string) ([]entities.Loan, map[string]interface{}, error)
 	GetAllLoansByStatus(statuses []string) ([]entities.Loan, error)
 	UpdateLoanByID(loan *entities.Loan) (*entities.Loan, error)
@@ -39,6 +41,15 @@ func (r *GormLoanRepository) GetLoanByID(id string) (*entities.Loan, error) {
 	return &loan, nil
 }
 
+func (r *GormLoanRepository) GetLoanByIDForUpdate(id string) (*entities.Loan, error) {
+	var loan entities.Loan
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&loan, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return &loan, nil
+}
+
 func (r *GormLoanRepository) GetLoanByUserID(userID string) ([]entities.Loan, map[string]interface{}, error) {
 	var loans []entities.Loan
 	if err := r.db.Where("user_id = ? AND (status = ? OR status = ?)", userID, "In_Progress", "Paused").Find(&loans).Error; err != nil {
@@ -46,16 +57,23 @@ func (r *GormLoanRepository) GetLoanByUserID(userID string) ([]entities.Loan, ma
 	}
 
 	var totalLoan int
-	var totalLoanAmount float64
-	var totalTransactionAmount float64
+	var totalLoanAmount entities.Money
+	var totalBalance entities.Money
+	var totalTransactionAmount entities.Money
 
 	for _, loan := range loans {
-		loanTotalAmount := float64(loan.RemainingMonths) * loan.MonthlyExpenses
+		loanTotalAmount := loan.MonthlyExpenses.Mul(loan.RemainingMonths)
 		totalLoanAmount += loanTotalAmount
+		if loan.Principal > 0 {
+			totalBalance += loan.Balance
+		} else {
+			totalBalance += loanTotalAmount
+		}
+
 		totalLoan++
 
 		var transactions []entities.Transaction
-		if err := r.db.Where("loan_id = ? AND (status = ? OR status = ?)", loan.ID, "ชำระ", "ค้างชำระ").Find(&transactions).Error; err != nil {
+		if err := r.db.Where("loan_id = ? AND (status = ? OR status = ?)", loan.ID, entities.TransactionDue, entities.TransactionOverdue).Find(&transactions).Error; err != nil {
 			return nil, nil, err
 		}
 
@@ -67,6 +85,7 @@ func (r *GormLoanRepository) GetLoanByUserID(userID string) ([]entities.Loan, ma
 	loanSummary := map[string]interface{}{
 		"total_loan":               totalLoan,
 		"total_amount":             totalLoanAmount,
+		"total_balance":            totalBalance,
 		"total_transaction_amount": totalTransactionAmount,
 	}
 
@@ -2,41 +2,59 @@ package usecases
 
 import (
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/loan/repositories"
 	transRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/transaction/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/unitofwork"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
 	"github.com/google/uuid"
 )
 
 type LoanUseCase interface {
 	CreateLoan(loan entities.Loan) (*entities.Loan, error)
-	GetLoanByID(id string) (*entities.Loan, error)
+	GetLoanByID(id, userID string) (*entities.Loan, error)
 	GetLoanByUserID(userID string) ([]entities.Loan, map[string]interface{}, error)
 	UpdateLoanStatusByID(id string, loan entities.Loan) (*entities.Loan, error)
-	DeleteLoanByID(id string) error
+	DeleteLoanByID(id, userID string) error
+	GetLoanSchedule(id, userID string) (*entities.LoanSchedule, error)
+	PlanDebtPayoff(userID string, options entities.PayoffOptions) (*entities.PayoffPlan, error)
+	PrepayLoan(id, userID string, prepayment entities.Prepayment) (*entities.Loan, error)
+	ChangeLoanRate(id, userID string, annualPercent float64) (*entities.Loan, error)
+	RefinanceLoan(id, userID string, terms entities.Loan) (*entities.Loan, error)
+	GetLoanPayments(id, userID string) ([]entities.LoanPayment, error)
+	GetLoanTransactions(id, userID string) ([]entities.Transaction, error)
 }
 
 type LoanUseCaseImpl struct {
 	loanrepo  repositories.LoanRepository
 	transrepo transRepo.TransRepository
+	uow       unitofwork.UnitOfWork
 }
 
-func NewLoanUseCase(loanrepo repositories.LoanRepository, transrepo transRepo.TransRepository) *LoanUseCaseImpl {
+func NewLoanUseCase(loanrepo repositories.LoanRepository, transrepo transRepo.TransRepository, uow unitofwork.UnitOfWork) *LoanUseCaseImpl {
 	return &LoanUseCaseImpl{
 		loanrepo:  loanrepo,
 		transrepo: transrepo,
+		uow:       uow,
 	}
 }
 
-func (u *LoanUseCaseImpl) CreateLoan(loan entities.Loan) (*entities.Loan, error) {
-	if loan.MonthlyExpenses <= 0 {
-		return nil, errors.New("monthly expense must be greater than zero")
-	}
+func createLoan(repos unitofwork.Repositories, loan entities.Loan) (*entities.Loan, error) {
+	if loan.Principal != 0 || loan.TermMonths != 0 {
+		if err := utils.OpenLoan(&loan, time.Now()); err != nil {
+			return nil, err
+		}
+	} else {
+		if loan.MonthlyExpenses <= 0 {
+			return nil, errors.New("monthly expense must be greater than zero")
+		}
 
-	if loan.RemainingMonths <= 0 {
-		return nil, errors.New("remaining months must be greater than zero")
+		if loan.RemainingMonths <= 0 {
+			return nil, errors.New("remaining months must be greater than zero")
+		}
 	}
 
 	loan.ID = uuid.New().String()
@@ -46,33 +64,67 @@ func (u *LoanUseCaseImpl) CreateLoan(loan entities.Loan) (*entities.Loan, error)
 		loan.Status = "Paused"
 	}
 
-	createdLoan, err := u.loanrepo.CreateLoan(&loan)
+	createdLoan, err := repos.Loans.CreateLoan(&loan)
 	if err != nil {
 		return nil, err
 	}
 
-	status := "ชำระ"
+	status := entities.TransactionDue
 	if loan.Status == "Paused" {
-		status = "หยุดพัก"
+		status = entities.TransactionPaused
+	}
+
+	now := time.Now()
+	installment := utils.NextInstallment(&loan, 0)
+	if installment.DueDate.IsZero() {
+		installment.DueDate = utils.BillingDueDate(now)
 	}
 
 	transaction := &entities.Transaction{
-		ID:        uuid.New().String(),
-		Status:    status,
-		UserID:    loan.UserID,
-		LoanID:    loan.ID,
-		CreatedAt: time.Now(),
+		ID:            uuid.New().String(),
+		Status:        status,
+		InstallmentNo: installment.Number,
+		DueDate:       installment.DueDate,
+		Amount:        installment.Payment,
+		Interest:      installment.Interest,
+		Principal:     installment.Principal,
+		UserID:        loan.UserID,
+		LoanID:        loan.ID,
+		CreatedAt:     now,
 	}
 
-	if err := u.transrepo.CreateTransaction(transaction); err != nil {
-		u.loanrepo.DeleteLoanByID(loan.ID)
+	if err := repos.Transactions.CreateTransaction(transaction); err != nil {
 		return nil, err
 	}
+
 	return createdLoan, nil
 }
 
-func (u *LoanUseCaseImpl) GetLoanByID(id string) (*entities.Loan, error) {
-	return u.loanrepo.GetLoanByID(id)
+func (u *LoanUseCaseImpl) CreateLoan(loan entities.Loan) (*entities.Loan, error) {
+	var createdLoan *entities.Loan
+	err := u.uow.Do(func(repos unitofwork.Repositories) error {
+		var err error
+		createdLoan, err = createLoan(repos, loan)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdLoan, nil
+}
+
+func (u *LoanUseCaseImpl) GetLoanByID(id, userID string) (*entities.Loan, error) {
+	loan, err := u.loanrepo.GetLoanByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.UserID != userID {
+		return nil, errors.New("loan not found")
+	}
+
+	return loan, nil
 }
 
 func (u *LoanUseCaseImpl) GetLoanByUserID(userID string) ([]entities.Loan, map[string]interface{}, error) {
@@ -85,6 +137,14 @@ func (u *LoanUseCaseImpl) UpdateLoanStatusByID(id string, loan entities.Loan) (*
 		return nil, err
 	}
 
+	if existingLoan.UserID != loan.UserID {
+		return nil, errors.New("loan not found")
+	}
+
+	if existingLoan.Status == "Refinanced" {
+		return nil, errors.New("loan has been refinanced")
+	}
+
 	existingLoan.Name = loan.Name
 	installmentChangedToFalse := existingLoan.Installment && !loan.Installment
 	installmentChangedToTrue := !existingLoan.Installment && loan.Installment
@@ -102,15 +162,14 @@ func (u *LoanUseCaseImpl) UpdateLoanStatusByID(id string, loan entities.Loan) (*
 
 	latestTransaction, err := u.transrepo.GetLatestTransactionByLoanID(id)
 	if err == nil {
-		if installmentChangedToFalse && latestTransaction.Status == "ชำระ" {
-			latestTransaction.Status = "หยุดพัก"
-			if err := u.transrepo.UpdateTransaction(latestTransaction); err != nil {
-				return nil, err
-			}
+		next := latestTransaction.Status
+		if installmentChangedToFalse {
+			next = entities.TransactionPaused
+		} else if installmentChangedToTrue {
+			next = entities.TransactionDue
 		}
 
-		if installmentChangedToTrue && latestTransaction.Status == "หยุดพัก" {
-			latestTransaction.Status = "ชำระ"
+		if utils.TransitionTransaction(latestTransaction, next) == nil {
 			if err := u.transrepo.UpdateTransaction(latestTransaction); err != nil {
 				return nil, err
 			}
@@ -126,14 +185,312 @@ func (u *LoanUseCaseImpl) UpdateLoanStatusByID(id string, loan entities.Loan) (*
 
 }
 
-func (u *LoanUseCaseImpl) DeleteLoanByID(id string) error {
-	if err := u.transrepo.DeleteTransactionsByLoanID(id); err != nil {
-		return err
+func (u *LoanUseCaseImpl) DeleteLoanByID(id, userID string) error {
+	return u.uow.Do(func(repos unitofwork.Repositories) error {
+		loan, err := lockLoan(repos, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if loan.RefinancedFrom != "" || loan.Status == "Refinanced" {
+			return errors.New("loan is part of a refinance")
+		}
+
+		payments, err := repos.Transactions.GetPaymentsByLoanIDs([]string{id})
+		if err != nil {
+			return err
+		}
+
+		if len(payments) > 0 {
+			return errors.New("loan has payment history")
+		}
+
+		transactions, err := repos.Transactions.GetTransactionByLoanIDs([]string{id})
+		if err != nil {
+			return err
+		}
+
+		for _, transaction := range transactions {
+			if transaction.Status == entities.TransactionPaid || transaction.PaidAmount > 0 {
+				return errors.New("loan has payment history")
+			}
+		}
+
+		if err := repos.Transactions.DeleteTransactionsByLoanID(id); err != nil {
+			return err
+		}
+
+		return repos.Loans.DeleteLoanByID(id)
+	})
+}
+
+func (u *LoanUseCaseImpl) GetLoanSchedule(id, userID string) (*entities.LoanSchedule, error) {
+	loan, err := u.GetLoanByID(id, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := u.loanrepo.DeleteLoanByID(id); err != nil {
+	return utils.LoanSchedule(loan)
+}
+
+func (u *LoanUseCaseImpl) PlanDebtPayoff(userID string, options entities.PayoffOptions) (*entities.PayoffPlan, error) {
+	loans, _, err := u.loanrepo.GetLoanByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.PlanDebtPayoff(loans, options, time.Now())
+}
+
+func isActiveLoan(loan *entities.Loan) bool {
+	return loan.Status == "In_Progress" || loan.Status == "Paused"
+}
+
+func lockLoan(repos unitofwork.Repositories, id, userID string) (*entities.Loan, error) {
+	loan, err := repos.Loans.GetLoanByIDForUpdate(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.UserID != userID {
+		return nil, errors.New("loan not found")
+	}
+
+	return loan, nil
+}
+
+func unpaidTransactions(repos unitofwork.Repositories, loanID string) ([]entities.Transaction, error) {
+	unpaid, err := repos.Transactions.GetUnpaidTransactionsByLoanIDs([]string{loanID})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(unpaid, func(i, j int) bool {
+		return unpaid[i].InstallmentNo < unpaid[j].InstallmentNo
+	})
+
+	return unpaid, nil
+}
+
+func rebillUnpaid(repos unitofwork.Repositories, loan *entities.Loan) error {
+	unpaid, err := unpaidTransactions(repos, loan.ID)
+	if err != nil {
 		return err
 	}
 
+	for i, transaction := range unpaid {
+		if transaction.PaidAmount > 0 {
+			continue
+		}
+
+		if loan.RemainingMonths == 0 {
+			if err := repos.Transactions.DeleteTransaction(transaction.ID); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		installment := utils.NextInstallment(loan, i)
+		transaction.Amount = installment.Payment
+		transaction.Interest = installment.Interest
+		transaction.Principal = installment.Principal
+		if err := repos.Transactions.UpdateTransaction(&transaction); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+func (u *LoanUseCaseImpl) PrepayLoan(id, userID string, prepayment entities.Prepayment) (*entities.Loan, error) {
+	var updatedLoan *entities.Loan
+	err := u.uow.Do(func(repos unitofwork.Repositories) error {
+		loan, err := lockLoan(repos, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if !isActiveLoan(loan) {
+			return errors.New("loan is not active")
+		}
+
+		if err := utils.ApplyPrepayment(loan, prepayment); err != nil {
+			return err
+		}
+
+		if err := rebillUnpaid(repos, loan); err != nil {
+			return err
+		}
+
+		if loan.RemainingMonths == 0 {
+			loan.Status = "Completed"
+			loan.Installment = false
+		}
+
+		updatedLoan, err = repos.Loans.UpdateLoanByID(loan)
+		if err != nil {
+			return err
+		}
+
+		return repos.Transactions.CreatePayment(&entities.LoanPayment{
+			ID:        uuid.New().String(),
+			Kind:      entities.LoanPaymentPrepayment,
+			Amount:    prepayment.Amount,
+			Principal: prepayment.Amount,
+			Balance:   updatedLoan.Balance,
+			LoanID:    id,
+			UserID:    userID,
+			PaidAt:    time.Now(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedLoan, nil
+}
+
+func (u *LoanUseCaseImpl) ChangeLoanRate(id, userID string, annualPercent float64) (*entities.Loan, error) {
+	var updatedLoan *entities.Loan
+	err := u.uow.Do(func(repos unitofwork.Repositories) error {
+		loan, err := lockLoan(repos, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if !isActiveLoan(loan) {
+			return errors.New("loan is not active")
+		}
+
+		if err := utils.ChangeLoanRate(loan, annualPercent); err != nil {
+			return err
+		}
+
+		if err := rebillUnpaid(repos, loan); err != nil {
+			return err
+		}
+
+		updatedLoan, err = repos.Loans.UpdateLoanByID(loan)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedLoan, nil
+}
+
+func (u *LoanUseCaseImpl) RefinanceLoan(id, userID string, terms entities.Loan) (*entities.Loan, error) {
+	var refinancedLoan *entities.Loan
+	err := u.uow.Do(func(repos unitofwork.Repositories) error {
+		existingLoan, err := lockLoan(repos, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if !isActiveLoan(existingLoan) {
+			return errors.New("loan is not active")
+		}
+
+		outstanding := utils.LoanOutstanding(existingLoan)
+		if terms.Name == "" {
+			terms.Name = existingLoan.Name
+		}
+
+		if terms.Type == "" {
+			terms.Type = existingLoan.Type
+		}
+
+		if terms.RateType == "" {
+			terms.RateType = existingLoan.RateType
+		}
+
+		if terms.Principal == 0 {
+			terms.Principal = outstanding
+		}
+
+		terms.Installment = true
+		terms.RefinancedFrom = existingLoan.ID
+		terms.UserID = userID
+		refinancedLoan, err = createLoan(repos, terms)
+		if err != nil {
+			return err
+		}
+
+		return closeRefinancedLoan(repos, existingLoan, outstanding)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refinancedLoan, nil
+}
+
+func closeRefinancedLoan(repos unitofwork.Repositories, loan *entities.Loan, outstanding entities.Money) error {
+	unpaid, err := unpaidTransactions(repos, loan.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, transaction := range unpaid {
+		if err := repos.Transactions.DeleteTransaction(transaction.ID); err != nil {
+			return err
+		}
+	}
+
+	loan.Status = "Refinanced"
+	loan.Installment = false
+	loan.Balance = 0
+	loan.RemainingMonths = 0
+	if _, err := repos.Loans.UpdateLoanByID(loan); err != nil {
+		return err
+	}
+
+	return repos.Transactions.CreatePayment(&entities.LoanPayment{
+		ID:        uuid.New().String(),
+		Kind:      entities.LoanPaymentRefinance,
+		Amount:    outstanding,
+		Principal: outstanding,
+		LoanID:    loan.ID,
+		UserID:    loan.UserID,
+		PaidAt:    time.Now(),
+	})
+}
+
+func (u *LoanUseCaseImpl) loanChain(id, userID string) ([]string, error) {
+	loan, err := u.GetLoanByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loanIDs := []string{loan.ID}
+	for loan.RefinancedFrom != "" {
+		loan, err = u.GetLoanByID(loan.RefinancedFrom, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		loanIDs = append(loanIDs, loan.ID)
+	}
+
+	return loanIDs, nil
+}
+
+func (u *LoanUseCaseImpl) GetLoanPayments(id, userID string) ([]entities.LoanPayment, error) {
+	loanIDs, err := u.loanChain(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.transrepo.GetPaymentsByLoanIDs(loanIDs)
+}
+
+func (u *LoanUseCaseImpl) GetLoanTransactions(id, userID string) ([]entities.Transaction, error) {
+	loanIDs, err := u.loanChain(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.transrepo.GetTransactionByLoanIDs(loanIDs)
+}
@@ -20,7 +20,7 @@ func TestCreateLoan(t *testing.T) {
 		loan := entities.Loan{
 			UserID:          "user-123",
 			Name:            "Test Loan",
-			MonthlyExpenses: 10000,
+			MonthlyExpenses: entities.Baht(10000),
 			RemainingMonths: 12,
 			Installment:     true,
 			Status:          "In_Progress",
@@ -33,7 +33,7 @@ func TestCreateLoan(t *testing.T) {
 		mockLoanRepo.On("CreateLoan", mock.AnythingOfType("*entities.Loan")).Return(&expectedLoan, nil)
 		mockTransRepo.On("CreateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(nil)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
 		result, err := useCase.CreateLoan(loan)
 
 		assert.NoError(t, err)
@@ -50,7 +50,7 @@ func TestCreateLoan(t *testing.T) {
 		loan := entities.Loan{
 			UserID:          "user-123",
 			Name:            "Test Loan",
-			MonthlyExpenses: 10000,
+			MonthlyExpenses: entities.Baht(10000),
 			RemainingMonths: 12,
 			Installment:     false,
 		}
@@ -62,7 +62,7 @@ func TestCreateLoan(t *testing.T) {
 		mockLoanRepo.On("CreateLoan", mock.AnythingOfType("*entities.Loan")).Return(&expectedLoan, nil)
 		mockTransRepo.On("CreateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(nil)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
 		result, err := useCase.CreateLoan(loan)
 
 		assert.NoError(t, err)
@@ -84,7 +84,7 @@ func TestCreateLoan(t *testing.T) {
 			Installment:     true,
 		}
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
 		result, err := useCase.CreateLoan(loan)
 
 		assert.Error(t, err)
@@ -101,12 +101,12 @@ func TestCreateLoan(t *testing.T) {
 		loan := entities.Loan{
 			UserID:          "user-123",
 			Name:            "Test Loan",
-			MonthlyExpenses: 10000,
+			MonthlyExpenses: entities.Baht(10000),
 			RemainingMonths: 0,
 			Installment:     true,
 		}
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
 		result, err := useCase.CreateLoan(loan)
 
 		assert.Error(t, err)
@@ -123,7 +123,7 @@ func TestCreateLoan(t *testing.T) {
 		loan := entities.Loan{
 			UserID:          "user-123",
 			Name:            "Test Loan",
-			MonthlyExpenses: 10000,
+			MonthlyExpenses: entities.Baht(10000),
 			RemainingMonths: 12,
 			Installment:     true,
 		}
@@ -131,7 +131,7 @@ func TestCreateLoan(t *testing.T) {
 		expectedError := errors.New("database error")
 		mockLoanRepo.On("CreateLoan", mock.AnythingOfType("*entities.Loan")).Return(&loan, expectedError)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
 		result, err := useCase.CreateLoan(loan)
 
 		assert.Error(t, err)
@@ -141,6 +141,51 @@ func TestCreateLoan(t *testing.T) {
 		mockTransRepo.AssertNotCalled(t, "CreateTransaction")
 	})
 
+	t.Run("success with amortizing terms", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		loan := entities.Loan{
+			UserID:       "user-123",
+			Name:         "Car Loan",
+			Type:         "car",
+			Principal:    entities.Baht(300000),
+			InterestRate: 3,
+			RateType:     entities.LoanRateFlat,
+			TermMonths:   48,
+			Installment:  true,
+		}
+
+		mockLoanRepo.On("CreateLoan", mock.MatchedBy(func(created *entities.Loan) bool {
+			return created.MonthlyExpenses == entities.Baht(7000) && created.RemainingMonths == 48 &&
+				created.Balance == entities.Baht(300000) && !created.StartDate.IsZero()
+		})).Return(&loan, nil)
+		mockTransRepo.On("CreateTransaction", mock.MatchedBy(func(transaction *entities.Transaction) bool {
+			return transaction.InstallmentNo == 1 && transaction.Amount == entities.Baht(7000) &&
+				transaction.Interest == entities.Baht(750) && transaction.Principal == entities.Baht(6250) && !transaction.DueDate.IsZero()
+		})).Return(nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.CreateLoan(loan)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockLoanRepo.AssertExpectations(t)
+		mockTransRepo.AssertExpectations(t)
+	})
+
+	t.Run("fail with invalid rate type", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.CreateLoan(entities.Loan{UserID: "user-123", Principal: entities.Baht(100000), InterestRate: 5, TermMonths: 12})
+
+		assert.EqualError(t, err, "invalid rate type")
+		assert.Nil(t, result)
+		mockLoanRepo.AssertNotCalled(t, "CreateLoan", mock.Anything)
+	})
+
 	t.Run("fail if transaction repository returns error", func(t *testing.T) {
 		mockLoanRepo := new(mocks.MockLoanRepository)
 		mockTransRepo := new(mocks.MockTransRepository)
@@ -148,7 +193,7 @@ func TestCreateLoan(t *testing.T) {
 		loan := entities.Loan{
 			UserID:          "user-123",
 			Name:            "Test Loan",
-			MonthlyExpenses: 10000,
+			MonthlyExpenses: entities.Baht(10000),
 			RemainingMonths: 12,
 			Installment:     true,
 		}
@@ -161,14 +206,15 @@ func TestCreateLoan(t *testing.T) {
 
 		mockLoanRepo.On("CreateLoan", mock.AnythingOfType("*entities.Loan")).Return(&expectedLoan, nil)
 		mockTransRepo.On("CreateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(expectedError)
-		mockLoanRepo.On("DeleteLoanByID", mock.AnythingOfType("string")).Return(nil)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		uow := mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, uow)
 		result, err := useCase.CreateLoan(loan)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Equal(t, expectedError, err)
+		assert.Equal(t, 1, uow.RolledBack)
 		mockLoanRepo.AssertExpectations(t)
 		mockTransRepo.AssertExpectations(t)
 	})
@@ -184,7 +230,7 @@ func TestGetLoanByID(t *testing.T) {
 			ID:              loanID,
 			UserID:          "user-123",
 			Name:            "Test Loan",
-			MonthlyExpenses: 10000,
+			MonthlyExpenses: entities.Baht(10000),
 			RemainingMonths: 12,
 			Installment:     true,
 			Status:          "In_Progress",
@@ -192,13 +238,82 @@ func TestGetLoanByID(t *testing.T) {
 
 		mockLoanRepo.On("GetLoanByID", loanID).Return(expectedLoan, nil)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
-		result, err := useCase.GetLoanByID(loanID)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.GetLoanByID(loanID, "user-123")
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedLoan, result)
 		mockLoanRepo.AssertExpectations(t)
 	})
+
+	t.Run("fail if loan belongs to another user", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		loanID := "loan-123"
+		mockLoanRepo.On("GetLoanByID", loanID).Return(&entities.Loan{ID: loanID, UserID: "user-456"}, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.GetLoanByID(loanID, "user-123")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "loan not found", err.Error())
+		mockLoanRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetLoanSchedule(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		mockLoanRepo.On("GetLoanByID", "loan-123").Return(&entities.Loan{
+			ID:              "loan-123",
+			UserID:          "user-123",
+			Principal:       entities.Baht(100000),
+			Balance:         entities.Baht(100000),
+			InterestRate:    12,
+			RateType:        entities.LoanRateFixed,
+			TermMonths:      12,
+			RemainingMonths: 12,
+			StartDate:       time.Date(2024, time.January, 15, 0, 0, 0, 0, time.Local),
+		}, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		schedule, err := useCase.GetLoanSchedule("loan-123", "user-123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "loan-123", schedule.LoanID)
+		assert.Len(t, schedule.Installments, 12)
+		assert.Equal(t, time.Date(2025, time.January, 15, 0, 0, 0, 0, time.Local), schedule.PayoffDate)
+	})
+
+	t.Run("fail without principal", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		mockLoanRepo.On("GetLoanByID", "loan-123").Return(&entities.Loan{ID: "loan-123", UserID: "user-123", MonthlyExpenses: entities.Baht(1000), RemainingMonths: 3}, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		schedule, err := useCase.GetLoanSchedule("loan-123", "user-123")
+
+		assert.Nil(t, schedule)
+		assert.EqualError(t, err, "loan has no principal")
+	})
+
+	t.Run("fail for another user", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		mockLoanRepo.On("GetLoanByID", "loan-123").Return(&entities.Loan{ID: "loan-123", UserID: "user-999"}, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		schedule, err := useCase.GetLoanSchedule("loan-123", "user-123")
+
+		assert.Nil(t, schedule)
+		assert.EqualError(t, err, "loan not found")
+	})
 }
 
 func TestGetLoanByUserID(t *testing.T) {
@@ -212,7 +327,7 @@ func TestGetLoanByUserID(t *testing.T) {
 				ID:              "loan-1",
 				UserID:          userID,
 				Name:            "Test Loan 1",
-				MonthlyExpenses: 10000,
+				MonthlyExpenses: entities.Baht(10000),
 				RemainingMonths: 12,
 				Installment:     true,
 				Status:          "In_Progress",
@@ -221,7 +336,7 @@ func TestGetLoanByUserID(t *testing.T) {
 				ID:              "loan-2",
 				UserID:          userID,
 				Name:            "Test Loan 2",
-				MonthlyExpenses: 5000,
+				MonthlyExpenses: entities.Baht(5000),
 				RemainingMonths: 6,
 				Installment:     false,
 				Status:          "Paused",
@@ -234,7 +349,7 @@ func TestGetLoanByUserID(t *testing.T) {
 
 		mockLoanRepo.On("GetLoanByUserID", userID).Return(expectedLoans, expectedMeta, nil)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
 		result, meta, err := useCase.GetLoanByUserID(userID)
 
 		assert.NoError(t, err)
@@ -255,7 +370,7 @@ func TestGetLoanByUserID(t *testing.T) {
 
 		mockLoanRepo.On("GetLoanByUserID", userID).Return(emptyLoans, emptyMeta, expectedError)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
 		result, meta, err := useCase.GetLoanByUserID(userID)
 
 		assert.Error(t, err)
@@ -276,13 +391,14 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 			ID:              loanID,
 			UserID:          "user-123",
 			Name:            "Test Loan",
-			MonthlyExpenses: 10000,
+			MonthlyExpenses: entities.Baht(10000),
 			RemainingMonths: 12,
 			Installment:     false,
 			Status:          "Paused",
 		}
 
 		updateLoan := entities.Loan{
+			UserID:      "user-123",
 			Name:        "Updated Loan",
 			Installment: true,
 		}
@@ -291,7 +407,7 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 			ID:              loanID,
 			UserID:          "user-123",
 			Name:            "Updated Loan",
-			MonthlyExpenses: 10000,
+			MonthlyExpenses: entities.Baht(10000),
 			RemainingMonths: 12,
 			Installment:     true,
 			Status:          "In_Progress",
@@ -300,7 +416,7 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 		latestTransaction := &entities.Transaction{
 			ID:        "trans-123",
 			LoanID:    loanID,
-			Status:    "หยุดพัก",
+			Status:    entities.TransactionPaused,
 			CreatedAt: time.Now(),
 		}
 
@@ -309,12 +425,12 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 		mockTransRepo.On("UpdateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(nil)
 		mockLoanRepo.On("UpdateLoanByID", mock.AnythingOfType("*entities.Loan")).Return(expectedLoan, nil)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
 		result, err := useCase.UpdateLoanStatusByID(loanID, updateLoan)
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedLoan, result)
-		assert.Equal(t, "ชำระ", latestTransaction.Status)
+		assert.Equal(t, entities.TransactionDue, latestTransaction.Status)
 		mockLoanRepo.AssertExpectations(t)
 		mockTransRepo.AssertExpectations(t)
 	})
@@ -335,6 +451,7 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 		}
 
 		updateLoan := entities.Loan{
+			UserID:      "user-123",
 			Name:        "Updated Loan",
 			Installment: true,
 		}
@@ -356,7 +473,7 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 
 		mockLoanRepo.On("UpdateLoanByID", mock.AnythingOfType("*entities.Loan")).Return(expectedLoan, nil)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
 		result, err := useCase.UpdateLoanStatusByID(loanID, updateLoan)
 
 		assert.NoError(t, err)
@@ -373,6 +490,7 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 
 		loanID := "non-existent-loan"
 		updateLoan := entities.Loan{
+			UserID:      "user-123",
 			Name:        "Updated Loan",
 			Installment: true,
 		}
@@ -380,7 +498,7 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 		var nilLoan *entities.Loan
 		mockLoanRepo.On("GetLoanByID", loanID).Return(nilLoan, errors.New("loan not found"))
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
 		result, err := useCase.UpdateLoanStatusByID(loanID, updateLoan)
 
 		assert.Error(t, err)
@@ -398,13 +516,14 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 			ID:              loanID,
 			UserID:          "user-123",
 			Name:            "Test Loan",
-			MonthlyExpenses: 10000,
+			MonthlyExpenses: entities.Baht(10000),
 			RemainingMonths: 12,
 			Installment:     false,
 			Status:          "Paused",
 		}
 
 		updateLoan := entities.Loan{
+			UserID:      "user-123",
 			Name:        "Updated Loan",
 			Installment: true,
 		}
@@ -412,7 +531,7 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 		latestTransaction := &entities.Transaction{
 			ID:        "trans-123",
 			LoanID:    loanID,
-			Status:    "หยุดพัก",
+			Status:    entities.TransactionPaused,
 			CreatedAt: time.Now(),
 		}
 
@@ -422,7 +541,7 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 		mockTransRepo.On("GetLatestTransactionByLoanID", loanID).Return(latestTransaction, nil)
 		mockTransRepo.On("UpdateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(expectedError)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
 		result, err := useCase.UpdateLoanStatusByID(loanID, updateLoan)
 
 		assert.Error(t, err)
@@ -442,13 +561,14 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 			ID:              loanID,
 			UserID:          "user-123",
 			Name:            "Test Loan",
-			MonthlyExpenses: 10000,
+			MonthlyExpenses: entities.Baht(10000),
 			RemainingMonths: 12,
 			Installment:     true,
 			Status:          "In_Progress",
 		}
 
 		updateLoan := entities.Loan{
+			UserID:      "user-123",
 			Name:        "Updated Loan",
 			Installment: false,
 		}
@@ -456,7 +576,7 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 		latestTransaction := &entities.Transaction{
 			ID:        "trans-123",
 			LoanID:    loanID,
-			Status:    "ชำระ",
+			Status:    entities.TransactionDue,
 			CreatedAt: time.Now(),
 		}
 
@@ -468,7 +588,7 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 		var nilLoan *entities.Loan
 		mockLoanRepo.On("UpdateLoanByID", mock.AnythingOfType("*entities.Loan")).Return(nilLoan, expectedError)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
 		result, err := useCase.UpdateLoanStatusByID(loanID, updateLoan)
 
 		assert.Error(t, err)
@@ -487,13 +607,14 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 			ID:              loanID,
 			UserID:          "user-123",
 			Name:            "Test Loan",
-			MonthlyExpenses: 10000,
+			MonthlyExpenses: entities.Baht(10000),
 			RemainingMonths: 12,
 			Installment:     true,
 			Status:          "In_Progress",
 		}
 
 		updateLoan := entities.Loan{
+			UserID:      "user-123",
 			Name:        "Updated Loan",
 			Installment: false,
 		}
@@ -502,7 +623,7 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 			ID:              loanID,
 			UserID:          "user-123",
 			Name:            "Updated Loan",
-			MonthlyExpenses: 10000,
+			MonthlyExpenses: entities.Baht(10000),
 			RemainingMonths: 12,
 			Installment:     false,
 			Status:          "Paused",
@@ -511,7 +632,7 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 		latestTransaction := &entities.Transaction{
 			ID:        "trans-123",
 			LoanID:    loanID,
-			Status:    "ชำระ",
+			Status:    entities.TransactionDue,
 			CreatedAt: time.Now(),
 		}
 
@@ -520,15 +641,43 @@ func TestUpdateLoanStatusByID(t *testing.T) {
 		mockTransRepo.On("UpdateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(nil)
 		mockLoanRepo.On("UpdateLoanByID", mock.AnythingOfType("*entities.Loan")).Return(expectedLoan, nil)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
 		result, err := useCase.UpdateLoanStatusByID(loanID, updateLoan)
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedLoan, result)
-		assert.Equal(t, "หยุดพัก", latestTransaction.Status)
+		assert.Equal(t, entities.TransactionPaused, latestTransaction.Status)
 		mockLoanRepo.AssertExpectations(t)
 		mockTransRepo.AssertExpectations(t)
 	})
+
+	t.Run("paid installment is not paused", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		existingLoan := &entities.Loan{
+			ID:              "loan-123",
+			UserID:          "user-123",
+			MonthlyExpenses: entities.Baht(10000),
+			RemainingMonths: 11,
+			Installment:     true,
+			Status:          "In_Progress",
+		}
+
+		latestTransaction := &entities.Transaction{ID: "trans-123", LoanID: "loan-123", Status: entities.TransactionPaid}
+
+		mockLoanRepo.On("GetLoanByID", "loan-123").Return(existingLoan, nil)
+		mockTransRepo.On("GetLatestTransactionByLoanID", "loan-123").Return(latestTransaction, nil)
+		mockLoanRepo.On("UpdateLoanByID", existingLoan).Return(existingLoan, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.UpdateLoanStatusByID("loan-123", entities.Loan{UserID: "user-123", Installment: false})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Paused", result.Status)
+		assert.Equal(t, entities.TransactionPaid, latestTransaction.Status)
+		mockTransRepo.AssertNotCalled(t, "UpdateTransaction", mock.Anything)
+	})
 }
 
 func TestDeleteLoanByID(t *testing.T) {
@@ -538,11 +687,14 @@ func TestDeleteLoanByID(t *testing.T) {
 
 		loanID := "loan-123"
 
+		mockLoanRepo.On("GetLoanByIDForUpdate", loanID).Return(&entities.Loan{ID: loanID, UserID: "user-123"}, nil)
+		mockTransRepo.On("GetPaymentsByLoanIDs", []string{loanID}).Return([]entities.LoanPayment{}, nil)
+		mockTransRepo.On("GetTransactionByLoanIDs", []string{loanID}).Return([]entities.Transaction{{ID: "trans-1", Status: entities.TransactionDue, LoanID: loanID}}, nil)
 		mockTransRepo.On("DeleteTransactionsByLoanID", loanID).Return(nil)
 		mockLoanRepo.On("DeleteLoanByID", loanID).Return(nil)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
-		err := useCase.DeleteLoanByID(loanID)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		err := useCase.DeleteLoanByID(loanID, "user-123")
 
 		assert.NoError(t, err)
 		mockLoanRepo.AssertExpectations(t)
@@ -556,10 +708,13 @@ func TestDeleteLoanByID(t *testing.T) {
 		loanID := "loan-123"
 		expectedError := errors.New("transaction deletion failed")
 
+		mockLoanRepo.On("GetLoanByIDForUpdate", loanID).Return(&entities.Loan{ID: loanID, UserID: "user-123"}, nil)
+		mockTransRepo.On("GetPaymentsByLoanIDs", []string{loanID}).Return([]entities.LoanPayment{}, nil)
+		mockTransRepo.On("GetTransactionByLoanIDs", []string{loanID}).Return([]entities.Transaction{{ID: "trans-1", Status: entities.TransactionDue, LoanID: loanID}}, nil)
 		mockTransRepo.On("DeleteTransactionsByLoanID", loanID).Return(expectedError)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
-		err := useCase.DeleteLoanByID(loanID)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		err := useCase.DeleteLoanByID(loanID, "user-123")
 
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
@@ -574,15 +729,395 @@ func TestDeleteLoanByID(t *testing.T) {
 		loanID := "loan-123"
 		expectedError := errors.New("loan deletion failed")
 
+		mockLoanRepo.On("GetLoanByIDForUpdate", loanID).Return(&entities.Loan{ID: loanID, UserID: "user-123"}, nil)
+		mockTransRepo.On("GetPaymentsByLoanIDs", []string{loanID}).Return([]entities.LoanPayment{}, nil)
+		mockTransRepo.On("GetTransactionByLoanIDs", []string{loanID}).Return([]entities.Transaction{{ID: "trans-1", Status: entities.TransactionDue, LoanID: loanID}}, nil)
 		mockTransRepo.On("DeleteTransactionsByLoanID", loanID).Return(nil)
 		mockLoanRepo.On("DeleteLoanByID", loanID).Return(expectedError)
 
-		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo)
-		err := useCase.DeleteLoanByID(loanID)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		err := useCase.DeleteLoanByID(loanID, "user-123")
 
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
 		mockLoanRepo.AssertExpectations(t)
 		mockTransRepo.AssertExpectations(t)
 	})
+	t.Run("fail if loan belongs to another user", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		loanID := "loan-123"
+		mockLoanRepo.On("GetLoanByIDForUpdate", loanID).Return(&entities.Loan{ID: loanID, UserID: "user-456"}, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		err := useCase.DeleteLoanByID(loanID, "user-123")
+
+		assert.Error(t, err)
+		assert.Equal(t, "loan not found", err.Error())
+		mockTransRepo.AssertNotCalled(t, "DeleteTransactionsByLoanID")
+		mockLoanRepo.AssertNotCalled(t, "DeleteLoanByID")
+	})
+
+	t.Run("fail if loan has payment history", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		loanID := "loan-123"
+		mockLoanRepo.On("GetLoanByIDForUpdate", loanID).Return(&entities.Loan{ID: loanID, UserID: "user-123"}, nil)
+		mockTransRepo.On("GetPaymentsByLoanIDs", []string{loanID}).Return([]entities.LoanPayment{{ID: "payment-1", LoanID: loanID}}, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		err := useCase.DeleteLoanByID(loanID, "user-123")
+
+		assert.EqualError(t, err, "loan has payment history")
+		mockTransRepo.AssertNotCalled(t, "DeleteTransactionsByLoanID", mock.Anything)
+		mockLoanRepo.AssertNotCalled(t, "DeleteLoanByID", mock.Anything)
+	})
+
+	t.Run("fail if a transaction was paid before payments were recorded", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		loanID := "loan-123"
+		mockLoanRepo.On("GetLoanByIDForUpdate", loanID).Return(&entities.Loan{ID: loanID, UserID: "user-123"}, nil)
+		mockTransRepo.On("GetPaymentsByLoanIDs", []string{loanID}).Return([]entities.LoanPayment{}, nil)
+		mockTransRepo.On("GetTransactionByLoanIDs", []string{loanID}).Return([]entities.Transaction{{ID: "trans-1", Status: entities.TransactionPaid, LoanID: loanID}}, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		err := useCase.DeleteLoanByID(loanID, "user-123")
+
+		assert.EqualError(t, err, "loan has payment history")
+		mockLoanRepo.AssertNotCalled(t, "DeleteLoanByID", mock.Anything)
+	})
+
+	t.Run("fail if loan is part of a refinance", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		mockLoanRepo.On("GetLoanByIDForUpdate", "loan-new").Return(&entities.Loan{ID: "loan-new", UserID: "user-123", RefinancedFrom: "loan-old"}, nil)
+		mockLoanRepo.On("GetLoanByIDForUpdate", "loan-old").Return(&entities.Loan{ID: "loan-old", UserID: "user-123", Status: "Refinanced"}, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+
+		assert.EqualError(t, useCase.DeleteLoanByID("loan-new", "user-123"), "loan is part of a refinance")
+		assert.EqualError(t, useCase.DeleteLoanByID("loan-old", "user-123"), "loan is part of a refinance")
+		mockLoanRepo.AssertNotCalled(t, "DeleteLoanByID", mock.Anything)
+	})
+}
+
+func TestPlanDebtPayoff(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		loans := []entities.Loan{
+			{ID: "loan-a", UserID: "user-123", MonthlyExpenses: entities.Baht(1000), RemainingMonths: 3},
+			{ID: "loan-b", UserID: "user-123", MonthlyExpenses: entities.Baht(500), RemainingMonths: 10},
+		}
+
+		mockLoanRepo.On("GetLoanByUserID", "user-123").Return(loans, map[string]interface{}{}, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		plan, err := useCase.PlanDebtPayoff("user-123", entities.PayoffOptions{ExtraBudget: entities.Baht(500), Order: []string{"loan-b"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(2000), plan.MonthlyBudget)
+		assert.Len(t, plan.Strategies, 3)
+		assert.Equal(t, []string{"loan-b", "loan-a"}, plan.Strategies[2].Order)
+		mockLoanRepo.AssertExpectations(t)
+	})
+
+	t.Run("fail without loans", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		mockLoanRepo.On("GetLoanByUserID", "user-123").Return([]entities.Loan{}, map[string]interface{}{}, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		plan, err := useCase.PlanDebtPayoff("user-123", entities.PayoffOptions{})
+
+		assert.Nil(t, plan)
+		assert.EqualError(t, err, "no active loans")
+	})
+}
+
+func TestPrepayLoan(t *testing.T) {
+	newLoan := func() *entities.Loan {
+		return &entities.Loan{
+			ID:              "loan-123",
+			UserID:          "user-123",
+			Status:          "In_Progress",
+			Installment:     true,
+			Principal:       entities.Baht(100000),
+			Balance:         entities.Baht(100000),
+			InterestRate:    12,
+			RateType:        entities.LoanRateFixed,
+			TermMonths:      12,
+			RemainingMonths: 12,
+			MonthlyExpenses: entities.MoneyFromFloat(8884.88),
+			StartDate:       time.Date(2024, time.January, 31, 0, 0, 0, 0, time.Local),
+		}
+	}
+
+	t.Run("success rebills unpaid installment", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		loan := newLoan()
+		transaction := entities.Transaction{ID: "trans-1", Status: entities.TransactionDue, InstallmentNo: 1, Amount: entities.MoneyFromFloat(8884.88), LoanID: "loan-123"}
+		var rebilled *entities.Transaction
+		var payment *entities.LoanPayment
+
+		mockLoanRepo.On("GetLoanByIDForUpdate", "loan-123").Return(loan, nil)
+		mockTransRepo.On("GetUnpaidTransactionsByLoanIDs", []string{"loan-123"}).Return([]entities.Transaction{transaction}, nil)
+		mockTransRepo.On("UpdateTransaction", mock.AnythingOfType("*entities.Transaction")).Run(func(args mock.Arguments) {
+			rebilled = args.Get(0).(*entities.Transaction)
+		}).Return(nil)
+		mockLoanRepo.On("UpdateLoanByID", loan).Return(loan, nil)
+		mockTransRepo.On("CreatePayment", mock.AnythingOfType("*entities.LoanPayment")).Run(func(args mock.Arguments) {
+			payment = args.Get(0).(*entities.LoanPayment)
+		}).Return(nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.PrepayLoan("loan-123", "user-123", entities.Prepayment{Amount: entities.Baht(20000), Mode: entities.PrepaymentReduceInstallment})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(80000), result.Balance)
+		assert.Equal(t, entities.MoneyFromFloat(7107.90), result.MonthlyExpenses)
+		assert.Equal(t, entities.MoneyFromFloat(7107.90), rebilled.Amount)
+		assert.Equal(t, entities.Baht(800), rebilled.Interest)
+		assert.Equal(t, entities.LoanPaymentPrepayment, payment.Kind)
+		assert.Equal(t, entities.Baht(20000), payment.Principal)
+		assert.Equal(t, entities.Baht(80000), payment.Balance)
+	})
+
+	t.Run("success paying off the loan", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		loan := newLoan()
+		mockLoanRepo.On("GetLoanByIDForUpdate", "loan-123").Return(loan, nil)
+		mockTransRepo.On("GetUnpaidTransactionsByLoanIDs", []string{"loan-123"}).Return([]entities.Transaction{{ID: "trans-1", Status: entities.TransactionDue, LoanID: "loan-123"}}, nil)
+		mockTransRepo.On("DeleteTransaction", "trans-1").Return(nil)
+		mockLoanRepo.On("UpdateLoanByID", loan).Return(loan, nil)
+		mockTransRepo.On("CreatePayment", mock.AnythingOfType("*entities.LoanPayment")).Return(nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.PrepayLoan("loan-123", "user-123", entities.Prepayment{Amount: entities.Baht(100000), Mode: entities.PrepaymentShortenTerm})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Completed", result.Status)
+		assert.Equal(t, 0, result.RemainingMonths)
+		mockTransRepo.AssertExpectations(t)
+	})
+
+	t.Run("fail for inactive loan", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		loan := newLoan()
+		loan.Status = "Completed"
+		mockLoanRepo.On("GetLoanByIDForUpdate", "loan-123").Return(loan, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.PrepayLoan("loan-123", "user-123", entities.Prepayment{Amount: entities.Baht(100), Mode: entities.PrepaymentShortenTerm})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "loan is not active")
+	})
+}
+
+func TestChangeLoanRate(t *testing.T) {
+	newLoan := func() *entities.Loan {
+		return &entities.Loan{
+			ID:              "loan-123",
+			UserID:          "user-123",
+			Status:          "In_Progress",
+			Installment:     true,
+			Principal:       entities.Baht(100000),
+			Balance:         entities.Baht(100000),
+			InterestRate:    12,
+			RateType:        entities.LoanRateFloating,
+			TermMonths:      12,
+			RemainingMonths: 12,
+			MonthlyExpenses: entities.MoneyFromFloat(8884.88),
+			StartDate:       time.Date(2024, time.January, 31, 0, 0, 0, 0, time.Local),
+		}
+	}
+
+	t.Run("success rebills unpaid installment", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		loan := newLoan()
+		transaction := entities.Transaction{ID: "trans-1", Status: entities.TransactionDue, InstallmentNo: 1, Amount: entities.MoneyFromFloat(8884.88), LoanID: "loan-123"}
+		var rebilled *entities.Transaction
+
+		mockLoanRepo.On("GetLoanByIDForUpdate", "loan-123").Return(loan, nil)
+		mockTransRepo.On("GetUnpaidTransactionsByLoanIDs", []string{"loan-123"}).Return([]entities.Transaction{transaction}, nil)
+		mockTransRepo.On("UpdateTransaction", mock.AnythingOfType("*entities.Transaction")).Run(func(args mock.Arguments) {
+			rebilled = args.Get(0).(*entities.Transaction)
+		}).Return(nil)
+		mockLoanRepo.On("UpdateLoanByID", loan).Return(loan, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.ChangeLoanRate("loan-123", "user-123", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, result.InterestRate)
+		assert.Equal(t, entities.MoneyFromFloat(8333.33), result.MonthlyExpenses)
+		assert.Equal(t, entities.MoneyFromFloat(8333.33), rebilled.Amount)
+		assert.Equal(t, entities.Money(0), rebilled.Interest)
+	})
+
+	t.Run("fail for fixed-rate loan", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		loan := newLoan()
+		loan.RateType = entities.LoanRateFixed
+		mockLoanRepo.On("GetLoanByIDForUpdate", "loan-123").Return(loan, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.ChangeLoanRate("loan-123", "user-123", 6)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "loan rate is not floating")
+		mockLoanRepo.AssertNotCalled(t, "UpdateLoanByID", mock.Anything)
+	})
+}
+
+func TestRefinanceLoan(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		existing := &entities.Loan{
+			ID:              "loan-123",
+			UserID:          "user-123",
+			Name:            "Car",
+			Type:            "car",
+			Status:          "In_Progress",
+			MonthlyExpenses: entities.Baht(5000),
+			RemainingMonths: 10,
+		}
+
+		var created *entities.Loan
+		var payment *entities.LoanPayment
+		mockLoanRepo.On("GetLoanByIDForUpdate", "loan-123").Return(existing, nil)
+		mockLoanRepo.On("CreateLoan", mock.AnythingOfType("*entities.Loan")).Run(func(args mock.Arguments) {
+			created = args.Get(0).(*entities.Loan)
+		}).Return(&entities.Loan{ID: "loan-456", RefinancedFrom: "loan-123"}, nil)
+		mockTransRepo.On("CreateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(nil)
+		mockTransRepo.On("GetUnpaidTransactionsByLoanIDs", []string{"loan-123"}).Return([]entities.Transaction{
+			{ID: "trans-due", Status: entities.TransactionDue, LoanID: "loan-123"},
+		}, nil)
+		mockTransRepo.On("DeleteTransaction", "trans-due").Return(nil)
+		mockLoanRepo.On("UpdateLoanByID", existing).Return(existing, nil)
+		mockTransRepo.On("CreatePayment", mock.AnythingOfType("*entities.LoanPayment")).Run(func(args mock.Arguments) {
+			payment = args.Get(0).(*entities.LoanPayment)
+		}).Return(nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.RefinanceLoan("loan-123", "user-123", entities.Loan{InterestRate: 6, RateType: entities.LoanRateFixed, TermMonths: 24})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "loan-456", result.ID)
+		assert.Equal(t, "loan-123", created.RefinancedFrom)
+		assert.Equal(t, "Car", created.Name)
+		assert.Equal(t, entities.Baht(50000), created.Principal)
+		assert.Equal(t, 24, created.RemainingMonths)
+		assert.Equal(t, "In_Progress", created.Status)
+		assert.Equal(t, "Refinanced", existing.Status)
+		assert.Equal(t, 0, existing.RemainingMonths)
+		assert.Equal(t, entities.LoanPaymentRefinance, payment.Kind)
+		assert.Equal(t, entities.Baht(50000), payment.Amount)
+		mockTransRepo.AssertExpectations(t)
+	})
+
+	t.Run("fail rolls back the new loan when closing the old one fails", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		existing := &entities.Loan{ID: "loan-123", UserID: "user-123", Status: "In_Progress", MonthlyExpenses: entities.Baht(5000), RemainingMonths: 10}
+		mockLoanRepo.On("GetLoanByIDForUpdate", "loan-123").Return(existing, nil)
+		mockLoanRepo.On("CreateLoan", mock.AnythingOfType("*entities.Loan")).Return(&entities.Loan{ID: "loan-456", RefinancedFrom: "loan-123"}, nil)
+		mockTransRepo.On("CreateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(nil)
+		mockTransRepo.On("GetUnpaidTransactionsByLoanIDs", []string{"loan-123"}).Return([]entities.Transaction{}, nil)
+		mockLoanRepo.On("UpdateLoanByID", existing).Return(existing, nil)
+		mockTransRepo.On("CreatePayment", mock.AnythingOfType("*entities.LoanPayment")).Return(errors.New("db error"))
+
+		uow := mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo)
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, uow)
+		result, err := useCase.RefinanceLoan("loan-123", "user-123", entities.Loan{InterestRate: 6, RateType: entities.LoanRateFixed, TermMonths: 24})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "db error")
+		assert.Equal(t, 1, uow.RolledBack)
+		assert.Equal(t, 0, uow.Committed)
+		mockLoanRepo.AssertNotCalled(t, "DeleteLoanByID", mock.Anything)
+	})
+
+	t.Run("fail with invalid terms", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		mockLoanRepo.On("GetLoanByIDForUpdate", "loan-123").Return(&entities.Loan{ID: "loan-123", UserID: "user-123", Status: "In_Progress", MonthlyExpenses: entities.Baht(5000), RemainingMonths: 10}, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.RefinanceLoan("loan-123", "user-123", entities.Loan{InterestRate: 6, RateType: entities.LoanRateFixed})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "term months must be greater than zero")
+		mockLoanRepo.AssertNotCalled(t, "UpdateLoanByID", mock.Anything)
+	})
+}
+
+func TestGetLoanPayments(t *testing.T) {
+	mockLoanRepo := new(mocks.MockLoanRepository)
+	mockTransRepo := new(mocks.MockTransRepository)
+
+	payments := []entities.LoanPayment{{ID: "payment-1", LoanID: "loan-old"}, {ID: "payment-2", LoanID: "loan-new"}}
+	mockLoanRepo.On("GetLoanByID", "loan-new").Return(&entities.Loan{ID: "loan-new", UserID: "user-123", RefinancedFrom: "loan-old"}, nil)
+	mockLoanRepo.On("GetLoanByID", "loan-old").Return(&entities.Loan{ID: "loan-old", UserID: "user-123"}, nil)
+	mockTransRepo.On("GetPaymentsByLoanIDs", []string{"loan-new", "loan-old"}).Return(payments, nil)
+
+	useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+	result, err := useCase.GetLoanPayments("loan-new", "user-123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, payments, result)
+}
+
+func TestGetLoanTransactions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		transactions := []entities.Transaction{{ID: "trans-1", Status: entities.TransactionPaid, LoanID: "loan-old"}, {ID: "trans-2", Status: entities.TransactionDue, LoanID: "loan-new"}}
+		mockLoanRepo.On("GetLoanByID", "loan-new").Return(&entities.Loan{ID: "loan-new", UserID: "user-123", RefinancedFrom: "loan-old"}, nil)
+		mockLoanRepo.On("GetLoanByID", "loan-old").Return(&entities.Loan{ID: "loan-old", UserID: "user-123"}, nil)
+		mockTransRepo.On("GetTransactionByLoanIDs", []string{"loan-new", "loan-old"}).Return(transactions, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.GetLoanTransactions("loan-new", "user-123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, transactions, result)
+	})
+
+	t.Run("fail for another user's loan", func(t *testing.T) {
+		mockLoanRepo := new(mocks.MockLoanRepository)
+		mockTransRepo := new(mocks.MockTransRepository)
+
+		mockLoanRepo.On("GetLoanByID", "loan-new").Return(&entities.Loan{ID: "loan-new", UserID: "user-456"}, nil)
+
+		useCase := usecases.NewLoanUseCase(mockLoanRepo, mockTransRepo, mocks.NewMockLoanUnitOfWork(mockLoanRepo, mockTransRepo))
+		result, err := useCase.GetLoanTransactions("loan-new", "user-123")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "loan not found")
+		mockTransRepo.AssertNotCalled(t, "GetTransactionByLoanIDs", mock.Anything)
+	})
 }
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/notification/usecases"
 	"github.com/gofiber/fiber/v2"
 )
@@ -24,12 +25,19 @@ func (c *NotiController) GetNotificationsByUserIDHandler(ctx *fiber.Ctx) error {
 		})
 	}
 
-	notis, err := c.notiusecase.GetNotificationsByUserID(userID)
+	var notis interface{}
+	var err error
+	if ctx.Query("cursor") == "" && ctx.Query("limit") == "" {
+		notis, err = c.notiusecase.GetAllNotificationsByUserID(userID, ctx.Query("type"))
+	} else {
+		notis, err = c.notiusecase.GetNotificationsByUserID(userID, ctx.Query("type"), ctx.Query("cursor"), ctx.QueryInt("limit"))
+	}
+
 	if err != nil {
-		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"status":      "Not Found",
-			"status_code": fiber.StatusNotFound,
-			"message":     "No Notification found for this user",
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Bad Request",
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
 			"result":      nil,
 		})
 	}
@@ -68,3 +76,226 @@ func (c *NotiController) MarkAsReadHandler(ctx *fiber.Ctx) error {
 		"message":     "Read notification successfully",
 	})
 }
+
+func (c *NotiController) CountUnreadHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	count, err := c.notiusecase.CountUnreadNotifications(userID)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusInternalServerError,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Unread notification count retrieved successfully",
+		"result": fiber.Map{
+			"unread": count,
+		},
+	})
+}
+
+func (c *NotiController) MarkAsReadByIDHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	if err := c.notiusecase.MarkNotificationAsReadByID(id, userID); err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Read notification successfully",
+	})
+}
+
+func (c *NotiController) MarkAsReadByIDsHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	var req struct {
+		IDs []string `json:"ids"`
+	}
+
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Bad Request",
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Invalid request body",
+			"result":      nil,
+		})
+	}
+
+	if err := c.notiusecase.MarkNotificationsAsReadByIDs(req.IDs, userID); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Bad Request",
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Read notification successfully",
+	})
+}
+
+func (c *NotiController) ArchiveHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	if err := c.notiusecase.ArchiveNotificationByID(id, userID); err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Archive notification successfully",
+	})
+}
+
+func (c *NotiController) DeleteHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	if err := c.notiusecase.DeleteNotificationByID(id, userID); err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Delete notification successfully",
+	})
+}
+
+func (c *NotiController) GetPreferenceHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	preference, err := c.notiusecase.GetPreference(userID)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusInternalServerError,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Notification preference retrieved successfully",
+		"result":      preference,
+	})
+}
+
+func (c *NotiController) UpdatePreferenceHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	var preference entities.NotificationPreference
+	if err := ctx.BodyParser(&preference); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Bad Request",
+			"status_code": fiber.StatusBadRequest,
+			"message":     "Invalid request body",
+			"result":      nil,
+		})
+	}
+
+	updated, err := c.notiusecase.UpdatePreference(userID, preference)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":      "Bad Request",
+			"status_code": fiber.StatusBadRequest,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Notification preference updated successfully",
+		"result":      updated,
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
@@ -28,13 +29,16 @@ func TestNotiHandlers(t *testing.T) {
 		mockUseCase.ExpectedCalls = nil
 		mockUseCase.Calls = nil
 
-		mockNotifications := []entities.Notification{
-			{ID: "1", UserID: "user_123", Message: "Test notification 1", IsRead: false},
-			{ID: "2", UserID: "user_123", Message: "Test notification 2", IsRead: true},
+		mockNotifications := &entities.NotificationPage{
+			Notifications: []entities.Notification{
+				{ID: "1", UserID: "user_123", Message: "Test notification 1", IsRead: false},
+				{ID: "2", UserID: "user_123", Message: "Test notification 2", IsRead: true},
+			},
+			NextCursor: "next",
 		}
 
-		mockUseCase.On("GetNotificationsByUserID", "user_123").Return(mockNotifications, nil).Once()
-		req := httptest.NewRequest("GET", "/notifications", nil)
+		mockUseCase.On("GetNotificationsByUserID", "user_123", "loan", "abc", 10).Return(mockNotifications, nil).Once()
+		req := httptest.NewRequest("GET", "/notifications?type=loan&cursor=abc&limit=10", nil)
 
 		resp, err := app.Test(req, -1)
 		assert.NoError(t, err)
@@ -52,6 +56,39 @@ func TestNotiHandlers(t *testing.T) {
 		mockUseCase.AssertExpectations(t)
 	})
 
+	t.Run("GetNotificationsByUserIDHandler - Unpaged", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/notifications", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user_123")
+			return controller.GetNotificationsByUserIDHandler(c)
+		})
+
+		mockUseCase.ExpectedCalls = nil
+		mockUseCase.Calls = nil
+
+		mockNotifications := []entities.Notification{
+			{ID: "1", UserID: "user_123", Message: "Test notification 1", IsRead: false},
+			{ID: "2", UserID: "user_123", Message: "Test notification 2", IsRead: true},
+		}
+
+		mockUseCase.On("GetAllNotificationsByUserID", "user_123", "").Return(mockNotifications, nil).Once()
+		req := httptest.NewRequest("GET", "/notifications", nil)
+
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		result, ok := responseMap["result"].([]interface{})
+		assert.True(t, ok)
+		assert.Len(t, result, 2)
+
+		mockUseCase.AssertExpectations(t)
+	})
+
 	t.Run("GetNotificationsByUserIDHandler - Unauthorized", func(t *testing.T) {
 		app := fiber.New()
 		app.Get("/notifications", controller.GetNotificationsByUserIDHandler)
@@ -71,7 +108,7 @@ func TestNotiHandlers(t *testing.T) {
 		assert.Nil(t, responseMap["result"])
 	})
 
-	t.Run("GetNotificationsByUserIDHandler - NotFound", func(t *testing.T) {
+	t.Run("GetNotificationsByUserIDHandler - BadRequest", func(t *testing.T) {
 		app := fiber.New()
 		app.Get("/notifications", func(c *fiber.Ctx) error {
 			c.Locals("user_id", "user_123")
@@ -81,20 +118,20 @@ func TestNotiHandlers(t *testing.T) {
 		mockUseCase.ExpectedCalls = nil
 		mockUseCase.Calls = nil
 
-		mockUseCase.On("GetNotificationsByUserID", "user_123").Return([]entities.Notification{}, errors.New("no notifications found")).Once()
-		req := httptest.NewRequest("GET", "/notifications", nil)
+		mockUseCase.On("GetAllNotificationsByUserID", "user_123", "unknown").Return(nil, errors.New("invalid notification type")).Once()
+		req := httptest.NewRequest("GET", "/notifications?type=unknown", nil)
 
 		resp, err := app.Test(req, -1)
 		assert.NoError(t, err)
-		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 
 		var responseMap map[string]interface{}
 		responseBody, _ := io.ReadAll(resp.Body)
 		json.Unmarshal(responseBody, &responseMap)
 
-		assert.Equal(t, "Not Found", responseMap["status"])
-		assert.Equal(t, float64(fiber.StatusNotFound), responseMap["status_code"])
-		assert.Equal(t, "No Notification found for this user", responseMap["message"])
+		assert.Equal(t, "Bad Request", responseMap["status"])
+		assert.Equal(t, float64(fiber.StatusBadRequest), responseMap["status_code"])
+		assert.Equal(t, "invalid notification type", responseMap["message"])
 		assert.Nil(t, responseMap["result"])
 
 		mockUseCase.AssertExpectations(t)
@@ -173,6 +210,145 @@ func TestNotiHandlers(t *testing.T) {
 		assert.Equal(t, "notifications not found", responseMap["message"])
 		assert.Nil(t, responseMap["result"])
 
+		mockUseCase.AssertExpectations(t)
+	})
+	t.Run("CountUnreadHandler - Success", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/notifications/unread", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user_123")
+			return controller.CountUnreadHandler(c)
+		})
+
+		mockUseCase.ExpectedCalls = nil
+		mockUseCase.Calls = nil
+
+		mockUseCase.On("CountUnreadNotifications", "user_123").Return(int64(3), nil).Once()
+		req := httptest.NewRequest("GET", "/notifications/unread", nil)
+
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		result := responseMap["result"].(map[string]interface{})
+		assert.Equal(t, float64(3), result["unread"])
+
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("MarkAsReadByIDHandler - Success", func(t *testing.T) {
+		app := fiber.New()
+		app.Put("/notifications/:id/read", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user_123")
+			return controller.MarkAsReadByIDHandler(c)
+		})
+
+		mockUseCase.ExpectedCalls = nil
+		mockUseCase.Calls = nil
+
+		mockUseCase.On("MarkNotificationAsReadByID", "noti_1", "user_123").Return(nil).Once()
+		req := httptest.NewRequest("PUT", "/notifications/noti_1/read", nil)
+
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("MarkAsReadByIDsHandler - Success", func(t *testing.T) {
+		app := fiber.New()
+		app.Put("/notifications/read", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user_123")
+			return controller.MarkAsReadByIDsHandler(c)
+		})
+
+		mockUseCase.ExpectedCalls = nil
+		mockUseCase.Calls = nil
+
+		mockUseCase.On("MarkNotificationsAsReadByIDs", []string{"noti_1", "noti_2"}, "user_123").Return(nil).Once()
+		req := httptest.NewRequest("PUT", "/notifications/read", strings.NewReader(`{"ids":["noti_1","noti_2"]}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("ArchiveHandler - NotFound", func(t *testing.T) {
+		app := fiber.New()
+		app.Put("/notifications/:id/archive", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user_123")
+			return controller.ArchiveHandler(c)
+		})
+
+		mockUseCase.ExpectedCalls = nil
+		mockUseCase.Calls = nil
+
+		mockUseCase.On("ArchiveNotificationByID", "noti_9", "user_123").Return(errors.New("record not found")).Once()
+		req := httptest.NewRequest("PUT", "/notifications/noti_9/archive", nil)
+
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("DeleteHandler - Success", func(t *testing.T) {
+		app := fiber.New()
+		app.Delete("/notifications/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user_123")
+			return controller.DeleteHandler(c)
+		})
+
+		mockUseCase.ExpectedCalls = nil
+		mockUseCase.Calls = nil
+
+		mockUseCase.On("DeleteNotificationByID", "noti_1", "user_123").Return(nil).Once()
+		req := httptest.NewRequest("DELETE", "/notifications/noti_1", nil)
+
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		assert.Equal(t, "Delete notification successfully", responseMap["message"])
+
+		mockUseCase.AssertExpectations(t)
+	})
+	t.Run("UpdatePreferenceHandler - BadRequest", func(t *testing.T) {
+		app := fiber.New()
+		app.Put("/notifications/preferences", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user_123")
+			return controller.UpdatePreferenceHandler(c)
+		})
+
+		mockUseCase.ExpectedCalls = nil
+		mockUseCase.Calls = nil
+
+		mockUseCase.On("UpdatePreference", "user_123", entities.NotificationPreference{WeeklyDigest: true}).Return(nil, errors.New("weekly digest requires the email channel")).Once()
+		req := httptest.NewRequest("PUT", "/notifications/preferences", strings.NewReader(`{"weekly_digest":true}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+
+		assert.Equal(t, "weekly digest requires the email channel", responseMap["message"])
+
 		mockUseCase.AssertExpectations(t)
 	})
 }
@@ -1,7 +1,10 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+
 	"gorm.io/gorm"
 )
 
@@ -15,23 +18,158 @@ func NewGormNotiRepository(db *gorm.DB) *GormNotiRepository {
 
 type NotiRepository interface {
 	CreateNotification(notification *entities.Notification) error
-	GetNotificationsByUserID(userID string) ([]entities.Notification, error)
+	GetNotificationsByUserID(userID string, filter entities.NotificationFilter) ([]entities.Notification, error)
+	GetUnreadNotificationsSince(userID string, since time.Time) ([]entities.Notification, error)
+	CountUnreadNotifications(userID string) (int64, error)
 	MarkNotificationAsRead(userID string) error
+	MarkNotificationAsReadByID(id, userID string) error
+	MarkNotificationsAsReadByIDs(ids []string, userID string) error
+	ArchiveNotificationByID(id, userID string) error
+	DeleteNotificationByID(id, userID string) error
+
+	GetPreferenceByUserID(userID string) (*entities.NotificationPreference, error)
+	SavePreference(preference *entities.NotificationPreference) (*entities.NotificationPreference, error)
+	GetDigestSubscribers() ([]entities.NotificationPreference, error)
+
+	QueueEmail(email *entities.QueuedEmail) error
+	GetQueuedEmails() ([]entities.QueuedEmail, error)
+	DeleteQueuedEmail(id string) error
 }
 
 func (r *GormNotiRepository) CreateNotification(notification *entities.Notification) error {
 	return r.db.Create(notification).Error
 }
 
-func (r *GormNotiRepository) GetNotificationsByUserID(userID string) ([]entities.Notification, error) {
+func (r *GormNotiRepository) GetNotificationsByUserID(userID string, filter entities.NotificationFilter) ([]entities.Notification, error) {
+	var notifications []entities.Notification
+	query := r.db.Where("user_id = ? AND is_archived = false", userID)
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+
+	if !filter.BeforeCreatedAt.IsZero() {
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", filter.BeforeCreatedAt, filter.BeforeCreatedAt, filter.BeforeID)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if err := query.Order("created_at DESC").Order("id DESC").Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r *GormNotiRepository) GetUnreadNotificationsSince(userID string, since time.Time) ([]entities.Notification, error) {
 	var notifications []entities.Notification
-	if err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&notifications).Error; err != nil {
+	if err := r.db.Where("user_id = ? AND is_read = false AND is_archived = false AND created_at > ?", userID, since).Order("created_at ASC").Find(&notifications).Error; err != nil {
 		return nil, err
 	}
 
 	return notifications, nil
 }
 
+func (r *GormNotiRepository) CountUnreadNotifications(userID string) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entities.Notification{}).Where("user_id = ? AND is_read = false AND is_archived = false", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (r *GormNotiRepository) MarkNotificationAsRead(userID string) error {
 	return r.db.Model(&entities.Notification{}).Where("user_id = ? AND is_read = false", userID).Update("is_read", true).Error
 }
+
+func (r *GormNotiRepository) MarkNotificationAsReadByID(id, userID string) error {
+	result := r.db.Model(&entities.Notification{}).Where("id = ? AND user_id = ?", id, userID).Update("is_read", true)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *GormNotiRepository) MarkNotificationsAsReadByIDs(ids []string, userID string) error {
+	return r.db.Model(&entities.Notification{}).Where("id IN ? AND user_id = ?", ids, userID).Update("is_read", true).Error
+}
+
+func (r *GormNotiRepository) ArchiveNotificationByID(id, userID string) error {
+	result := r.db.Model(&entities.Notification{}).Where("id = ? AND user_id = ?", id, userID).Updates(map[string]interface{}{
+		"is_archived": true,
+		"is_read":     true,
+	})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *GormNotiRepository) DeleteNotificationByID(id, userID string) error {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&entities.Notification{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *GormNotiRepository) GetPreferenceByUserID(userID string) (*entities.NotificationPreference, error) {
+	var preference entities.NotificationPreference
+	if err := r.db.Where("user_id = ?", userID).First(&preference).Error; err != nil {
+		return nil, err
+	}
+
+	return &preference, nil
+}
+
+func (r *GormNotiRepository) SavePreference(preference *entities.NotificationPreference) (*entities.NotificationPreference, error) {
+	if err := r.db.Save(preference).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetPreferenceByUserID(preference.UserID)
+}
+
+func (r *GormNotiRepository) GetDigestSubscribers() ([]entities.NotificationPreference, error) {
+	var preferences []entities.NotificationPreference
+	if err := r.db.Preload("User").Where("weekly_digest = true AND email = true").Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+func (r *GormNotiRepository) QueueEmail(email *entities.QueuedEmail) error {
+	return r.db.Create(email).Error
+}
+
+func (r *GormNotiRepository) GetQueuedEmails() ([]entities.QueuedEmail, error) {
+	var emails []entities.QueuedEmail
+	if err := r.db.Order("created_at ASC").Find(&emails).Error; err != nil {
+		return nil, err
+	}
+
+	return emails, nil
+}
+
+func (r *GormNotiRepository) DeleteQueuedEmail(id string) error {
+	return r.db.Where("id = ?", id).Delete(&entities.QueuedEmail{}).Error
+}
@@ -0,0 +1,248 @@
+package usecases
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	loanRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/loan/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/notification/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/socket"
+	userRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	notificationMailTemplate = "./assets/NotificationMail.html"
+	digestMailTemplate       = "./assets/DigestMail.html"
+)
+
+type Mailer interface {
+	Send(to, subject, templatePath string, data interface{}) error
+}
+
+type NotiDispatcher interface {
+	Dispatch(notification *entities.Notification) error
+	SendWeeklyDigest() error
+	SendQueuedEmails() error
+}
+
+type NotiDispatcherImpl struct {
+	notirepo repositories.NotiRepository
+	userrepo userRepo.UserRepository
+	loanrepo loanRepo.LoanRepository
+	mailer   Mailer
+	location *time.Location
+}
+
+func NewNotiDispatcher(notirepo repositories.NotiRepository, userrepo userRepo.UserRepository, loanrepo loanRepo.LoanRepository, mailer Mailer) *NotiDispatcherImpl {
+	location, err := time.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		location = time.FixedZone("ICT", 7*60*60)
+	}
+
+	return &NotiDispatcherImpl{
+		notirepo: notirepo,
+		userrepo: userrepo,
+		loanrepo: loanrepo,
+		mailer:   mailer,
+		location: location,
+	}
+}
+
+func defaultPreference(userID string) *entities.NotificationPreference {
+	return &entities.NotificationPreference{
+		UserID:                userID,
+		AssetEnabled:          true,
+		HouseEnabled:          true,
+		LoanEnabled:           true,
+		RetirementPlanEnabled: true,
+		InApp:                 true,
+	}
+}
+
+func typeEnabled(preference *entities.NotificationPreference, notiType string) bool {
+	switch notiType {
+	case "asset":
+		return preference.AssetEnabled
+	case "house":
+		return preference.HouseEnabled
+	case "loan":
+		return preference.LoanEnabled
+	case "retirementplan":
+		return preference.RetirementPlanEnabled
+	default:
+		return true
+	}
+}
+
+func (d *NotiDispatcherImpl) preference(userID string) *entities.NotificationPreference {
+	preference, err := d.notirepo.GetPreferenceByUserID(userID)
+	if err != nil {
+		return defaultPreference(userID)
+	}
+
+	return preference
+}
+
+func (d *NotiDispatcherImpl) Dispatch(notification *entities.Notification) error {
+	if notification == nil {
+		return errors.New("notification is empty")
+	}
+
+	preference := d.preference(notification.UserID)
+	if !typeEnabled(preference, notification.Type) {
+		return nil
+	}
+
+	quiet := d.quiet(preference)
+	if preference.InApp {
+		if err := d.notirepo.CreateNotification(notification); err != nil {
+			return err
+		}
+
+		if !quiet {
+			localized := *notification
+			if localized.TemplateKey != "" {
+				utils.LocalizeNotification(&localized, d.locale(notification.UserID))
+			}
+
+			socket.SendNotificationToUser(notification.UserID, localized)
+		}
+	}
+
+	if preference.Email {
+		if quiet {
+			return d.notirepo.QueueEmail(&entities.QueuedEmail{
+				ID:              uuid.New().String(),
+				UserID:          notification.UserID,
+				NotificationID:  notification.ID,
+				Message:         notification.Message,
+				TemplateKey:     notification.TemplateKey,
+				TemplateVariant: notification.TemplateVariant,
+				TemplateParams:  notification.TemplateParams,
+			})
+		}
+
+		return d.sendEmail(*notification)
+	}
+
+	return nil
+}
+
+func (d *NotiDispatcherImpl) quiet(preference *entities.NotificationPreference) bool {
+	return utils.InQuietHours(preference.QuietHoursStart, preference.QuietHoursEnd, time.Now().In(d.location))
+}
+
+func (d *NotiDispatcherImpl) sendEmail(notification entities.Notification) error {
+	user, err := d.userrepo.GetUserByID(notification.UserID)
+	if err != nil {
+		return err
+	}
+
+	utils.LocalizeNotification(&notification, user.Locale)
+	return d.mailer.Send(user.Email, "Kasian Phrom Notification", notificationMailTemplate, struct {
+		Username string
+		Message  string
+	}{
+		Username: user.Username,
+		Message:  notification.Message,
+	})
+}
+
+func (d *NotiDispatcherImpl) SendQueuedEmails() error {
+	emails, err := d.notirepo.GetQueuedEmails()
+	if err != nil {
+		return err
+	}
+
+	for _, email := range emails {
+		preference := d.preference(email.UserID)
+		if preference.Email && d.quiet(preference) {
+			continue
+		}
+
+		if preference.Email {
+			if err := d.sendEmail(entities.Notification{
+				ID:              email.NotificationID,
+				UserID:          email.UserID,
+				Message:         email.Message,
+				TemplateKey:     email.TemplateKey,
+				TemplateVariant: email.TemplateVariant,
+				TemplateParams:  email.TemplateParams,
+			}); err != nil {
+				log.Printf("Failed to send queued email to %s: %v", email.UserID, err)
+				continue
+			}
+		}
+
+		if err := d.notirepo.DeleteQueuedEmail(email.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *NotiDispatcherImpl) locale(userID string) string {
+	user, err := d.userrepo.GetUserByID(userID)
+	if err != nil || user.Locale == "" {
+		return utils.DefaultLocale
+	}
+
+	return user.Locale
+}
+
+func (d *NotiDispatcherImpl) SendWeeklyDigest() error {
+	subscribers, err := d.notirepo.GetDigestSubscribers()
+	if err != nil {
+		return err
+	}
+
+	for _, subscriber := range subscribers {
+		if err := d.sendDigest(subscriber.UserID); err != nil {
+			log.Printf("Failed to send weekly digest to %s: %v", subscriber.UserID, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *NotiDispatcherImpl) sendDigest(userID string) error {
+	user, err := d.userrepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	loans, _, err := d.loanrepo.GetLoanByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	unread, err := d.notirepo.CountUnreadNotifications(userID)
+	if err != nil {
+		return err
+	}
+
+	var house *entities.SelectedHouse
+	if user.House.NursingHouseID != "" && user.House.NursingHouseID != entities.DefaultNursingHouseID {
+		house = &user.House
+	}
+
+	return d.mailer.Send(user.Email, "Your weekly Kasian Phrom summary", digestMailTemplate, struct {
+		Username string
+		Assets   []entities.Asset
+		House    *entities.SelectedHouse
+		Loans    []entities.Loan
+		Unread   int64
+	}{
+		Username: user.Username,
+		Assets:   user.Assets,
+		House:    house,
+		Loans:    loans,
+		Unread:   unread,
+	})
+}
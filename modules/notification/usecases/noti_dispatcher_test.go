@@ -0,0 +1,243 @@
+package usecases_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/notification/usecases"
+	"github.com/XzerozZ/Kasian_Phrom_BE/testing/repositories/mocks"
+	usecaseMocks "github.com/XzerozZ/Kasian_Phrom_BE/testing/usecases/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupDispatcher() (*usecases.NotiDispatcherImpl, *mocks.MockNotiRepository, *mocks.MockUserRepository, *mocks.MockLoanRepository, *usecaseMocks.MockMailer) {
+	notiRepo := new(mocks.MockNotiRepository)
+	userRepo := new(mocks.MockUserRepository)
+	loanRepo := new(mocks.MockLoanRepository)
+	mailer := new(usecaseMocks.MockMailer)
+	return usecases.NewNotiDispatcher(notiRepo, userRepo, loanRepo, mailer), notiRepo, userRepo, loanRepo, mailer
+}
+
+func TestDispatch(t *testing.T) {
+	t.Run("Default preference stores in-app notification", func(t *testing.T) {
+		dispatcher, notiRepo, _, _, mailer := setupDispatcher()
+		notification := &entities.Notification{ID: "noti1", UserID: "user1", Type: "asset", Message: "done"}
+
+		notiRepo.On("GetPreferenceByUserID", "user1").Return(nil, errors.New("record not found"))
+		notiRepo.On("CreateNotification", notification).Return(nil)
+
+		err := dispatcher.Dispatch(notification)
+
+		assert.NoError(t, err)
+		notiRepo.AssertExpectations(t)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Disabled type is skipped", func(t *testing.T) {
+		dispatcher, notiRepo, _, _, mailer := setupDispatcher()
+		notification := &entities.Notification{ID: "noti2", UserID: "user1", Type: "loan"}
+
+		notiRepo.On("GetPreferenceByUserID", "user1").Return(&entities.NotificationPreference{
+			UserID:       "user1",
+			AssetEnabled: true,
+			LoanEnabled:  false,
+			InApp:        true,
+			Email:        true,
+		}, nil)
+
+		err := dispatcher.Dispatch(notification)
+
+		assert.NoError(t, err)
+		notiRepo.AssertNotCalled(t, "CreateNotification", mock.Anything)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Email channel sends mail", func(t *testing.T) {
+		dispatcher, notiRepo, userRepo, _, mailer := setupDispatcher()
+		notification := &entities.Notification{ID: "noti3", UserID: "user1", Type: "house", Message: "house done"}
+
+		notiRepo.On("GetPreferenceByUserID", "user1").Return(&entities.NotificationPreference{
+			UserID:       "user1",
+			HouseEnabled: true,
+			Email:        true,
+		}, nil)
+		userRepo.On("GetUserByID", "user1").Return(&entities.User{ID: "user1", Username: "tester", Email: "tester@example.com"}, nil)
+		mailer.On("Send", "tester@example.com", "Kasian Phrom Notification", "./assets/NotificationMail.html", mock.Anything).Return(nil)
+
+		err := dispatcher.Dispatch(notification)
+
+		assert.NoError(t, err)
+		notiRepo.AssertNotCalled(t, "CreateNotification", mock.Anything)
+		mailer.AssertExpectations(t)
+	})
+
+	t.Run("Email is rendered in the user's locale", func(t *testing.T) {
+		dispatcher, notiRepo, userRepo, _, mailer := setupDispatcher()
+		notification := &entities.Notification{
+			ID:              "noti5",
+			UserID:          "user1",
+			Type:            "loan",
+			Message:         "thai message",
+			TemplateKey:     "loan.success",
+			TemplateVariant: 0,
+			TemplateParams:  entities.NotificationParams{"name": "Car loan"},
+		}
+
+		notiRepo.On("GetPreferenceByUserID", "user1").Return(&entities.NotificationPreference{
+			UserID:      "user1",
+			LoanEnabled: true,
+			Email:       true,
+		}, nil)
+		userRepo.On("GetUserByID", "user1").Return(&entities.User{ID: "user1", Username: "tester", Email: "tester@example.com", Locale: "en"}, nil)
+		mailer.On("Send", "tester@example.com", "Kasian Phrom Notification", "./assets/NotificationMail.html", mock.MatchedBy(func(data interface{}) bool {
+			return strings.Contains(fmt.Sprintf("%+v", data), "You have fully paid off Car loan")
+		})).Return(nil)
+
+		err := dispatcher.Dispatch(notification)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "thai message", notification.Message)
+		mailer.AssertExpectations(t)
+	})
+
+	t.Run("Quiet hours queue email and keep in-app record", func(t *testing.T) {
+		dispatcher, notiRepo, _, _, mailer := setupDispatcher()
+		notification := &entities.Notification{ID: "noti4", UserID: "user1", Type: "asset", Message: "asset done", TemplateKey: "asset.success"}
+		now := time.Now().In(time.FixedZone("ICT", 7*60*60))
+
+		notiRepo.On("GetPreferenceByUserID", "user1").Return(&entities.NotificationPreference{
+			UserID:          "user1",
+			AssetEnabled:    true,
+			InApp:           true,
+			Email:           true,
+			QuietHoursStart: now.Add(-time.Hour).Format("15:04"),
+			QuietHoursEnd:   now.Add(time.Hour).Format("15:04"),
+		}, nil)
+		notiRepo.On("CreateNotification", notification).Return(nil)
+		notiRepo.On("QueueEmail", mock.MatchedBy(func(email *entities.QueuedEmail) bool {
+			return email.ID != "" && email.UserID == "user1" && email.NotificationID == "noti4" &&
+				email.Message == "asset done" && email.TemplateKey == "asset.success"
+		})).Return(nil).Once()
+
+		err := dispatcher.Dispatch(notification)
+
+		assert.NoError(t, err)
+		notiRepo.AssertExpectations(t)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Store error is returned", func(t *testing.T) {
+		dispatcher, notiRepo, _, _, _ := setupDispatcher()
+		notification := &entities.Notification{ID: "noti5", UserID: "user1", Type: "asset"}
+
+		notiRepo.On("GetPreferenceByUserID", "user1").Return(nil, errors.New("record not found"))
+		notiRepo.On("CreateNotification", notification).Return(errors.New("database error"))
+
+		err := dispatcher.Dispatch(notification)
+
+		assert.Error(t, err)
+		assert.Equal(t, "database error", err.Error())
+	})
+}
+
+func TestSendQueuedEmails(t *testing.T) {
+	now := time.Now().In(time.FixedZone("ICT", 7*60*60))
+	quietPreference := func(userID string) *entities.NotificationPreference {
+		return &entities.NotificationPreference{
+			UserID:          userID,
+			Email:           true,
+			QuietHoursStart: now.Add(-time.Hour).Format("15:04"),
+			QuietHoursEnd:   now.Add(time.Hour).Format("15:04"),
+		}
+	}
+
+	t.Run("Sends after quiet hours and keeps the rest", func(t *testing.T) {
+		dispatcher, notiRepo, userRepo, _, mailer := setupDispatcher()
+
+		notiRepo.On("GetQueuedEmails").Return([]entities.QueuedEmail{
+			{ID: "q1", UserID: "user1", NotificationID: "noti1", Message: "asset done"},
+			{ID: "q2", UserID: "user2", NotificationID: "noti2", Message: "loan due"},
+		}, nil).Once()
+		notiRepo.On("GetPreferenceByUserID", "user1").Return(&entities.NotificationPreference{UserID: "user1", Email: true}, nil)
+		notiRepo.On("GetPreferenceByUserID", "user2").Return(quietPreference("user2"), nil)
+		userRepo.On("GetUserByID", "user1").Return(&entities.User{ID: "user1", Username: "tester", Email: "tester@example.com"}, nil)
+		mailer.On("Send", "tester@example.com", "Kasian Phrom Notification", "./assets/NotificationMail.html", mock.MatchedBy(func(data interface{}) bool {
+			return strings.Contains(fmt.Sprintf("%v", data), "asset done")
+		})).Return(nil).Once()
+		notiRepo.On("DeleteQueuedEmail", "q1").Return(nil).Once()
+
+		err := dispatcher.SendQueuedEmails()
+
+		assert.NoError(t, err)
+		mailer.AssertExpectations(t)
+		notiRepo.AssertExpectations(t)
+		notiRepo.AssertNotCalled(t, "DeleteQueuedEmail", "q2")
+	})
+
+	t.Run("Drops email when the channel was turned off", func(t *testing.T) {
+		dispatcher, notiRepo, _, _, mailer := setupDispatcher()
+
+		notiRepo.On("GetQueuedEmails").Return([]entities.QueuedEmail{{ID: "q1", UserID: "user1", Message: "asset done"}}, nil).Once()
+		notiRepo.On("GetPreferenceByUserID", "user1").Return(&entities.NotificationPreference{UserID: "user1"}, nil)
+		notiRepo.On("DeleteQueuedEmail", "q1").Return(nil).Once()
+
+		err := dispatcher.SendQueuedEmails()
+
+		assert.NoError(t, err)
+		notiRepo.AssertExpectations(t)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Keeps email that fails to send", func(t *testing.T) {
+		dispatcher, notiRepo, userRepo, _, mailer := setupDispatcher()
+
+		notiRepo.On("GetQueuedEmails").Return([]entities.QueuedEmail{{ID: "q1", UserID: "user1", Message: "asset done"}}, nil).Once()
+		notiRepo.On("GetPreferenceByUserID", "user1").Return(&entities.NotificationPreference{UserID: "user1", Email: true}, nil)
+		userRepo.On("GetUserByID", "user1").Return(&entities.User{ID: "user1", Email: "tester@example.com"}, nil)
+		mailer.On("Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("smtp down")).Once()
+
+		err := dispatcher.SendQueuedEmails()
+
+		assert.NoError(t, err)
+		notiRepo.AssertNotCalled(t, "DeleteQueuedEmail", mock.Anything)
+	})
+}
+
+func TestSendWeeklyDigest(t *testing.T) {
+	t.Run("Send digest to every subscriber", func(t *testing.T) {
+		dispatcher, notiRepo, userRepo, loanRepo, mailer := setupDispatcher()
+
+		notiRepo.On("GetDigestSubscribers").Return([]entities.NotificationPreference{
+			{UserID: "user1", Email: true, WeeklyDigest: true},
+			{UserID: "user2", Email: true, WeeklyDigest: true},
+		}, nil)
+
+		userRepo.On("GetUserByID", "user1").Return(&entities.User{ID: "user1", Email: "one@example.com", Assets: []entities.Asset{{Name: "Car"}}}, nil)
+		userRepo.On("GetUserByID", "user2").Return((*entities.User)(nil), errors.New("user not found"))
+		loanRepo.On("GetLoanByUserID", "user1").Return([]entities.Loan{{Name: "Home"}}, map[string]interface{}{}, nil)
+		notiRepo.On("CountUnreadNotifications", "user1").Return(int64(2), nil)
+		mailer.On("Send", "one@example.com", "Your weekly Kasian Phrom summary", "./assets/DigestMail.html", mock.Anything).Return(nil).Once()
+
+		err := dispatcher.SendWeeklyDigest()
+
+		assert.NoError(t, err)
+		mailer.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("Subscriber lookup error", func(t *testing.T) {
+		dispatcher, notiRepo, _, _, _ := setupDispatcher()
+
+		notiRepo.On("GetDigestSubscribers").Return([]entities.NotificationPreference{}, errors.New("database error"))
+
+		err := dispatcher.SendWeeklyDigest()
+
+		assert.Error(t, err)
+	})
+}
@@ -1,27 +1,215 @@
 package usecases
 
 import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/notification/repositories"
+	userRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+)
+
+const (
+	defaultNotificationLimit = 20
+	maxNotificationLimit     = 100
 )
 
+var notificationTypes = map[string]bool{
+	"asset":          true,
+	"house":          true,
+	"loan":           true,
+	"retirementplan": true,
+}
+
 type NotiUsecase interface {
-	GetNotificationsByUserID(userID string) ([]entities.Notification, error)
+	GetNotificationsByUserID(userID, notiType, cursor string, limit int) (*entities.NotificationPage, error)
+	GetAllNotificationsByUserID(userID, notiType string) ([]entities.Notification, error)
+	CountUnreadNotifications(userID string) (int64, error)
 	MarkNotificationsAsRead(userID string) error
+	MarkNotificationAsReadByID(id, userID string) error
+	MarkNotificationsAsReadByIDs(ids []string, userID string) error
+	ArchiveNotificationByID(id, userID string) error
+	DeleteNotificationByID(id, userID string) error
+	GetPreference(userID string) (*entities.NotificationPreference, error)
+	UpdatePreference(userID string, preference entities.NotificationPreference) (*entities.NotificationPreference, error)
 }
 
 type NotiUseCaseImpl struct {
 	notirepo repositories.NotiRepository
+	userrepo userRepo.UserRepository
+}
+
+func NewNotiUseCase(notirepo repositories.NotiRepository, userrepo userRepo.UserRepository) *NotiUseCaseImpl {
+	return &NotiUseCaseImpl{
+		notirepo: notirepo,
+		userrepo: userrepo,
+	}
+}
+
+func (u *NotiUseCaseImpl) GetNotificationsByUserID(userID, notiType, cursor string, limit int) (*entities.NotificationPage, error) {
+	if notiType != "" && !notificationTypes[notiType] {
+		return nil, errors.New("invalid notification type")
+	}
+
+	if limit <= 0 {
+		limit = defaultNotificationLimit
+	}
+
+	if limit > maxNotificationLimit {
+		limit = maxNotificationLimit
+	}
+
+	filter := entities.NotificationFilter{
+		Type:  notiType,
+		Limit: limit + 1,
+	}
+
+	if cursor != "" {
+		createdAt, id, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.BeforeCreatedAt = createdAt
+		filter.BeforeID = id
+	}
+
+	notifications, err := u.notirepo.GetNotificationsByUserID(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &entities.NotificationPage{Notifications: notifications}
+	if len(notifications) > limit {
+		page.Notifications = notifications[:limit]
+		last := page.Notifications[limit-1]
+		page.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+
+	u.localize(userID, page.Notifications)
+	return page, nil
 }
 
-func NewNotiUseCase(notirepo repositories.NotiRepository) *NotiUseCaseImpl {
-	return &NotiUseCaseImpl{notirepo: notirepo}
+func (u *NotiUseCaseImpl) GetAllNotificationsByUserID(userID, notiType string) ([]entities.Notification, error) {
+	if notiType != "" && !notificationTypes[notiType] {
+		return nil, errors.New("invalid notification type")
+	}
+
+	notifications, err := u.notirepo.GetNotificationsByUserID(userID, entities.NotificationFilter{Type: notiType})
+	if err != nil {
+		return nil, err
+	}
+
+	u.localize(userID, notifications)
+	return notifications, nil
+}
+
+func (u *NotiUseCaseImpl) localize(userID string, notifications []entities.Notification) {
+	locale := ""
+	for i := range notifications {
+		if notifications[i].TemplateKey == "" {
+			continue
+		}
+
+		if locale == "" {
+			locale = u.locale(userID)
+		}
+
+		utils.LocalizeNotification(&notifications[i], locale)
+	}
+}
+
+func (u *NotiUseCaseImpl) locale(userID string) string {
+	user, err := u.userrepo.GetUserByID(userID)
+	if err != nil || user.Locale == "" {
+		return utils.DefaultLocale
+	}
+
+	return user.Locale
 }
 
-func (u *NotiUseCaseImpl) GetNotificationsByUserID(userID string) ([]entities.Notification, error) {
-	return u.notirepo.GetNotificationsByUserID(userID)
+func (u *NotiUseCaseImpl) CountUnreadNotifications(userID string) (int64, error) {
+	return u.notirepo.CountUnreadNotifications(userID)
 }
 
 func (u *NotiUseCaseImpl) MarkNotificationsAsRead(userID string) error {
 	return u.notirepo.MarkNotificationAsRead(userID)
 }
+
+func (u *NotiUseCaseImpl) MarkNotificationAsReadByID(id, userID string) error {
+	return u.notirepo.MarkNotificationAsReadByID(id, userID)
+}
+
+func (u *NotiUseCaseImpl) MarkNotificationsAsReadByIDs(ids []string, userID string) error {
+	if len(ids) == 0 {
+		return errors.New("notification ids are required")
+	}
+
+	return u.notirepo.MarkNotificationsAsReadByIDs(ids, userID)
+}
+
+func (u *NotiUseCaseImpl) ArchiveNotificationByID(id, userID string) error {
+	return u.notirepo.ArchiveNotificationByID(id, userID)
+}
+
+func (u *NotiUseCaseImpl) DeleteNotificationByID(id, userID string) error {
+	return u.notirepo.DeleteNotificationByID(id, userID)
+}
+
+func (u *NotiUseCaseImpl) GetPreference(userID string) (*entities.NotificationPreference, error) {
+	preference, err := u.notirepo.GetPreferenceByUserID(userID)
+	if err != nil {
+		return defaultPreference(userID), nil
+	}
+
+	return preference, nil
+}
+
+func (u *NotiUseCaseImpl) UpdatePreference(userID string, preference entities.NotificationPreference) (*entities.NotificationPreference, error) {
+	if (preference.QuietHoursStart == "") != (preference.QuietHoursEnd == "") {
+		return nil, errors.New("quiet hours start and end must be set together")
+	}
+
+	if preference.QuietHoursStart != "" {
+		if _, err := utils.ParseClock(preference.QuietHoursStart); err != nil {
+			return nil, errors.New("quiet hours must be in HH:MM format")
+		}
+
+		if _, err := utils.ParseClock(preference.QuietHoursEnd); err != nil {
+			return nil, errors.New("quiet hours must be in HH:MM format")
+		}
+	}
+
+	if preference.WeeklyDigest && !preference.Email {
+		return nil, errors.New("weekly digest requires the email channel")
+	}
+
+	preference.UserID = userID
+	return u.notirepo.SavePreference(&preference)
+}
+
+func encodeCursor(createdAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.UTC().Format(time.RFC3339Nano) + "|" + id))
+}
+
+func decodeCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", errors.New("invalid cursor")
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return time.Time{}, "", errors.New("invalid cursor")
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, "", errors.New("invalid cursor")
+	}
+
+	return createdAt, parts[1], nil
+}
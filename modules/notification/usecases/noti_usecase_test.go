@@ -3,16 +3,68 @@ package usecases_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/notification/usecases"
 	"github.com/XzerozZ/Kasian_Phrom_BE/testing/repositories/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNotiUseCase(t *testing.T) {
 	mockRepo := new(mocks.MockNotiRepository)
-	useCase := usecases.NewNotiUseCase(mockRepo)
+	mockUserRepo := new(mocks.MockUserRepository)
+	useCase := usecases.NewNotiUseCase(mockRepo, mockUserRepo)
+
+	t.Run("GetNotificationsByUserID - Renders in user locale", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.Calls = nil
+		mockUserRepo.ExpectedCalls = nil
+		mockUserRepo.Calls = nil
+
+		userID := "user_123"
+		notifications := []entities.Notification{
+			{ID: "1", UserID: userID, Message: "ข้อความ", TemplateKey: "asset.success", TemplateVariant: 0, TemplateParams: entities.NotificationParams{"name": "Car"}},
+			{ID: "2", UserID: userID, Message: "Legacy message"},
+		}
+
+		mockRepo.On("GetNotificationsByUserID", userID, entities.NotificationFilter{Limit: 21}).Return(notifications, nil).Once()
+		mockUserRepo.On("GetUserByID", userID).Return(&entities.User{ID: userID, Locale: "en"}, nil).Once()
+
+		page, err := useCase.GetNotificationsByUserID(userID, "", "", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "🎯 Done! You have fully saved for Car. Great job!", page.Notifications[0].Message)
+		assert.Equal(t, "Legacy message", page.Notifications[1].Message)
+		mockRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetAllNotificationsByUserID - Success", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.Calls = nil
+
+		userID := "user_123"
+		expectedNotifications := []entities.Notification{
+			{ID: "1", UserID: userID, Type: "loan", Message: "Test notification 1"},
+		}
+
+		mockRepo.On("GetNotificationsByUserID", userID, entities.NotificationFilter{Type: "loan"}).Return(expectedNotifications, nil).Once()
+
+		notifications, err := useCase.GetAllNotificationsByUserID(userID, "loan")
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedNotifications, notifications)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetAllNotificationsByUserID - Invalid Type", func(t *testing.T) {
+		notifications, err := useCase.GetAllNotificationsByUserID("user_123", "unknown")
+
+		assert.Nil(t, notifications)
+		assert.EqualError(t, err, "invalid notification type")
+	})
 
 	t.Run("GetNotificationsByUserID - Success", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
@@ -24,15 +76,16 @@ func TestNotiUseCase(t *testing.T) {
 			{ID: "2", UserID: userID, Message: "Test notification 2", IsRead: true},
 		}
 
-		mockRepo.On("GetNotificationsByUserID", userID).Return(expectedNotifications, nil).Once()
+		mockRepo.On("GetNotificationsByUserID", userID, entities.NotificationFilter{Limit: 21}).Return(expectedNotifications, nil).Once()
 
-		notifications, err := useCase.GetNotificationsByUserID(userID)
+		page, err := useCase.GetNotificationsByUserID(userID, "", "", 0)
 
 		assert.NoError(t, err)
-		assert.Equal(t, expectedNotifications, notifications)
-		assert.Len(t, notifications, 2)
-		assert.Equal(t, "Test notification 1", notifications[0].Message)
-		assert.Equal(t, "Test notification 2", notifications[1].Message)
+		assert.Equal(t, expectedNotifications, page.Notifications)
+		assert.Len(t, page.Notifications, 2)
+		assert.Empty(t, page.NextCursor)
+		assert.Equal(t, "Test notification 1", page.Notifications[0].Message)
+		assert.Equal(t, "Test notification 2", page.Notifications[1].Message)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -42,14 +95,13 @@ func TestNotiUseCase(t *testing.T) {
 
 		userID := "user_123"
 		expectedError := errors.New("database error")
+		mockRepo.On("GetNotificationsByUserID", userID, entities.NotificationFilter{Limit: 21}).Return([]entities.Notification{}, expectedError).Once()
 
-		mockRepo.On("GetNotificationsByUserID", userID).Return([]entities.Notification{}, expectedError).Once()
-
-		notifications, err := useCase.GetNotificationsByUserID(userID)
+		page, err := useCase.GetNotificationsByUserID(userID, "", "", 0)
 
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
-		assert.Empty(t, notifications)
+		assert.Nil(t, page)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -59,13 +111,110 @@ func TestNotiUseCase(t *testing.T) {
 
 		userID := "user_123"
 		expectedNotifications := []entities.Notification{}
+		mockRepo.On("GetNotificationsByUserID", userID, entities.NotificationFilter{Limit: 21}).Return(expectedNotifications, nil).Once()
+
+		page, err := useCase.GetNotificationsByUserID(userID, "", "", 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, page.Notifications)
+		assert.Empty(t, page.NextCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetNotificationsByUserID - Paginate With Cursor", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.Calls = nil
+
+		userID := "user_123"
+		base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		firstPage := []entities.Notification{
+			{ID: "3", UserID: userID, Type: "loan", CreatedAt: base.Add(2 * time.Minute)},
+			{ID: "2", UserID: userID, Type: "loan", CreatedAt: base.Add(time.Minute)},
+			{ID: "1", UserID: userID, Type: "loan", CreatedAt: base},
+		}
+
+		mockRepo.On("GetNotificationsByUserID", userID, entities.NotificationFilter{Type: "loan", Limit: 3}).Return(firstPage, nil).Once()
+
+		page, err := useCase.GetNotificationsByUserID(userID, "loan", "", 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, page.Notifications, 2)
+		assert.NotEmpty(t, page.NextCursor)
+
+		mockRepo.On("GetNotificationsByUserID", userID, entities.NotificationFilter{
+			Type:            "loan",
+			BeforeCreatedAt: base.Add(time.Minute),
+			BeforeID:        "2",
+			Limit:           3,
+		}).Return(firstPage[2:], nil).Once()
+
+		page, err = useCase.GetNotificationsByUserID(userID, "loan", page.NextCursor, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, page.Notifications, 1)
+		assert.Equal(t, "1", page.Notifications[0].ID)
+		assert.Empty(t, page.NextCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("GetNotificationsByUserID - Invalid Type", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.Calls = nil
+
+		page, err := useCase.GetNotificationsByUserID("user_123", "unknown", "", 0)
+
+		assert.Error(t, err)
+		assert.Nil(t, page)
+		assert.Equal(t, "invalid notification type", err.Error())
+		mockRepo.AssertNotCalled(t, "GetNotificationsByUserID")
+	})
 
-		mockRepo.On("GetNotificationsByUserID", userID).Return(expectedNotifications, nil).Once()
+	t.Run("GetNotificationsByUserID - Invalid Cursor", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.Calls = nil
+
+		page, err := useCase.GetNotificationsByUserID("user_123", "", "not-a-cursor", 0)
+
+		assert.Error(t, err)
+		assert.Nil(t, page)
+		assert.Equal(t, "invalid cursor", err.Error())
+		mockRepo.AssertNotCalled(t, "GetNotificationsByUserID")
+	})
+
+	t.Run("MarkNotificationsAsReadByIDs - Empty IDs", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.Calls = nil
+
+		err := useCase.MarkNotificationsAsReadByIDs([]string{}, "user_123")
+
+		assert.Error(t, err)
+		assert.Equal(t, "notification ids are required", err.Error())
+		mockRepo.AssertNotCalled(t, "MarkNotificationsAsReadByIDs")
+	})
+
+	t.Run("MarkNotificationsAsReadByIDs - Success", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.Calls = nil
+
+		ids := []string{"1", "2"}
+		mockRepo.On("MarkNotificationsAsReadByIDs", ids, "user_123").Return(nil).Once()
 
-		notifications, err := useCase.GetNotificationsByUserID(userID)
+		err := useCase.MarkNotificationsAsReadByIDs(ids, "user_123")
 
 		assert.NoError(t, err)
-		assert.Empty(t, notifications)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("CountUnreadNotifications - Success", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.Calls = nil
+
+		mockRepo.On("CountUnreadNotifications", "user_123").Return(int64(4), nil).Once()
+
+		count, err := useCase.CountUnreadNotifications("user_123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), count)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -114,4 +263,65 @@ func TestNotiUseCase(t *testing.T) {
 		assert.Equal(t, expectedError, err)
 		mockRepo.AssertExpectations(t)
 	})
+	t.Run("GetPreference - Default When Missing", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.Calls = nil
+
+		mockRepo.On("GetPreferenceByUserID", "user_123").Return(nil, errors.New("record not found")).Once()
+
+		preference, err := useCase.GetPreference("user_123")
+
+		assert.NoError(t, err)
+		assert.True(t, preference.InApp)
+		assert.True(t, preference.AssetEnabled)
+		assert.False(t, preference.Email)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("UpdatePreference - Success", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.Calls = nil
+
+		preference := entities.NotificationPreference{
+			AssetEnabled:    true,
+			InApp:           true,
+			Email:           true,
+			WeeklyDigest:    true,
+			QuietHoursStart: "22:00",
+			QuietHoursEnd:   "07:00",
+		}
+
+		mockRepo.On("SavePreference", mock.MatchedBy(func(p *entities.NotificationPreference) bool {
+			return p.UserID == "user_123" && p.WeeklyDigest
+		})).Return(&preference, nil).Once()
+
+		result, err := useCase.UpdatePreference("user_123", preference)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("UpdatePreference - Invalid Quiet Hours", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.Calls = nil
+
+		result, err := useCase.UpdatePreference("user_123", entities.NotificationPreference{QuietHoursStart: "10pm", QuietHoursEnd: "07:00"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "quiet hours must be in HH:MM format", err.Error())
+	})
+
+	t.Run("UpdatePreference - Digest Without Email", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.Calls = nil
+
+		result, err := useCase.UpdatePreference("user_123", entities.NotificationPreference{WeeklyDigest: true})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "weekly digest requires the email channel", err.Error())
+		mockRepo.AssertNotCalled(t, "SavePreference", mock.Anything)
+	})
 }
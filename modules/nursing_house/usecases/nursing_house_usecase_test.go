@@ -21,8 +21,8 @@ func TestGetAllNh(t *testing.T) {
 	useCase := usecases.NewNhUseCase(mockRepo, configs.Supabase{}, configs.Recommend{})
 
 	mockNursingHouses := []entities.NursingHouse{
-		{ID: "NH001", Name: "Test Home 1", Price: 1000},
-		{ID: "NH002", Name: "Test Home 2", Price: 2000},
+		{ID: "NH001", Name: "Test Home 1", Price: entities.Baht(1000)},
+		{ID: "NH002", Name: "Test Home 2", Price: entities.Baht(2000)},
 	}
 
 	mockRepo.On("GetAllNh").Return(mockNursingHouses, nil)
@@ -86,7 +86,7 @@ func TestGetNhByID(t *testing.T) {
 	mockNursingHouse := &entities.NursingHouse{
 		ID:    "NH001",
 		Name:  "Test Home",
-		Price: 1000,
+		Price: entities.Baht(1000),
 	}
 
 	mockRepo.On("GetNhByID", "NH001").Return(mockNursingHouse, nil)
@@ -135,7 +135,7 @@ func TestCreateNh_InvalidPrice(t *testing.T) {
 
 	nursingHouse := entities.NursingHouse{
 		Name:  "Test Home",
-		Price: -1000,
+		Price: entities.Baht(-1000),
 	}
 
 	mockRepo.On("GetNhNextID").Return("NH005", nil)
@@ -164,7 +164,7 @@ func TestCreateNh_NoImages(t *testing.T) {
 
 	nursingHouse := entities.NursingHouse{
 		Name:  "Test Home",
-		Price: 1000,
+		Price: entities.Baht(1000),
 	}
 
 	mockRepo.On("GetNhNextID").Return("NH005", nil)
@@ -190,7 +190,7 @@ func TestGetNhByIDForUser_NewHistory(t *testing.T) {
 	mockNursingHouse := &entities.NursingHouse{
 		ID:    "NH001",
 		Name:  "Test Home",
-		Price: 1000,
+		Price: entities.Baht(1000),
 	}
 
 	userID := "user123"
@@ -215,7 +215,7 @@ func TestGetNhByIDForUser_ExistingHistory(t *testing.T) {
 	mockNursingHouse := &entities.NursingHouse{
 		ID:    "NH001",
 		Name:  "Test Home",
-		Price: 1000,
+		Price: entities.Baht(1000),
 	}
 
 	userID := "user123"
@@ -252,7 +252,7 @@ func TestGetNhByIDForUser_SameHistory(t *testing.T) {
 	mockNursingHouse := &entities.NursingHouse{
 		ID:    "NH001",
 		Name:  "Test Home",
-		Price: 1000,
+		Price: entities.Baht(1000),
 	}
 
 	userID := "user123"
@@ -285,12 +285,12 @@ func TestRecommendationCosine_NoHistory(t *testing.T) {
 	userID := "user123"
 
 	mockNursingHouses := []entities.NursingHouse{
-		{ID: "NH001", Name: "Test Home 1", Price: 1000},
-		{ID: "NH002", Name: "Test Home 2", Price: 2000},
-		{ID: "NH003", Name: "Test Home 3", Price: 3000},
-		{ID: "NH004", Name: "Test Home 4", Price: 4000},
-		{ID: "NH005", Name: "Test Home 5", Price: 5000},
-		{ID: "NH006", Name: "Test Home 6", Price: 6000},
+		{ID: "NH001", Name: "Test Home 1", Price: entities.Baht(1000)},
+		{ID: "NH002", Name: "Test Home 2", Price: entities.Baht(2000)},
+		{ID: "NH003", Name: "Test Home 3", Price: entities.Baht(3000)},
+		{ID: "NH004", Name: "Test Home 4", Price: entities.Baht(4000)},
+		{ID: "NH005", Name: "Test Home 5", Price: entities.Baht(5000)},
+		{ID: "NH006", Name: "Test Home 6", Price: entities.Baht(6000)},
 	}
 
 	mockRepo.On("GetNhHistory", userID).Return(nil, gorm.ErrRecordNotFound)
@@ -311,12 +311,12 @@ func TestRecommendationLLM_NoHistory(t *testing.T) {
 	userID := "user123"
 
 	mockNursingHouses := []entities.NursingHouse{
-		{ID: "NH001", Name: "Test Home 1", Price: 1000},
-		{ID: "NH002", Name: "Test Home 2", Price: 2000},
-		{ID: "NH003", Name: "Test Home 3", Price: 3000},
-		{ID: "NH004", Name: "Test Home 4", Price: 4000},
-		{ID: "NH005", Name: "Test Home 5", Price: 5000},
-		{ID: "NH006", Name: "Test Home 6", Price: 6000},
+		{ID: "NH001", Name: "Test Home 1", Price: entities.Baht(1000)},
+		{ID: "NH002", Name: "Test Home 2", Price: entities.Baht(2000)},
+		{ID: "NH003", Name: "Test Home 3", Price: entities.Baht(3000)},
+		{ID: "NH004", Name: "Test Home 4", Price: entities.Baht(4000)},
+		{ID: "NH005", Name: "Test Home 5", Price: entities.Baht(5000)},
+		{ID: "NH006", Name: "Test Home 6", Price: entities.Baht(6000)},
 	}
 
 	mockRepo.On("GetNhHistory", userID).Return(nil, gorm.ErrRecordNotFound)
@@ -341,7 +341,7 @@ func TestUpdateNhByID(t *testing.T) {
 		Name:         "Original Name",
 		Province:     "Original Province",
 		Address:      "Original Address",
-		Price:        1000,
+		Price:        entities.Baht(1000),
 		Google_map:   "Original Google Map Link",
 		Phone_number: "Original Phone",
 		Web_site:     "Original Website",
@@ -357,7 +357,7 @@ func TestUpdateNhByID(t *testing.T) {
 		Name:         "Updated Name",
 		Province:     "Updated Province",
 		Address:      "Updated Address",
-		Price:        2000,
+		Price:        entities.Baht(2000),
 		Google_map:   "Updated Google Map Link",
 		Phone_number: "Updated Phone",
 		Web_site:     "Updated Website",
@@ -408,7 +408,7 @@ func TestUpdateNhByID_InvalidPrice(t *testing.T) {
 
 	updatedNursingHouse := entities.NursingHouse{
 		Name:  "Updated Name",
-		Price: -1000,
+		Price: entities.Baht(-1000),
 	}
 
 	app := fiber.New()
@@ -434,7 +434,7 @@ func TestUpdateNhByID_NotFound(t *testing.T) {
 
 	updatedNursingHouse := entities.NursingHouse{
 		Name:  "Updated Name",
-		Price: 1000,
+		Price: entities.Baht(1000),
 	}
 
 	mockRepo.On("GetNhByID", nhID).Return(nil, errors.New("nursing house not found"))
@@ -460,7 +460,7 @@ func TestCreateNhMock(t *testing.T) {
 
 	nursingHouse := entities.NursingHouse{
 		Name:  "Test Home",
-		Price: 1000,
+		Price: entities.Baht(1000),
 	}
 
 	links := []string{"link1", "link2", "link3"}
@@ -469,7 +469,7 @@ func TestCreateNhMock(t *testing.T) {
 	mockResult := &entities.NursingHouse{
 		ID:    "NH005",
 		Name:  "Test Home",
-		Price: 1000,
+		Price: entities.Baht(1000),
 		Images: []entities.Image{
 			{ID: "mock-id-1", ImageLink: "link1"},
 			{ID: "mock-id-2", ImageLink: "link2"},
@@ -487,7 +487,7 @@ func TestCreateNhMock(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "NH005", result.ID)
 	assert.Equal(t, "Test Home", result.Name)
-	assert.Equal(t, 1000, result.Price)
+	assert.Equal(t, entities.Baht(1000), result.Price)
 	assert.Equal(t, 3, len(result.Images))
 
 	mockRepo.AssertExpectations(t)
@@ -499,7 +499,7 @@ func TestCreateNhMock_InvalidPrice(t *testing.T) {
 
 	nursingHouse := entities.NursingHouse{
 		Name:  "Test Home",
-		Price: -1000,
+		Price: entities.Baht(-1000),
 	}
 
 	links := []string{"link1", "link2", "link3"}
@@ -0,0 +1,325 @@
+package controllers
+
+import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/portfolio/usecases"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PortfolioController struct {
+	portfoliousecase usecases.PortfolioUseCase
+}
+
+func NewPortfolioController(portfoliousecase usecases.PortfolioUseCase) *PortfolioController {
+	return &PortfolioController{portfoliousecase: portfoliousecase}
+}
+
+func (c *PortfolioController) CreateHoldingHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	var holding entities.Holding
+	if err := ctx.BodyParser(&holding); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	if holding.Name == "" || holding.Kind == "" {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     "Name or Kind is missing.",
+			"result":      nil,
+		})
+	}
+
+	createdHolding, err := c.portfoliousecase.CreateHolding(userID, holding, ctx.QueryBool("replace_investment"))
+	if err != nil {
+		if err.Error() == "holdings replace the investment typed into the retirement plan" {
+			return ctx.Status(fiber.ErrConflict.Code).JSON(fiber.Map{
+				"status":      fiber.ErrConflict.Message,
+				"status_code": fiber.ErrConflict.Code,
+				"message":     err.Error(),
+				"result":      nil,
+			})
+		}
+
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusCreated,
+		"message":     "Holding created successfully",
+		"result":      createdHolding,
+	})
+}
+
+func (c *PortfolioController) GetPortfolioHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	portfolio, err := c.portfoliousecase.GetPortfolio(userID)
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Portfolio retrieved successfully",
+		"result":      portfolio,
+	})
+}
+
+func (c *PortfolioController) GetHoldingByIDHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	holding, err := c.portfoliousecase.GetHoldingByID(userID, id)
+	if err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Holding retrieved successfully",
+		"result":      holding,
+	})
+}
+
+func (c *PortfolioController) UpdateHoldingHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	var holding entities.Holding
+	if err := ctx.BodyParser(&holding); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	updatedHolding, err := c.portfoliousecase.UpdateHolding(userID, id, holding)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Holding updated successfully",
+		"result":      updatedHolding,
+	})
+}
+
+func (c *PortfolioController) DeleteHoldingHandler(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	if err := c.portfoliousecase.DeleteHolding(userID, id); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Holding deleted successfully",
+		"result":      nil,
+	})
+}
+
+func parsePrices(ctx *fiber.Ctx) ([]entities.PriceQuote, error) {
+	if file, err := ctx.FormFile("file"); err == nil {
+		src, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		defer src.Close()
+		return utils.ParsePriceCSV(src)
+	}
+
+	var request struct {
+		Prices []struct {
+			Symbol string  `json:"symbol"`
+			Price  float64 `json:"price"`
+			Date   string  `json:"date"`
+		} `json:"prices"`
+	}
+
+	if err := ctx.BodyParser(&request); err != nil {
+		return nil, err
+	}
+
+	quotes := make([]entities.PriceQuote, 0, len(request.Prices))
+	for _, price := range request.Prices {
+		pricedAt, err := utils.ParsePriceDate(price.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		quotes = append(quotes, entities.PriceQuote{Symbol: price.Symbol, Price: price.Price, PricedAt: pricedAt})
+	}
+
+	return quotes, nil
+}
+
+func (c *PortfolioController) ImportPricesHandler(ctx *fiber.Ctx) error {
+	quotes, err := parsePrices(ctx)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	result, err := c.portfoliousecase.ImportPrices(quotes)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Prices imported successfully",
+		"result":      result,
+	})
+}
+
+func (c *PortfolioController) GetValuationHistoryHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	from, err := utils.ParsePriceDate(ctx.Query("from"))
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	to, err := utils.ParsePriceDate(ctx.Query("to"))
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	history, err := c.portfoliousecase.GetValuationHistory(userID, from, to)
+	if err != nil {
+		if err.Error() == "invalid date range" {
+			return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"status":      fiber.ErrBadRequest.Message,
+				"status_code": fiber.ErrBadRequest.Code,
+				"message":     err.Error(),
+				"result":      nil,
+			})
+		}
+
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Portfolio history retrieved successfully",
+		"result":      history,
+	})
+}
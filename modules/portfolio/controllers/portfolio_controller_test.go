@@ -0,0 +1,376 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/portfolio/controllers"
+	"github.com/XzerozZ/Kasian_Phrom_BE/testing/usecases/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTest(_ *testing.T) (*controllers.PortfolioController, *mocks.MockPortfolioUseCase, *fiber.App) {
+	mockUseCase := new(mocks.MockPortfolioUseCase)
+	controller := controllers.NewPortfolioController(mockUseCase)
+	app := fiber.New()
+	return controller, mockUseCase, app
+}
+
+func TestGetPortfolioHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/portfolio", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetPortfolioHandler(c)
+		})
+
+		mockUseCase.On("GetPortfolio", "user-123").Return(&entities.PortfolioSummary{
+			MarketValue: entities.Baht(100000),
+			Allocation:  []entities.AllocationSlice{{AssetClass: entities.AssetClassEquity, Percent: 60, Target: 50, Drift: 10, Alert: true}},
+			Drifted:     true,
+		}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/portfolio", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		result := responseMap["result"].(map[string]interface{})
+		assert.Equal(t, 100000.0, result["market_value"])
+		assert.Equal(t, true, result["drifted"])
+		assert.Equal(t, 10.0, result["allocation"].([]interface{})[0].(map[string]interface{})["drift"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/portfolio", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetPortfolioHandler(c)
+		})
+
+		mockUseCase.On("GetPortfolio", "user-123").Return(nil, errors.New("database error")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/portfolio", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestCreateHoldingHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/portfolio/holdings", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.CreateHoldingHandler(c)
+		})
+
+		mockUseCase.On("CreateHolding", "user-123", mock.MatchedBy(func(holding entities.Holding) bool {
+			return holding.Name == "K-SET50" && holding.Kind == entities.HoldingKindFund && holding.Quantity == 1000.5 && holding.CostBasis == entities.Baht(10000)
+		}), false).Return(&entities.Holding{ID: "h1", Name: "K-SET50"}, nil).Once()
+
+		req := httptest.NewRequest("POST", "/portfolio/holdings", strings.NewReader(`{"name":"K-SET50","kind":"fund","asset_class":"equity","quantity":1000.5,"cost_basis":10000}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "Holding created successfully", responseMap["message"])
+		assert.Equal(t, "h1", responseMap["result"].(map[string]interface{})["holding_id"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Missing Fields", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/portfolio/holdings", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.CreateHoldingHandler(c)
+		})
+
+		req := httptest.NewRequest("POST", "/portfolio/holdings", strings.NewReader(`{"name":"K-SET50"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "Name or Kind is missing.", responseMap["message"])
+		mockUseCase.AssertNotCalled(t, "CreateHolding", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid Holding", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/portfolio/holdings", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.CreateHoldingHandler(c)
+		})
+
+		mockUseCase.On("CreateHolding", "user-123", mock.Anything, false).Return(nil, errors.New("asset class is required for funds")).Once()
+
+		req := httptest.NewRequest("POST", "/portfolio/holdings", strings.NewReader(`{"name":"K-SET50","kind":"fund"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "asset class is required for funds", responseMap["message"])
+	})
+
+	t.Run("Typed-in Investment", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/portfolio/holdings", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.CreateHoldingHandler(c)
+		})
+
+		mockUseCase.On("CreateHolding", "user-123", mock.Anything, false).Return(nil, errors.New("holdings replace the investment typed into the retirement plan")).Once()
+
+		req := httptest.NewRequest("POST", "/portfolio/holdings", strings.NewReader(`{"name":"PTT","kind":"stock"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("Replace Investment", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/portfolio/holdings", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.CreateHoldingHandler(c)
+		})
+
+		mockUseCase.On("CreateHolding", "user-123", mock.Anything, true).Return(&entities.Holding{ID: "h1"}, nil).Once()
+
+		req := httptest.NewRequest("POST", "/portfolio/holdings?replace_investment=true", strings.NewReader(`{"name":"PTT","kind":"stock"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestGetHoldingByIDHandler(t *testing.T) {
+	controller, mockUseCase, app := setupTest(t)
+	app.Get("/portfolio/holdings/:id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return controller.GetHoldingByIDHandler(c)
+	})
+
+	mockUseCase.On("GetHoldingByID", "user-123", "h2").Return(nil, errors.New("holding not found")).Once()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/portfolio/holdings/h2", nil), -1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	var responseMap map[string]interface{}
+	responseBody, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(responseBody, &responseMap)
+	assert.Equal(t, "holding not found", responseMap["message"])
+}
+
+func TestUpdateHoldingHandler(t *testing.T) {
+	controller, mockUseCase, app := setupTest(t)
+	app.Put("/portfolio/holdings/:id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return controller.UpdateHoldingHandler(c)
+	})
+
+	mockUseCase.On("UpdateHolding", "user-123", "h1", mock.MatchedBy(func(holding entities.Holding) bool {
+		return holding.Price == 12.3456
+	})).Return(&entities.Holding{ID: "h1", Price: 12.3456}, nil).Once()
+
+	req := httptest.NewRequest("PUT", "/portfolio/holdings/h1", strings.NewReader(`{"name":"K-SET50","kind":"fund","asset_class":"equity","price":12.3456}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var responseMap map[string]interface{}
+	responseBody, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(responseBody, &responseMap)
+	assert.Equal(t, "Holding updated successfully", responseMap["message"])
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestDeleteHoldingHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Delete("/portfolio/holdings/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.DeleteHoldingHandler(c)
+		})
+
+		mockUseCase.On("DeleteHolding", "user-123", "h1").Return(nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/portfolio/holdings/h1", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Delete("/portfolio/holdings/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.DeleteHoldingHandler(c)
+		})
+
+		mockUseCase.On("DeleteHolding", "user-123", "h1").Return(errors.New("holding not found")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/portfolio/holdings/h1", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestImportPricesHandler(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/portfolio/prices", controller.ImportPricesHandler)
+
+		mockUseCase.On("ImportPrices", []entities.PriceQuote{
+			{Symbol: "K-SET50", Price: 12.5, PricedAt: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.Local)},
+			{Symbol: "PTT", Price: 34.25},
+		}).Return(&entities.PriceImportResult{Imported: 2, UpdatedHoldings: 3}, nil).Once()
+
+		req := httptest.NewRequest("POST", "/portfolio/prices", strings.NewReader(`{"prices":[{"symbol":"K-SET50","price":12.5,"date":"2024-05-01"},{"symbol":"PTT","price":34.25}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		result := responseMap["result"].(map[string]interface{})
+		assert.Equal(t, 3.0, result["updated_holdings"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/portfolio/prices", controller.ImportPricesHandler)
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("file", "prices.csv")
+		_, _ = part.Write([]byte("symbol,price,date\nk-set50,12.5,2024-05-01\n"))
+		writer.Close()
+
+		mockUseCase.On("ImportPrices", []entities.PriceQuote{
+			{Symbol: "K-SET50", Price: 12.5, PricedAt: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.Local)},
+		}).Return(&entities.PriceImportResult{Imported: 1}, nil).Once()
+
+		req := httptest.NewRequest("POST", "/portfolio/prices", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Invalid CSV", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/portfolio/prices", controller.ImportPricesHandler)
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("file", "prices.csv")
+		_, _ = part.Write([]byte("symbol,price\nPTT,abc\n"))
+		writer.Close()
+
+		req := httptest.NewRequest("POST", "/portfolio/prices", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "line 2: invalid price", responseMap["message"])
+		mockUseCase.AssertNotCalled(t, "ImportPrices", mock.Anything)
+	})
+}
+
+func TestGetValuationHistoryHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/portfolio/history", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetValuationHistoryHandler(c)
+		})
+
+		from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.Local)
+		mockUseCase.On("GetValuationHistory", "user-123", from, time.Time{}).Return([]entities.PortfolioSnapshot{
+			{ID: "s1", Date: from, MarketValue: entities.Baht(100000)},
+		}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/portfolio/history?from=2024-01-01", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		result := responseMap["result"].([]interface{})
+		assert.Equal(t, 100000.0, result[0].(map[string]interface{})["market_value"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Date", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/portfolio/history", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetValuationHistoryHandler(c)
+		})
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/portfolio/history?to=01-01-2024", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockUseCase.AssertNotCalled(t, "GetValuationHistory", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid Range", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/portfolio/history", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetValuationHistoryHandler(c)
+		})
+
+		mockUseCase.On("GetValuationHistory", "user-123", mock.Anything, mock.Anything).Return(nil, errors.New("invalid date range")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/portfolio/history?from=2024-02-01&to=2024-01-01", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormPortfolioRepository struct {
+	db *gorm.DB
+}
+
+func NewGormPortfolioRepository(db *gorm.DB) *GormPortfolioRepository {
+	return &GormPortfolioRepository{db: db}
+}
+
+type PortfolioRepository interface {
+	CreateHolding(holding *entities.Holding) (*entities.Holding, error)
+	GetHoldingByID(id string) (*entities.Holding, error)
+	GetHoldingsByUserID(userID string) ([]entities.Holding, error)
+	UpdateHolding(holding *entities.Holding) (*entities.Holding, error)
+	DeleteHolding(id string) error
+	GetUserIDsWithHoldings() ([]string, error)
+	UpsertPriceQuotes(quotes []entities.PriceQuote) error
+	ApplyPriceQuote(quote *entities.PriceQuote) (int64, error)
+	UpsertSnapshot(snapshot *entities.PortfolioSnapshot) error
+	GetSnapshotsByUserID(userID string, from, to time.Time) ([]entities.PortfolioSnapshot, error)
+}
+
+func (r *GormPortfolioRepository) CreateHolding(holding *entities.Holding) (*entities.Holding, error) {
+	if err := r.db.Create(&holding).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetHoldingByID(holding.ID)
+}
+
+func (r *GormPortfolioRepository) GetHoldingByID(id string) (*entities.Holding, error) {
+	var holding entities.Holding
+	if err := r.db.First(&holding, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return &holding, nil
+}
+
+func (r *GormPortfolioRepository) GetHoldingsByUserID(userID string) ([]entities.Holding, error) {
+	var holdings []entities.Holding
+	if err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&holdings).Error; err != nil {
+		return nil, err
+	}
+
+	return holdings, nil
+}
+
+func (r *GormPortfolioRepository) UpdateHolding(holding *entities.Holding) (*entities.Holding, error) {
+	if err := r.db.Save(&holding).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetHoldingByID(holding.ID)
+}
+
+func (r *GormPortfolioRepository) DeleteHolding(id string) error {
+	return r.db.Where("id = ?", id).Delete(&entities.Holding{}).Error
+}
+
+func (r *GormPortfolioRepository) GetUserIDsWithHoldings() ([]string, error) {
+	var userIDs []string
+	if err := r.db.Model(&entities.Holding{}).Distinct().Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+func (r *GormPortfolioRepository) UpsertPriceQuotes(quotes []entities.PriceQuote) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "symbol"}, {Name: "priced_at"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price"}),
+	}).Create(&quotes).Error
+}
+
+func (r *GormPortfolioRepository) ApplyPriceQuote(quote *entities.PriceQuote) (int64, error) {
+	result := r.db.Model(&entities.Holding{}).
+		Where("UPPER(symbol) = ? AND priced_at < ?", quote.Symbol, quote.PricedAt.AddDate(0, 0, 1)).
+		Updates(map[string]interface{}{"price": quote.Price, "priced_at": quote.PricedAt})
+	return result.RowsAffected, result.Error
+}
+
+func (r *GormPortfolioRepository) UpsertSnapshot(snapshot *entities.PortfolioSnapshot) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"market_value", "cost_basis"}),
+	}).Create(snapshot).Error
+}
+
+func (r *GormPortfolioRepository) GetSnapshotsByUserID(userID string, from, to time.Time) ([]entities.PortfolioSnapshot, error) {
+	var snapshots []entities.PortfolioSnapshot
+	if err := r.db.Where("user_id = ? AND date BETWEEN ? AND ?", userID, from, to).Order("date ASC").Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
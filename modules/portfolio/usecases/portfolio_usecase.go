@@ -0,0 +1,430 @@
+package usecases
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	ledger "github.com/XzerozZ/Kasian_Phrom_BE/modules/ledger/usecases"
+	notiUsecase "github.com/XzerozZ/Kasian_Phrom_BE/modules/notification/usecases"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/portfolio/repositories"
+	quizRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/quiz/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/unitofwork"
+	userRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type PortfolioUseCase interface {
+	GetPortfolio(userID string) (*entities.PortfolioSummary, error)
+	CreateHolding(userID string, holding entities.Holding, replaceInvestment bool) (*entities.Holding, error)
+	GetHoldingByID(userID, id string) (*entities.Holding, error)
+	UpdateHolding(userID, id string, holding entities.Holding) (*entities.Holding, error)
+	DeleteHolding(userID, id string) error
+	ImportPrices(quotes []entities.PriceQuote) (*entities.PriceImportResult, error)
+	SnapshotPortfolios() error
+	GetValuationHistory(userID string, from, to time.Time) ([]entities.PortfolioSnapshot, error)
+}
+
+type PortfolioUseCaseImpl struct {
+	portfoliorepo repositories.PortfolioRepository
+	quizrepo      quizRepo.QuizRepository
+	userrepo      userRepo.UserRepository
+	dispatcher    notiUsecase.NotiDispatcher
+	uow           unitofwork.UnitOfWork
+}
+
+func NewPortfolioUseCase(portfoliorepo repositories.PortfolioRepository, quizrepo quizRepo.QuizRepository, userrepo userRepo.UserRepository, dispatcher notiUsecase.NotiDispatcher, uow unitofwork.UnitOfWork) *PortfolioUseCaseImpl {
+	return &PortfolioUseCaseImpl{
+		portfoliorepo: portfoliorepo,
+		quizrepo:      quizrepo,
+		userrepo:      userrepo,
+		dispatcher:    dispatcher,
+		uow:           uow,
+	}
+}
+
+func validateHolding(holding *entities.Holding) error {
+	if holding.Name == "" {
+		return errors.New("holding name is missing")
+	}
+
+	if holding.Quantity < 0 || holding.CostBasis < 0 || holding.Price < 0 {
+		return errors.New("holding values must not be negative")
+	}
+
+	assetClass, err := utils.HoldingAssetClass(holding.Kind, holding.AssetClass)
+	if err != nil {
+		return err
+	}
+
+	holding.AssetClass = assetClass
+	holding.Symbol = utils.NormalizeSymbol(holding.Symbol)
+	if holding.Price == 0 && holding.Quantity > 0 {
+		holding.Price = holding.CostBasis.Float64() / holding.Quantity
+	}
+
+	return nil
+}
+
+func (u *PortfolioUseCaseImpl) loadPortfolio(userID string) ([]entities.Holding, *entities.Risk, entities.Money, error) {
+	holdings, err := u.portfoliorepo.GetHoldingsByUserID(userID)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	user, err := u.userrepo.GetUserByID(userID)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var risk *entities.Risk
+	if quiz, err := u.quizrepo.GetQuizByUserID(userID); err == nil {
+		risk = &quiz.Risk
+	}
+
+	return holdings, risk, user.RetirementPlan.CurrentTotalInvestment, nil
+}
+
+func (u *PortfolioUseCaseImpl) alertDrift(userID string, before, after *entities.PortfolioSummary) {
+	if before.Drifted || !after.Drifted {
+		return
+	}
+
+	var drifted []string
+	for _, slice := range after.Allocation {
+		if slice.Alert {
+			drifted = append(drifted, slice.AssetClass)
+		}
+	}
+
+	if notification := utils.AlertNoti("portfolio", userID, strings.Join(drifted, ", "), userID, after.MarketValue); notification != nil {
+		_ = u.dispatcher.Dispatch(notification)
+	}
+}
+
+func syncInvestment(repos unitofwork.Repositories, userID string, marketValue entities.Money, now time.Time, description string) (*entities.Notification, error) {
+	plan, err := repos.Retirements.GetRetirementByUserIDForUpdate(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan.ID == "" || plan.CurrentTotalInvestment == marketValue {
+		return nil, nil
+	}
+
+	change := marketValue - plan.CurrentTotalInvestment
+	plan.CurrentTotalInvestment = marketValue
+	var notification *entities.Notification
+	allMoney := plan.CurrentSavings + plan.CurrentTotalInvestment
+	if allMoney >= plan.LastRequiredFunds {
+		if plan.Status != "Completed" {
+			notification = utils.SuccessNotification("retirementplan", userID, plan.PlanName, plan.ID, allMoney)
+		}
+
+		plan.Status = "Completed"
+		plan.LastMonthlyExpenses = 0
+	} else {
+		plan.Status = "In_Progress"
+	}
+
+	if _, err := repos.Retirements.UpdateRetirementPlan(plan); err != nil {
+		return nil, err
+	}
+
+	if _, err := ledger.PostEntry(repos.Ledger, userID, description, now, ledger.Leg{Kind: entities.LedgerAccountRetirementInvestment, RefID: userID, Name: plan.PlanName, Amount: change, Balance: marketValue}); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+func (u *PortfolioUseCaseImpl) dispatch(notification *entities.Notification) {
+	if notification != nil {
+		_ = u.dispatcher.Dispatch(notification)
+	}
+}
+
+func (u *PortfolioUseCaseImpl) GetPortfolio(userID string) (*entities.PortfolioSummary, error) {
+	holdings, risk, planInvestment, err := u.loadPortfolio(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.SummarizePortfolio(holdings, risk, planInvestment), nil
+}
+
+func (u *PortfolioUseCaseImpl) CreateHolding(userID string, holding entities.Holding, replaceInvestment bool) (*entities.Holding, error) {
+	if err := validateHolding(&holding); err != nil {
+		return nil, err
+	}
+
+	holdings, risk, planInvestment, err := u.loadPortfolio(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	description := "portfolio valuation"
+	if len(holdings) == 0 && planInvestment > 0 {
+		if !replaceInvestment {
+			return nil, errors.New("holdings replace the investment typed into the retirement plan")
+		}
+
+		description = "Replace typed-in investment with portfolio"
+	}
+
+	holding.ID = uuid.New().String()
+	holding.UserID = userID
+	if holding.PricedAt.IsZero() {
+		holding.PricedAt = time.Now()
+	}
+
+	var createdHolding *entities.Holding
+	var after *entities.PortfolioSummary
+	var notification *entities.Notification
+	err = u.uow.Do(func(repos unitofwork.Repositories) error {
+		createdHolding, err = repos.Portfolio.CreateHolding(&holding)
+		if err != nil {
+			return err
+		}
+
+		after = utils.SummarizePortfolio(append(holdings, *createdHolding), risk, planInvestment)
+		notification, err = syncInvestment(repos, userID, after.MarketValue, time.Now(), description)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	u.alertDrift(userID, utils.SummarizePortfolio(holdings, risk, planInvestment), after)
+	u.dispatch(notification)
+	return createdHolding, nil
+}
+
+func (u *PortfolioUseCaseImpl) GetHoldingByID(userID, id string) (*entities.Holding, error) {
+	holding, err := u.portfoliorepo.GetHoldingByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if holding.UserID != userID {
+		return nil, errors.New("holding not found")
+	}
+
+	return holding, nil
+}
+
+func (u *PortfolioUseCaseImpl) UpdateHolding(userID, id string, holding entities.Holding) (*entities.Holding, error) {
+	existing, err := u.GetHoldingByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if holding.Price == 0 {
+		holding.Price = existing.Price
+	}
+
+	if err := validateHolding(&holding); err != nil {
+		return nil, err
+	}
+
+	holdings, risk, planInvestment, err := u.loadPortfolio(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if holding.Price != existing.Price {
+		existing.PricedAt = time.Now()
+	}
+
+	existing.Name = holding.Name
+	existing.Symbol = holding.Symbol
+	existing.Kind = holding.Kind
+	existing.AssetClass = holding.AssetClass
+	existing.Quantity = holding.Quantity
+	existing.CostBasis = holding.CostBasis
+	existing.Price = holding.Price
+	var updatedHolding *entities.Holding
+	var after *entities.PortfolioSummary
+	var notification *entities.Notification
+	err = u.uow.Do(func(repos unitofwork.Repositories) error {
+		updatedHolding, err = repos.Portfolio.UpdateHolding(existing)
+		if err != nil {
+			return err
+		}
+
+		updated := make([]entities.Holding, 0, len(holdings))
+		for _, item := range holdings {
+			if item.ID == id {
+				item = *updatedHolding
+			}
+
+			updated = append(updated, item)
+		}
+
+		after = utils.SummarizePortfolio(updated, risk, planInvestment)
+		notification, err = syncInvestment(repos, userID, after.MarketValue, time.Now(), "portfolio valuation")
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	u.alertDrift(userID, utils.SummarizePortfolio(holdings, risk, planInvestment), after)
+	u.dispatch(notification)
+	return updatedHolding, nil
+}
+
+func (u *PortfolioUseCaseImpl) DeleteHolding(userID, id string) error {
+	if _, err := u.GetHoldingByID(userID, id); err != nil {
+		return err
+	}
+
+	holdings, risk, planInvestment, err := u.loadPortfolio(userID)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]entities.Holding, 0, len(holdings))
+	for _, item := range holdings {
+		if item.ID != id {
+			remaining = append(remaining, item)
+		}
+	}
+
+	after := utils.SummarizePortfolio(remaining, risk, planInvestment)
+	var notification *entities.Notification
+	err = u.uow.Do(func(repos unitofwork.Repositories) error {
+		if err := repos.Portfolio.DeleteHolding(id); err != nil {
+			return err
+		}
+
+		notification, err = syncInvestment(repos, userID, after.MarketValue, time.Now(), "portfolio valuation")
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	u.alertDrift(userID, utils.SummarizePortfolio(holdings, risk, planInvestment), after)
+	u.dispatch(notification)
+	return nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func (u *PortfolioUseCaseImpl) ImportPrices(quotes []entities.PriceQuote) (*entities.PriceImportResult, error) {
+	if len(quotes) == 0 {
+		return nil, errors.New("no prices to import")
+	}
+
+	today := startOfDay(time.Now())
+	unique := make([]entities.PriceQuote, 0, len(quotes))
+	seen := make(map[string]int)
+	for _, quote := range quotes {
+		if err := utils.ValidatePriceQuote(&quote); err != nil {
+			return nil, err
+		}
+
+		quote.PricedAt = startOfDay(quote.PricedAt)
+		if quote.PricedAt.IsZero() {
+			quote.PricedAt = today
+		}
+
+		if quote.PricedAt.After(today) {
+			return nil, errors.New("price date must not be in the future")
+		}
+
+		key := quote.Symbol + "|" + quote.PricedAt.Format("2006-01-02")
+		if i, ok := seen[key]; ok {
+			unique[i].Price = quote.Price
+			continue
+		}
+
+		quote.ID = uuid.New().String()
+		seen[key] = len(unique)
+		unique = append(unique, quote)
+	}
+
+	if err := u.portfoliorepo.UpsertPriceQuotes(unique); err != nil {
+		return nil, err
+	}
+
+	result := &entities.PriceImportResult{Imported: len(unique)}
+	for i := range unique {
+		updated, err := u.portfoliorepo.ApplyPriceQuote(&unique[i])
+		if err != nil {
+			return nil, err
+		}
+
+		result.UpdatedHoldings += updated
+	}
+
+	return result, nil
+}
+
+func (u *PortfolioUseCaseImpl) SnapshotPortfolios() error {
+	userIDs, err := u.portfoliorepo.GetUserIDsWithHoldings()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, userID := range userIDs {
+		holdings, err := u.portfoliorepo.GetHoldingsByUserID(userID)
+		if err != nil {
+			log.Printf("Failed to snapshot portfolio of user %s: %v", userID, err)
+			continue
+		}
+
+		summary := utils.SummarizePortfolio(holdings, nil, 0)
+		var notification *entities.Notification
+		err = u.uow.Do(func(repos unitofwork.Repositories) error {
+			if err := repos.Portfolio.UpsertSnapshot(&entities.PortfolioSnapshot{
+				ID:          uuid.New().String(),
+				UserID:      userID,
+				Date:        startOfDay(now),
+				MarketValue: summary.MarketValue,
+				CostBasis:   summary.CostBasis,
+			}); err != nil {
+				return err
+			}
+
+			notification, err = syncInvestment(repos, userID, summary.MarketValue, now, "portfolio valuation")
+			return err
+		})
+
+		if err != nil {
+			log.Printf("Failed to snapshot portfolio of user %s: %v", userID, err)
+			continue
+		}
+
+		u.dispatch(notification)
+	}
+
+	return nil
+}
+
+func (u *PortfolioUseCaseImpl) GetValuationHistory(userID string, from, to time.Time) ([]entities.PortfolioSnapshot, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	if from.IsZero() {
+		from = to.AddDate(-1, 0, 0)
+	}
+
+	if from.After(to) {
+		return nil, errors.New("invalid date range")
+	}
+
+	return u.portfoliorepo.GetSnapshotsByUserID(userID, startOfDay(from), startOfDay(to))
+}
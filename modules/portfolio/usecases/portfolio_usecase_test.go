@@ -0,0 +1,434 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/portfolio/usecases"
+	"github.com/XzerozZ/Kasian_Phrom_BE/testing/repositories/mocks"
+	usecaseMocks "github.com/XzerozZ/Kasian_Phrom_BE/testing/usecases/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type portfolioMocks struct {
+	portfolioRepo  *mocks.MockPortfolioRepository
+	quizRepo       *mocks.MockQuizRepository
+	userRepo       *mocks.MockUserRepository
+	retirementRepo *mocks.MockRetirementRepository
+	ledgerRepo     *mocks.MockLedgerRepository
+	dispatcher     *usecaseMocks.MockNotiDispatcher
+}
+
+func setupPortfolioUseCase() (*usecases.PortfolioUseCaseImpl, *portfolioMocks) {
+	m := &portfolioMocks{
+		portfolioRepo:  new(mocks.MockPortfolioRepository),
+		quizRepo:       new(mocks.MockQuizRepository),
+		userRepo:       new(mocks.MockUserRepository),
+		retirementRepo: new(mocks.MockRetirementRepository),
+		ledgerRepo:     new(mocks.MockLedgerRepository),
+		dispatcher:     new(usecaseMocks.MockNotiDispatcher),
+	}
+
+	uow := mocks.NewMockUnitOfWork(m.userRepo, new(mocks.MockAssetRepository), m.retirementRepo, m.ledgerRepo)
+	uow.Repositories.Portfolio = m.portfolioRepo
+	return usecases.NewPortfolioUseCase(m.portfolioRepo, m.quizRepo, m.userRepo, m.dispatcher, uow), m
+}
+
+func (m *portfolioMocks) expectNoPlan() {
+	m.retirementRepo.On("GetRetirementByUserIDForUpdate", "user-123").Return(&entities.RetirementPlan{}, nil).Once()
+}
+
+func (m *portfolioMocks) expectPortfolio(holdings []entities.Holding, riskID int) {
+	m.portfolioRepo.On("GetHoldingsByUserID", "user-123").Return(holdings, nil).Once()
+	m.userRepo.On("GetUserByID", "user-123").Return(&entities.User{
+		ID:             "user-123",
+		RetirementPlan: entities.RetirementPlan{CurrentTotalInvestment: entities.Baht(50000)},
+	}, nil).Once()
+
+	if riskID == 0 {
+		m.quizRepo.On("GetQuizByUserID", "user-123").Return(nil, gorm.ErrRecordNotFound).Once()
+		return
+	}
+
+	m.quizRepo.On("GetQuizByUserID", "user-123").Return(&entities.Quiz{UserID: "user-123", RiskID: riskID, Risk: entities.Risk{ID: riskID, RiskName: "ความเสี่ยงปานกลางค่อนข้างสูง"}}, nil).Once()
+}
+
+func balancedHoldings() []entities.Holding {
+	return []entities.Holding{
+		{ID: "h1", Name: "K-SET50", Kind: entities.HoldingKindFund, AssetClass: entities.AssetClassEquity, Quantity: 5000, Price: 10, CostBasis: entities.Baht(50000), UserID: "user-123"},
+		{ID: "h2", Name: "LB29", Kind: entities.HoldingKindBond, AssetClass: entities.AssetClassFixedIncome, Quantity: 40, Price: 1000, CostBasis: entities.Baht(40000), UserID: "user-123"},
+		{ID: "h3", Name: "ออมทรัพย์", Kind: entities.HoldingKindDeposit, AssetClass: entities.AssetClassCash, Quantity: 10000, Price: 1, CostBasis: entities.Baht(10000), UserID: "user-123"},
+	}
+}
+
+func TestGetPortfolio(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.expectPortfolio(balancedHoldings(), 3)
+
+		result, err := useCase.GetPortfolio("user-123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Risk.ID)
+		assert.Equal(t, entities.Baht(100000), result.MarketValue)
+		assert.Equal(t, entities.Baht(50000), result.PlanInvestment)
+		assert.False(t, result.Drifted)
+	})
+
+	t.Run("No Quiz", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.expectPortfolio(balancedHoldings(), 0)
+
+		result, err := useCase.GetPortfolio("user-123")
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.Risk)
+		assert.Equal(t, 0.0, result.Allocation[0].Target)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.portfolioRepo.On("GetHoldingsByUserID", "user-123").Return(nil, errors.New("database error")).Once()
+
+		result, err := useCase.GetPortfolio("user-123")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "database error")
+	})
+}
+
+func TestCreateHolding(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.expectPortfolio(balancedHoldings(), 3)
+		m.portfolioRepo.On("CreateHolding", mock.MatchedBy(func(holding *entities.Holding) bool {
+			return holding.ID != "" && holding.UserID == "user-123" && holding.AssetClass == entities.AssetClassCash &&
+				holding.Price == 1 && !holding.PricedAt.IsZero()
+		})).Return(&entities.Holding{ID: "h4", Name: "ฝากประจำ", Kind: entities.HoldingKindDeposit, AssetClass: entities.AssetClassCash, Quantity: 5000, Price: 1, CostBasis: entities.Baht(5000)}, nil).Once()
+		m.expectNoPlan()
+
+		result, err := useCase.CreateHolding("user-123", entities.Holding{Name: "ฝากประจำ", Kind: entities.HoldingKindDeposit, Quantity: 5000, CostBasis: entities.Baht(5000)}, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "h4", result.ID)
+		m.portfolioRepo.AssertExpectations(t)
+		m.dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything)
+	})
+
+	t.Run("Drift Alert", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.expectPortfolio(balancedHoldings(), 3)
+		created := &entities.Holding{ID: "h4", Name: "PTT", Kind: entities.HoldingKindStock, AssetClass: entities.AssetClassEquity, Quantity: 1000, Price: 40, CostBasis: entities.Baht(40000)}
+		m.portfolioRepo.On("CreateHolding", mock.Anything).Return(created, nil).Once()
+		m.expectNoPlan()
+		m.dispatcher.On("Dispatch", mock.MatchedBy(func(notification *entities.Notification) bool {
+			return notification.Type == "portfolio" && notification.TemplateKey == "portfolio.alert" &&
+				notification.TemplateParams["name"] == "equity, fixed_income" && notification.Balance == entities.Baht(140000)
+		})).Return(nil).Once()
+
+		_, err := useCase.CreateHolding("user-123", entities.Holding{Name: "PTT", Kind: entities.HoldingKindStock, Quantity: 1000, Price: 40, CostBasis: entities.Baht(40000)}, false)
+
+		assert.NoError(t, err)
+		m.dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("Syncs Plan Investment", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.expectPortfolio(balancedHoldings(), 0)
+		m.portfolioRepo.On("CreateHolding", mock.MatchedBy(func(holding *entities.Holding) bool {
+			return holding.Symbol == "SCBSET"
+		})).Return(&entities.Holding{ID: "h4", Symbol: "SCBSET", AssetClass: entities.AssetClassEquity, Quantity: 1000, Price: 20}, nil).Once()
+		m.retirementRepo.On("GetRetirementByUserIDForUpdate", "user-123").Return(&entities.RetirementPlan{
+			ID: "plan-1", PlanName: "เกษียณสุข", CurrentSavings: entities.Baht(10000), CurrentTotalInvestment: entities.Baht(50000), LastRequiredFunds: entities.Baht(1000000), Status: "In_Progress",
+		}, nil).Once()
+		m.retirementRepo.On("UpdateRetirementPlan", mock.MatchedBy(func(plan *entities.RetirementPlan) bool {
+			return plan.CurrentTotalInvestment == entities.Baht(120000) && plan.Status == "In_Progress"
+		})).Return(&entities.RetirementPlan{}, nil).Once()
+		m.ledgerRepo.On("GetAccount", "user-123", entities.LedgerAccountRetirementInvestment, "user-123").Return(&entities.LedgerAccount{ID: "investment"}, nil).Once()
+		m.ledgerRepo.On("GetAccount", "user-123", entities.LedgerAccountExternal, "user-123").Return(&entities.LedgerAccount{ID: "external"}, nil).Once()
+		m.ledgerRepo.On("CreateEntry", mock.MatchedBy(func(entry *entities.LedgerEntry) bool {
+			return entry.Description == "portfolio valuation" && len(entry.Postings) == 2 &&
+				entry.Postings[0].AccountID == "investment" && entry.Postings[0].Amount == entities.Baht(70000)
+		})).Return(&entities.LedgerEntry{}, nil).Once()
+
+		_, err := useCase.CreateHolding("user-123", entities.Holding{Name: "SCBSET", Symbol: " scbset ", Kind: entities.HoldingKindStock, Quantity: 1000, Price: 20, CostBasis: entities.Baht(18000)}, false)
+
+		assert.NoError(t, err)
+		m.retirementRepo.AssertExpectations(t)
+		m.ledgerRepo.AssertExpectations(t)
+	})
+
+	t.Run("Fund Without Asset Class", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+
+		result, err := useCase.CreateHolding("user-123", entities.Holding{Name: "K-SET50", Kind: entities.HoldingKindFund, Quantity: 100}, false)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "asset class is required for funds")
+		m.portfolioRepo.AssertNotCalled(t, "CreateHolding", mock.Anything)
+	})
+
+	t.Run("First Holding Needs Confirmation", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.expectPortfolio(nil, 0)
+
+		result, err := useCase.CreateHolding("user-123", entities.Holding{Name: "PTT", Kind: entities.HoldingKindStock, Quantity: 1000, Price: 40}, false)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "holdings replace the investment typed into the retirement plan")
+		m.portfolioRepo.AssertNotCalled(t, "CreateHolding", mock.Anything)
+		m.retirementRepo.AssertNotCalled(t, "UpdateRetirementPlan", mock.Anything)
+	})
+
+	t.Run("First Holding Replaces Investment", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.expectPortfolio(nil, 0)
+		m.portfolioRepo.On("CreateHolding", mock.Anything).Return(&entities.Holding{ID: "h1", AssetClass: entities.AssetClassEquity, Quantity: 1000, Price: 40}, nil).Once()
+		m.retirementRepo.On("GetRetirementByUserIDForUpdate", "user-123").Return(&entities.RetirementPlan{
+			ID: "plan-1", CurrentTotalInvestment: entities.Baht(50000), LastRequiredFunds: entities.Baht(1000000),
+		}, nil).Once()
+		m.retirementRepo.On("UpdateRetirementPlan", mock.MatchedBy(func(plan *entities.RetirementPlan) bool {
+			return plan.CurrentTotalInvestment == entities.Baht(40000)
+		})).Return(&entities.RetirementPlan{}, nil).Once()
+		m.ledgerRepo.On("GetAccount", "user-123", mock.Anything, "user-123").Return(&entities.LedgerAccount{ID: "account"}, nil)
+		m.ledgerRepo.On("CreateEntry", mock.MatchedBy(func(entry *entities.LedgerEntry) bool {
+			return entry.Description == "Replace typed-in investment with portfolio"
+		})).Return(&entities.LedgerEntry{}, nil).Once()
+
+		_, err := useCase.CreateHolding("user-123", entities.Holding{Name: "PTT", Kind: entities.HoldingKindStock, Quantity: 1000, Price: 40}, true)
+
+		assert.NoError(t, err)
+		m.retirementRepo.AssertExpectations(t)
+		m.ledgerRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Values", func(t *testing.T) {
+		useCase, _ := setupPortfolioUseCase()
+
+		result, err := useCase.CreateHolding("user-123", entities.Holding{Name: "PTT", Kind: entities.HoldingKindStock, Quantity: -1}, false)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "holding values must not be negative")
+	})
+}
+
+func TestGetHoldingByID(t *testing.T) {
+	useCase, m := setupPortfolioUseCase()
+	m.portfolioRepo.On("GetHoldingByID", "h1").Return(&entities.Holding{ID: "h1", UserID: "user-999"}, nil).Once()
+
+	result, err := useCase.GetHoldingByID("user-123", "h1")
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "holding not found")
+}
+
+func TestUpdateHolding(t *testing.T) {
+	t.Run("Keeps Price", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		holdings := balancedHoldings()
+		existing := holdings[0]
+		m.portfolioRepo.On("GetHoldingByID", "h1").Return(&existing, nil).Once()
+		m.expectPortfolio(holdings, 3)
+		m.portfolioRepo.On("UpdateHolding", mock.MatchedBy(func(holding *entities.Holding) bool {
+			return holding.ID == "h1" && holding.Quantity == 5500 && holding.Price == 10 && holding.CostBasis == entities.Baht(55000)
+		})).Return(&entities.Holding{ID: "h1", AssetClass: entities.AssetClassEquity, Quantity: 5500, Price: 10}, nil).Once()
+		m.expectNoPlan()
+
+		_, err := useCase.UpdateHolding("user-123", "h1", entities.Holding{Name: "K-SET50", Kind: entities.HoldingKindFund, AssetClass: entities.AssetClassEquity, Quantity: 5500, CostBasis: entities.Baht(55000)})
+
+		assert.NoError(t, err)
+		m.portfolioRepo.AssertExpectations(t)
+		m.dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything)
+	})
+
+	t.Run("Other User", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.portfolioRepo.On("GetHoldingByID", "h1").Return(&entities.Holding{ID: "h1", UserID: "user-999"}, nil).Once()
+
+		result, err := useCase.UpdateHolding("user-123", "h1", entities.Holding{Name: "PTT", Kind: entities.HoldingKindStock})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "holding not found")
+		m.portfolioRepo.AssertNotCalled(t, "UpdateHolding", mock.Anything)
+	})
+}
+
+func TestDeleteHolding(t *testing.T) {
+	t.Run("Drift Alert", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		holdings := balancedHoldings()
+		m.portfolioRepo.On("GetHoldingByID", "h2").Return(&holdings[1], nil).Once()
+		m.expectPortfolio(holdings, 3)
+		m.portfolioRepo.On("DeleteHolding", "h2").Return(nil).Once()
+		m.expectNoPlan()
+		m.dispatcher.On("Dispatch", mock.Anything).Return(nil).Once()
+
+		err := useCase.DeleteHolding("user-123", "h2")
+
+		assert.NoError(t, err)
+		m.portfolioRepo.AssertExpectations(t)
+		m.dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		holdings := balancedHoldings()
+		m.portfolioRepo.On("GetHoldingByID", "h2").Return(&holdings[1], nil).Once()
+		m.expectPortfolio(holdings, 3)
+		m.portfolioRepo.On("DeleteHolding", "h2").Return(errors.New("database error")).Once()
+
+		err := useCase.DeleteHolding("user-123", "h2")
+
+		assert.EqualError(t, err, "database error")
+		m.dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything)
+	})
+}
+
+func TestImportPrices(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		date := time.Date(2024, time.May, 1, 15, 30, 0, 0, time.Local)
+		m.portfolioRepo.On("UpsertPriceQuotes", mock.MatchedBy(func(quotes []entities.PriceQuote) bool {
+			return len(quotes) == 2 && quotes[0].Symbol == "K-SET50" && quotes[0].Price == 12.5 && quotes[0].ID != "" &&
+				quotes[0].PricedAt.Equal(time.Date(2024, time.May, 1, 0, 0, 0, 0, time.Local)) &&
+				quotes[1].Symbol == "PTT" && !quotes[1].PricedAt.IsZero()
+		})).Return(nil).Once()
+		m.portfolioRepo.On("ApplyPriceQuote", mock.Anything).Return(int64(2), nil).Twice()
+
+		result, err := useCase.ImportPrices([]entities.PriceQuote{
+			{Symbol: "k-set50", Price: 12, PricedAt: date},
+			{Symbol: "PTT", Price: 34.25},
+			{Symbol: "K-SET50 ", Price: 12.5, PricedAt: date},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Imported)
+		assert.Equal(t, int64(4), result.UpdatedHoldings)
+		m.portfolioRepo.AssertExpectations(t)
+	})
+
+	t.Run("Future Date", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+
+		result, err := useCase.ImportPrices([]entities.PriceQuote{{Symbol: "PTT", Price: 34, PricedAt: time.Now().AddDate(0, 0, 2)}})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "price date must not be in the future")
+		m.portfolioRepo.AssertNotCalled(t, "UpsertPriceQuotes", mock.Anything)
+	})
+
+	t.Run("Invalid Price", func(t *testing.T) {
+		useCase, _ := setupPortfolioUseCase()
+
+		result, err := useCase.ImportPrices([]entities.PriceQuote{{Symbol: "PTT"}})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "price must be greater than zero")
+	})
+
+	t.Run("No Prices", func(t *testing.T) {
+		useCase, _ := setupPortfolioUseCase()
+
+		result, err := useCase.ImportPrices(nil)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "no prices to import")
+	})
+}
+
+func TestSnapshotPortfolios(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.portfolioRepo.On("GetUserIDsWithHoldings").Return([]string{"user-123"}, nil).Once()
+		m.portfolioRepo.On("GetHoldingsByUserID", "user-123").Return(balancedHoldings(), nil).Once()
+		m.portfolioRepo.On("UpsertSnapshot", mock.MatchedBy(func(snapshot *entities.PortfolioSnapshot) bool {
+			return snapshot.UserID == "user-123" && snapshot.MarketValue == entities.Baht(100000) &&
+				snapshot.CostBasis == entities.Baht(100000) && snapshot.Date.Hour() == 0
+		})).Return(nil).Once()
+		m.retirementRepo.On("GetRetirementByUserIDForUpdate", "user-123").Return(&entities.RetirementPlan{
+			ID: "plan-1", CurrentSavings: entities.Baht(900000), CurrentTotalInvestment: entities.Baht(50000), LastRequiredFunds: entities.Baht(1000000), LastMonthlyExpenses: entities.Baht(5000), Status: "In_Progress",
+		}, nil).Once()
+		m.retirementRepo.On("UpdateRetirementPlan", mock.MatchedBy(func(plan *entities.RetirementPlan) bool {
+			return plan.CurrentTotalInvestment == entities.Baht(100000) && plan.Status == "Completed" && plan.LastMonthlyExpenses == 0
+		})).Return(&entities.RetirementPlan{}, nil).Once()
+		m.ledgerRepo.On("GetAccount", "user-123", mock.Anything, "user-123").Return(&entities.LedgerAccount{ID: "account"}, nil)
+		m.ledgerRepo.On("CreateEntry", mock.Anything).Return(&entities.LedgerEntry{}, nil).Once()
+		m.dispatcher.On("Dispatch", mock.MatchedBy(func(notification *entities.Notification) bool {
+			return notification.Type == "retirementplan"
+		})).Return(nil).Once()
+
+		err := useCase.SnapshotPortfolios()
+
+		assert.NoError(t, err)
+		m.portfolioRepo.AssertExpectations(t)
+		m.retirementRepo.AssertExpectations(t)
+		m.dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("Unchanged Investment", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.portfolioRepo.On("GetUserIDsWithHoldings").Return([]string{"user-123"}, nil).Once()
+		m.portfolioRepo.On("GetHoldingsByUserID", "user-123").Return(balancedHoldings(), nil).Once()
+		m.portfolioRepo.On("UpsertSnapshot", mock.Anything).Return(nil).Once()
+		m.retirementRepo.On("GetRetirementByUserIDForUpdate", "user-123").Return(&entities.RetirementPlan{ID: "plan-1", CurrentTotalInvestment: entities.Baht(100000)}, nil).Once()
+
+		err := useCase.SnapshotPortfolios()
+
+		assert.NoError(t, err)
+		m.retirementRepo.AssertNotCalled(t, "UpdateRetirementPlan", mock.Anything)
+		m.ledgerRepo.AssertNotCalled(t, "CreateEntry", mock.Anything)
+	})
+
+	t.Run("Continues After User Error", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.portfolioRepo.On("GetUserIDsWithHoldings").Return([]string{"user-1", "user-123"}, nil).Once()
+		m.portfolioRepo.On("GetHoldingsByUserID", "user-1").Return(nil, errors.New("database error")).Once()
+		m.portfolioRepo.On("GetHoldingsByUserID", "user-123").Return(balancedHoldings(), nil).Once()
+		m.portfolioRepo.On("UpsertSnapshot", mock.MatchedBy(func(snapshot *entities.PortfolioSnapshot) bool {
+			return snapshot.UserID == "user-123"
+		})).Return(nil).Once()
+		m.retirementRepo.On("GetRetirementByUserIDForUpdate", "user-123").Return(&entities.RetirementPlan{ID: "plan-1", CurrentTotalInvestment: entities.Baht(100000)}, nil).Once()
+
+		err := useCase.SnapshotPortfolios()
+
+		assert.NoError(t, err)
+		m.portfolioRepo.AssertExpectations(t)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.portfolioRepo.On("GetUserIDsWithHoldings").Return(nil, errors.New("database error")).Once()
+
+		err := useCase.SnapshotPortfolios()
+
+		assert.EqualError(t, err, "database error")
+	})
+}
+
+func TestGetValuationHistory(t *testing.T) {
+	t.Run("Default Range", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+		m.portfolioRepo.On("GetSnapshotsByUserID", "user-123", mock.MatchedBy(func(from time.Time) bool {
+			return from.Before(time.Now().AddDate(0, -11, 0)) && from.Hour() == 0
+		}), mock.AnythingOfType("time.Time")).Return([]entities.PortfolioSnapshot{{ID: "s1"}}, nil).Once()
+
+		result, err := useCase.GetValuationHistory("user-123", time.Time{}, time.Time{})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("Invalid Range", func(t *testing.T) {
+		useCase, m := setupPortfolioUseCase()
+
+		result, err := useCase.GetValuationHistory("user-123", time.Now(), time.Now().AddDate(0, -1, 0))
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "invalid date range")
+		m.portfolioRepo.AssertNotCalled(t, "GetSnapshotsByUserID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -278,3 +278,314 @@ func TestRetirementHandlers(t *testing.T) {
 		mockUseCase.AssertExpectations(t)
 	})
 }
+
+func TestGetProjectionHandler(t *testing.T) {
+	mockUseCase := new(mocks.MockRetirementUseCase)
+	controller := controllers.NewRetirementController(mockUseCase)
+	app := fiber.New()
+	app.Get("/retirement/projection", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user123")
+		return controller.GetProjectionHandler(c)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		projection := &entities.RetirementProjection{
+			BalanceAtRetirement:   entities.Baht(1500000),
+			ShortfallAtRetirement: entities.MoneyFromFloat(2500.5),
+			Points: []entities.ProjectionPoint{
+				{Phase: entities.ProjectionPhaseAccumulation, Balance: entities.Baht(1000)},
+			},
+		}
+		mockUseCase.On("GetProjection", "user123").Return(projection, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/projection", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&response)
+		assert.Equal(t, "Retirement projection retrieved successfully", response["message"])
+		result := response["result"].(map[string]interface{})
+		assert.Equal(t, float64(1500000), result["balance_at_retirement"])
+		assert.Equal(t, 2500.5, result["shortfall_at_retirement"])
+		assert.Len(t, result["points"], 1)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Plan Not Found", func(t *testing.T) {
+		mockUseCase.On("GetProjection", "user123").Return(nil, errors.New("record not found")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/projection", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("No User ID", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/retirement/projection", controller.GetProjectionHandler)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/projection", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestSimulateRetirementHandler(t *testing.T) {
+	mockUseCase := new(mocks.MockRetirementUseCase)
+	controller := controllers.NewRetirementController(mockUseCase)
+	app := fiber.New()
+	app.Get("/retirement/simulation", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user123")
+		return controller.SimulateRetirementHandler(c)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		saving := entities.Baht(12000)
+		result := &entities.MonteCarloResult{
+			Paths:                 500,
+			Seed:                  42,
+			SuccessProbability:    0.72,
+			RequiredMonthlySaving: &saving,
+			Bands:                 []entities.BalanceBand{{P50: entities.Baht(1000)}},
+		}
+		mockUseCase.On("SimulateRetirement", "user123", mock.MatchedBy(func(options entities.MonteCarloOptions) bool {
+			return options.Paths == 500 && options.TargetConfidence == 0.95 && options.Seed != nil && *options.Seed == 42
+		})).Return(result, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/simulation?paths=500&confidence=0.95&seed=42", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&response)
+		assert.Equal(t, "Retirement simulation completed successfully", response["message"])
+		body := response["result"].(map[string]interface{})
+		assert.Equal(t, "42", body["seed"])
+		assert.Equal(t, 0.72, body["success_probability"])
+		assert.Equal(t, float64(12000), body["required_monthly_saving"])
+		assert.Len(t, body["bands"], 1)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Defaults Without Query", func(t *testing.T) {
+		mockUseCase.On("SimulateRetirement", "user123", entities.MonteCarloOptions{}).Return(&entities.MonteCarloResult{}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/simulation", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Seed", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/simulation?seed=abc", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Invalid Options", func(t *testing.T) {
+		mockUseCase.On("SimulateRetirement", "user123", mock.Anything).Return(nil, errors.New("paths must be between 1 and 10000")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/simulation?paths=20000", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+		var response map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&response)
+		assert.Equal(t, "paths must be between 1 and 10000", response["message"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("No User ID", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/retirement/simulation", controller.SimulateRetirementHandler)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/simulation", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestRetirementPlanHandlers(t *testing.T) {
+	mockUseCase := new(mocks.MockRetirementUseCase)
+	controller := controllers.NewRetirementController(mockUseCase)
+	app := fiber.New()
+	withUser := func(handler fiber.Handler) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user123")
+			return handler(c)
+		}
+	}
+
+	app.Get("/retirement/plans", withUser(controller.GetRetirementPlansHandler))
+	app.Get("/retirement/plans/:id", withUser(controller.GetRetirementPlanByIDHandler))
+	app.Put("/retirement/plans/:id", withUser(controller.UpdateRetirementPlanHandler))
+	app.Post("/retirement/plans/:id/activate", withUser(controller.ActivateRetirementPlanHandler))
+	app.Get("/retirement/plans/:id/revisions", withUser(controller.GetRetirementPlanRevisionsHandler))
+
+	t.Run("List Plans", func(t *testing.T) {
+		mockUseCase.On("GetRetirementsByUserID", "user123").Return([]entities.RetirementPlan{
+			{ID: "plan-1", PlanName: "Retire at 60", IsActive: true},
+			{ID: "plan-2", PlanName: "Retire at 55"},
+		}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/plans", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&response)
+		result := response["result"].([]interface{})
+		assert.Len(t, result, 2)
+		assert.Equal(t, true, result[0].(map[string]interface{})["is_active"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Get Plan Not Found", func(t *testing.T) {
+		mockUseCase.On("GetPlanByID", "user123", "plan-9").Return(nil, errors.New("retirement plan not found")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/plans/plan-9", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Update Plan", func(t *testing.T) {
+		mockUseCase.On("UpdatePlanByID", "user123", "plan-2", mock.MatchedBy(func(r entities.RetirementPlan) bool {
+			return r.PlanName == "Retire at 55" && r.RetirementAge == 55
+		})).Return(&entities.RetirementPlan{ID: "plan-2", PlanName: "Retire at 55", RetirementAge: 55}, nil).Once()
+
+		req := httptest.NewRequest("PUT", "/retirement/plans/plan-2", bytes.NewBufferString(`{"planName":"Retire at 55","retirement_age":55}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Update Plan Missing Name", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/retirement/plans/plan-2", bytes.NewBufferString(`{"retirementAge":55}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Activate Plan", func(t *testing.T) {
+		mockUseCase.On("ActivatePlan", "user123", "plan-2").Return(&entities.RetirementPlan{ID: "plan-2", IsActive: true}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/retirement/plans/plan-2/activate", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&response)
+		assert.Equal(t, "Retirement plan activated successfully", response["message"])
+		assert.Equal(t, true, response["result"].(map[string]interface{})["is_active"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Activate Plan Failure", func(t *testing.T) {
+		mockUseCase.On("ActivatePlan", "user123", "plan-9").Return(nil, errors.New("retirement plan not found")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/retirement/plans/plan-9/activate", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Plan Revisions", func(t *testing.T) {
+		mockUseCase.On("GetPlanRevisions", "user123", "plan-1").Return([]entities.RetirementPlanRevision{
+			{PlanID: "plan-1", Revision: 1, RetirementAge: 60},
+			{PlanID: "plan-1", Revision: 2, RetirementAge: 58},
+		}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/plans/plan-1/revisions", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&response)
+		assert.Len(t, response["result"], 2)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("No User ID", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/retirement/plans", controller.GetRetirementPlansHandler)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/plans", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestPlanDecumulationHandler(t *testing.T) {
+	mockUseCase := new(mocks.MockRetirementUseCase)
+	controller := controllers.NewRetirementController(mockUseCase)
+	app := fiber.New()
+	app.Get("/retirement/decumulation", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user123")
+		return controller.PlanDecumulationHandler(c)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		depletionYear := 2061
+		result := &entities.DecumulationPlan{
+			Months:          240,
+			StartingBalance: entities.Baht(3000000),
+			Strategies: []entities.DecumulationStrategy{
+				{Strategy: entities.WithdrawalStrategyFixedAmount, SustainableMonthlyWithdrawal: entities.MoneyFromFloat(15250.5), DepletionYear: &depletionYear},
+			},
+		}
+		mockUseCase.On("PlanDecumulation", "user123", mock.MatchedBy(func(options entities.DecumulationOptions) bool {
+			return options.WithdrawalRate == 3.5 && options.AnnualReturn != nil && *options.AnnualReturn == 4
+		})).Return(result, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/decumulation?withdrawal_rate=3.5&annual_return=4", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var response map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&response)
+		assert.Equal(t, "Retirement decumulation plan retrieved successfully", response["message"])
+		strategy := response["result"].(map[string]interface{})["strategies"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "fixed_amount", strategy["strategy"])
+		assert.Equal(t, 15250.5, strategy["sustainable_monthly_withdrawal"])
+		assert.Equal(t, float64(2061), strategy["depletion_year"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Defaults Without Query", func(t *testing.T) {
+		mockUseCase.On("PlanDecumulation", "user123", entities.DecumulationOptions{}).Return(&entities.DecumulationPlan{}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/decumulation", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Annual Return", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/decumulation?annual_return=abc", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Invalid Options", func(t *testing.T) {
+		mockUseCase.On("PlanDecumulation", "user123", mock.Anything).Return(nil, errors.New("withdrawal rate must be between 0 and 100")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/decumulation?withdrawal_rate=150", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("No User ID", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/retirement/decumulation", controller.PlanDecumulationHandler)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/retirement/decumulation", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+}
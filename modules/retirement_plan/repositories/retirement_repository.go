@@ -3,7 +3,10 @@ package repositories
 import (
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 
+	"github.com/google/uuid"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GormRetirementRepository struct {
@@ -17,8 +20,50 @@ func NewGormRetirementRepository(db *gorm.DB) *GormRetirementRepository {
 type RetirementRepository interface {
 	CreateRetirement(retirement *entities.RetirementPlan) (*entities.RetirementPlan, error)
 	GetRetirementByID(id string) (*entities.RetirementPlan, error)
+	GetRetirementByIDForUpdate(id string) (*entities.RetirementPlan, error)
 	GetRetirementByUserID(userID string) (*entities.RetirementPlan, error)
+	GetRetirementByUserIDForUpdate(userID string) (*entities.RetirementPlan, error)
+	GetRetirementsByUserID(userID string) ([]entities.RetirementPlan, error)
 	UpdateRetirementPlan(retirement *entities.RetirementPlan) (*entities.RetirementPlan, error)
+	AppendRevision(plan *entities.RetirementPlan) error
+	GetRevisionsByPlanID(planID string) ([]entities.RetirementPlanRevision, error)
+}
+
+func newRevision(plan *entities.RetirementPlan, revision int) *entities.RetirementPlanRevision {
+	return &entities.RetirementPlanRevision{
+		ID:                      uuid.New().String(),
+		PlanID:                  plan.ID,
+		Revision:                revision,
+		UserID:                  plan.UserID,
+		PlanName:                plan.PlanName,
+		BirthDate:               plan.BirthDate,
+		RetirementAge:           plan.RetirementAge,
+		ExpectLifespan:          plan.ExpectLifespan,
+		CurrentSavings:          plan.CurrentSavings,
+		CurrentSavingsReturns:   plan.CurrentSavingsReturns,
+		MonthlyIncome:           plan.MonthlyIncome,
+		MonthlyExpenses:         plan.MonthlyExpenses,
+		CurrentTotalInvestment:  plan.CurrentTotalInvestment,
+		InvestmentReturn:        plan.InvestmentReturn,
+		ExpectedMonthlyExpenses: plan.ExpectedMonthlyExpenses,
+		ExpectedInflation:       plan.ExpectedInflation,
+		AnnualExpenseIncrease:   plan.AnnualExpenseIncrease,
+		AnnualSavingsReturn:     plan.AnnualSavingsReturn,
+		AnnualInvestmentReturn:  plan.AnnualInvestmentReturn,
+		LastRequiredFunds:       plan.LastRequiredFunds,
+		LastMonthlyExpenses:     plan.LastMonthlyExpenses,
+		Status:                  plan.Status,
+		IsActive:                plan.IsActive,
+	}
+}
+
+func (r *GormRetirementRepository) AppendRevision(plan *entities.RetirementPlan) error {
+	var latest int
+	if err := r.db.Model(&entities.RetirementPlanRevision{}).Select("COALESCE(MAX(revision), 0)").Where("plan_id = ?", plan.ID).Scan(&latest).Error; err != nil {
+		return err
+	}
+
+	return r.db.Create(newRevision(plan, latest+1)).Error
 }
 
 func (r *GormRetirementRepository) CreateRetirement(retirement *entities.RetirementPlan) (*entities.RetirementPlan, error) {
@@ -38,15 +83,42 @@ func (r *GormRetirementRepository) GetRetirementByID(id string) (*entities.Retir
 	return &retirement, nil
 }
 
+func (r *GormRetirementRepository) GetRetirementByIDForUpdate(id string) (*entities.RetirementPlan, error) {
+	var retirement entities.RetirementPlan
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&retirement, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return &retirement, nil
+}
+
 func (r *GormRetirementRepository) GetRetirementByUserID(userID string) (*entities.RetirementPlan, error) {
 	var retirement entities.RetirementPlan
-	if err := r.db.Where("user_id = ?", userID).Find(&retirement).Error; err != nil {
+	if err := r.db.Where("user_id = ? AND is_active = ?", userID, true).Find(&retirement).Error; err != nil {
+		return nil, err
+	}
+
+	return &retirement, nil
+}
+
+func (r *GormRetirementRepository) GetRetirementByUserIDForUpdate(userID string) (*entities.RetirementPlan, error) {
+	var retirement entities.RetirementPlan
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ? AND is_active = ?", userID, true).Find(&retirement).Error; err != nil {
 		return nil, err
 	}
 
 	return &retirement, nil
 }
 
+func (r *GormRetirementRepository) GetRetirementsByUserID(userID string) ([]entities.RetirementPlan, error) {
+	var retirements []entities.RetirementPlan
+	if err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&retirements).Error; err != nil {
+		return nil, err
+	}
+
+	return retirements, nil
+}
+
 func (r *GormRetirementRepository) UpdateRetirementPlan(retirement *entities.RetirementPlan) (*entities.RetirementPlan, error) {
 	if err := r.db.Save(&retirement).Error; err != nil {
 		return nil, err
@@ -54,3 +126,12 @@ func (r *GormRetirementRepository) UpdateRetirementPlan(retirement *entities.Ret
 
 	return r.GetRetirementByID(retirement.ID)
 }
+
+func (r *GormRetirementRepository) GetRevisionsByPlanID(planID string) ([]entities.RetirementPlanRevision, error) {
+	var revisions []entities.RetirementPlanRevision
+	if err := r.db.Where("plan_id = ?", planID).Order("revision ASC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
@@ -5,9 +5,14 @@ import (
 	"time"
 
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	ledger "github.com/XzerozZ/Kasian_Phrom_BE/modules/ledger/usecases"
+	quizRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/quiz/repositories"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/retirement_plan/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/unitofwork"
+	userRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/repositories"
 	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type RetirementUseCase interface {
@@ -15,14 +20,42 @@ type RetirementUseCase interface {
 	GetRetirementByID(id string) (*entities.RetirementPlan, error)
 	GetRetirementByUserID(userID string) (*entities.RetirementPlan, error)
 	UpdateRetirementByID(userID string, retirement entities.RetirementPlan) (*entities.RetirementPlan, error)
+	GetRetirementsByUserID(userID string) ([]entities.RetirementPlan, error)
+	GetPlanByID(userID, planID string) (*entities.RetirementPlan, error)
+	UpdatePlanByID(userID, planID string, retirement entities.RetirementPlan) (*entities.RetirementPlan, error)
+	ActivatePlan(userID, planID string) (*entities.RetirementPlan, error)
+	GetPlanRevisions(userID, planID string) ([]entities.RetirementPlanRevision, error)
+	GetProjection(userID string) (*entities.RetirementProjection, error)
+	SimulateRetirement(userID string, options entities.MonteCarloOptions) (*entities.MonteCarloResult, error)
+	PlanDecumulation(userID string, options entities.DecumulationOptions) (*entities.DecumulationPlan, error)
 }
 
 type RetirementUseCaseImpl struct {
 	retirerepo repositories.RetirementRepository
+	userrepo   userRepositories.UserRepository
+	quizrepo   quizRepositories.QuizRepository
+	uow        unitofwork.UnitOfWork
 }
 
-func NewRetirementUseCase(retirerepo repositories.RetirementRepository) *RetirementUseCaseImpl {
-	return &RetirementUseCaseImpl{retirerepo: retirerepo}
+func NewRetirementUseCase(retirerepo repositories.RetirementRepository, userrepo userRepositories.UserRepository, quizrepo quizRepositories.QuizRepository, uow unitofwork.UnitOfWork) *RetirementUseCaseImpl {
+	return &RetirementUseCaseImpl{
+		retirerepo: retirerepo,
+		userrepo:   userrepo,
+		quizrepo:   quizrepo,
+		uow:        uow,
+	}
+}
+
+func postBalances(repos unitofwork.Repositories, plan *entities.RetirementPlan, savings, investment entities.Money, description string) error {
+	if !plan.IsActive {
+		return nil
+	}
+
+	_, err := ledger.PostEntry(repos.Ledger, plan.UserID, description+" "+plan.PlanName, time.Now(),
+		ledger.Leg{Kind: entities.LedgerAccountRetirementSavings, RefID: plan.UserID, Name: plan.PlanName, Amount: plan.CurrentSavings - savings, Balance: plan.CurrentSavings},
+		ledger.Leg{Kind: entities.LedgerAccountRetirementInvestment, RefID: plan.UserID, Name: plan.PlanName, Amount: plan.CurrentTotalInvestment - investment, Balance: plan.CurrentTotalInvestment},
+	)
+	return err
 }
 
 func (u *RetirementUseCaseImpl) CreateRetirement(retirement entities.RetirementPlan) (*entities.RetirementPlan, int, error) {
@@ -89,6 +122,17 @@ func (u *RetirementUseCaseImpl) CreateRetirement(retirement entities.RetirementP
 		return nil, 0, errors.New("retirementAge must be less than ExpectLifespan")
 	}
 
+	active, err := u.retirerepo.GetRetirementByUserID(retirement.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	retirement.IsActive = active.ID == ""
+	if !retirement.IsActive {
+		retirement.CurrentSavings = active.CurrentSavings
+		retirement.CurrentTotalInvestment = active.CurrentTotalInvestment
+	}
+
 	requiredFunds, err := utils.CalculateRetirementFunds(&retirement, planAge)
 	if err != nil {
 		return nil, 0, err
@@ -104,7 +148,19 @@ func (u *RetirementUseCaseImpl) CreateRetirement(retirement entities.RetirementP
 	retirement.LastCalculatedMonth = currentMonth
 	retirement.LastRequiredFunds = requiredFunds
 	retirement.LastMonthlyExpenses = monthlySavings
-	createdRetire, err := u.retirerepo.CreateRetirement(&retirement)
+	var createdRetire *entities.RetirementPlan
+	err = u.uow.Do(func(repos unitofwork.Repositories) error {
+		var err error
+		if createdRetire, err = repos.Retirements.CreateRetirement(&retirement); err != nil {
+			return err
+		}
+
+		if err := postBalances(repos, createdRetire, 0, 0, "Create retirement plan"); err != nil {
+			return err
+		}
+
+		return repos.Retirements.AppendRevision(createdRetire)
+	})
 	if err != nil {
 		return nil, 0, err
 	}
@@ -126,6 +182,28 @@ func (u *RetirementUseCaseImpl) UpdateRetirementByID(userID string, retirement e
 		return nil, err
 	}
 
+	tracked, err := u.tracksInvestment(userID, existingRetirement)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.updatePlan(existingRetirement.ID, retirement, tracked)
+}
+
+func (u *RetirementUseCaseImpl) tracksInvestment(userID string, existingRetirement *entities.RetirementPlan) (bool, error) {
+	if !existingRetirement.IsActive {
+		return false, nil
+	}
+
+	user, err := u.userrepo.GetUserByID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return len(user.Holdings) > 0, nil
+}
+
+func (u *RetirementUseCaseImpl) updatePlan(planID string, retirement entities.RetirementPlan, tracked bool) (*entities.RetirementPlan, error) {
 	age, err := utils.CalculateRetirementPlanAge(retirement.BirthDate, time.Now())
 	if err != nil {
 		return nil, err
@@ -175,56 +253,238 @@ func (u *RetirementUseCaseImpl) UpdateRetirementByID(userID string, retirement e
 		return nil, errors.New("retirementAge must be less than ExpectLifespan")
 	}
 
-	currentYear, currentMonth := time.Now().Year(), int(time.Now().Month())
-	needsRecalculation := false
-	recalculateFunds := false
-	if existingRetirement.ExpectLifespan != retirement.ExpectLifespan || existingRetirement.RetirementAge != retirement.RetirementAge || existingRetirement.ExpectedMonthlyExpenses != retirement.ExpectedMonthlyExpenses || existingRetirement.ExpectedInflation != retirement.ExpectedInflation {
-		recalculateFunds = true
-		needsRecalculation = true
-	}
-
-	if currentMonth != existingRetirement.LastCalculatedMonth {
-		needsRecalculation = true
-	}
-
-	existingRetirement.BirthDate = retirement.BirthDate
-	existingRetirement.ExpectLifespan = retirement.ExpectLifespan
-	existingRetirement.RetirementAge = retirement.RetirementAge
-	existingRetirement.PlanName = retirement.PlanName
-	existingRetirement.MonthlyIncome = retirement.MonthlyIncome
-	existingRetirement.ExpectedMonthlyExpenses = retirement.ExpectedMonthlyExpenses
-	existingRetirement.MonthlyExpenses = retirement.MonthlyExpenses
-	existingRetirement.CurrentSavingsReturns = retirement.CurrentSavingsReturns
-	existingRetirement.InvestmentReturn = retirement.InvestmentReturn
-	existingRetirement.ExpectedInflation = retirement.ExpectedInflation
-	existingRetirement.AnnualExpenseIncrease = retirement.AnnualExpenseIncrease
-	existingRetirement.AnnualSavingsReturn = retirement.AnnualSavingsReturn
-	existingRetirement.AnnualInvestmentReturn = retirement.AnnualInvestmentReturn
-	existingRetirement.CurrentTotalInvestment = retirement.CurrentTotalInvestment
-	if needsRecalculation {
-		if recalculateFunds {
-			requiredFunds, err := utils.CalculateRetirementFunds(existingRetirement, age)
+	var updated *entities.RetirementPlan
+	err = u.uow.Do(func(repos unitofwork.Repositories) error {
+		existingRetirement, err := repos.Retirements.GetRetirementByIDForUpdate(planID)
+		if err != nil {
+			return err
+		}
+
+		if tracked {
+			retirement.CurrentTotalInvestment = existingRetirement.CurrentTotalInvestment
+		}
+
+		savings, investment := existingRetirement.CurrentSavings, existingRetirement.CurrentTotalInvestment
+
+		currentYear, currentMonth := time.Now().Year(), int(time.Now().Month())
+		needsRecalculation := false
+		recalculateFunds := false
+		if existingRetirement.ExpectLifespan != retirement.ExpectLifespan || existingRetirement.RetirementAge != retirement.RetirementAge || existingRetirement.ExpectedMonthlyExpenses != retirement.ExpectedMonthlyExpenses || existingRetirement.ExpectedInflation != retirement.ExpectedInflation {
+			recalculateFunds = true
+			needsRecalculation = true
+		}
+
+		if currentMonth != existingRetirement.LastCalculatedMonth {
+			needsRecalculation = true
+		}
+
+		existingRetirement.BirthDate = retirement.BirthDate
+		existingRetirement.ExpectLifespan = retirement.ExpectLifespan
+		existingRetirement.RetirementAge = retirement.RetirementAge
+		existingRetirement.PlanName = retirement.PlanName
+		existingRetirement.MonthlyIncome = retirement.MonthlyIncome
+		existingRetirement.ExpectedMonthlyExpenses = retirement.ExpectedMonthlyExpenses
+		existingRetirement.MonthlyExpenses = retirement.MonthlyExpenses
+		existingRetirement.CurrentSavingsReturns = retirement.CurrentSavingsReturns
+		existingRetirement.InvestmentReturn = retirement.InvestmentReturn
+		existingRetirement.ExpectedInflation = retirement.ExpectedInflation
+		existingRetirement.AnnualExpenseIncrease = retirement.AnnualExpenseIncrease
+		existingRetirement.AnnualSavingsReturn = retirement.AnnualSavingsReturn
+		existingRetirement.AnnualInvestmentReturn = retirement.AnnualInvestmentReturn
+		existingRetirement.CurrentTotalInvestment = retirement.CurrentTotalInvestment
+		if needsRecalculation {
+			if recalculateFunds {
+				requiredFunds, err := utils.CalculateRetirementFunds(existingRetirement, age)
+				if err != nil {
+					return err
+				}
+				existingRetirement.LastRequiredFunds = requiredFunds
+			}
+
+			monthlySavings, err := utils.CalculateMonthlySavings(existingRetirement, age, currentYear, currentMonth)
 			if err != nil {
-				return nil, err
+				return err
+			}
+
+			existingRetirement.LastCalculatedMonth = currentMonth
+			existingRetirement.LastMonthlyExpenses = monthlySavings
+			currentTotalMoney := existingRetirement.CurrentSavings + existingRetirement.CurrentTotalInvestment
+			if currentTotalMoney >= existingRetirement.LastRequiredFunds {
+				existingRetirement.Status = "Completed"
+				existingRetirement.LastMonthlyExpenses = 0
+			} else {
+				existingRetirement.Status = "In_Progress"
+			}
+		}
+
+		if updated, err = repos.Retirements.UpdateRetirementPlan(existingRetirement); err != nil {
+			return err
+		}
+
+		if err := postBalances(repos, existingRetirement, savings, investment, "Edit retirement plan"); err != nil {
+			return err
+		}
+
+		return repos.Retirements.AppendRevision(updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (u *RetirementUseCaseImpl) GetRetirementsByUserID(userID string) ([]entities.RetirementPlan, error) {
+	return u.retirerepo.GetRetirementsByUserID(userID)
+}
+
+func (u *RetirementUseCaseImpl) GetPlanByID(userID, planID string) (*entities.RetirementPlan, error) {
+	retirement, err := u.retirerepo.GetRetirementByID(planID)
+	if err != nil || retirement.UserID != userID {
+		return nil, errors.New("retirement plan not found")
+	}
+
+	return retirement, nil
+}
+
+func (u *RetirementUseCaseImpl) UpdatePlanByID(userID, planID string, retirement entities.RetirementPlan) (*entities.RetirementPlan, error) {
+	existingRetirement, err := u.GetPlanByID(userID, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked, err := u.tracksInvestment(userID, existingRetirement)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.updatePlan(existingRetirement.ID, retirement, tracked)
+}
+
+func (u *RetirementUseCaseImpl) ActivatePlan(userID, planID string) (*entities.RetirementPlan, error) {
+	var activated *entities.RetirementPlan
+	err := u.uow.Do(func(repos unitofwork.Repositories) error {
+		active, err := repos.Retirements.GetRetirementByUserIDForUpdate(userID)
+		if err != nil {
+			return err
+		}
+
+		retirement, err := repos.Retirements.GetRetirementByIDForUpdate(planID)
+		if err != nil || retirement.UserID != userID {
+			return errors.New("retirement plan not found")
+		}
+
+		if retirement.ID == active.ID {
+			activated = retirement
+			return nil
+		}
+
+		if active.ID != "" {
+			retirement.CurrentSavings = active.CurrentSavings
+			retirement.CurrentTotalInvestment = active.CurrentTotalInvestment
+			active.IsActive = false
+			if _, err := repos.Retirements.UpdateRetirementPlan(active); err != nil {
+				return err
 			}
-			existingRetirement.LastRequiredFunds = requiredFunds
+
+			if err := repos.Retirements.AppendRevision(active); err != nil {
+				return err
+			}
+		}
+
+		retirement.IsActive = true
+		if err := utils.RecalculateRetirementPlan(retirement, time.Now()); err != nil {
+			return err
+		}
+
+		if activated, err = repos.Retirements.UpdateRetirementPlan(retirement); err != nil {
+			return err
+		}
+
+		if err := repos.Retirements.AppendRevision(activated); err != nil {
+			return err
 		}
 
-		monthlySavings, err := utils.CalculateMonthlySavings(existingRetirement, age, currentYear, currentMonth)
+		house, err := repos.Users.GetSelectedHouseForUpdate(userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		house.LastCalculatedMonth = 0
+		_, err = repos.Users.UpdateSelectedHouse(house)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return activated, nil
+}
+
+func (u *RetirementUseCaseImpl) GetPlanRevisions(userID, planID string) ([]entities.RetirementPlanRevision, error) {
+	if _, err := u.GetPlanByID(userID, planID); err != nil {
+		return nil, err
+	}
+
+	return u.retirerepo.GetRevisionsByPlanID(planID)
+}
+
+func (u *RetirementUseCaseImpl) GetProjection(userID string) (*entities.RetirementProjection, error) {
+	user, err := u.userrepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.RetirementPlan.ID == "" {
+		return nil, errors.New("retirement plan not found")
+	}
+
+	now := time.Now()
+	var vehicles []entities.VehicleProjection
+	if len(user.Vehicles) > 0 {
+		contributions, err := u.userrepo.GetUserDepositsInRange(userID, time.Time{}, now)
 		if err != nil {
 			return nil, err
 		}
 
-		existingRetirement.LastCalculatedMonth = currentMonth
-		existingRetirement.LastMonthlyExpenses = monthlySavings
-		currentTotalMoney := existingRetirement.CurrentSavings + existingRetirement.CurrentTotalInvestment
-		if currentTotalMoney >= existingRetirement.LastRequiredFunds {
-			existingRetirement.Status = "Completed"
-			existingRetirement.LastMonthlyExpenses = 0
-		} else {
-			existingRetirement.Status = "In_Progress"
+		vehicles, err = utils.ProjectVehicles(&user.RetirementPlan, user.Vehicles, contributions, now)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	return u.retirerepo.UpdateRetirementPlan(existingRetirement)
+	return utils.ProjectRetirement(&user.RetirementPlan, vehicles, now)
+}
+
+func (u *RetirementUseCaseImpl) SimulateRetirement(userID string, options entities.MonteCarloOptions) (*entities.MonteCarloResult, error) {
+	retirement, err := u.retirerepo.GetRetirementByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	riskLevel := utils.DefaultRiskLevel
+	quiz, err := u.quizrepo.GetQuizByUserID(userID)
+	if err == nil {
+		riskLevel = quiz.RiskID
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	return utils.SimulateRetirement(retirement, riskLevel, options, time.Now())
+}
+
+func (u *RetirementUseCaseImpl) PlanDecumulation(userID string, options entities.DecumulationOptions) (*entities.DecumulationPlan, error) {
+	user, err := u.userrepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.RetirementPlan.ID == "" {
+		return nil, errors.New("retirement plan not found")
+	}
+
+	return utils.PlanDecumulation(&user.RetirementPlan, &user.House, options, time.Now())
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/XzerozZ/Kasian_Phrom_BE/testing/repositories/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func createValidRetirementPlan() entities.RetirementPlan {
@@ -20,23 +21,27 @@ func createValidRetirementPlan() entities.RetirementPlan {
 		ExpectLifespan:          80,
 		RetirementAge:           60,
 		PlanName:                "Test Plan",
-		MonthlyIncome:           50000,
-		MonthlyExpenses:         30000,
-		CurrentSavings:          500000,
+		MonthlyIncome:           entities.Baht(50000),
+		MonthlyExpenses:         entities.Baht(30000),
+		CurrentSavings:          entities.Baht(500000),
 		CurrentSavingsReturns:   3.0,
-		CurrentTotalInvestment:  1000000,
+		CurrentTotalInvestment:  entities.Baht(1000000),
 		InvestmentReturn:        7.0,
 		ExpectedInflation:       2.5,
-		ExpectedMonthlyExpenses: 40000,
+		ExpectedMonthlyExpenses: entities.Baht(40000),
 		AnnualExpenseIncrease:   3.0,
 		AnnualSavingsReturn:     3.0,
 		AnnualInvestmentReturn:  7.0,
 	}
 }
 
+func retirementUnitOfWork(retirementRepo *mocks.MockRetirementRepository, userRepo *mocks.MockUserRepository) *mocks.MockUnitOfWork {
+	return mocks.NewMockUnitOfWork(userRepo, new(mocks.MockAssetRepository), retirementRepo, new(mocks.MockLedgerRepository))
+}
+
 func TestCreateRetirement_Success(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	retirementPlan := createValidRetirementPlan()
 
@@ -44,7 +49,11 @@ func TestCreateRetirement_Success(t *testing.T) {
 	expectedReturnPlan.ID = "generated-uuid"
 	expectedReturnPlan.Status = "In_Progress"
 
-	mockRepo.On("CreateRetirement", mock.AnythingOfType("*entities.RetirementPlan")).Return(&expectedReturnPlan, nil)
+	mockRepo.On("GetRetirementByUserID", "test-user-id").Return(&entities.RetirementPlan{}, nil).Once()
+	mockRepo.On("CreateRetirement", mock.MatchedBy(func(plan *entities.RetirementPlan) bool {
+		return plan.IsActive
+	})).Return(&expectedReturnPlan, nil)
+	mockRepo.On("AppendRevision", &expectedReturnPlan).Return(nil).Once()
 
 	result, age, err := useCase.CreateRetirement(retirementPlan)
 
@@ -57,10 +66,10 @@ func TestCreateRetirement_Success(t *testing.T) {
 
 func TestCreateRetirement_NegativeCurrentSavings(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	retirementPlan := createValidRetirementPlan()
-	retirementPlan.CurrentSavings = -1
+	retirementPlan.CurrentSavings = entities.Baht(-1)
 
 	result, age, err := useCase.CreateRetirement(retirementPlan)
 
@@ -73,10 +82,10 @@ func TestCreateRetirement_NegativeCurrentSavings(t *testing.T) {
 
 func TestCreateRetirement_NegativeMonthlyIncome(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	retirementPlan := createValidRetirementPlan()
-	retirementPlan.MonthlyIncome = -1
+	retirementPlan.MonthlyIncome = entities.Baht(-1)
 
 	result, age, err := useCase.CreateRetirement(retirementPlan)
 
@@ -89,7 +98,7 @@ func TestCreateRetirement_NegativeMonthlyIncome(t *testing.T) {
 
 func TestCreateRetirement_ZeroCurrentSavingsReturns(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	retirementPlan := createValidRetirementPlan()
 	retirementPlan.CurrentSavingsReturns = 0
@@ -105,7 +114,7 @@ func TestCreateRetirement_ZeroCurrentSavingsReturns(t *testing.T) {
 
 func TestCreateRetirement_AgeOlderThanRetirementAge(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	retirementPlan := createValidRetirementPlan()
 	retirementPlan.BirthDate = time.Now().AddDate(-65, 0, 0).Format("02-01-2006")
@@ -121,7 +130,7 @@ func TestCreateRetirement_AgeOlderThanRetirementAge(t *testing.T) {
 
 func TestCreateRetirement_RetirementAgeHigherThanLifespan(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	retirementPlan := createValidRetirementPlan()
 	retirementPlan.RetirementAge = 85
@@ -138,11 +147,12 @@ func TestCreateRetirement_RetirementAgeHigherThanLifespan(t *testing.T) {
 
 func TestCreateRetirement_RepositoryError(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	retirementPlan := createValidRetirementPlan()
 	expectedError := errors.New("database error")
 
+	mockRepo.On("GetRetirementByUserID", "test-user-id").Return(&entities.RetirementPlan{}, nil).Once()
 	mockRepo.On("CreateRetirement", mock.AnythingOfType("*entities.RetirementPlan")).Return(&entities.RetirementPlan{}, expectedError)
 
 	result, age, err := useCase.CreateRetirement(retirementPlan)
@@ -156,7 +166,7 @@ func TestCreateRetirement_RepositoryError(t *testing.T) {
 
 func TestGetRetirementByID_Success(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	expectedPlan := createValidRetirementPlan()
 	expectedPlan.ID = "test-id"
@@ -173,7 +183,7 @@ func TestGetRetirementByID_Success(t *testing.T) {
 
 func TestGetRetirementByID_NotFound(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	expectedError := errors.New("record not found")
 
@@ -189,7 +199,7 @@ func TestGetRetirementByID_NotFound(t *testing.T) {
 
 func TestGetRetirementByUserID_Success(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	expectedPlan := createValidRetirementPlan()
 	expectedPlan.UserID = "test-user-id"
@@ -206,7 +216,7 @@ func TestGetRetirementByUserID_Success(t *testing.T) {
 
 func TestGetRetirementByUserID_NotFound(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	expectedError := errors.New("record not found")
 
@@ -222,24 +232,26 @@ func TestGetRetirementByUserID_NotFound(t *testing.T) {
 
 func TestUpdateRetirementByID_Success(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	userID := "test-user-id"
 	existingPlan := createValidRetirementPlan()
 	existingPlan.ID = "test-id"
 	existingPlan.UserID = userID
 	existingPlan.LastCalculatedMonth = int(time.Now().Month())
-	existingPlan.LastRequiredFunds = 10000000
-	existingPlan.LastMonthlyExpenses = 35000
+	existingPlan.LastRequiredFunds = entities.Baht(10000000)
+	existingPlan.LastMonthlyExpenses = entities.Baht(35000)
 
 	updatedPlan := createValidRetirementPlan()
 	updatedPlan.ID = "test-id"
 	updatedPlan.UserID = userID
-	updatedPlan.MonthlyIncome = 60000
-	updatedPlan.ExpectedMonthlyExpenses = 45000
+	updatedPlan.MonthlyIncome = entities.Baht(60000)
+	updatedPlan.ExpectedMonthlyExpenses = entities.Baht(45000)
 
 	mockRepo.On("GetRetirementByUserID", userID).Return(&existingPlan, nil)
+	mockRepo.On("GetRetirementByIDForUpdate", "test-id").Return(&existingPlan, nil).Once()
 	mockRepo.On("UpdateRetirementPlan", mock.AnythingOfType("*entities.RetirementPlan")).Return(&updatedPlan, nil)
+	mockRepo.On("AppendRevision", &updatedPlan).Return(nil).Once()
 
 	result, err := useCase.UpdateRetirementByID(userID, updatedPlan)
 
@@ -250,7 +262,7 @@ func TestUpdateRetirementByID_Success(t *testing.T) {
 
 func TestUpdateRetirementByID_UserNotFound(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	userID := "non-existent-user-id"
 	updatedPlan := createValidRetirementPlan()
@@ -268,13 +280,13 @@ func TestUpdateRetirementByID_UserNotFound(t *testing.T) {
 
 func TestUpdateRetirementByID_NegativeMonthlyIncome(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	userID := "test-user-id"
 	existingPlan := createValidRetirementPlan()
 
 	updatedPlan := createValidRetirementPlan()
-	updatedPlan.MonthlyIncome = -1
+	updatedPlan.MonthlyIncome = entities.Baht(-1)
 
 	mockRepo.On("GetRetirementByUserID", userID).Return(&existingPlan, nil)
 
@@ -288,32 +300,471 @@ func TestUpdateRetirementByID_NegativeMonthlyIncome(t *testing.T) {
 
 func TestUpdateRetirementByID_StatusCompletedWhenFundsSufficient(t *testing.T) {
 	mockRepo := new(mocks.MockRetirementRepository)
-	useCase := usecases.NewRetirementUseCase(mockRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
 
 	userID := "test-user-id"
 	existingPlan := createValidRetirementPlan()
 	existingPlan.ID = "test-id"
 	existingPlan.UserID = userID
 	existingPlan.LastCalculatedMonth = (int(time.Now().Month()) + 1) % 12
-	existingPlan.LastRequiredFunds = 1000000
+	existingPlan.LastRequiredFunds = entities.Baht(1000000)
 
 	updatedPlan := createValidRetirementPlan()
 	updatedPlan.ID = "test-id"
 	updatedPlan.UserID = userID
-	updatedPlan.CurrentSavings = 500000
-	updatedPlan.CurrentTotalInvestment = 1000000
+	updatedPlan.CurrentSavings = entities.Baht(500000)
+	updatedPlan.CurrentTotalInvestment = entities.Baht(1000000)
 
 	expectedUpdatedPlan := updatedPlan
 	expectedUpdatedPlan.Status = "Completed"
 	expectedUpdatedPlan.LastMonthlyExpenses = 0
 
 	mockRepo.On("GetRetirementByUserID", userID).Return(&existingPlan, nil)
+	mockRepo.On("GetRetirementByIDForUpdate", "test-id").Return(&existingPlan, nil).Once()
 	mockRepo.On("UpdateRetirementPlan", mock.AnythingOfType("*entities.RetirementPlan")).Return(&expectedUpdatedPlan, nil)
+	mockRepo.On("AppendRevision", &expectedUpdatedPlan).Return(nil).Once()
 
 	result, err := useCase.UpdateRetirementByID(userID, updatedPlan)
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "Completed", result.Status)
-	assert.Equal(t, float64(0), result.LastMonthlyExpenses)
+	assert.Equal(t, entities.Money(0), result.LastMonthlyExpenses)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateRetirementByID_KeepsInvestmentTrackedByHoldings(t *testing.T) {
+	mockRepo := new(mocks.MockRetirementRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	useCase := usecases.NewRetirementUseCase(mockRepo, mockUserRepo, new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, mockUserRepo))
+
+	userID := "test-user-id"
+	existingPlan := createValidRetirementPlan()
+	existingPlan.ID = "test-id"
+	existingPlan.IsActive = true
+	existingPlan.CurrentTotalInvestment = entities.Baht(250000)
+
+	updatedPlan := createValidRetirementPlan()
+	updatedPlan.CurrentTotalInvestment = entities.Baht(900000)
+
+	mockRepo.On("GetRetirementByUserID", userID).Return(&existingPlan, nil)
+	mockUserRepo.On("GetUserByID", userID).Return(&entities.User{ID: userID, Holdings: []entities.Holding{{ID: "h1"}}}, nil)
+	mockRepo.On("GetRetirementByIDForUpdate", "test-id").Return(&existingPlan, nil).Once()
+	mockRepo.On("UpdateRetirementPlan", mock.MatchedBy(func(plan *entities.RetirementPlan) bool {
+		return plan.CurrentTotalInvestment == entities.Baht(250000)
+	})).Return(&existingPlan, nil)
+	mockRepo.On("AppendRevision", &existingPlan).Return(nil).Once()
+
+	result, err := useCase.UpdateRetirementByID(userID, updatedPlan)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUpdateRetirementByID_PostsInvestmentEdit(t *testing.T) {
+	mockRepo := new(mocks.MockRetirementRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
+	uow := mocks.NewMockUnitOfWork(mockUserRepo, new(mocks.MockAssetRepository), mockRepo, ledgerRepo)
+	useCase := usecases.NewRetirementUseCase(mockRepo, mockUserRepo, new(mocks.MockQuizRepository), uow)
+
+	userID := "test-user-id"
+	existingPlan := createValidRetirementPlan()
+	existingPlan.ID = "test-id"
+	existingPlan.IsActive = true
+
+	updatedPlan := createValidRetirementPlan()
+	updatedPlan.CurrentTotalInvestment = entities.Baht(1200000)
+
+	mockRepo.On("GetRetirementByUserID", userID).Return(&existingPlan, nil)
+	mockUserRepo.On("GetUserByID", userID).Return(&entities.User{ID: userID}, nil)
+	mockRepo.On("GetRetirementByIDForUpdate", "test-id").Return(&existingPlan, nil).Once()
+	mockRepo.On("UpdateRetirementPlan", mock.AnythingOfType("*entities.RetirementPlan")).Return(&existingPlan, nil).Once()
+	mockRepo.On("AppendRevision", &existingPlan).Return(nil).Once()
+	ledgerRepo.On("GetAccount", userID, entities.LedgerAccountRetirementInvestment, userID).Return(&entities.LedgerAccount{ID: "investment"}, nil).Once()
+	ledgerRepo.On("GetAccount", userID, entities.LedgerAccountExternal, userID).Return(&entities.LedgerAccount{ID: "external"}, nil).Once()
+	ledgerRepo.On("CreateEntry", mock.MatchedBy(func(entry *entities.LedgerEntry) bool {
+		return entry.Description == "Edit retirement plan Test Plan" &&
+			len(entry.Postings) == 2 &&
+			entry.Postings[0].AccountID == "investment" &&
+			entry.Postings[0].Amount == entities.Baht(200000)
+	})).Return(&entities.LedgerEntry{ID: "entry-1"}, nil).Once()
+
+	_, err := useCase.UpdateRetirementByID(userID, updatedPlan)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, uow.Committed)
+	ledgerRepo.AssertExpectations(t)
+}
+
+func TestCreateRetirement_SecondPlanInactive(t *testing.T) {
+	mockRepo := new(mocks.MockRetirementRepository)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
+	active := createValidRetirementPlan()
+	active.ID = "plan-1"
+	active.IsActive = true
+	active.CurrentSavings = entities.Baht(750000)
+	active.CurrentTotalInvestment = entities.Baht(250000)
+
+	retirement := createValidRetirementPlan()
+	retirement.PlanName = "Retire at 55"
+	retirement.RetirementAge = 55
+
+	mockRepo.On("GetRetirementByUserID", "test-user-id").Return(&active, nil).Once()
+	mockRepo.On("CreateRetirement", mock.MatchedBy(func(plan *entities.RetirementPlan) bool {
+		return !plan.IsActive &&
+			plan.CurrentSavings == entities.Baht(750000) &&
+			plan.CurrentTotalInvestment == entities.Baht(250000)
+	})).Return(&retirement, nil).Once()
+	mockRepo.On("AppendRevision", &retirement).Return(nil).Once()
+
+	_, _, err := useCase.CreateRetirement(retirement)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetPlanByID(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.MockRetirementRepository)
+		useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
+		plan := createValidRetirementPlan()
+		plan.ID = "plan-1"
+
+		mockRepo.On("GetRetirementByID", "plan-1").Return(&plan, nil).Once()
+
+		result, err := useCase.GetPlanByID("test-user-id", "plan-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "plan-1", result.ID)
+	})
+
+	t.Run("Other User", func(t *testing.T) {
+		mockRepo := new(mocks.MockRetirementRepository)
+		useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
+		plan := createValidRetirementPlan()
+		plan.ID = "plan-1"
+
+		mockRepo.On("GetRetirementByID", "plan-1").Return(&plan, nil).Once()
+
+		result, err := useCase.GetPlanByID("other-user-id", "plan-1")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "retirement plan not found")
+	})
+}
+
+func TestUpdatePlanByID(t *testing.T) {
+	mockRepo := new(mocks.MockRetirementRepository)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
+	existing := createValidRetirementPlan()
+	existing.ID = "plan-2"
+
+	update := createValidRetirementPlan()
+	update.RetirementAge = 55
+
+	mockRepo.On("GetRetirementByID", "plan-2").Return(&existing, nil).Once()
+	mockRepo.On("GetRetirementByIDForUpdate", "plan-2").Return(&existing, nil).Once()
+	updated := mockRepo.On("UpdateRetirementPlan", mock.MatchedBy(func(plan *entities.RetirementPlan) bool {
+		return plan.ID == "plan-2" && plan.RetirementAge == 55
+	})).Return(&existing, nil).Once()
+	mockRepo.On("AppendRevision", &existing).Return(nil).Once().NotBefore(updated)
+
+	result, err := useCase.UpdatePlanByID("test-user-id", "plan-2", update)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 55, result.RetirementAge)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUpdatePlanByID_UpdateFailsWithoutRevision(t *testing.T) {
+	mockRepo := new(mocks.MockRetirementRepository)
+	uow := retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository))
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), uow)
+	existing := createValidRetirementPlan()
+	existing.ID = "plan-2"
+
+	mockRepo.On("GetRetirementByID", "plan-2").Return(&existing, nil).Once()
+	mockRepo.On("GetRetirementByIDForUpdate", "plan-2").Return(&existing, nil).Once()
+	mockRepo.On("UpdateRetirementPlan", mock.AnythingOfType("*entities.RetirementPlan")).Return((*entities.RetirementPlan)(nil), errors.New("update failed")).Once()
+
+	result, err := useCase.UpdatePlanByID("test-user-id", "plan-2", createValidRetirementPlan())
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "update failed")
+	assert.Equal(t, 1, uow.RolledBack)
+	mockRepo.AssertNotCalled(t, "AppendRevision", mock.Anything)
+}
+
+func TestActivatePlan(t *testing.T) {
+	newUnitOfWork := func() (*mocks.MockUnitOfWork, *mocks.MockRetirementRepository, *mocks.MockUserRepository) {
+		retirementRepo := new(mocks.MockRetirementRepository)
+		userRepo := new(mocks.MockUserRepository)
+		return mocks.NewMockUnitOfWork(userRepo, new(mocks.MockAssetRepository), retirementRepo, new(mocks.MockLedgerRepository)), retirementRepo, userRepo
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		uow, retirementRepo, userRepo := newUnitOfWork()
+		useCase := usecases.NewRetirementUseCase(retirementRepo, userRepo, new(mocks.MockQuizRepository), uow)
+		active := createValidRetirementPlan()
+		active.ID = "plan-1"
+		active.IsActive = true
+		active.CurrentSavings = entities.Baht(800000)
+		active.CurrentTotalInvestment = entities.Baht(200000)
+		target := createValidRetirementPlan()
+		target.ID = "plan-2"
+		target.RetirementAge = 55
+		target.CurrentSavings = entities.Baht(500000)
+		house := &entities.SelectedHouse{UserID: "test-user-id", LastCalculatedMonth: 7}
+
+		retirementRepo.On("GetRetirementByUserIDForUpdate", "test-user-id").Return(&active, nil).Once()
+		retirementRepo.On("GetRetirementByIDForUpdate", "plan-2").Return(&target, nil).Once()
+		deactivated := retirementRepo.On("UpdateRetirementPlan", mock.MatchedBy(func(plan *entities.RetirementPlan) bool {
+			return plan.ID == "plan-1" && !plan.IsActive
+		})).Return(&active, nil).Once()
+		retirementRepo.On("AppendRevision", &active).Return(nil).Once()
+		retirementRepo.On("AppendRevision", &target).Return(nil).Once()
+		retirementRepo.On("UpdateRetirementPlan", mock.MatchedBy(func(plan *entities.RetirementPlan) bool {
+			return plan.ID == "plan-2" && plan.IsActive &&
+				plan.CurrentSavings == entities.Baht(800000) &&
+				plan.CurrentTotalInvestment == entities.Baht(200000) &&
+				plan.LastRequiredFunds > 0
+		})).Return(&target, nil).Once().NotBefore(deactivated)
+		userRepo.On("GetSelectedHouseForUpdate", "test-user-id").Return(house, nil).Once()
+		userRepo.On("UpdateSelectedHouse", mock.MatchedBy(func(h *entities.SelectedHouse) bool {
+			return h.LastCalculatedMonth == 0
+		})).Return(house, nil).Once()
+
+		result, err := useCase.ActivatePlan("test-user-id", "plan-2")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "plan-2", result.ID)
+		assert.Equal(t, 1, uow.Committed)
+		retirementRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("Without Nursing House", func(t *testing.T) {
+		uow, retirementRepo, userRepo := newUnitOfWork()
+		useCase := usecases.NewRetirementUseCase(retirementRepo, userRepo, new(mocks.MockQuizRepository), uow)
+		target := createValidRetirementPlan()
+		target.ID = "plan-2"
+
+		retirementRepo.On("GetRetirementByUserIDForUpdate", "test-user-id").Return(&entities.RetirementPlan{}, nil).Once()
+		retirementRepo.On("GetRetirementByIDForUpdate", "plan-2").Return(&target, nil).Once()
+		retirementRepo.On("UpdateRetirementPlan", mock.AnythingOfType("*entities.RetirementPlan")).Return(&target, nil).Once()
+		retirementRepo.On("AppendRevision", &target).Return(nil).Once()
+		userRepo.On("GetSelectedHouseForUpdate", "test-user-id").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, err := useCase.ActivatePlan("test-user-id", "plan-2")
+
+		assert.NoError(t, err)
+		assert.True(t, result.IsActive)
+		assert.Equal(t, 1, uow.Committed)
+		userRepo.AssertNotCalled(t, "UpdateSelectedHouse", mock.Anything)
+	})
+
+	t.Run("Other User", func(t *testing.T) {
+		uow, retirementRepo, userRepo := newUnitOfWork()
+		useCase := usecases.NewRetirementUseCase(retirementRepo, userRepo, new(mocks.MockQuizRepository), uow)
+		target := createValidRetirementPlan()
+		target.ID = "plan-2"
+		target.UserID = "other-user-id"
+
+		retirementRepo.On("GetRetirementByUserIDForUpdate", "test-user-id").Return(&entities.RetirementPlan{ID: "plan-1"}, nil).Once()
+		retirementRepo.On("GetRetirementByIDForUpdate", "plan-2").Return(&target, nil).Once()
+
+		result, err := useCase.ActivatePlan("test-user-id", "plan-2")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "retirement plan not found")
+		assert.Equal(t, 1, uow.RolledBack)
+		retirementRepo.AssertNotCalled(t, "UpdateRetirementPlan", mock.Anything)
+	})
+}
+
+func TestGetPlanRevisions(t *testing.T) {
+	mockRepo := new(mocks.MockRetirementRepository)
+	useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
+	plan := createValidRetirementPlan()
+	plan.ID = "plan-1"
+	revisions := []entities.RetirementPlanRevision{
+		{PlanID: "plan-1", Revision: 1, RetirementAge: 60},
+		{PlanID: "plan-1", Revision: 2, RetirementAge: 58},
+	}
+
+	mockRepo.On("GetRetirementByID", "plan-1").Return(&plan, nil).Once()
+	mockRepo.On("GetRevisionsByPlanID", "plan-1").Return(revisions, nil).Once()
+
+	result, err := useCase.GetPlanRevisions("test-user-id", "plan-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, revisions, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetProjection(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		useCase := usecases.NewRetirementUseCase(new(mocks.MockRetirementRepository), userRepo, new(mocks.MockQuizRepository), nil)
+		plan := createValidRetirementPlan()
+		plan.ID = "plan-1"
+		plan.LastMonthlyExpenses = entities.Baht(20000)
+
+		userRepo.On("GetUserByID", "test-user-id").Return(&entities.User{ID: "test-user-id", RetirementPlan: plan}, nil).Once()
+
+		projection, err := useCase.GetProjection("test-user-id")
+
+		assert.NoError(t, err)
+		assert.Len(t, projection.Points, 50*12)
+		assert.Equal(t, entities.ProjectionPhaseAccumulation, projection.Points[0].Phase)
+		assert.Equal(t, entities.ProjectionPhaseRetirement, projection.Points[len(projection.Points)-1].Phase)
+		assert.Equal(t, entities.Baht(20000), projection.MonthlyContribution)
+		assert.Empty(t, projection.Vehicles)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("Includes Savings Vehicles", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		useCase := usecases.NewRetirementUseCase(new(mocks.MockRetirementRepository), userRepo, new(mocks.MockQuizRepository), nil)
+		plan := createValidRetirementPlan()
+		plan.ID = "plan-1"
+		user := &entities.User{
+			ID:             "test-user-id",
+			RetirementPlan: plan,
+			Vehicles: []entities.SavingsVehicle{
+				{ID: "vehicle-1", Name: "กองทุนสำรองเลี้ยงชีพ", Type: entities.VehicleTypeProvidentFund, CurrentMoney: entities.Baht(100000), OpenedAt: time.Now().AddDate(-3, 0, 0)},
+			},
+		}
+
+		userRepo.On("GetUserByID", "test-user-id").Return(user, nil).Once()
+		userRepo.On("GetUserDepositsInRange", "test-user-id", time.Time{}, mock.AnythingOfType("time.Time")).Return([]entities.History{}, nil).Once()
+
+		projection, err := useCase.GetProjection("test-user-id")
+
+		assert.NoError(t, err)
+		assert.Len(t, projection.Vehicles, 1)
+		assert.Equal(t, projection.RetirementMonth, projection.Vehicles[0].PayoutMonth)
+		assert.Equal(t, entities.Baht(100000), projection.Vehicles[0].ProjectedBalance)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("Plan Not Found", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		useCase := usecases.NewRetirementUseCase(new(mocks.MockRetirementRepository), userRepo, new(mocks.MockQuizRepository), nil)
+
+		userRepo.On("GetUserByID", "test-user-id").Return(&entities.User{ID: "test-user-id"}, nil).Once()
+
+		projection, err := useCase.GetProjection("test-user-id")
+
+		assert.Nil(t, projection)
+		assert.EqualError(t, err, "retirement plan not found")
+	})
+}
+
+func TestSimulateRetirement(t *testing.T) {
+	seed := uint64(42)
+	options := entities.MonteCarloOptions{Paths: 50, TargetConfidence: 0.8, Seed: &seed}
+
+	t.Run("Uses Quiz Risk Level", func(t *testing.T) {
+		mockRepo := new(mocks.MockRetirementRepository)
+		mockQuizRepo := new(mocks.MockQuizRepository)
+		useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), mockQuizRepo, nil)
+		plan := createValidRetirementPlan()
+		plan.LastMonthlyExpenses = entities.Baht(20000)
+
+		mockRepo.On("GetRetirementByUserID", "test-user-id").Return(&plan, nil).Once()
+		mockQuizRepo.On("GetQuizByUserID", "test-user-id").Return(&entities.Quiz{UserID: "test-user-id", RiskID: 5}, nil).Once()
+
+		result, err := useCase.SimulateRetirement("test-user-id", options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, result.RiskLevel)
+		assert.Equal(t, 50, result.Paths)
+		assert.Equal(t, seed, result.Seed)
+		assert.Equal(t, entities.Baht(20000), result.MonthlyContribution)
+		mockRepo.AssertExpectations(t)
+		mockQuizRepo.AssertExpectations(t)
+	})
+
+	t.Run("No Quiz Uses Default Risk Level", func(t *testing.T) {
+		mockRepo := new(mocks.MockRetirementRepository)
+		mockQuizRepo := new(mocks.MockQuizRepository)
+		useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), mockQuizRepo, nil)
+		plan := createValidRetirementPlan()
+
+		mockRepo.On("GetRetirementByUserID", "test-user-id").Return(&plan, nil).Once()
+		mockQuizRepo.On("GetQuizByUserID", "test-user-id").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, err := useCase.SimulateRetirement("test-user-id", options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.RiskLevel)
+	})
+
+	t.Run("Quiz Lookup Fails", func(t *testing.T) {
+		mockRepo := new(mocks.MockRetirementRepository)
+		mockQuizRepo := new(mocks.MockQuizRepository)
+		useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), mockQuizRepo, nil)
+		plan := createValidRetirementPlan()
+
+		mockRepo.On("GetRetirementByUserID", "test-user-id").Return(&plan, nil).Once()
+		mockQuizRepo.On("GetQuizByUserID", "test-user-id").Return(nil, errors.New("database error")).Once()
+
+		result, err := useCase.SimulateRetirement("test-user-id", options)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "database error")
+	})
+
+	t.Run("Plan Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.MockRetirementRepository)
+		useCase := usecases.NewRetirementUseCase(mockRepo, new(mocks.MockUserRepository), new(mocks.MockQuizRepository), retirementUnitOfWork(mockRepo, new(mocks.MockUserRepository)))
+
+		mockRepo.On("GetRetirementByUserID", "test-user-id").Return(nil, errors.New("record not found")).Once()
+
+		result, err := useCase.SimulateRetirement("test-user-id", options)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "record not found")
+	})
+}
+
+func TestPlanDecumulation(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		useCase := usecases.NewRetirementUseCase(new(mocks.MockRetirementRepository), userRepo, new(mocks.MockQuizRepository), nil)
+		plan := createValidRetirementPlan()
+		plan.ID = "plan-1"
+		plan.LastRequiredFunds = entities.Baht(12000000)
+		user := &entities.User{
+			ID:             "test-user-id",
+			RetirementPlan: plan,
+			House:          entities.SelectedHouse{NursingHouse: entities.NursingHouse{Price: entities.Baht(15000)}},
+		}
+
+		userRepo.On("GetUserByID", "test-user-id").Return(user, nil).Once()
+
+		result, err := useCase.PlanDecumulation("test-user-id", entities.DecumulationOptions{WithdrawalRate: 4})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 20*12, result.Months)
+		assert.Equal(t, entities.Baht(12000000)+entities.Baht(15000).Mul(240), result.StartingBalance)
+		assert.Equal(t, entities.Baht(15000), result.NursingHouseMonthly)
+		assert.Len(t, result.Strategies, 3)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("No Retirement Plan", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		useCase := usecases.NewRetirementUseCase(new(mocks.MockRetirementRepository), userRepo, new(mocks.MockQuizRepository), nil)
+
+		userRepo.On("GetUserByID", "test-user-id").Return(&entities.User{ID: "test-user-id"}, nil).Once()
+
+		result, err := useCase.PlanDecumulation("test-user-id", entities.DecumulationOptions{})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "retirement plan not found")
+	})
+}
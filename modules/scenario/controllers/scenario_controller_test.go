@@ -0,0 +1,239 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/scenario/controllers"
+	"github.com/XzerozZ/Kasian_Phrom_BE/testing/usecases/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTest(_ *testing.T) (*controllers.ScenarioController, *mocks.MockScenarioUseCase, *fiber.App) {
+	mockUseCase := new(mocks.MockScenarioUseCase)
+	controller := controllers.NewScenarioController(mockUseCase)
+	app := fiber.New()
+	return controller, mockUseCase, app
+}
+
+func TestCreateScenarioHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/scenario", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.CreateScenarioHandler(c)
+		})
+
+		mockUseCase.On("CreateScenario", "user-123", mock.MatchedBy(func(request entities.ScenarioRequest) bool {
+			return request.Name != nil && *request.Name == "Retire early" &&
+				request.RetirementAge != nil && *request.RetirementAge == 55 &&
+				request.ExpectedMonthlyExpenses != nil && *request.ExpectedMonthlyExpenses == entities.MoneyFromFloat(25000.5) &&
+				request.ExpectLifespan == nil
+		})).Return(&entities.Scenario{ID: "scenario-1", Name: "Retire early"}, nil).Once()
+
+		req := httptest.NewRequest("POST", "/scenario", strings.NewReader(`{"name":"Retire early","retirement_age":55,"expected_monthly_expenses":25000.5}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "Scenario created successfully", responseMap["message"])
+		assert.Equal(t, "scenario-1", responseMap["result"].(map[string]interface{})["scenario_id"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/scenario", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.CreateScenarioHandler(c)
+		})
+
+		mockUseCase.On("CreateScenario", "user-123", mock.Anything).Return(nil, errors.New("retirementAge must be less than ExpectLifespan")).Once()
+
+		req := httptest.NewRequest("POST", "/scenario", strings.NewReader(`{"expect_lifespan":50}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "retirementAge must be less than ExpectLifespan", responseMap["message"])
+	})
+}
+
+func TestGetScenarioHandlers(t *testing.T) {
+	t.Run("List", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/scenario", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetScenariosHandler(c)
+		})
+
+		mockUseCase.On("GetScenariosByUserID", "user-123").Return([]entities.Scenario{{ID: "scenario-1"}, {ID: "scenario-2"}}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/scenario", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Len(t, responseMap["result"], 2)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/scenario/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetScenarioByIDHandler(c)
+		})
+
+		mockUseCase.On("GetScenarioByID", "user-123", "scenario-9").Return(nil, errors.New("scenario not found")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/scenario/scenario-9", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestUpdateScenarioHandler(t *testing.T) {
+	controller, mockUseCase, app := setupTest(t)
+	app.Put("/scenario/:id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return controller.UpdateScenarioHandler(c)
+	})
+
+	mockUseCase.On("UpdateScenario", "user-123", "scenario-1", mock.MatchedBy(func(request entities.ScenarioRequest) bool {
+		return request.NursingHouseID != nil && *request.NursingHouseID == "00003" && request.Name == nil
+	})).Return(&entities.Scenario{ID: "scenario-1"}, nil).Once()
+
+	req := httptest.NewRequest("PUT", "/scenario/scenario-1", strings.NewReader(`{"nh_id":"00003"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestDeleteScenarioHandler(t *testing.T) {
+	controller, mockUseCase, app := setupTest(t)
+	app.Delete("/scenario/:id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return controller.DeleteScenarioHandler(c)
+	})
+
+	mockUseCase.On("DeleteScenario", "user-123", "scenario-1").Return(nil).Once()
+
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/scenario/scenario-1", nil), -1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestCompareScenariosHandler(t *testing.T) {
+	t.Run("Selected IDs", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/scenario/compare", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.CompareScenariosHandler(c)
+		})
+
+		mockUseCase.On("CompareScenarios", "user-123", []string{"scenario-1", "scenario-2"}).Return([]entities.ScenarioSummary{
+			{Name: "My Plan", Active: true, Shortfall: entities.Baht(100000)},
+			{ScenarioID: "scenario-1", Shortfall: entities.Baht(50000)},
+			{ScenarioID: "scenario-2", Shortfall: 0},
+		}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/scenario/compare?ids=scenario-1,%20scenario-2,", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		result := responseMap["result"].([]interface{})
+		assert.Len(t, result, 3)
+		assert.Equal(t, true, result[0].(map[string]interface{})["active"])
+		assert.Equal(t, float64(50000), result[1].(map[string]interface{})["shortfall"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("All Scenarios", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/scenario/compare", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.CompareScenariosHandler(c)
+		})
+
+		mockUseCase.On("CompareScenarios", "user-123", []string(nil)).Return([]entities.ScenarioSummary{{Active: true}}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/scenario/compare", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestPromoteScenarioHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/scenario/:id/promote", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.PromoteScenarioHandler(c)
+		})
+
+		mockUseCase.On("PromoteScenario", "user-123", "scenario-1").Return(&entities.RetirementPlan{ID: "plan-1", RetirementAge: 55}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/scenario/scenario-1/promote", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "Scenario promoted to active plan successfully", responseMap["message"])
+		assert.Equal(t, float64(55), responseMap["result"].(map[string]interface{})["retirement_age"])
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/scenario/:id/promote", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.PromoteScenarioHandler(c)
+		})
+
+		mockUseCase.On("PromoteScenario", "user-123", "scenario-1").Return(nil, errors.New("house savings must be transferred before removing the nursing house")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/scenario/scenario-1/promote", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("No User ID", func(t *testing.T) {
+		controller, _, app := setupTest(t)
+		app.Post("/scenario/:id/promote", controller.PromoteScenarioHandler)
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/scenario/scenario-1/promote", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+
+	"gorm.io/gorm"
+)
+
+type GormScenarioRepository struct {
+	db *gorm.DB
+}
+
+func NewGormScenarioRepository(db *gorm.DB) *GormScenarioRepository {
+	return &GormScenarioRepository{db: db}
+}
+
+type ScenarioRepository interface {
+	CreateScenario(scenario *entities.Scenario) (*entities.Scenario, error)
+	GetScenarioByID(id string) (*entities.Scenario, error)
+	GetScenariosByUserID(userID string) ([]entities.Scenario, error)
+	UpdateScenario(scenario *entities.Scenario) (*entities.Scenario, error)
+	DeleteScenario(id string) error
+}
+
+func (r *GormScenarioRepository) CreateScenario(scenario *entities.Scenario) (*entities.Scenario, error) {
+	if err := r.db.Omit("NursingHouse").Create(&scenario).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetScenarioByID(scenario.ID)
+}
+
+func (r *GormScenarioRepository) GetScenarioByID(id string) (*entities.Scenario, error) {
+	var scenario entities.Scenario
+	if err := r.db.Preload("Assets").Preload("NursingHouse.Images").First(&scenario, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return &scenario, nil
+}
+
+func (r *GormScenarioRepository) GetScenariosByUserID(userID string) ([]entities.Scenario, error) {
+	var scenarios []entities.Scenario
+	if err := r.db.Preload("Assets").Preload("NursingHouse.Images").Where("user_id = ?", userID).Order("created_at ASC").Find(&scenarios).Error; err != nil {
+		return nil, err
+	}
+
+	return scenarios, nil
+}
+
+func (r *GormScenarioRepository) UpdateScenario(scenario *entities.Scenario) (*entities.Scenario, error) {
+	if err := r.db.Omit("Assets", "NursingHouse").Save(&scenario).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetScenarioByID(scenario.ID)
+}
+
+func (r *GormScenarioRepository) DeleteScenario(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entities.ScenarioAsset{}, "scenario_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&entities.Scenario{}, "id = ?", id).Error
+	})
+}
@@ -0,0 +1,396 @@
+package usecases
+
+import (
+	"errors"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	nhRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/nursing_house/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/scenario/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/unitofwork"
+	userRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type ScenarioUseCase interface {
+	CreateScenario(userID string, request entities.ScenarioRequest) (*entities.Scenario, error)
+	GetScenarioByID(userID, id string) (*entities.Scenario, error)
+	GetScenariosByUserID(userID string) ([]entities.Scenario, error)
+	UpdateScenario(userID, id string, request entities.ScenarioRequest) (*entities.Scenario, error)
+	DeleteScenario(userID, id string) error
+	CompareScenarios(userID string, ids []string) ([]entities.ScenarioSummary, error)
+	PromoteScenario(userID, id string) (*entities.RetirementPlan, error)
+}
+
+type ScenarioUseCaseImpl struct {
+	scenariorepo repositories.ScenarioRepository
+	userrepo     userRepo.UserRepository
+	nhrepo       nhRepo.NhRepository
+	uow          unitofwork.UnitOfWork
+}
+
+func NewScenarioUseCase(scenariorepo repositories.ScenarioRepository, userrepo userRepo.UserRepository, nhrepo nhRepo.NhRepository, uow unitofwork.UnitOfWork) *ScenarioUseCaseImpl {
+	return &ScenarioUseCaseImpl{
+		scenariorepo: scenariorepo,
+		userrepo:     userrepo,
+		nhrepo:       nhrepo,
+		uow:          uow,
+	}
+}
+
+func scenarioUser(scenario *entities.Scenario) *entities.User {
+	user := &entities.User{
+		ID: scenario.UserID,
+		RetirementPlan: entities.RetirementPlan{
+			PlanName:                scenario.Name,
+			BirthDate:               scenario.BirthDate,
+			RetirementAge:           scenario.RetirementAge,
+			ExpectLifespan:          scenario.ExpectLifespan,
+			CurrentSavings:          scenario.CurrentSavings,
+			CurrentSavingsReturns:   scenario.CurrentSavingsReturns,
+			CurrentTotalInvestment:  scenario.CurrentTotalInvestment,
+			InvestmentReturn:        scenario.InvestmentReturn,
+			ExpectedMonthlyExpenses: scenario.ExpectedMonthlyExpenses,
+			ExpectedInflation:       scenario.ExpectedInflation,
+			AnnualExpenseIncrease:   scenario.AnnualExpenseIncrease,
+			AnnualSavingsReturn:     scenario.AnnualSavingsReturn,
+			AnnualInvestmentReturn:  scenario.AnnualInvestmentReturn,
+		},
+		House: entities.SelectedHouse{
+			UserID:         scenario.UserID,
+			NursingHouseID: scenario.NursingHouseID,
+			CurrentMoney:   scenario.HouseCurrentMoney,
+			NursingHouse:   scenario.NursingHouse,
+		},
+	}
+
+	for _, asset := range scenario.Assets {
+		user.Assets = append(user.Assets, entities.Asset{
+			ID:           asset.AssetID,
+			Name:         asset.Name,
+			Type:         asset.Type,
+			TotalCost:    asset.TotalCost,
+			CurrentMoney: asset.CurrentMoney,
+			Status:       asset.Status,
+			EndYear:      asset.EndYear,
+		})
+	}
+
+	return user
+}
+
+func (u *ScenarioUseCaseImpl) applyRequest(scenario *entities.Scenario, request entities.ScenarioRequest) error {
+	if request.Name != nil {
+		scenario.Name = *request.Name
+	}
+
+	if request.RetirementAge != nil {
+		scenario.RetirementAge = *request.RetirementAge
+	}
+
+	if request.ExpectLifespan != nil {
+		scenario.ExpectLifespan = *request.ExpectLifespan
+	}
+
+	if request.CurrentSavingsReturns != nil {
+		scenario.CurrentSavingsReturns = *request.CurrentSavingsReturns
+	}
+
+	if request.InvestmentReturn != nil {
+		scenario.InvestmentReturn = *request.InvestmentReturn
+	}
+
+	if request.ExpectedMonthlyExpenses != nil {
+		scenario.ExpectedMonthlyExpenses = *request.ExpectedMonthlyExpenses
+	}
+
+	if request.ExpectedInflation != nil {
+		scenario.ExpectedInflation = *request.ExpectedInflation
+	}
+
+	if request.AnnualExpenseIncrease != nil {
+		scenario.AnnualExpenseIncrease = *request.AnnualExpenseIncrease
+	}
+
+	if request.AnnualSavingsReturn != nil {
+		scenario.AnnualSavingsReturn = *request.AnnualSavingsReturn
+	}
+
+	if request.AnnualInvestmentReturn != nil {
+		scenario.AnnualInvestmentReturn = *request.AnnualInvestmentReturn
+	}
+
+	if request.NursingHouseID != nil && *request.NursingHouseID != scenario.NursingHouseID {
+		nursingHouse, err := u.nhrepo.GetNhByID(*request.NursingHouseID)
+		if err != nil {
+			return errors.New("nursing house not found")
+		}
+
+		scenario.NursingHouseID = nursingHouse.ID
+		scenario.NursingHouse = *nursingHouse
+	}
+
+	if scenario.Name == "" {
+		return errors.New("name is required")
+	}
+
+	age, err := utils.CalculateRetirementPlanAge(scenario.BirthDate, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if age >= scenario.RetirementAge {
+		return errors.New("age must be less than RetirementAge")
+	}
+
+	if scenario.RetirementAge >= scenario.ExpectLifespan {
+		return errors.New("retirementAge must be less than ExpectLifespan")
+	}
+
+	if scenario.CurrentSavingsReturns <= 0 {
+		return errors.New("currentSavingsReturns must be greater than zero")
+	}
+
+	if scenario.InvestmentReturn <= 0 {
+		return errors.New("investmentReturn must be greater than zero")
+	}
+
+	if scenario.ExpectedInflation <= 0 {
+		return errors.New("expectedInflation must be greater than zero")
+	}
+
+	if scenario.ExpectedMonthlyExpenses <= 0 {
+		return errors.New("expectedMonthlyExpenses must be greater than zero")
+	}
+
+	if scenario.AnnualExpenseIncrease < 0 {
+		return errors.New("annualExpenseIncrease must be greater than or equal to zero")
+	}
+
+	if scenario.AnnualSavingsReturn < 0 {
+		return errors.New("annualSavingsReturn must be greater than or equal to zero")
+	}
+
+	if scenario.AnnualInvestmentReturn < 0 {
+		return errors.New("annualInvestmentReturn must be greater than or equal to zero")
+	}
+
+	return nil
+}
+
+func (u *ScenarioUseCaseImpl) CreateScenario(userID string, request entities.ScenarioRequest) (*entities.Scenario, error) {
+	user, err := u.userrepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := user.RetirementPlan
+	if plan.ID == "" {
+		return nil, errors.New("retirement plan not found")
+	}
+
+	scenario := &entities.Scenario{
+		ID:                      uuid.New().String(),
+		UserID:                  userID,
+		Name:                    plan.PlanName,
+		BirthDate:               plan.BirthDate,
+		RetirementAge:           plan.RetirementAge,
+		ExpectLifespan:          plan.ExpectLifespan,
+		CurrentSavings:          plan.CurrentSavings,
+		CurrentSavingsReturns:   plan.CurrentSavingsReturns,
+		CurrentTotalInvestment:  plan.CurrentTotalInvestment,
+		InvestmentReturn:        plan.InvestmentReturn,
+		ExpectedMonthlyExpenses: plan.ExpectedMonthlyExpenses,
+		ExpectedInflation:       plan.ExpectedInflation,
+		AnnualExpenseIncrease:   plan.AnnualExpenseIncrease,
+		AnnualSavingsReturn:     plan.AnnualSavingsReturn,
+		AnnualInvestmentReturn:  plan.AnnualInvestmentReturn,
+		NursingHouseID:          user.House.NursingHouseID,
+		HouseCurrentMoney:       user.House.CurrentMoney,
+		NursingHouse:            user.House.NursingHouse,
+	}
+
+	for _, asset := range user.Assets {
+		scenario.Assets = append(scenario.Assets, entities.ScenarioAsset{
+			ID:           uuid.New().String(),
+			ScenarioID:   scenario.ID,
+			AssetID:      asset.ID,
+			Name:         asset.Name,
+			Type:         asset.Type,
+			TotalCost:    asset.TotalCost,
+			CurrentMoney: asset.CurrentMoney,
+			Status:       asset.Status,
+			EndYear:      asset.EndYear,
+		})
+	}
+
+	if err := u.applyRequest(scenario, request); err != nil {
+		return nil, err
+	}
+
+	return u.scenariorepo.CreateScenario(scenario)
+}
+
+func (u *ScenarioUseCaseImpl) GetScenarioByID(userID, id string) (*entities.Scenario, error) {
+	scenario, err := u.scenariorepo.GetScenarioByID(id)
+	if err != nil || scenario.UserID != userID {
+		return nil, errors.New("scenario not found")
+	}
+
+	return scenario, nil
+}
+
+func (u *ScenarioUseCaseImpl) GetScenariosByUserID(userID string) ([]entities.Scenario, error) {
+	return u.scenariorepo.GetScenariosByUserID(userID)
+}
+
+func (u *ScenarioUseCaseImpl) UpdateScenario(userID, id string, request entities.ScenarioRequest) (*entities.Scenario, error) {
+	scenario, err := u.GetScenarioByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.applyRequest(scenario, request); err != nil {
+		return nil, err
+	}
+
+	return u.scenariorepo.UpdateScenario(scenario)
+}
+
+func (u *ScenarioUseCaseImpl) DeleteScenario(userID, id string) error {
+	if _, err := u.GetScenarioByID(userID, id); err != nil {
+		return err
+	}
+
+	return u.scenariorepo.DeleteScenario(id)
+}
+
+func (u *ScenarioUseCaseImpl) CompareScenarios(userID string, ids []string) ([]entities.ScenarioSummary, error) {
+	user, err := u.userrepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.RetirementPlan.ID == "" {
+		return nil, errors.New("retirement plan not found")
+	}
+
+	var scenarios []entities.Scenario
+	if len(ids) == 0 {
+		if scenarios, err = u.scenariorepo.GetScenariosByUserID(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, id := range ids {
+		scenario, err := u.GetScenarioByID(userID, id)
+		if err != nil {
+			return nil, err
+		}
+
+		scenarios = append(scenarios, *scenario)
+	}
+
+	now := time.Now()
+	active, err := utils.CalculatePlanSummary(user, now)
+	if err != nil {
+		return nil, err
+	}
+
+	active.Name = user.RetirementPlan.PlanName
+	active.Active = true
+	summaries := []entities.ScenarioSummary{*active}
+	for i := range scenarios {
+		summary, err := utils.CalculatePlanSummary(scenarioUser(&scenarios[i]), now)
+		if err != nil {
+			return nil, err
+		}
+
+		summary.ScenarioID = scenarios[i].ID
+		summary.Name = scenarios[i].Name
+		summaries = append(summaries, *summary)
+	}
+
+	return summaries, nil
+}
+
+func (u *ScenarioUseCaseImpl) PromoteScenario(userID, id string) (*entities.RetirementPlan, error) {
+	scenario, err := u.GetScenarioByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	nursingHouse, err := u.nhrepo.GetNhByID(scenario.NursingHouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	var promoted *entities.RetirementPlan
+	err = u.uow.Do(func(repos unitofwork.Repositories) error {
+		plan, err := repos.Retirements.GetRetirementByUserIDForUpdate(userID)
+		if err != nil {
+			return err
+		}
+
+		if plan.ID == "" {
+			return errors.New("retirement plan not found")
+		}
+
+		house, err := repos.Users.GetSelectedHouseForUpdate(userID)
+		if err != nil {
+			return err
+		}
+
+		if scenario.NursingHouseID == entities.DefaultNursingHouseID && house.NursingHouseID != entities.DefaultNursingHouseID && house.CurrentMoney > 0 {
+			return errors.New("house savings must be transferred before removing the nursing house")
+		}
+
+		plan.RetirementAge = scenario.RetirementAge
+		plan.ExpectLifespan = scenario.ExpectLifespan
+		plan.CurrentSavingsReturns = scenario.CurrentSavingsReturns
+		plan.InvestmentReturn = scenario.InvestmentReturn
+		plan.ExpectedMonthlyExpenses = scenario.ExpectedMonthlyExpenses
+		plan.ExpectedInflation = scenario.ExpectedInflation
+		plan.AnnualExpenseIncrease = scenario.AnnualExpenseIncrease
+		plan.AnnualSavingsReturn = scenario.AnnualSavingsReturn
+		plan.AnnualInvestmentReturn = scenario.AnnualInvestmentReturn
+
+		now := time.Now()
+		if err := utils.RecalculateRetirementPlan(plan, now); err != nil {
+			return err
+		}
+
+		if promoted, err = repos.Retirements.UpdateRetirementPlan(plan); err != nil {
+			return err
+		}
+
+		if err := repos.Retirements.AppendRevision(promoted); err != nil {
+			return err
+		}
+
+		house.NursingHouseID = nursingHouse.ID
+		house.NursingHouse = *nursingHouse
+		monthlyExpenses, err := utils.CalculateNursingHouseMonthlyExpense(&entities.User{RetirementPlan: *plan, House: *house}, nursingHouse.Price, now.Year(), int(now.Month()))
+		if err != nil {
+			return err
+		}
+
+		house.MonthlyExpenses = monthlyExpenses
+		house.LastCalculatedMonth = int(now.Month())
+		house.Status = entities.StatusInProgress
+		if house.NursingHouseID == entities.DefaultNursingHouseID || house.CurrentMoney >= nursingHouse.Price.Mul((plan.ExpectLifespan-plan.RetirementAge)*12) {
+			house.Status = entities.StatusCompleted
+		}
+
+		_, err = repos.Users.UpdateSelectedHouse(house)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return promoted, nil
+}
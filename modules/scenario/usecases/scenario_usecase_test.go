@@ -0,0 +1,334 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/scenario/usecases"
+	"github.com/XzerozZ/Kasian_Phrom_BE/testing/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type scenarioDeps struct {
+	scenarioRepo   *mocks.MockScenarioRepository
+	userRepo       *mocks.MockUserRepository
+	nhRepo         *mocks.MockNhRepository
+	retirementRepo *mocks.MockRetirementRepository
+	uow            *mocks.MockUnitOfWork
+	useCase        *usecases.ScenarioUseCaseImpl
+}
+
+func setupScenarioUseCase() *scenarioDeps {
+	deps := &scenarioDeps{
+		scenarioRepo:   new(mocks.MockScenarioRepository),
+		userRepo:       new(mocks.MockUserRepository),
+		nhRepo:         new(mocks.MockNhRepository),
+		retirementRepo: new(mocks.MockRetirementRepository),
+	}
+
+	deps.uow = mocks.NewMockUnitOfWork(deps.userRepo, new(mocks.MockAssetRepository), deps.retirementRepo, new(mocks.MockLedgerRepository))
+	deps.useCase = usecases.NewScenarioUseCase(deps.scenarioRepo, deps.userRepo, deps.nhRepo, deps.uow)
+	return deps
+}
+
+func scenarioTestPlan() entities.RetirementPlan {
+	return entities.RetirementPlan{
+		ID:                      "plan-1",
+		PlanName:                "My Plan",
+		BirthDate:               time.Now().AddDate(-30, 0, 0).Format("02-01-2006"),
+		RetirementAge:           60,
+		ExpectLifespan:          80,
+		CurrentSavings:          entities.Baht(500000),
+		CurrentSavingsReturns:   3,
+		CurrentTotalInvestment:  entities.Baht(1000000),
+		InvestmentReturn:        7,
+		ExpectedMonthlyExpenses: entities.Baht(40000),
+		ExpectedInflation:       2.5,
+		AnnualExpenseIncrease:   3,
+		AnnualSavingsReturn:     3,
+		AnnualInvestmentReturn:  7,
+		Status:                  "In_Progress",
+		UserID:                  "user-1",
+	}
+}
+
+func scenarioTestUser() *entities.User {
+	return &entities.User{
+		ID:             "user-1",
+		RetirementPlan: scenarioTestPlan(),
+		House: entities.SelectedHouse{
+			UserID:         "user-1",
+			NursingHouseID: "00002",
+			CurrentMoney:   entities.Baht(10000),
+			Status:         "In_Progress",
+			NursingHouse:   entities.NursingHouse{ID: "00002", Name: "Sunshine", Price: entities.Baht(15000)},
+		},
+		Assets: []entities.Asset{
+			{ID: "asset-1", Name: "Car", Type: "car", TotalCost: entities.Baht(500000), CurrentMoney: entities.Baht(100000), Status: "In_Progress", EndYear: "2035"},
+		},
+	}
+}
+
+func scenarioFromUser(user *entities.User) *entities.Scenario {
+	plan := user.RetirementPlan
+	return &entities.Scenario{
+		ID:                      "scenario-1",
+		UserID:                  user.ID,
+		Name:                    "Retire at 55",
+		BirthDate:               plan.BirthDate,
+		RetirementAge:           55,
+		ExpectLifespan:          plan.ExpectLifespan,
+		CurrentSavings:          plan.CurrentSavings,
+		CurrentSavingsReturns:   plan.CurrentSavingsReturns,
+		CurrentTotalInvestment:  plan.CurrentTotalInvestment,
+		InvestmentReturn:        plan.InvestmentReturn,
+		ExpectedMonthlyExpenses: plan.ExpectedMonthlyExpenses,
+		ExpectedInflation:       plan.ExpectedInflation,
+		AnnualExpenseIncrease:   plan.AnnualExpenseIncrease,
+		AnnualSavingsReturn:     plan.AnnualSavingsReturn,
+		AnnualInvestmentReturn:  plan.AnnualInvestmentReturn,
+		NursingHouseID:          "00003",
+		HouseCurrentMoney:       user.House.CurrentMoney,
+		NursingHouse:            entities.NursingHouse{ID: "00003", Name: "Riverside", Price: entities.Baht(20000)},
+	}
+}
+
+func TestCreateScenario(t *testing.T) {
+	t.Run("Clones Plan Assets And House", func(t *testing.T) {
+		deps := setupScenarioUseCase()
+		retirementAge, nhID := 55, "00003"
+		deps.userRepo.On("GetUserByID", "user-1").Return(scenarioTestUser(), nil).Once()
+		deps.nhRepo.On("GetNhByID", "00003").Return(&entities.NursingHouse{ID: "00003", Name: "Riverside", Price: entities.Baht(20000)}, nil).Once()
+		deps.scenarioRepo.On("CreateScenario", mock.MatchedBy(func(scenario *entities.Scenario) bool {
+			return scenario.UserID == "user-1" &&
+				scenario.Name == "My Plan" &&
+				scenario.RetirementAge == 55 &&
+				scenario.ExpectLifespan == 80 &&
+				scenario.CurrentSavings == entities.Baht(500000) &&
+				scenario.NursingHouseID == "00003" &&
+				scenario.NursingHouse.Price == entities.Baht(20000) &&
+				scenario.HouseCurrentMoney == entities.Baht(10000) &&
+				len(scenario.Assets) == 1 &&
+				scenario.Assets[0].AssetID == "asset-1" &&
+				scenario.Assets[0].ScenarioID == scenario.ID &&
+				scenario.Assets[0].CurrentMoney == entities.Baht(100000)
+		})).Return(&entities.Scenario{ID: "scenario-1"}, nil).Once()
+
+		scenario, err := deps.useCase.CreateScenario("user-1", entities.ScenarioRequest{RetirementAge: &retirementAge, NursingHouseID: &nhID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "scenario-1", scenario.ID)
+		deps.userRepo.AssertExpectations(t)
+		deps.nhRepo.AssertExpectations(t)
+		deps.scenarioRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Tweak", func(t *testing.T) {
+		deps := setupScenarioUseCase()
+		lifespan := 55
+		deps.userRepo.On("GetUserByID", "user-1").Return(scenarioTestUser(), nil).Once()
+
+		scenario, err := deps.useCase.CreateScenario("user-1", entities.ScenarioRequest{ExpectLifespan: &lifespan})
+
+		assert.Nil(t, scenario)
+		assert.EqualError(t, err, "retirementAge must be less than ExpectLifespan")
+		deps.scenarioRepo.AssertNotCalled(t, "CreateScenario", mock.Anything)
+	})
+
+	t.Run("Unknown Nursing House", func(t *testing.T) {
+		deps := setupScenarioUseCase()
+		nhID := "99999"
+		deps.userRepo.On("GetUserByID", "user-1").Return(scenarioTestUser(), nil).Once()
+		deps.nhRepo.On("GetNhByID", "99999").Return(nil, errors.New("record not found")).Once()
+
+		scenario, err := deps.useCase.CreateScenario("user-1", entities.ScenarioRequest{NursingHouseID: &nhID})
+
+		assert.Nil(t, scenario)
+		assert.EqualError(t, err, "nursing house not found")
+	})
+
+	t.Run("No Retirement Plan", func(t *testing.T) {
+		deps := setupScenarioUseCase()
+		user := scenarioTestUser()
+		user.RetirementPlan = entities.RetirementPlan{}
+		deps.userRepo.On("GetUserByID", "user-1").Return(user, nil).Once()
+
+		scenario, err := deps.useCase.CreateScenario("user-1", entities.ScenarioRequest{})
+
+		assert.Nil(t, scenario)
+		assert.EqualError(t, err, "retirement plan not found")
+	})
+}
+
+func TestGetScenarioByID(t *testing.T) {
+	t.Run("Other User", func(t *testing.T) {
+		deps := setupScenarioUseCase()
+		deps.scenarioRepo.On("GetScenarioByID", "scenario-1").Return(&entities.Scenario{ID: "scenario-1", UserID: "user-2"}, nil).Once()
+
+		scenario, err := deps.useCase.GetScenarioByID("user-1", "scenario-1")
+
+		assert.Nil(t, scenario)
+		assert.EqualError(t, err, "scenario not found")
+	})
+}
+
+func TestUpdateScenario(t *testing.T) {
+	t.Run("Applies Tweaks", func(t *testing.T) {
+		deps := setupScenarioUseCase()
+		name, inflation := "High inflation", 5.0
+		deps.scenarioRepo.On("GetScenarioByID", "scenario-1").Return(scenarioFromUser(scenarioTestUser()), nil).Once()
+		deps.scenarioRepo.On("UpdateScenario", mock.MatchedBy(func(scenario *entities.Scenario) bool {
+			return scenario.Name == "High inflation" && scenario.ExpectedInflation == 5 && scenario.RetirementAge == 55
+		})).Return(&entities.Scenario{ID: "scenario-1"}, nil).Once()
+
+		scenario, err := deps.useCase.UpdateScenario("user-1", "scenario-1", entities.ScenarioRequest{Name: &name, ExpectedInflation: &inflation})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "scenario-1", scenario.ID)
+		deps.scenarioRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Tweak", func(t *testing.T) {
+		deps := setupScenarioUseCase()
+		inflation := 0.0
+		deps.scenarioRepo.On("GetScenarioByID", "scenario-1").Return(scenarioFromUser(scenarioTestUser()), nil).Once()
+
+		scenario, err := deps.useCase.UpdateScenario("user-1", "scenario-1", entities.ScenarioRequest{ExpectedInflation: &inflation})
+
+		assert.Nil(t, scenario)
+		assert.EqualError(t, err, "expectedInflation must be greater than zero")
+	})
+}
+
+func TestDeleteScenario(t *testing.T) {
+	deps := setupScenarioUseCase()
+	deps.scenarioRepo.On("GetScenarioByID", "scenario-1").Return(scenarioFromUser(scenarioTestUser()), nil).Once()
+	deps.scenarioRepo.On("DeleteScenario", "scenario-1").Return(nil).Once()
+
+	err := deps.useCase.DeleteScenario("user-1", "scenario-1")
+
+	assert.NoError(t, err)
+	deps.scenarioRepo.AssertExpectations(t)
+}
+
+func TestCompareScenarios(t *testing.T) {
+	t.Run("Active Plan First", func(t *testing.T) {
+		deps := setupScenarioUseCase()
+		user := scenarioTestUser()
+		deps.userRepo.On("GetUserByID", "user-1").Return(user, nil).Once()
+		deps.scenarioRepo.On("GetScenarioByID", "scenario-1").Return(scenarioFromUser(user), nil).Once()
+
+		summaries, err := deps.useCase.CompareScenarios("user-1", []string{"scenario-1"})
+
+		assert.NoError(t, err)
+		assert.Len(t, summaries, 2)
+		assert.True(t, summaries[0].Active)
+		assert.Equal(t, "My Plan", summaries[0].Name)
+		assert.Equal(t, 60, summaries[0].RetirementAge)
+		assert.Equal(t, "00002", summaries[0].NursingHouseID)
+		assert.False(t, summaries[1].Active)
+		assert.Equal(t, "scenario-1", summaries[1].ScenarioID)
+		assert.Equal(t, 55, summaries[1].RetirementAge)
+		assert.Equal(t, entities.Baht(20000).Mul(25*12), summaries[1].NursingHouseCost)
+		assert.Greater(t, summaries[1].MonthlySaving, summaries[0].MonthlySaving)
+		deps.scenarioRepo.AssertNotCalled(t, "GetScenariosByUserID", mock.Anything)
+	})
+
+	t.Run("All Scenarios When No IDs", func(t *testing.T) {
+		deps := setupScenarioUseCase()
+		user := scenarioTestUser()
+		deps.userRepo.On("GetUserByID", "user-1").Return(user, nil).Once()
+		deps.scenarioRepo.On("GetScenariosByUserID", "user-1").Return([]entities.Scenario{*scenarioFromUser(user)}, nil).Once()
+
+		summaries, err := deps.useCase.CompareScenarios("user-1", nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, summaries, 2)
+		deps.scenarioRepo.AssertExpectations(t)
+	})
+
+	t.Run("Scenario Of Other User", func(t *testing.T) {
+		deps := setupScenarioUseCase()
+		deps.userRepo.On("GetUserByID", "user-1").Return(scenarioTestUser(), nil).Once()
+		deps.scenarioRepo.On("GetScenarioByID", "scenario-2").Return(&entities.Scenario{ID: "scenario-2", UserID: "user-2"}, nil).Once()
+
+		summaries, err := deps.useCase.CompareScenarios("user-1", []string{"scenario-2"})
+
+		assert.Nil(t, summaries)
+		assert.EqualError(t, err, "scenario not found")
+	})
+}
+
+func TestPromoteScenario(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		deps := setupScenarioUseCase()
+		user := scenarioTestUser()
+		plan := user.RetirementPlan
+		house := user.House
+		deps.scenarioRepo.On("GetScenarioByID", "scenario-1").Return(scenarioFromUser(user), nil).Once()
+		deps.nhRepo.On("GetNhByID", "00003").Return(&entities.NursingHouse{ID: "00003", Name: "Riverside", Price: entities.Baht(25000)}, nil).Once()
+		deps.retirementRepo.On("GetRetirementByUserIDForUpdate", "user-1").Return(&plan, nil).Once()
+		deps.userRepo.On("GetSelectedHouseForUpdate", "user-1").Return(&house, nil).Once()
+		deps.retirementRepo.On("UpdateRetirementPlan", mock.MatchedBy(func(updated *entities.RetirementPlan) bool {
+			return updated.RetirementAge == 55 &&
+				updated.PlanName == "My Plan" &&
+				updated.CurrentSavings == entities.Baht(500000) &&
+				updated.LastRequiredFunds > 0 &&
+				updated.LastMonthlyExpenses > 0 &&
+				updated.LastCalculatedMonth == int(time.Now().Month()) &&
+				updated.Status == "In_Progress"
+		})).Return(&plan, nil).Once()
+		deps.retirementRepo.On("AppendRevision", &plan).Return(nil).Once()
+		deps.userRepo.On("UpdateSelectedHouse", mock.MatchedBy(func(updated *entities.SelectedHouse) bool {
+			return updated.NursingHouseID == "00003" &&
+				updated.NursingHouse.Price == entities.Baht(25000) &&
+				updated.CurrentMoney == entities.Baht(10000) &&
+				updated.MonthlyExpenses > 0 &&
+				updated.Status == "In_Progress"
+		})).Return(&house, nil).Once()
+
+		promoted, err := deps.useCase.PromoteScenario("user-1", "scenario-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "plan-1", promoted.ID)
+		assert.Equal(t, 1, deps.uow.Committed)
+		deps.retirementRepo.AssertExpectations(t)
+		deps.userRepo.AssertExpectations(t)
+		deps.nhRepo.AssertExpectations(t)
+	})
+
+	t.Run("Removing House With Savings", func(t *testing.T) {
+		deps := setupScenarioUseCase()
+		user := scenarioTestUser()
+		plan := user.RetirementPlan
+		house := user.House
+		scenario := scenarioFromUser(user)
+		scenario.NursingHouseID = "00001"
+		scenario.NursingHouse = entities.NursingHouse{ID: "00001"}
+		deps.scenarioRepo.On("GetScenarioByID", "scenario-1").Return(scenario, nil).Once()
+		deps.nhRepo.On("GetNhByID", "00001").Return(&entities.NursingHouse{ID: "00001"}, nil).Once()
+		deps.retirementRepo.On("GetRetirementByUserIDForUpdate", "user-1").Return(&plan, nil).Once()
+		deps.userRepo.On("GetSelectedHouseForUpdate", "user-1").Return(&house, nil).Once()
+
+		promoted, err := deps.useCase.PromoteScenario("user-1", "scenario-1")
+
+		assert.Nil(t, promoted)
+		assert.EqualError(t, err, "house savings must be transferred before removing the nursing house")
+		assert.Equal(t, 1, deps.uow.RolledBack)
+		deps.retirementRepo.AssertNotCalled(t, "UpdateRetirementPlan", mock.Anything)
+	})
+
+	t.Run("Scenario Not Found", func(t *testing.T) {
+		deps := setupScenarioUseCase()
+		deps.scenarioRepo.On("GetScenarioByID", "scenario-1").Return(nil, errors.New("record not found")).Once()
+
+		promoted, err := deps.useCase.PromoteScenario("user-1", "scenario-1")
+
+		assert.Nil(t, promoted)
+		assert.EqualError(t, err, "scenario not found")
+		assert.Equal(t, 0, deps.uow.Committed+deps.uow.RolledBack)
+	})
+}
@@ -10,6 +10,9 @@ import (
 	favControllers "github.com/XzerozZ/Kasian_Phrom_BE/modules/favorite/controllers"
 	favRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/favorite/repositories"
 	favUseCases "github.com/XzerozZ/Kasian_Phrom_BE/modules/favorite/usecases"
+	ledgerControllers "github.com/XzerozZ/Kasian_Phrom_BE/modules/ledger/controllers"
+	ledgerRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/ledger/repositories"
+	ledgerUseCases "github.com/XzerozZ/Kasian_Phrom_BE/modules/ledger/usecases"
 	loanControllers "github.com/XzerozZ/Kasian_Phrom_BE/modules/loan/controllers"
 	loanRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/loan/repositories"
 	loanUseCases "github.com/XzerozZ/Kasian_Phrom_BE/modules/loan/usecases"
@@ -22,21 +25,35 @@ import (
 	nhControllers "github.com/XzerozZ/Kasian_Phrom_BE/modules/nursing_house/controllers"
 	nhRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/nursing_house/repositories"
 	nhUseCases "github.com/XzerozZ/Kasian_Phrom_BE/modules/nursing_house/usecases"
+	portfolioControllers "github.com/XzerozZ/Kasian_Phrom_BE/modules/portfolio/controllers"
+	portfolioRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/portfolio/repositories"
+	portfolioUseCases "github.com/XzerozZ/Kasian_Phrom_BE/modules/portfolio/usecases"
 	quizControllers "github.com/XzerozZ/Kasian_Phrom_BE/modules/quiz/controllers"
 	quizRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/quiz/repositories"
 	quizUseCases "github.com/XzerozZ/Kasian_Phrom_BE/modules/quiz/usecases"
 	retirementControllers "github.com/XzerozZ/Kasian_Phrom_BE/modules/retirement_plan/controllers"
 	retirementRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/retirement_plan/repositories"
 	retirementUseCases "github.com/XzerozZ/Kasian_Phrom_BE/modules/retirement_plan/usecases"
+	scenarioControllers "github.com/XzerozZ/Kasian_Phrom_BE/modules/scenario/controllers"
+	scenarioRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/scenario/repositories"
+	scenarioUseCases "github.com/XzerozZ/Kasian_Phrom_BE/modules/scenario/usecases"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/socket"
+	taxControllers "github.com/XzerozZ/Kasian_Phrom_BE/modules/tax/controllers"
+	taxRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/tax/repositories"
+	taxUseCases "github.com/XzerozZ/Kasian_Phrom_BE/modules/tax/usecases"
 	transControllers "github.com/XzerozZ/Kasian_Phrom_BE/modules/transaction/controllers"
 	transRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/transaction/repositories"
 	transUseCases "github.com/XzerozZ/Kasian_Phrom_BE/modules/transaction/usecases"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/unitofwork"
 	userControllers "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/controllers"
 	userRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/repositories"
 	userUseCases "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/usecases"
+	vehicleControllers "github.com/XzerozZ/Kasian_Phrom_BE/modules/vehicle/controllers"
+	vehicleRepositories "github.com/XzerozZ/Kasian_Phrom_BE/modules/vehicle/repositories"
+	vehicleUseCases "github.com/XzerozZ/Kasian_Phrom_BE/modules/vehicle/usecases"
 	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/database"
 	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/middlewares"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -56,15 +73,23 @@ func SetupRoutes(app *fiber.App, jwt configs.JWT, supa configs.Supabase, mail co
 		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
 	}))
 
-	setupNursingHouseRoutes(app, db, supa, recom, jwt)
-	SetupNewsRoutes(app, db, supa)
-	setupFavoriteRoutes(app, jwt, db)
-	setupAssetRoutes(app, jwt, db)
-	setupUserRoutes(app, db, jwt, supa, mail)
-	setupRetirementRoutes(app, jwt, db)
-	setupLoanRoutes(app, jwt, db)
-	setupQuizRoutes(app, jwt, db)
-	setupNotiRoutes(app, jwt, db)
+	auth := middlewares.JWTMiddleware(jwt, userRepositories.NewGormUserRepository(db))
+	admin := middlewares.RequireRole("Admin")
+	dispatcher := notiUseCases.NewNotiDispatcher(notiRepositories.NewGormNotiRepository(db), userRepositories.NewGormUserRepository(db), loanRepositories.NewGormLoanRepository(db), utils.NewSMTPMailer(mail))
+	setupNursingHouseRoutes(app, db, supa, recom, auth, admin)
+	SetupNewsRoutes(app, db, supa, auth, admin)
+	setupFavoriteRoutes(app, auth, db)
+	setupAssetRoutes(app, auth, db, dispatcher)
+	setupUserRoutes(app, db, jwt, auth, supa, mail, dispatcher)
+	setupRetirementRoutes(app, auth, db)
+	setupLoanRoutes(app, auth, admin, db, dispatcher)
+	setupQuizRoutes(app, auth, db)
+	setupNotiRoutes(app, auth, db, dispatcher)
+	setupLedgerRoutes(app, auth, admin, db)
+	setupScenarioRoutes(app, auth, db)
+	setupVehicleRoutes(app, auth, db)
+	setupTaxRoutes(app, auth, admin, db)
+	setupPortfolioRoutes(app, auth, admin, db, dispatcher)
 
 	app.Get("/", func(ctx *fiber.Ctx) error {
 		return ctx.JSON(fiber.Map{
@@ -73,30 +98,29 @@ func SetupRoutes(app *fiber.App, jwt configs.JWT, supa configs.Supabase, mail co
 		})
 	})
 
-	app.Get("/ws/:user_id", websocket.New(socket.WebSocketHandler))
+	app.Get("/ws", middlewares.TokenFromQuery("token"), auth, socket.UpgradeHandler, websocket.New(socket.NewWebSocketHandler(notiRepositories.NewGormNotiRepository(db), userRepositories.NewGormUserRepository(db))))
 }
 
-func SetupNewsRoutes(app *fiber.App, db *gorm.DB, supa configs.Supabase) {
+func SetupNewsRoutes(app *fiber.App, db *gorm.DB, supa configs.Supabase, auth, admin fiber.Handler) {
 	newsRepository := newsRepositories.NewGormNewsRepository(db)
 	newsUseCase := newsUseCases.NewNewsUseCase(newsRepository, supa)
 	newsController := newsControllers.NewNewsController(newsUseCase)
 
 	newsGroup := app.Group("/news")
-	newsGroup.Post("/", newsController.CreateNewsHandler)
+	newsGroup.Post("/", auth, admin, newsController.CreateNewsHandler)
 	newsGroup.Get("/", newsController.GetAllNewsHandler)
 	newsGroup.Get("/id", newsController.GetNewsNextIDHandler)
 	newsGroup.Get("/:id", newsController.GetNewsByIDHandler)
-	newsGroup.Put("/:id", newsController.UpdateNewsByIDHandler)
-	newsGroup.Delete("/:id", newsController.DeleteNewsByIDHandler)
+	newsGroup.Put("/:id", auth, admin, newsController.UpdateNewsByIDHandler)
+	newsGroup.Delete("/:id", auth, admin, newsController.DeleteNewsByIDHandler)
 }
 
-func setupUserRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, supa configs.Supabase, mail configs.Mail) {
+func setupUserRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, auth fiber.Handler, supa configs.Supabase, mail configs.Mail, dispatcher notiUseCases.NotiDispatcher) {
 	userRepository := userRepositories.NewGormUserRepository(db)
 	retirementRepository := retirementRepositories.NewGormRetirementRepository(db)
 	nhRepository := nhRepositories.NewGormNhRepository(db)
 	assetRepository := assetRepositories.NewGormAssetRepository(db)
-	notiRepository := notiRepositories.NewGormNotiRepository(db)
-	userUseCase := userUseCases.NewUserUseCase(userRepository, retirementRepository, assetRepository, notiRepository, nhRepository, jwt, supa, mail)
+	userUseCase := userUseCases.NewUserUseCase(userRepository, retirementRepository, assetRepository, dispatcher, nhRepository, loanRepositories.NewGormLoanRepository(db), jwt, supa, mail, unitofwork.NewGormUnitOfWork(db))
 	userController := userControllers.NewUserController(userUseCase)
 
 	authGroup := app.Group("/auth")
@@ -107,120 +131,229 @@ func setupUserRoutes(app *fiber.App, db *gorm.DB, jwt configs.JWT, supa configs.
 	authGroup.Post("/forgotpassword", userController.ForgotPasswordHandler)
 	authGroup.Post("/forgotpassword/otp", userController.VerifyOTPHandler)
 	authGroup.Put("/forgotpassword/changepassword", userController.ChangedPasswordHandler)
-	authGroup.Put("/resetpassword", middlewares.JWTMiddleware(jwt), userController.ResetPasswordHandler)
-	authGroup.Post("/logout", middlewares.JWTMiddleware(jwt), userController.LogoutHandler)
+	authGroup.Post("/refresh", userController.RefreshTokenHandler)
+	authGroup.Put("/resetpassword", auth, userController.ResetPasswordHandler)
+	authGroup.Post("/logout", auth, userController.LogoutHandler)
+	authGroup.Post("/logout/all", auth, userController.LogoutAllHandler)
 
 	userGroup := app.Group("/user")
-	userGroup.Get("/", middlewares.JWTMiddleware(jwt), userController.GetUserByIDHandler)
-	userGroup.Get("/plan", middlewares.JWTMiddleware(jwt), userController.GetRetirementPlanHandler)
-	userGroup.Get("/selected", middlewares.JWTMiddleware(jwt), userController.GetSelectedHouseHandler)
-	userGroup.Put("/", middlewares.JWTMiddleware(jwt), userController.UpdateUserByIDHandler)
-	userGroup.Put("/:nh_id", middlewares.JWTMiddleware(jwt), userController.UpdateSelectedHouseHandler)
+	userGroup.Get("/", auth, userController.GetUserByIDHandler)
+	userGroup.Get("/plan", auth, userController.GetRetirementPlanHandler)
+	userGroup.Get("/selected", auth, userController.GetSelectedHouseHandler)
+	userGroup.Get("/readiness", auth, userController.GetReadinessReportHandler)
+	userGroup.Put("/", auth, userController.UpdateUserByIDHandler)
+	userGroup.Put("/:nh_id", auth, userController.UpdateSelectedHouseHandler)
 
 	historyGroup := app.Group("/history")
-	historyGroup.Post("/", middlewares.JWTMiddleware(jwt), userController.CreateHistoryHandler)
-	historyGroup.Get("/", middlewares.JWTMiddleware(jwt), userController.GetHistoryByUserIDHandler)
-	historyGroup.Get("/summary", middlewares.JWTMiddleware(jwt), userController.GetSummaryHistoryByUserIDHandler)
+	historyGroup.Post("/", auth, userController.CreateHistoryHandler)
+	historyGroup.Get("/", auth, userController.GetHistoryByUserIDHandler)
+	historyGroup.Get("/summary", auth, userController.GetSummaryHistoryByUserIDHandler)
 }
 
-func setupNursingHouseRoutes(app *fiber.App, db *gorm.DB, supa configs.Supabase, recom configs.Recommend, jwt configs.JWT) {
+func setupNursingHouseRoutes(app *fiber.App, db *gorm.DB, supa configs.Supabase, recom configs.Recommend, auth, admin fiber.Handler) {
 	nhRepository := nhRepositories.NewGormNhRepository(db)
 	nhUseCase := nhUseCases.NewNhUseCase(nhRepository, supa, recom)
 	nhController := nhControllers.NewNhController(nhUseCase)
 
 	nhGroup := app.Group("/nursinghouses")
-	nhGroup.Post("/", nhController.CreateNhHandler)
-	nhGroup.Post("/mock", nhController.CreateNhMockHandler)
+	nhGroup.Post("/", auth, admin, nhController.CreateNhHandler)
+	nhGroup.Post("/mock", auth, admin, nhController.CreateNhMockHandler)
 	nhGroup.Get("/", nhController.GetAllNhHandler)
 	nhGroup.Get("/active", nhController.GetAllActiveNhHandler)
 	nhGroup.Get("/inactive", nhController.GetAllInactiveNhHandler)
 	nhGroup.Get("/id", nhController.GetNhNextIDHandler)
 	nhGroup.Get("/:id", nhController.GetNhByIDHandler)
-	nhGroup.Put("/:id", nhController.UpdateNhByIDHandler)
+	nhGroup.Put("/:id", auth, admin, nhController.UpdateNhByIDHandler)
 
-	nhGroup.Get("/user/:id", middlewares.JWTMiddleware(jwt), nhController.GetNhByIDForUserHandler)
-	nhGroup.Get("/recommend/cosine", middlewares.JWTMiddleware(jwt), nhController.GetRecommendCosine)
-	nhGroup.Get("/recommend/llm", middlewares.JWTMiddleware(jwt), nhController.GetRecommendLLM)
+	nhGroup.Get("/user/:id", auth, nhController.GetNhByIDForUserHandler)
+	nhGroup.Get("/recommend/cosine", auth, nhController.GetRecommendCosine)
+	nhGroup.Get("/recommend/llm", auth, nhController.GetRecommendLLM)
 }
 
-func setupFavoriteRoutes(app *fiber.App, jwt configs.JWT, db *gorm.DB) {
+func setupFavoriteRoutes(app *fiber.App, auth fiber.Handler, db *gorm.DB) {
 	favRepository := favRepositories.NewGormFavRepository(db)
 	favUseCase := favUseCases.NewFavUseCase(favRepository)
 	favController := favControllers.NewFavController(favUseCase)
 
 	favGroup := app.Group("/favorite")
-	favGroup.Post("/", middlewares.JWTMiddleware(jwt), favController.CreateFavHandler)
-	favGroup.Get("/", middlewares.JWTMiddleware(jwt), favController.GetFavByUserIDHandler)
-	favGroup.Get("/:nh_id", middlewares.JWTMiddleware(jwt), favController.CheckFavHandler)
-	favGroup.Delete("/:nh_id", middlewares.JWTMiddleware(jwt), favController.DeleteFavByIDHandler)
+	favGroup.Post("/", auth, favController.CreateFavHandler)
+	favGroup.Get("/", auth, favController.GetFavByUserIDHandler)
+	favGroup.Get("/:nh_id", auth, favController.CheckFavHandler)
+	favGroup.Delete("/:nh_id", auth, favController.DeleteFavByIDHandler)
 }
 
-func setupAssetRoutes(app *fiber.App, jwt configs.JWT, db *gorm.DB) {
+func setupAssetRoutes(app *fiber.App, auth fiber.Handler, db *gorm.DB, dispatcher notiUseCases.NotiDispatcher) {
 	assetRepository := assetRepositories.NewGormAssetRepository(db)
 	userRepository := userRepositories.NewGormUserRepository(db)
 	retirementRepository := retirementRepositories.NewGormRetirementRepository(db)
 	nhRepository := nhRepositories.NewGormNhRepository(db)
-	notiRepository := notiRepositories.NewGormNotiRepository(db)
-	assetUseCase := assetUseCases.NewAssetUseCase(assetRepository, userRepository, nhRepository, retirementRepository, notiRepository)
+	assetUseCase := assetUseCases.NewAssetUseCase(assetRepository, userRepository, nhRepository, retirementRepository, dispatcher, unitofwork.NewGormUnitOfWork(db))
 	assetController := assetControllers.NewAssetController(assetUseCase)
 
 	assetGroup := app.Group("/asset")
-	assetGroup.Post("/", middlewares.JWTMiddleware(jwt), assetController.CreateAssetHandler)
-	assetGroup.Get("/:id", assetController.GetAssetByIDHandler)
-	assetGroup.Get("/", middlewares.JWTMiddleware(jwt), assetController.GetAssetByUserIDHandler)
-	assetGroup.Put("/:id", middlewares.JWTMiddleware(jwt), assetController.UpdateAssetByIDHandler)
-	assetGroup.Delete("/:id", middlewares.JWTMiddleware(jwt), assetController.DeleteAssetByIDHandler)
+	assetGroup.Post("/", auth, assetController.CreateAssetHandler)
+	assetGroup.Get("/:id", auth, assetController.GetAssetByIDHandler)
+	assetGroup.Get("/", auth, assetController.GetAssetByUserIDHandler)
+	assetGroup.Put("/:id", auth, assetController.UpdateAssetByIDHandler)
+	assetGroup.Delete("/:id", auth, assetController.DeleteAssetByIDHandler)
 }
 
-func setupRetirementRoutes(app *fiber.App, jwt configs.JWT, db *gorm.DB) {
+func setupRetirementRoutes(app *fiber.App, auth fiber.Handler, db *gorm.DB) {
 	retirementRepository := retirementRepositories.NewGormRetirementRepository(db)
-	retirementUseCase := retirementUseCases.NewRetirementUseCase(retirementRepository)
+	quizRepository := quizRepositories.NewGormQuizRepository(db)
+	retirementUseCase := retirementUseCases.NewRetirementUseCase(retirementRepository, userRepositories.NewGormUserRepository(db), quizRepository, unitofwork.NewGormUnitOfWork(db))
 	retirementController := retirementControllers.NewRetirementController(retirementUseCase)
 
 	retirementGroup := app.Group("/retirement")
-	retirementGroup.Post("/", middlewares.JWTMiddleware(jwt), retirementController.CreateRetirementHandler)
-	retirementGroup.Get("/", middlewares.JWTMiddleware(jwt), retirementController.GetRetirementByUserIDHandler)
-	retirementGroup.Put("/", middlewares.JWTMiddleware(jwt), retirementController.UpdateRetirementHandler)
+	retirementGroup.Post("/", auth, retirementController.CreateRetirementHandler)
+	retirementGroup.Get("/", auth, retirementController.GetRetirementByUserIDHandler)
+	retirementGroup.Put("/", auth, retirementController.UpdateRetirementHandler)
+	retirementGroup.Get("/projection", auth, retirementController.GetProjectionHandler)
+	retirementGroup.Get("/simulation", auth, retirementController.SimulateRetirementHandler)
+	retirementGroup.Get("/decumulation", auth, retirementController.PlanDecumulationHandler)
+	retirementGroup.Get("/plans", auth, retirementController.GetRetirementPlansHandler)
+	retirementGroup.Get("/plans/:id", auth, retirementController.GetRetirementPlanByIDHandler)
+	retirementGroup.Put("/plans/:id", auth, retirementController.UpdateRetirementPlanHandler)
+	retirementGroup.Post("/plans/:id/activate", auth, retirementController.ActivateRetirementPlanHandler)
+	retirementGroup.Get("/plans/:id/revisions", auth, retirementController.GetRetirementPlanRevisionsHandler)
 }
 
-func setupLoanRoutes(app *fiber.App, jwt configs.JWT, db *gorm.DB) {
+func setupLoanRoutes(app *fiber.App, auth, admin fiber.Handler, db *gorm.DB, dispatcher notiUseCases.NotiDispatcher) {
 	loanRepository := loanRepositories.NewGormLoanRepository(db)
 	transRepository := transRepositories.NewGormTransRepository(db)
-	notiRepository := notiRepositories.NewGormNotiRepository(db)
-	loanUseCase := loanUseCases.NewLoanUseCase(loanRepository, transRepository)
-	transUseCase := transUseCases.NewTransactionUseCase(transRepository, loanRepository, notiRepository)
+	loanUseCase := loanUseCases.NewLoanUseCase(loanRepository, transRepository, unitofwork.NewGormUnitOfWork(db))
+	transUseCase := transUseCases.NewTransactionUseCase(transRepository, loanRepository, userRepositories.NewGormUserRepository(db), dispatcher, unitofwork.NewGormUnitOfWork(db))
 	loanController := loanControllers.NewLoanController(loanUseCase, transUseCase)
 	transController := transControllers.NewTransactionController(transUseCase)
 
 	loanGroup := app.Group("/loan")
-	loanGroup.Post("/", middlewares.JWTMiddleware(jwt), loanController.CreateLoanHandler)
-	loanGroup.Get("/:id", loanController.GetLoanByIDHandler)
-	loanGroup.Get("/", middlewares.JWTMiddleware(jwt), loanController.GetLoanByUserIDHandler)
-	loanGroup.Put("/:id/status", middlewares.JWTMiddleware(jwt), loanController.UpdateLoanStatusByIDHandler)
-	loanGroup.Delete("/:id", middlewares.JWTMiddleware(jwt), loanController.DeleteLoanHandler)
+	loanGroup.Post("/", auth, loanController.CreateLoanHandler)
+	loanGroup.Get("/payoff", auth, loanController.PlanDebtPayoffHandler)
+	loanGroup.Get("/:id", auth, loanController.GetLoanByIDHandler)
+	loanGroup.Get("/:id/schedule", auth, loanController.GetLoanScheduleHandler)
+	loanGroup.Get("/:id/payments", auth, loanController.GetLoanPaymentsHandler)
+	loanGroup.Get("/:id/transactions", auth, loanController.GetLoanTransactionsHandler)
+	loanGroup.Post("/:id/prepayments", auth, loanController.PrepayLoanHandler)
+	loanGroup.Post("/:id/refinance", auth, loanController.RefinanceLoanHandler)
+	loanGroup.Put("/:id/rate", auth, loanController.ChangeLoanRateHandler)
+	loanGroup.Get("/", auth, loanController.GetLoanByUserIDHandler)
+	loanGroup.Put("/:id/status", auth, loanController.UpdateLoanStatusByIDHandler)
+	loanGroup.Delete("/:id", auth, loanController.DeleteLoanHandler)
 
 	transGroup := app.Group("/transaction")
-	transGroup.Post("/all", transController.CreateTransactionsForAllUsersHandler)
-	transGroup.Get("/", middlewares.JWTMiddleware(jwt), transController.GetTransactionByUserIDHandler)
-	transGroup.Put("/:id", middlewares.JWTMiddleware(jwt), transController.MarkTransactiontoPaidHandler)
+	transGroup.Post("/all", auth, admin, transController.CreateTransactionsForAllUsersHandler)
+	transGroup.Get("/", auth, transController.GetTransactionByUserIDHandler)
+	transGroup.Get("/stats", auth, transController.GetPaymentStatsHandler)
+	transGroup.Put("/:id", auth, transController.MarkTransactiontoPaidHandler)
+	transGroup.Post("/:id/payments", auth, transController.PayTransactionHandler)
+
+	utils.ScheduleJob("0 0 1 * *", "Monthly transaction job", transUseCase.CreateTransactionsForAllUsers)
 }
 
-func setupQuizRoutes(app *fiber.App, jwt configs.JWT, db *gorm.DB) {
+func setupQuizRoutes(app *fiber.App, auth fiber.Handler, db *gorm.DB) {
 	quizRepository := quizRepositories.NewGormQuizRepository(db)
 	quizUseCase := quizUseCases.NewQuizUseCase(quizRepository)
 	quizController := quizControllers.NewQuizController(quizUseCase)
 
 	quizGroup := app.Group("/quiz")
-	quizGroup.Post("/", middlewares.JWTMiddleware(jwt), quizController.CreateQuizHandler)
-	quizGroup.Get("/", middlewares.JWTMiddleware(jwt), quizController.GetQuizByUserIDHandler)
+	quizGroup.Post("/", auth, quizController.CreateQuizHandler)
+	quizGroup.Get("/", auth, quizController.GetQuizByUserIDHandler)
 }
 
-func setupNotiRoutes(app *fiber.App, jwt configs.JWT, db *gorm.DB) {
+func setupNotiRoutes(app *fiber.App, auth fiber.Handler, db *gorm.DB, dispatcher notiUseCases.NotiDispatcher) {
 	notiRepository := notiRepositories.NewGormNotiRepository(db)
-	notiUseCase := notiUseCases.NewNotiUseCase(notiRepository)
+	userRepository := userRepositories.NewGormUserRepository(db)
+	notiUseCase := notiUseCases.NewNotiUseCase(notiRepository, userRepository)
 	notiController := notiControllers.NewNotiController(notiUseCase)
 
-	quizGroup := app.Group("/notification")
-	quizGroup.Get("/", middlewares.JWTMiddleware(jwt), notiController.GetNotificationsByUserIDHandler)
-	quizGroup.Put("/", middlewares.JWTMiddleware(jwt), notiController.MarkAsReadHandler)
+	notiGroup := app.Group("/notification")
+	notiGroup.Get("/", auth, notiController.GetNotificationsByUserIDHandler)
+	notiGroup.Get("/unread", auth, notiController.CountUnreadHandler)
+	notiGroup.Get("/preferences", auth, notiController.GetPreferenceHandler)
+	notiGroup.Put("/preferences", auth, notiController.UpdatePreferenceHandler)
+	notiGroup.Put("/", auth, notiController.MarkAsReadHandler)
+	notiGroup.Put("/read", auth, notiController.MarkAsReadByIDsHandler)
+	notiGroup.Put("/:id/read", auth, notiController.MarkAsReadByIDHandler)
+	notiGroup.Put("/:id/archive", auth, notiController.ArchiveHandler)
+	notiGroup.Delete("/:id", auth, notiController.DeleteHandler)
+
+	utils.ScheduleJob("0 9 * * 1", "Weekly digest job", dispatcher.SendWeeklyDigest)
+	utils.ScheduleJob("*/15 * * * *", "Queued email job", dispatcher.SendQueuedEmails)
+}
+
+func setupLedgerRoutes(app *fiber.App, auth, admin fiber.Handler, db *gorm.DB) {
+	ledgerRepository := ledgerRepositories.NewGormLedgerRepository(db)
+	ledgerUseCase := ledgerUseCases.NewLedgerUseCase(ledgerRepository, unitofwork.NewGormUnitOfWork(db))
+	ledgerController := ledgerControllers.NewLedgerController(ledgerUseCase)
+
+	ledgerGroup := app.Group("/ledger")
+	ledgerGroup.Get("/accounts", auth, ledgerController.GetBalancesHandler)
+	ledgerGroup.Get("/accounts/:id", auth, ledgerController.GetStatementHandler)
+	ledgerGroup.Get("/reconcile", auth, ledgerController.ReconcileHandler)
+	ledgerGroup.Post("/users/:id/adjustments", auth, admin, ledgerController.AdjustBalancesHandler)
+}
+
+func setupScenarioRoutes(app *fiber.App, auth fiber.Handler, db *gorm.DB) {
+	scenarioRepository := scenarioRepositories.NewGormScenarioRepository(db)
+	userRepository := userRepositories.NewGormUserRepository(db)
+	nhRepository := nhRepositories.NewGormNhRepository(db)
+	scenarioUseCase := scenarioUseCases.NewScenarioUseCase(scenarioRepository, userRepository, nhRepository, unitofwork.NewGormUnitOfWork(db))
+	scenarioController := scenarioControllers.NewScenarioController(scenarioUseCase)
+
+	scenarioGroup := app.Group("/scenario")
+	scenarioGroup.Post("/", auth, scenarioController.CreateScenarioHandler)
+	scenarioGroup.Get("/", auth, scenarioController.GetScenariosHandler)
+	scenarioGroup.Get("/compare", auth, scenarioController.CompareScenariosHandler)
+	scenarioGroup.Get("/:id", auth, scenarioController.GetScenarioByIDHandler)
+	scenarioGroup.Put("/:id", auth, scenarioController.UpdateScenarioHandler)
+	scenarioGroup.Delete("/:id", auth, scenarioController.DeleteScenarioHandler)
+	scenarioGroup.Post("/:id/promote", auth, scenarioController.PromoteScenarioHandler)
+}
+
+func setupVehicleRoutes(app *fiber.App, auth fiber.Handler, db *gorm.DB) {
+	vehicleRepository := vehicleRepositories.NewGormVehicleRepository(db)
+	userRepository := userRepositories.NewGormUserRepository(db)
+	vehicleUseCase := vehicleUseCases.NewVehicleUseCase(vehicleRepository, userRepository)
+	vehicleController := vehicleControllers.NewVehicleController(vehicleUseCase)
+
+	vehicleGroup := app.Group("/vehicle")
+	vehicleGroup.Get("/rules", vehicleController.GetRulesHandler)
+	vehicleGroup.Post("/", auth, vehicleController.CreateVehicleHandler)
+	vehicleGroup.Get("/", auth, vehicleController.GetVehiclesHandler)
+	vehicleGroup.Get("/:id", auth, vehicleController.GetVehicleByIDHandler)
+	vehicleGroup.Put("/:id", auth, vehicleController.UpdateVehicleHandler)
+	vehicleGroup.Delete("/:id", auth, vehicleController.DeleteVehicleHandler)
+}
+
+func setupTaxRoutes(app *fiber.App, auth, admin fiber.Handler, db *gorm.DB) {
+	taxRepository := taxRepositories.NewGormTaxRepository(db)
+	userRepository := userRepositories.NewGormUserRepository(db)
+	taxUseCase := taxUseCases.NewTaxUseCase(taxRepository, userRepository)
+	taxController := taxControllers.NewTaxController(taxUseCase)
+
+	taxGroup := app.Group("/tax")
+	taxGroup.Get("/tables", taxController.GetTaxTablesHandler)
+	taxGroup.Post("/tables", auth, admin, taxController.CreateTaxTableHandler)
+	taxGroup.Get("/deductions", auth, taxController.GetDeductionsHandler)
+	taxGroup.Put("/deductions", auth, taxController.UpdateDeductionsHandler)
+	taxGroup.Get("/estimate", auth, taxController.GetEstimateHandler)
+}
+
+func setupPortfolioRoutes(app *fiber.App, auth, admin fiber.Handler, db *gorm.DB, dispatcher notiUseCases.NotiDispatcher) {
+	portfolioRepository := portfolioRepositories.NewGormPortfolioRepository(db)
+	quizRepository := quizRepositories.NewGormQuizRepository(db)
+	userRepository := userRepositories.NewGormUserRepository(db)
+	portfolioUseCase := portfolioUseCases.NewPortfolioUseCase(portfolioRepository, quizRepository, userRepository, dispatcher, unitofwork.NewGormUnitOfWork(db))
+	portfolioController := portfolioControllers.NewPortfolioController(portfolioUseCase)
+
+	portfolioGroup := app.Group("/portfolio")
+	portfolioGroup.Get("/", auth, portfolioController.GetPortfolioHandler)
+	portfolioGroup.Get("/history", auth, portfolioController.GetValuationHistoryHandler)
+	portfolioGroup.Post("/prices", auth, admin, portfolioController.ImportPricesHandler)
+	portfolioGroup.Post("/holdings", auth, portfolioController.CreateHoldingHandler)
+	portfolioGroup.Get("/holdings/:id", auth, portfolioController.GetHoldingByIDHandler)
+	portfolioGroup.Put("/holdings/:id", auth, portfolioController.UpdateHoldingHandler)
+	portfolioGroup.Delete("/holdings/:id", auth, portfolioController.DeleteHoldingHandler)
+
+	utils.ScheduleJob("0 0 * * *", "Daily portfolio valuation job", portfolioUseCase.SnapshotPortfolios)
 }
@@ -3,53 +3,159 @@ package socket
 import (
 	"encoding/json"
 	"log"
-	"sync"
+	"time"
 
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	notiRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/notification/repositories"
+	userRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/websocket/v2"
 )
 
-var (
-	clients sync.Map
-)
+var hub = NewHub()
 
-func WebSocketHandler(c *websocket.Conn) {
-	userID := c.Params("user_id")
-	if userID == "" {
-		log.Println("Missing user ID")
-		c.Close()
-		return
+type clientMessage struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+func UpgradeHandler(ctx *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(ctx) {
+		return fiber.ErrUpgradeRequired
 	}
 
-	clients.Store(userID, c)
-	log.Printf("User %s connected", userID)
+	return ctx.Next()
+}
+
+func NewWebSocketHandler(notirepo notiRepo.NotiRepository, userrepo userRepo.UserRepository) func(*websocket.Conn) {
+	return func(c *websocket.Conn) {
+		userID, ok := c.Locals("user_id").(string)
+		if !ok || userID == "" {
+			log.Println("Missing user ID")
+			c.Close()
+			return
+		}
+
+		client := newClient(userID, c)
+		if !hub.Register(client) {
+			client.close()
+			return
+		}
 
-	defer func() {
-		clients.Delete(userID)
-		c.Close()
-		log.Printf("User %s disconnected", userID)
-	}()
+		log.Printf("User %s connected", userID)
+		defer func() {
+			hub.Unregister(client)
+			log.Printf("User %s disconnected", userID)
+		}()
 
-	for {
-		_, msg, err := c.ReadMessage()
+		go client.ping()
+
+		if err := replayNotifications(client, notirepo, userrepo, c.Query("since")); err != nil {
+			log.Printf("Error replaying notifications to %s: %v", userID, err)
+		}
+
+		c.SetReadDeadline(time.Now().Add(pongWait))
+		c.SetPongHandler(func(string) error {
+			return c.SetReadDeadline(time.Now().Add(pongWait))
+		})
+
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+					log.Printf("Error reading message from %s: %v", userID, err)
+				}
+				break
+			}
+
+			var message clientMessage
+			if err := json.Unmarshal(msg, &message); err != nil {
+				log.Printf("Received from %s: %s", userID, string(msg))
+				continue
+			}
+
+			switch message.Type {
+			case "ack":
+				if message.ID == "" {
+					continue
+				}
+
+				if err := notirepo.MarkNotificationAsReadByID(message.ID, userID); err != nil {
+					log.Printf("Error acknowledging notification %s for %s: %v", message.ID, userID, err)
+				}
+			default:
+				log.Printf("Received from %s: %s", userID, string(msg))
+			}
+		}
+	}
+}
+
+func replayNotifications(client *Client, notirepo notiRepo.NotiRepository, userrepo userRepo.UserRepository, cursor string) error {
+	var since time.Time
+	if cursor != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, cursor)
+		if err != nil {
+			log.Printf("Invalid notification cursor from %s: %s", client.userID, cursor)
+		} else {
+			since = parsed
+		}
+	}
+
+	notifications, err := notirepo.GetUnreadNotificationsSince(client.userID, since)
+	if err != nil {
+		return err
+	}
+
+	locale := ""
+	for _, noti := range notifications {
+		if noti.TemplateKey != "" {
+			if locale == "" {
+				locale = utils.DefaultLocale
+				if user, err := userrepo.GetUserByID(client.userID); err == nil && user.Locale != "" {
+					locale = user.Locale
+				}
+			}
+
+			utils.LocalizeNotification(&noti, locale)
+		}
+
+		notiJSON, err := json.Marshal(noti)
 		if err != nil {
-			log.Printf("Error reading message from %s: %v", userID, err)
-			break
+			return err
+		}
+
+		if err := client.write(websocket.TextMessage, notiJSON); err != nil {
+			return err
 		}
-		log.Printf("Received from %s: %s", userID, string(msg))
 	}
+
+	return nil
 }
 
-func SendNotificationToUser(userID string, noti entities.Notification) {
-	client, exists := clients.Load(userID)
-	if !exists {
-		log.Printf("User %s not connected", userID)
-		return
+func (c *Client) ping() {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.write(websocket.PingMessage, nil); err != nil {
+				log.Printf("Error sending ping to %s: %v", c.userID, err)
+				hub.Unregister(c)
+				return
+			}
+		case <-c.done:
+			return
+		}
 	}
+}
 
-	conn, ok := client.(*websocket.Conn)
-	if !ok {
-		log.Printf("Invalid WebSocket connection for user %s", userID)
+func SendNotificationToUser(userID string, noti entities.Notification) {
+	clients := hub.Clients(userID)
+	if len(clients) == 0 {
+		log.Printf("User %s not connected", userID)
 		return
 	}
 
@@ -59,10 +165,14 @@ func SendNotificationToUser(userID string, noti entities.Notification) {
 		return
 	}
 
-	err = conn.WriteMessage(websocket.TextMessage, notiJSON)
-	if err != nil {
-		log.Printf("Error sending message to %s: %v", userID, err)
-		conn.Close()
-		clients.Delete(userID)
+	for _, client := range clients {
+		if err := client.write(websocket.TextMessage, notiJSON); err != nil {
+			log.Printf("Error sending message to %s: %v", userID, err)
+			hub.Unregister(client)
+		}
 	}
 }
+
+func Shutdown() {
+	hub.Shutdown()
+}
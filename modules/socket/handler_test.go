@@ -0,0 +1,109 @@
+package socket_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/socket"
+	"github.com/XzerozZ/Kasian_Phrom_BE/testing/repositories/mocks"
+
+	fasthttpws "github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func startServer(t *testing.T, notiRepo *mocks.MockNotiRepository) string {
+	app := fiber.New()
+	app.Get("/ws", func(c *fiber.Ctx) error {
+		c.Locals("user_id", c.Query("user"))
+		return c.Next()
+	}, socket.UpgradeHandler, websocket.New(socket.NewWebSocketHandler(notiRepo, new(mocks.MockUserRepository))))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go app.Listener(ln)
+	t.Cleanup(func() { app.Shutdown() })
+
+	return "ws://" + ln.Addr().String() + "/ws"
+}
+
+func dial(t *testing.T, url string) *fasthttpws.Conn {
+	conn, _, err := fasthttpws.DefaultDialer.Dial(url, nil)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestWebSocketHandler(t *testing.T) {
+	notiRepo := new(mocks.MockNotiRepository)
+	url := startServer(t, notiRepo)
+
+	t.Run("Fan-out to every connection of the user", func(t *testing.T) {
+		notiRepo.On("GetUnreadNotificationsSince", mock.Anything, time.Time{}).Return([]entities.Notification{}, nil)
+
+		first := dial(t, url+"?user=user1")
+		second := dial(t, url+"?user=user1")
+		other := dial(t, url+"?user=user2")
+		time.Sleep(50 * time.Millisecond)
+
+		socket.SendNotificationToUser("user1", entities.Notification{ID: "noti1", UserID: "user1", Message: "hello"})
+
+		for _, conn := range []*fasthttpws.Conn{first, second} {
+			conn.SetReadDeadline(time.Now().Add(time.Second))
+			_, msg, err := conn.ReadMessage()
+			assert.NoError(t, err)
+			assert.Contains(t, string(msg), "noti1")
+		}
+
+		other.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		_, _, err := other.ReadMessage()
+		assert.Error(t, err)
+	})
+
+	t.Run("Replay unread notifications since cursor", func(t *testing.T) {
+		since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		notiRepo.On("GetUnreadNotificationsSince", "user4", since).Return([]entities.Notification{
+			{ID: "noti2", UserID: "user4", Message: "first"},
+			{ID: "noti3", UserID: "user4", Message: "second"},
+		}, nil).Once()
+
+		conn := dial(t, url+"?user=user4&since="+since.Format(time.RFC3339Nano))
+		for _, id := range []string{"noti2", "noti3"} {
+			conn.SetReadDeadline(time.Now().Add(time.Second))
+			_, msg, err := conn.ReadMessage()
+			assert.NoError(t, err)
+			assert.Contains(t, string(msg), id)
+		}
+	})
+
+	t.Run("Ack marks notification as read", func(t *testing.T) {
+		acked := make(chan struct{})
+		notiRepo.On("MarkNotificationAsReadByID", "noti4", "user5").Return(nil).Once().Run(func(mock.Arguments) {
+			close(acked)
+		})
+
+		conn := dial(t, url+"?user=user5")
+		assert.NoError(t, conn.WriteMessage(fasthttpws.TextMessage, []byte(`{"type":"ack","id":"noti4"}`)))
+
+		select {
+		case <-acked:
+		case <-time.After(time.Second):
+			t.Fatal("ack was not processed")
+		}
+	})
+
+	t.Run("Shutdown closes every connection", func(t *testing.T) {
+		conn := dial(t, url+"?user=user3")
+		time.Sleep(50 * time.Millisecond)
+
+		socket.Shutdown()
+
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		_, _, err := conn.ReadMessage()
+		assert.True(t, fasthttpws.IsCloseError(err, fasthttpws.CloseGoingAway))
+	})
+}
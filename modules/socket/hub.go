@@ -0,0 +1,124 @@
+package socket
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gofiber/websocket/v2"
+)
+
+const (
+	writeWait  = 10 * time.Second
+	pongWait   = 60 * time.Second
+	pingPeriod = (pongWait * 9) / 10
+)
+
+type Client struct {
+	userID string
+	conn   *websocket.Conn
+	mu     sync.Mutex
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newClient(userID string, conn *websocket.Conn) *Client {
+	return &Client{
+		userID: userID,
+		conn:   conn,
+		done:   make(chan struct{}),
+	}
+}
+
+func (c *Client) write(messageType int, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+		return err
+	}
+
+	return c.conn.WriteMessage(messageType, data)
+}
+
+func (c *Client) close() {
+	c.once.Do(func() {
+		close(c.done)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(writeWait))
+		_ = c.conn.Close()
+	})
+}
+
+type Hub struct {
+	mu      sync.RWMutex
+	clients map[string]map[*Client]struct{}
+	closed  bool
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		clients: make(map[string]map[*Client]struct{}),
+	}
+}
+
+func (h *Hub) Register(client *Client) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return false
+	}
+
+	if _, ok := h.clients[client.userID]; !ok {
+		h.clients[client.userID] = make(map[*Client]struct{})
+	}
+
+	h.clients[client.userID][client] = struct{}{}
+	return true
+}
+
+func (h *Hub) Unregister(client *Client) {
+	h.mu.Lock()
+	if conns, ok := h.clients[client.userID]; ok {
+		delete(conns, client)
+		if len(conns) == 0 {
+			delete(h.clients, client.userID)
+		}
+	}
+	h.mu.Unlock()
+
+	client.close()
+}
+
+func (h *Hub) Clients(userID string) []*Client {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	clients := make([]*Client, 0, len(h.clients[userID]))
+	for client := range h.clients[userID] {
+		clients = append(clients, client)
+	}
+
+	return clients
+}
+
+func (h *Hub) IsConnected(userID string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients[userID]) > 0
+}
+
+func (h *Hub) Shutdown() {
+	h.mu.Lock()
+	h.closed = true
+	clients := h.clients
+	h.clients = make(map[string]map[*Client]struct{})
+	h.mu.Unlock()
+
+	for _, conns := range clients {
+		for client := range conns {
+			client.close()
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/tax/usecases"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TaxController struct {
+	taxusecase usecases.TaxUseCase
+}
+
+func NewTaxController(taxusecase usecases.TaxUseCase) *TaxController {
+	return &TaxController{taxusecase: taxusecase}
+}
+
+func parseTaxYear(value string) (int, bool) {
+	if value == "" {
+		return time.Now().Year(), true
+	}
+
+	year, err := strconv.Atoi(value)
+	if err != nil || year <= 0 {
+		return 0, false
+	}
+
+	return year, true
+}
+
+func (c *TaxController) GetTaxTablesHandler(ctx *fiber.Ctx) error {
+	tables, err := c.taxusecase.GetTaxTables()
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Tax tables retrieved successfully",
+		"result":      tables,
+	})
+}
+
+func (c *TaxController) CreateTaxTableHandler(ctx *fiber.Ctx) error {
+	var table entities.TaxTable
+	if err := ctx.BodyParser(&table); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	createdTable, err := c.taxusecase.CreateTaxTable(table)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusCreated,
+		"message":     "Tax table created successfully",
+		"result":      createdTable,
+	})
+}
+
+func (c *TaxController) GetDeductionsHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	year, ok := parseTaxYear(ctx.Query("year"))
+	if !ok {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     "Invalid tax year",
+			"result":      nil,
+		})
+	}
+
+	deductions, err := c.taxusecase.GetDeductions(userID, year)
+	if err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Tax deductions retrieved successfully",
+		"result":      deductions,
+	})
+}
+
+func (c *TaxController) UpdateDeductionsHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	year, ok := parseTaxYear(ctx.Query("year"))
+	if !ok {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     "Invalid tax year",
+			"result":      nil,
+		})
+	}
+
+	var request struct {
+		Deductions []entities.TaxDeduction `json:"deductions"`
+	}
+
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	deductions, err := c.taxusecase.UpdateDeductions(userID, year, request.Deductions)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Tax deductions updated successfully",
+		"result":      deductions,
+	})
+}
+
+func (c *TaxController) GetEstimateHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	year, ok := parseTaxYear(ctx.Query("year"))
+	if !ok {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     "Invalid tax year",
+			"result":      nil,
+		})
+	}
+
+	version := ctx.QueryInt("version")
+	if version < 0 {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     "Invalid tax table version",
+			"result":      nil,
+		})
+	}
+
+	estimate, err := c.taxusecase.GetEstimate(userID, year, version)
+	if err != nil {
+		if err.Error() == "retirement plan not found" || err.Error() == "tax table not found" {
+			return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+				"status":      fiber.ErrNotFound.Message,
+				"status_code": fiber.ErrNotFound.Code,
+				"message":     err.Error(),
+				"result":      nil,
+			})
+		}
+
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Tax estimate retrieved successfully",
+		"result":      estimate,
+	})
+}
@@ -0,0 +1,229 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/tax/controllers"
+	"github.com/XzerozZ/Kasian_Phrom_BE/testing/usecases/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTest(_ *testing.T) (*controllers.TaxController, *mocks.MockTaxUseCase, *fiber.App) {
+	mockUseCase := new(mocks.MockTaxUseCase)
+	controller := controllers.NewTaxController(mockUseCase)
+	app := fiber.New()
+	return controller, mockUseCase, app
+}
+
+func TestGetTaxTablesHandler(t *testing.T) {
+	controller, mockUseCase, app := setupTest(t)
+	app.Get("/tax/tables", controller.GetTaxTablesHandler)
+
+	mockUseCase.On("GetTaxTables").Return([]entities.TaxTable{{ID: "table-1", TaxYear: 2024, Version: 1}}, nil).Once()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/tax/tables", nil), -1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var responseMap map[string]interface{}
+	responseBody, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(responseBody, &responseMap)
+	result := responseMap["result"].([]interface{})
+	assert.Equal(t, 2024.0, result[0].(map[string]interface{})["tax_year"])
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestCreateTaxTableHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/tax/tables", controller.CreateTaxTableHandler)
+
+		mockUseCase.On("CreateTaxTable", mock.MatchedBy(func(table entities.TaxTable) bool {
+			return table.TaxYear == 2025 && len(table.Brackets) == 2 && table.Brackets[1].Threshold == entities.Baht(150000)
+		})).Return(&entities.TaxTable{ID: "table-2", TaxYear: 2025, Version: 1}, nil).Once()
+
+		req := httptest.NewRequest("POST", "/tax/tables", strings.NewReader(`{"tax_year":2025,"brackets":[{"threshold":0,"rate":0},{"threshold":150000,"rate":5}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "Tax table created successfully", responseMap["message"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Table", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/tax/tables", controller.CreateTaxTableHandler)
+
+		mockUseCase.On("CreateTaxTable", mock.Anything).Return(nil, errors.New("first tax bracket must start at zero")).Once()
+
+		req := httptest.NewRequest("POST", "/tax/tables", strings.NewReader(`{"tax_year":2025,"brackets":[{"threshold":100,"rate":5}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "first tax bracket must start at zero", responseMap["message"])
+	})
+}
+
+func TestGetDeductionsHandler(t *testing.T) {
+	t.Run("Default Year", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/tax/deductions", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetDeductionsHandler(c)
+		})
+
+		mockUseCase.On("GetDeductions", "user-123", time.Now().Year()).Return([]entities.TaxDeduction{}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/tax/deductions", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Year", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/tax/deductions", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetDeductionsHandler(c)
+		})
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/tax/deductions?year=abc", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "Invalid tax year", responseMap["message"])
+		mockUseCase.AssertNotCalled(t, "GetDeductions", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateDeductionsHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Put("/tax/deductions", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.UpdateDeductionsHandler(c)
+		})
+
+		mockUseCase.On("UpdateDeductions", "user-123", 2024, mock.MatchedBy(func(deductions []entities.TaxDeduction) bool {
+			return len(deductions) == 1 && deductions[0].Name == "ประกันชีวิต" && deductions[0].Amount == entities.Baht(100000)
+		})).Return([]entities.TaxDeduction{{ID: "deduction-1", Name: "ประกันชีวิต", Amount: entities.Baht(100000)}}, nil).Once()
+
+		req := httptest.NewRequest("PUT", "/tax/deductions?year=2024", strings.NewReader(`{"deductions":[{"name":"ประกันชีวิต","amount":100000}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		result := responseMap["result"].([]interface{})
+		assert.Equal(t, "deduction-1", result[0].(map[string]interface{})["deduction_id"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Deduction", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Put("/tax/deductions", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.UpdateDeductionsHandler(c)
+		})
+
+		mockUseCase.On("UpdateDeductions", "user-123", 2024, mock.Anything).Return(nil, errors.New("deduction amount must not be negative")).Once()
+
+		req := httptest.NewRequest("PUT", "/tax/deductions?year=2024", strings.NewReader(`{"deductions":[{"name":"ประกันชีวิต","amount":-1}]}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "deduction amount must not be negative", responseMap["message"])
+	})
+}
+
+func TestGetEstimateHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/tax/estimate", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetEstimateHandler(c)
+		})
+
+		mockUseCase.On("GetEstimate", "user-123", 2024, 1).Return(&entities.TaxOptimization{
+			Estimate:    entities.TaxEstimate{TaxYear: 2024, Tax: entities.Baht(21500)},
+			TotalSaving: entities.Baht(21500),
+		}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/tax/estimate?year=2024&version=1", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		result := responseMap["result"].(map[string]interface{})
+		assert.Equal(t, 21500.0, result["estimate"].(map[string]interface{})["tax"])
+		assert.Equal(t, 21500.0, result["total_saving"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("No Retirement Plan", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/tax/estimate", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetEstimateHandler(c)
+		})
+
+		mockUseCase.On("GetEstimate", "user-123", 2024, 0).Return(nil, errors.New("retirement plan not found")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/tax/estimate?year=2024", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "retirement plan not found", responseMap["message"])
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/tax/estimate", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetEstimateHandler(c)
+		})
+
+		mockUseCase.On("GetEstimate", "user-123", 2024, 0).Return(nil, errors.New("database error")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/tax/estimate?year=2024", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	})
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+
+	"gorm.io/gorm"
+)
+
+type GormTaxRepository struct {
+	db *gorm.DB
+}
+
+func NewGormTaxRepository(db *gorm.DB) *GormTaxRepository {
+	return &GormTaxRepository{db: db}
+}
+
+type TaxRepository interface {
+	CreateTaxTable(table *entities.TaxTable) (*entities.TaxTable, error)
+	GetTaxTables() ([]entities.TaxTable, error)
+	GetTaxTable(year, version int) (*entities.TaxTable, error)
+	GetLatestTaxTableVersion(year int) (int, error)
+	GetDeductionsByUserID(userID string, year int) ([]entities.TaxDeduction, error)
+	ReplaceDeductions(userID string, year int, deductions []entities.TaxDeduction) ([]entities.TaxDeduction, error)
+}
+
+func preloadBrackets(db *gorm.DB) *gorm.DB {
+	return db.Order("threshold ASC")
+}
+
+func (r *GormTaxRepository) CreateTaxTable(table *entities.TaxTable) (*entities.TaxTable, error) {
+	if err := r.db.Create(&table).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetTaxTable(table.TaxYear, table.Version)
+}
+
+func (r *GormTaxRepository) GetTaxTables() ([]entities.TaxTable, error) {
+	var tables []entities.TaxTable
+	if err := r.db.Preload("Brackets", preloadBrackets).Order("tax_year DESC, version DESC").Find(&tables).Error; err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+func (r *GormTaxRepository) GetTaxTable(year, version int) (*entities.TaxTable, error) {
+	var table entities.TaxTable
+	query := r.db.Preload("Brackets", preloadBrackets)
+	if version > 0 {
+		query = query.Where("tax_year = ? AND version = ?", year, version)
+	} else {
+		query = query.Where("tax_year <= ?", year).Order("tax_year DESC, version DESC")
+	}
+
+	if err := query.First(&table).Error; err != nil {
+		return nil, err
+	}
+
+	return &table, nil
+}
+
+func (r *GormTaxRepository) GetLatestTaxTableVersion(year int) (int, error) {
+	var version int
+	if err := r.db.Model(&entities.TaxTable{}).Where("tax_year = ?", year).Select("COALESCE(MAX(version), 0)").Scan(&version).Error; err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (r *GormTaxRepository) GetDeductionsByUserID(userID string, year int) ([]entities.TaxDeduction, error) {
+	var deductions []entities.TaxDeduction
+	if err := r.db.Where("user_id = ? AND tax_year = ?", userID, year).Order("created_at ASC, name ASC").Find(&deductions).Error; err != nil {
+		return nil, err
+	}
+
+	return deductions, nil
+}
+
+func (r *GormTaxRepository) ReplaceDeductions(userID string, year int, deductions []entities.TaxDeduction) ([]entities.TaxDeduction, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND tax_year = ?", userID, year).Delete(&entities.TaxDeduction{}).Error; err != nil {
+			return err
+		}
+
+		if len(deductions) == 0 {
+			return nil
+		}
+
+		return tx.Create(&deductions).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetDeductionsByUserID(userID, year)
+}
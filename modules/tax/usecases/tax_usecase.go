@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"errors"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/tax/repositories"
+	userRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TaxUseCase interface {
+	GetTaxTables() ([]entities.TaxTable, error)
+	CreateTaxTable(table entities.TaxTable) (*entities.TaxTable, error)
+	GetDeductions(userID string, year int) ([]entities.TaxDeduction, error)
+	UpdateDeductions(userID string, year int, deductions []entities.TaxDeduction) ([]entities.TaxDeduction, error)
+	GetEstimate(userID string, year, version int) (*entities.TaxOptimization, error)
+}
+
+type TaxUseCaseImpl struct {
+	taxrepo  repositories.TaxRepository
+	userrepo userRepo.UserRepository
+}
+
+func NewTaxUseCase(taxrepo repositories.TaxRepository, userrepo userRepo.UserRepository) *TaxUseCaseImpl {
+	return &TaxUseCaseImpl{
+		taxrepo:  taxrepo,
+		userrepo: userrepo,
+	}
+}
+
+func (u *TaxUseCaseImpl) GetTaxTables() ([]entities.TaxTable, error) {
+	return u.taxrepo.GetTaxTables()
+}
+
+func (u *TaxUseCaseImpl) CreateTaxTable(table entities.TaxTable) (*entities.TaxTable, error) {
+	if err := utils.ValidateTaxTable(&table); err != nil {
+		return nil, err
+	}
+
+	version, err := u.taxrepo.GetLatestTaxTableVersion(table.TaxYear)
+	if err != nil {
+		return nil, err
+	}
+
+	table.ID = uuid.New().String()
+	table.Version = version + 1
+	for i := range table.Brackets {
+		table.Brackets[i].ID = uuid.New().String()
+		table.Brackets[i].TableID = table.ID
+	}
+
+	return u.taxrepo.CreateTaxTable(&table)
+}
+
+func (u *TaxUseCaseImpl) GetDeductions(userID string, year int) ([]entities.TaxDeduction, error) {
+	return u.taxrepo.GetDeductionsByUserID(userID, year)
+}
+
+func (u *TaxUseCaseImpl) UpdateDeductions(userID string, year int, deductions []entities.TaxDeduction) ([]entities.TaxDeduction, error) {
+	for i := range deductions {
+		if deductions[i].Name == "" {
+			return nil, errors.New("deduction name is missing")
+		}
+
+		if deductions[i].Amount < 0 {
+			return nil, errors.New("deduction amount must not be negative")
+		}
+
+		deductions[i].ID = uuid.New().String()
+		deductions[i].UserID = userID
+		deductions[i].TaxYear = year
+	}
+
+	return u.taxrepo.ReplaceDeductions(userID, year, deductions)
+}
+
+func (u *TaxUseCaseImpl) GetEstimate(userID string, year, version int) (*entities.TaxOptimization, error) {
+	user, err := u.userrepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.RetirementPlan.ID == "" {
+		return nil, errors.New("retirement plan not found")
+	}
+
+	table, err := u.taxrepo.GetTaxTable(year, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("tax table not found")
+		}
+
+		return nil, err
+	}
+
+	deductions, err := u.taxrepo.GetDeductionsByUserID(userID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalDeductions entities.Money
+	for _, deduction := range deductions {
+		totalDeductions += deduction.Amount
+	}
+
+	now := time.Now()
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, now.Location())
+	contributions, err := u.userrepo.GetUserDepositsInRange(userID, yearStart, yearStart.AddDate(1, 0, 0).Add(-time.Microsecond))
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.OptimizeTax(table, &user.RetirementPlan, year, totalDeductions, contributions, now)
+}
@@ -0,0 +1,178 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/tax/usecases"
+	"github.com/XzerozZ/Kasian_Phrom_BE/testing/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupTaxUseCase() (*usecases.TaxUseCaseImpl, *mocks.MockTaxRepository, *mocks.MockUserRepository) {
+	taxRepo := new(mocks.MockTaxRepository)
+	userRepo := new(mocks.MockUserRepository)
+	return usecases.NewTaxUseCase(taxRepo, userRepo), taxRepo, userRepo
+}
+
+func taxTable() *entities.TaxTable {
+	return &entities.TaxTable{
+		ID:                "table-1",
+		TaxYear:           2024,
+		Version:           1,
+		ExpenseRate:       50,
+		ExpenseCap:        entities.Baht(100000),
+		PersonalAllowance: entities.Baht(60000),
+		Brackets: []entities.TaxBracket{
+			{Threshold: 0, Rate: 0},
+			{Threshold: entities.Baht(150000), Rate: 5},
+			{Threshold: entities.Baht(300000), Rate: 10},
+			{Threshold: entities.Baht(500000), Rate: 15},
+		},
+	}
+}
+
+func taxUser() *entities.User {
+	return &entities.User{
+		ID: "user-123",
+		RetirementPlan: entities.RetirementPlan{
+			ID:             "plan-1",
+			BirthDate:      time.Now().AddDate(-40, 0, 0).Format("02-01-2006"),
+			RetirementAge:  60,
+			ExpectLifespan: 80,
+			MonthlyIncome:  entities.Baht(50000),
+		},
+	}
+}
+
+func TestCreateTaxTable(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		useCase, taxRepo, _ := setupTaxUseCase()
+
+		taxRepo.On("GetLatestTaxTableVersion", 2025).Return(1, nil).Once()
+		taxRepo.On("CreateTaxTable", mock.MatchedBy(func(table *entities.TaxTable) bool {
+			return table.ID != "" && table.Version == 2 && table.Brackets[0].Threshold == 0 &&
+				table.Brackets[1].ID != "" && table.Brackets[1].TableID == table.ID
+		})).Return(&entities.TaxTable{ID: "table-2", TaxYear: 2025, Version: 2}, nil).Once()
+
+		result, err := useCase.CreateTaxTable(entities.TaxTable{
+			TaxYear:  2025,
+			Brackets: []entities.TaxBracket{{Threshold: entities.Baht(150000), Rate: 5}, {Threshold: 0, Rate: 0}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Version)
+		taxRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Table", func(t *testing.T) {
+		useCase, taxRepo, _ := setupTaxUseCase()
+
+		result, err := useCase.CreateTaxTable(entities.TaxTable{TaxYear: 2025})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "tax table must have at least one bracket")
+		taxRepo.AssertNotCalled(t, "CreateTaxTable", mock.Anything)
+	})
+}
+
+func TestUpdateDeductions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		useCase, taxRepo, _ := setupTaxUseCase()
+
+		taxRepo.On("ReplaceDeductions", "user-123", 2024, mock.MatchedBy(func(deductions []entities.TaxDeduction) bool {
+			return len(deductions) == 1 && deductions[0].ID != "" && deductions[0].UserID == "user-123" && deductions[0].TaxYear == 2024
+		})).Return([]entities.TaxDeduction{{ID: "deduction-1", Name: "ประกันชีวิต", Amount: entities.Baht(100000)}}, nil).Once()
+
+		result, err := useCase.UpdateDeductions("user-123", 2024, []entities.TaxDeduction{{Name: "ประกันชีวิต", Amount: entities.Baht(100000)}})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		taxRepo.AssertExpectations(t)
+	})
+
+	t.Run("Negative Amount", func(t *testing.T) {
+		useCase, taxRepo, _ := setupTaxUseCase()
+
+		result, err := useCase.UpdateDeductions("user-123", 2024, []entities.TaxDeduction{{Name: "ประกันชีวิต", Amount: entities.Baht(-1)}})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "deduction amount must not be negative")
+		taxRepo.AssertNotCalled(t, "ReplaceDeductions", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Missing Name", func(t *testing.T) {
+		useCase, _, _ := setupTaxUseCase()
+
+		result, err := useCase.UpdateDeductions("user-123", 2024, []entities.TaxDeduction{{Amount: entities.Baht(1000)}})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "deduction name is missing")
+	})
+}
+
+func TestGetEstimate(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		useCase, taxRepo, userRepo := setupTaxUseCase()
+
+		userRepo.On("GetUserByID", "user-123").Return(taxUser(), nil).Once()
+		taxRepo.On("GetTaxTable", 2025, 0).Return(taxTable(), nil).Once()
+		taxRepo.On("GetDeductionsByUserID", "user-123", 2025).Return([]entities.TaxDeduction{{Name: "ประกันชีวิต", Amount: entities.Baht(40000)}}, nil).Once()
+		userRepo.On("GetUserDepositsInRange", "user-123", mock.MatchedBy(func(start time.Time) bool {
+			return start.Year() == 2025 && start.YearDay() == 1
+		}), mock.MatchedBy(func(end time.Time) bool {
+			return end.Year() == 2025 && end.Month() == time.December
+		})).Return([]entities.History{}, nil).Once()
+
+		result, err := useCase.GetEstimate("user-123", 2025, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2025, result.Estimate.TaxYear)
+		assert.Equal(t, entities.Baht(40000), result.Estimate.Deductions)
+		assert.Equal(t, entities.Baht(400000), result.Estimate.TaxableIncome)
+		assert.Equal(t, entities.Baht(17500), result.Estimate.Tax)
+		taxRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("No Retirement Plan", func(t *testing.T) {
+		useCase, taxRepo, userRepo := setupTaxUseCase()
+
+		userRepo.On("GetUserByID", "user-123").Return(&entities.User{ID: "user-123"}, nil).Once()
+
+		result, err := useCase.GetEstimate("user-123", 2025, 0)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "retirement plan not found")
+		taxRepo.AssertNotCalled(t, "GetTaxTable", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Tax Table Not Found", func(t *testing.T) {
+		useCase, taxRepo, userRepo := setupTaxUseCase()
+
+		userRepo.On("GetUserByID", "user-123").Return(taxUser(), nil).Once()
+		taxRepo.On("GetTaxTable", 2010, 0).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, err := useCase.GetEstimate("user-123", 2010, 0)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "tax table not found")
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		useCase, taxRepo, userRepo := setupTaxUseCase()
+
+		userRepo.On("GetUserByID", "user-123").Return(taxUser(), nil).Once()
+		taxRepo.On("GetTaxTable", 2025, 2).Return(taxTable(), nil).Once()
+		taxRepo.On("GetDeductionsByUserID", "user-123", 2025).Return(nil, errors.New("database error")).Once()
+
+		result, err := useCase.GetEstimate("user-123", 2025, 2)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "database error")
+	})
+}
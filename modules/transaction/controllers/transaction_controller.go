@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/transaction/usecases"
 	"github.com/gofiber/fiber/v2"
 )
@@ -58,6 +59,50 @@ func (c *TransactionController) MarkTransactiontoPaidHandler(ctx *fiber.Ctx) err
 	})
 }
 
+func (c *TransactionController) PayTransactionHandler(ctx *fiber.Ctx) error {
+	transactionID := ctx.Params("id")
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	type PaymentRequest struct {
+		Amount entities.Money `json:"amount"`
+	}
+
+	var req PaymentRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	transaction, err := c.transusecase.PayTransaction(transactionID, userID, req.Amount)
+	if err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Payment recorded successfully",
+		"result":      transaction,
+	})
+}
+
 func (c *TransactionController) GetTransactionByUserIDHandler(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(string)
 	if !ok || userID == "" {
@@ -86,3 +131,32 @@ func (c *TransactionController) GetTransactionByUserIDHandler(ctx *fiber.Ctx) er
 		"result":      transactions,
 	})
 }
+
+func (c *TransactionController) GetPaymentStatsHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	stats, err := c.transusecase.GetPaymentStats(userID)
+	if err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Payment statistics retrieved successfully",
+		"result":      stats,
+	})
+}
@@ -3,9 +3,11 @@ package controllers_test
 import (
 	"errors"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/transaction/controllers"
 	"github.com/XzerozZ/Kasian_Phrom_BE/testing/usecases/mocks"
 	"github.com/gofiber/fiber/v2"
@@ -103,7 +105,7 @@ func TestGetTransactionByUserIDHandler(t *testing.T) {
 		mockTransactions := []map[string]interface{}{
 			{
 				"trans_id":   "trans-123",
-				"status":     "ชำระ",
+				"status":     entities.TransactionDue,
 				"created_at": time.Now(),
 				"loan": map[string]interface{}{
 					"id":   "loan-123",
@@ -112,7 +114,7 @@ func TestGetTransactionByUserIDHandler(t *testing.T) {
 			},
 			{
 				"trans_id":   "trans-456",
-				"status":     "ชำระแล้ว",
+				"status":     entities.TransactionPaid,
 				"created_at": time.Now(),
 				"loan": map[string]interface{}{
 					"id":   "loan-456",
@@ -165,3 +167,78 @@ func TestGetTransactionByUserIDHandler(t *testing.T) {
 		mockUseCase.AssertExpectations(t)
 	})
 }
+
+func TestGetPaymentStatsHandler(t *testing.T) {
+	mockUseCase := new(mocks.MockTransactionUseCase)
+	controller := controllers.NewTransactionController(mockUseCase)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUseCase.On("GetPaymentStats", "user-123").Return(&entities.PaymentStats{Paid: 4, OnTime: 3, Late: 1, OnTimeRate: 75}, nil).Once()
+
+		app := fiber.New()
+		app.Get("/transactions/stats", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetPaymentStatsHandler(c)
+		})
+
+		req := httptest.NewRequest("GET", "/transactions/stats", nil)
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Unauthorized - Missing User ID", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/transactions/stats", controller.GetPaymentStatsHandler)
+
+		req := httptest.NewRequest("GET", "/transactions/stats", nil)
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestPayTransactionHandler(t *testing.T) {
+	mockUseCase := new(mocks.MockTransactionUseCase)
+	controller := controllers.NewTransactionController(mockUseCase)
+
+	t.Run("Success", func(t *testing.T) {
+		transaction := &entities.Transaction{ID: "trans-123", Status: entities.TransactionDue, PaidAmount: entities.MoneyFromFloat(2500.50)}
+		mockUseCase.On("PayTransaction", "trans-123", "user-123", entities.MoneyFromFloat(2500.50)).Return(transaction, nil).Once()
+
+		app := fiber.New()
+		app.Post("/transactions/:id/payments", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.PayTransactionHandler(c)
+		})
+
+		req := httptest.NewRequest("POST", "/transactions/trans-123/payments", strings.NewReader(`{"amount": 2500.50}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("UseCase Error", func(t *testing.T) {
+		mockUseCase.On("PayTransaction", "trans-123", "user-123", entities.Baht(99999)).Return(nil, errors.New("payment exceeds amount due")).Once()
+
+		app := fiber.New()
+		app.Post("/transactions/:id/payments", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.PayTransactionHandler(c)
+		})
+
+		req := httptest.NewRequest("POST", "/transactions/trans-123/payments", strings.NewReader(`{"amount": 99999}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+}
@@ -1,8 +1,11 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GormTransRepository struct {
@@ -16,13 +19,17 @@ func NewGormTransRepository(db *gorm.DB) *GormTransRepository {
 type TransRepository interface {
 	CreateTransaction(transaction *entities.Transaction) error
 	GetTransactionByID(id string) (*entities.Transaction, error)
+	GetTransactionByIDForUpdate(id string) (*entities.Transaction, error)
 	GetTransactionByUserID(userID string) ([]map[string]interface{}, error)
 	GetTransactionByLoanIDs(loanIDs []string) ([]entities.Transaction, error)
+	GetUnpaidTransactionsByLoanIDs(loanIDs []string) ([]entities.Transaction, error)
+	GetTransactionHistoryByUserID(userID string) ([]entities.Transaction, error)
 	GetLatestTransactionByLoanID(loanID string) (*entities.Transaction, error)
 	UpdateTransaction(transaction *entities.Transaction) error
 	DeleteTransaction(id string) error
 	DeleteTransactionsByLoanID(loanID string) error
-	CountTransactionsByLoanID(loanID string) (int, error)
+	CreatePayment(payment *entities.LoanPayment) error
+	GetPaymentsByLoanIDs(loanIDs []string) ([]entities.LoanPayment, error)
 }
 
 func (r *GormTransRepository) CreateTransaction(transaction *entities.Transaction) error {
@@ -38,18 +45,36 @@ func (r *GormTransRepository) GetTransactionByID(id string) (*entities.Transacti
 	return &transaction, nil
 }
 
+func (r *GormTransRepository) GetTransactionByIDForUpdate(id string) (*entities.Transaction, error) {
+	var transaction entities.Transaction
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Loan").Where("id = ?", id).First(&transaction).Error; err != nil {
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
 func (r *GormTransRepository) GetTransactionByUserID(userID string) ([]map[string]interface{}, error) {
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
 	var transactions []entities.Transaction
-	if err := r.db.Preload("Loan").Where("user_id = ?", userID).Find(&transactions).Error; err != nil {
+	if err := r.db.Preload("Loan").Where("user_id = ? AND (status <> ? OR paid_at >= ?)", userID, entities.TransactionPaid, monthStart).Find(&transactions).Error; err != nil {
 		return nil, err
 	}
 
 	var result []map[string]interface{}
 	for _, trans := range transactions {
-		totalAmount := float64(trans.Loan.RemainingMonths) * trans.Loan.MonthlyExpenses
+		totalAmount := trans.Loan.MonthlyExpenses.Mul(trans.Loan.RemainingMonths)
 		transactionData := map[string]interface{}{
 			"transaction_id": trans.ID,
 			"status":         trans.Status,
+			"installment_no": trans.InstallmentNo,
+			"due_date":       trans.DueDate,
+			"amount":         trans.Amount,
+			"interest":       trans.Interest,
+			"principal":      trans.Principal,
+			"paid_amount":    trans.PaidAmount,
+			"paid_at":        trans.PaidAt,
 			"created_at":     trans.CreatedAt,
 			"loan": map[string]interface{}{
 				"loan_id":          trans.Loan.ID,
@@ -59,6 +84,7 @@ func (r *GormTransRepository) GetTransactionByUserID(userID string) ([]map[strin
 				"remaining_months": trans.Loan.RemainingMonths,
 				"installment":      trans.Loan.Installment,
 				"status":           trans.Loan.Status,
+				"balance":          trans.Loan.Balance,
 			},
 			"total_amount": totalAmount,
 		}
@@ -71,7 +97,25 @@ func (r *GormTransRepository) GetTransactionByUserID(userID string) ([]map[strin
 
 func (r *GormTransRepository) GetTransactionByLoanIDs(loanIDs []string) ([]entities.Transaction, error) {
 	var transactions []entities.Transaction
-	if err := r.db.Preload("Loan").Where("loan_id IN ?", loanIDs).Find(&transactions).Error; err != nil {
+	if err := r.db.Preload("Loan").Where("loan_id IN ?", loanIDs).Order("due_date ASC, installment_no ASC, created_at ASC").Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (r *GormTransRepository) GetUnpaidTransactionsByLoanIDs(loanIDs []string) ([]entities.Transaction, error) {
+	var transactions []entities.Transaction
+	if err := r.db.Preload("Loan").Where("loan_id IN ? AND status <> ?", loanIDs, entities.TransactionPaid).Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (r *GormTransRepository) GetTransactionHistoryByUserID(userID string) ([]entities.Transaction, error) {
+	var transactions []entities.Transaction
+	if err := r.db.Where("user_id = ?", userID).Order("due_date ASC").Find(&transactions).Error; err != nil {
 		return nil, err
 	}
 
@@ -95,15 +139,19 @@ func (r *GormTransRepository) DeleteTransaction(id string) error {
 	return r.db.Where("id = ?", id).Delete(&entities.Transaction{}).Error
 }
 
-func (r *GormTransRepository) CountTransactionsByLoanID(loanID string) (int, error) {
-	var count int64
-	if err := r.db.Model(&entities.Transaction{}).Where("loan_id = ?", loanID).Count(&count).Error; err != nil {
-		return 0, err
-	}
+func (r *GormTransRepository) DeleteTransactionsByLoanID(loanID string) error {
+	return r.db.Where("loan_id = ?", loanID).Delete(&entities.Transaction{}).Error
+}
 
-	return int(count), nil
+func (r *GormTransRepository) CreatePayment(payment *entities.LoanPayment) error {
+	return r.db.Create(payment).Error
 }
 
-func (r *GormTransRepository) DeleteTransactionsByLoanID(loanID string) error {
-	return r.db.Where("loan_id = ?", loanID).Delete(&entities.Transaction{}).Error
+func (r *GormTransRepository) GetPaymentsByLoanIDs(loanIDs []string) ([]entities.LoanPayment, error) {
+	var payments []entities.LoanPayment
+	if err := r.db.Where("loan_id IN ?", loanIDs).Order("paid_at ASC").Find(&payments).Error; err != nil {
+		return nil, err
+	}
+
+	return payments, nil
 }
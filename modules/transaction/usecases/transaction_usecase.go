@@ -6,9 +6,10 @@ import (
 
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	loanRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/loan/repositories"
-	notiRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/notification/repositories"
-	"github.com/XzerozZ/Kasian_Phrom_BE/modules/socket"
+	notiUsecase "github.com/XzerozZ/Kasian_Phrom_BE/modules/notification/usecases"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/transaction/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/unitofwork"
+	userRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/repositories"
 	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
 	"github.com/google/uuid"
 )
@@ -16,20 +17,26 @@ import (
 type TransactionUseCase interface {
 	CreateTransactionsForAllUsers() error
 	MarkTransactiontoPaid(id, userID string) error
+	PayTransaction(id, userID string, amount entities.Money) (*entities.Transaction, error)
 	GetTransactionByUserID(userID string) ([]map[string]interface{}, error)
+	GetPaymentStats(userID string) (*entities.PaymentStats, error)
 }
 
 type TransactionUseCaseImpl struct {
-	transrepo repositories.TransRepository
-	loanrepo  loanRepo.LoanRepository
-	notirepo  notiRepo.NotiRepository
+	transrepo  repositories.TransRepository
+	loanrepo   loanRepo.LoanRepository
+	userrepo   userRepo.UserRepository
+	dispatcher notiUsecase.NotiDispatcher
+	uow        unitofwork.UnitOfWork
 }
 
-func NewTransactionUseCase(transrepo repositories.TransRepository, loanrepo loanRepo.LoanRepository, notirepo notiRepo.NotiRepository) *TransactionUseCaseImpl {
+func NewTransactionUseCase(transrepo repositories.TransRepository, loanrepo loanRepo.LoanRepository, userrepo userRepo.UserRepository, dispatcher notiUsecase.NotiDispatcher, uow unitofwork.UnitOfWork) *TransactionUseCaseImpl {
 	return &TransactionUseCaseImpl{
-		transrepo: transrepo,
-		loanrepo:  loanrepo,
-		notirepo:  notirepo,
+		transrepo:  transrepo,
+		loanrepo:   loanrepo,
+		userrepo:   userrepo,
+		dispatcher: dispatcher,
+		uow:        uow,
 	}
 }
 
@@ -48,21 +55,21 @@ func (u *TransactionUseCaseImpl) CreateTransactionsForAllUsers() error {
 		loanIDs[i] = loan.ID
 	}
 
-	existingTransactions, err := u.transrepo.GetTransactionByLoanIDs(loanIDs)
+	unpaidTransactions, err := u.transrepo.GetUnpaidTransactionsByLoanIDs(loanIDs)
 	if err != nil {
 		return err
 	}
 
-	for _, trans := range existingTransactions {
-		if trans.Status == "ชำระแล้ว" || trans.Status == "หยุดพัก" {
-			if err := u.transrepo.DeleteTransaction(trans.ID); err != nil {
-				return err
-			}
-		} else if trans.Status == "ชำระ" {
-			trans.Status = "ค้างชำระ"
+	now := time.Now()
+	unpaid := make(map[string]int)
+	paused := make(map[string]bool)
+	for _, trans := range unpaidTransactions {
+		unpaid[trans.LoanID]++
+		if trans.Status == entities.TransactionPaused {
+			paused[trans.LoanID] = true
+		} else if utils.IsOverdue(&trans, now) && utils.TransitionTransaction(&trans, entities.TransactionOverdue) == nil {
 			notification := utils.AlertNoti("loan", trans.UserID, trans.Loan.Name, trans.LoanID, trans.Loan.MonthlyExpenses)
-			_ = u.notirepo.CreateNotification(notification)
-			socket.SendNotificationToUser(trans.UserID, *notification)
+			_ = u.dispatcher.Dispatch(notification)
 			if err := u.transrepo.UpdateTransaction(&trans); err != nil {
 				return err
 			}
@@ -70,76 +77,224 @@ func (u *TransactionUseCaseImpl) CreateTransactionsForAllUsers() error {
 	}
 
 	for _, loan := range loans {
-		existingTransactions, err := u.transrepo.CountTransactionsByLoanID(loan.ID)
-		if err != nil {
-			return err
-		}
-
-		if existingTransactions >= loan.RemainingMonths {
+		if unpaid[loan.ID] >= loan.RemainingMonths {
 			continue
 		}
 
-		transactionStatus := "ชำระ"
+		transactionStatus := entities.TransactionDue
 		if loan.Status == "Paused" {
-			transactionStatus = "หยุดพัก"
-		} else if loan.Status == "In_Progress" {
-			transactionStatus = "ชำระ"
+			if paused[loan.ID] {
+				continue
+			}
+
+			transactionStatus = entities.TransactionPaused
+		}
+
+		installment := utils.NextInstallment(&loan, unpaid[loan.ID])
+		if installment.DueDate.IsZero() {
+			installment.DueDate = utils.BillingDueDate(now)
 		}
 
 		transaction := &entities.Transaction{
-			ID:        uuid.New().String(),
-			Status:    transactionStatus,
-			UserID:    loan.UserID,
-			LoanID:    loan.ID,
-			CreatedAt: time.Now(),
+			ID:            uuid.New().String(),
+			Status:        transactionStatus,
+			InstallmentNo: installment.Number,
+			DueDate:       installment.DueDate,
+			Amount:        installment.Payment,
+			Interest:      installment.Interest,
+			Principal:     installment.Principal,
+			UserID:        loan.UserID,
+			LoanID:        loan.ID,
+			CreatedAt:     now,
 		}
 
 		if err := u.transrepo.CreateTransaction(transaction); err != nil {
 			return err
 		}
-
 	}
 
 	return nil
 }
 
-func (u *TransactionUseCaseImpl) MarkTransactiontoPaid(id, userID string) error {
-	transaction, err := u.transrepo.GetTransactionByID(id)
+func amountDue(transaction *entities.Transaction) entities.Money {
+	if transaction.Amount > 0 {
+		return transaction.Amount
+	}
+
+	return transaction.Loan.MonthlyExpenses
+}
+
+func lockPayableTransaction(repos unitofwork.Repositories, id, userID string) (*entities.Transaction, *entities.Loan, error) {
+	transaction, err := repos.Transactions.GetTransactionByID(id)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	if transaction.Status == "หยุดพัก" {
-		return errors.New("transaction is not in a payable state")
+	if transaction.UserID != userID {
+		return nil, nil, errors.New("transaction not found")
 	}
 
-	transaction.Status = "ชำระแล้ว"
-	if err := u.transrepo.UpdateTransaction(transaction); err != nil {
-		return err
+	// Lock the loan before the transaction, the order every loan write takes.
+	loan, err := repos.Loans.GetLoanByIDForUpdate(transaction.LoanID)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	loan, err := u.loanrepo.GetLoanByID(transaction.LoanID)
+	transaction, err = repos.Transactions.GetTransactionByIDForUpdate(id)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	if loan.RemainingMonths > 0 {
+	if transaction.Status == entities.TransactionPaid {
+		return nil, nil, errors.New("transaction is already paid")
+	}
+
+	if !utils.CanTransitionTransaction(transaction.Status, entities.TransactionPaid) {
+		return nil, nil, errors.New("transaction is not in a payable state")
+	}
+
+	return transaction, loan, nil
+}
+
+func pay(repos unitofwork.Repositories, transaction *entities.Transaction, loan *entities.Loan, amount entities.Money) (*entities.Notification, error) {
+	now := time.Now()
+	interest := min(amount, max(transaction.Interest-transaction.PaidAmount, 0))
+	transaction.PaidAmount += amount
+	paid := transaction.PaidAmount >= amountDue(transaction)
+	kind := entities.LoanPaymentPartial
+	if paid {
+		if err := utils.TransitionTransaction(transaction, entities.TransactionPaid); err != nil {
+			return nil, err
+		}
+
+		transaction.PaidAt = &now
+		kind = entities.LoanPaymentInstallment
+	}
+
+	if err := repos.Transactions.UpdateTransaction(transaction); err != nil {
+		return nil, err
+	}
+
+	var notification *entities.Notification
+	if paid && loan.RemainingMonths > 0 {
 		loan.RemainingMonths--
+		if utils.IsAmortizing(loan) {
+			loan.Balance = max(loan.Balance-transaction.Principal, 0)
+			if loan.RemainingMonths == 0 {
+				loan.Balance = 0
+			}
+		}
+
 		if loan.RemainingMonths == 0 {
 			loan.Status = "Completed"
-			notification := utils.SuccessNotification("loan", userID, loan.Name, loan.ID, 0)
-			_ = u.notirepo.CreateNotification(notification)
-			socket.SendNotificationToUser(userID, *notification)
+			notification = utils.SuccessNotification("loan", transaction.UserID, loan.Name, loan.ID, 0)
+		}
+
+		if _, err := repos.Loans.UpdateLoanByID(loan); err != nil {
+			return nil, err
+		}
+	}
+
+	err := repos.Transactions.CreatePayment(&entities.LoanPayment{
+		ID:            uuid.New().String(),
+		Kind:          kind,
+		Amount:        amount,
+		Interest:      interest,
+		Principal:     amount - interest,
+		Balance:       utils.LoanOutstanding(loan),
+		TransactionID: transaction.ID,
+		LoanID:        transaction.LoanID,
+		UserID:        transaction.UserID,
+		PaidAt:        now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+func (u *TransactionUseCaseImpl) payInstallment(id, userID string, amountOf func(transaction *entities.Transaction) (entities.Money, error)) (*entities.Transaction, error) {
+	var transaction *entities.Transaction
+	var notification *entities.Notification
+	err := u.uow.Do(func(repos unitofwork.Repositories) error {
+		locked, loan, err := lockPayableTransaction(repos, id, userID)
+		if err != nil {
+			return err
+		}
+
+		amount, err := amountOf(locked)
+		if err != nil {
+			return err
 		}
 
-		if _, err := u.loanrepo.UpdateLoanByID(loan); err != nil {
+		notification, err = pay(repos, locked, loan, amount)
+		if err != nil {
 			return err
 		}
+
+		transaction = locked
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	if notification != nil {
+		_ = u.dispatcher.Dispatch(notification)
+	}
+
+	return transaction, nil
+}
+
+func (u *TransactionUseCaseImpl) MarkTransactiontoPaid(id, userID string) error {
+	_, err := u.payInstallment(id, userID, func(transaction *entities.Transaction) (entities.Money, error) {
+		return max(amountDue(transaction)-transaction.PaidAmount, 0), nil
+	})
+
+	return err
+}
+
+func (u *TransactionUseCaseImpl) PayTransaction(id, userID string, amount entities.Money) (*entities.Transaction, error) {
+	if amount <= 0 {
+		return nil, errors.New("payment amount must be greater than zero")
+	}
+
+	return u.payInstallment(id, userID, func(transaction *entities.Transaction) (entities.Money, error) {
+		if amount > amountDue(transaction)-transaction.PaidAmount {
+			return 0, errors.New("payment exceeds amount due")
+		}
+
+		return amount, nil
+	})
 }
 
 func (u *TransactionUseCaseImpl) GetTransactionByUserID(userID string) ([]map[string]interface{}, error) {
-	return u.transrepo.GetTransactionByUserID(userID)
+	transactions, err := u.transrepo.GetTransactionByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := utils.DefaultLocale
+	if user, err := u.userrepo.GetUserByID(userID); err == nil && user.Locale != "" {
+		locale = user.Locale
+	}
+
+	for _, transaction := range transactions {
+		if status, ok := transaction["status"].(entities.TransactionStatus); ok {
+			transaction["status_label"] = utils.TransactionStatusLabel(status, locale)
+		}
+	}
+
+	return transactions, nil
+}
+
+func (u *TransactionUseCaseImpl) GetPaymentStats(userID string) (*entities.PaymentStats, error) {
+	transactions, err := u.transrepo.GetTransactionHistoryByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := utils.PaymentStatistics(transactions)
+	return &stats, nil
 }
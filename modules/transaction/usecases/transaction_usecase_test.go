@@ -3,10 +3,12 @@ package usecases_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/transaction/usecases"
 	"github.com/XzerozZ/Kasian_Phrom_BE/testing/repositories/mocks"
+	usecaseMocks "github.com/XzerozZ/Kasian_Phrom_BE/testing/usecases/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -15,7 +17,7 @@ func TestCreateTransactionsForAllUsers(t *testing.T) {
 	t.Run("Success - Create transactions for loans", func(t *testing.T) {
 		transRepo := new(mocks.MockTransRepository)
 		loanRepo := new(mocks.MockLoanRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 
 		loans := []entities.Loan{
 			{
@@ -37,7 +39,7 @@ func TestCreateTransactionsForAllUsers(t *testing.T) {
 		existingTransactions := []entities.Transaction{
 			{
 				ID:     "trans1",
-				Status: "ชำระ",
+				Status: entities.TransactionDue,
 				UserID: "user1",
 				LoanID: "loan1",
 				Loan:   loans[0],
@@ -45,31 +47,72 @@ func TestCreateTransactionsForAllUsers(t *testing.T) {
 		}
 
 		loanRepo.On("GetAllLoansByStatus", []string{"In_Progress", "Paused"}).Return(loans, nil)
-		transRepo.On("GetTransactionByLoanIDs", []string{"loan1", "loan2"}).Return(existingTransactions, nil)
+		transRepo.On("GetUnpaidTransactionsByLoanIDs", []string{"loan1", "loan2"}).Return(existingTransactions, nil)
 		transRepo.On("UpdateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(nil)
-		notiRepo.On("CreateNotification", mock.AnythingOfType("*entities.Notification")).Return(nil)
+		dispatcher.On("Dispatch", mock.AnythingOfType("*entities.Notification")).Return(nil)
 
-		transRepo.On("CountTransactionsByLoanID", "loan1").Return(1, nil)
-		transRepo.On("CountTransactionsByLoanID", "loan2").Return(0, nil)
-		transRepo.On("CreateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(nil)
+		transRepo.On("CreateTransaction", mock.MatchedBy(func(transaction *entities.Transaction) bool {
+			return transaction.LoanID == "loan1" && transaction.Status == entities.TransactionDue && !transaction.DueDate.IsZero()
+		})).Return(nil)
+		transRepo.On("CreateTransaction", mock.MatchedBy(func(transaction *entities.Transaction) bool {
+			return transaction.LoanID == "loan2" && transaction.Status == entities.TransactionPaused
+		})).Return(nil)
 
-		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, notiRepo)
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
 		err := useCase.CreateTransactionsForAllUsers()
 
 		assert.NoError(t, err)
 		transRepo.AssertExpectations(t)
 		loanRepo.AssertExpectations(t)
-		notiRepo.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("Success - Bills the next scheduled installment", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		loans := []entities.Loan{
+			{
+				ID:              "loan1",
+				UserID:          "user1",
+				Status:          "In_Progress",
+				Principal:       entities.Baht(100000),
+				Balance:         entities.Baht(100000),
+				InterestRate:    12,
+				RateType:        entities.LoanRateFixed,
+				TermMonths:      12,
+				RemainingMonths: 12,
+				StartDate:       time.Date(2024, time.January, 31, 0, 0, 0, 0, time.Local),
+			},
+		}
+
+		loanRepo.On("GetAllLoansByStatus", []string{"In_Progress", "Paused"}).Return(loans, nil)
+		transRepo.On("GetUnpaidTransactionsByLoanIDs", []string{"loan1"}).Return([]entities.Transaction{
+			{ID: "trans1", Status: entities.TransactionDue, InstallmentNo: 1, UserID: "user1", LoanID: "loan1", Loan: loans[0]},
+		}, nil)
+		transRepo.On("UpdateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(nil)
+		dispatcher.On("Dispatch", mock.AnythingOfType("*entities.Notification")).Return(nil)
+		transRepo.On("CreateTransaction", mock.MatchedBy(func(transaction *entities.Transaction) bool {
+			return transaction.InstallmentNo == 2 && transaction.Interest == entities.MoneyFromFloat(921.15) &&
+				transaction.DueDate.Equal(time.Date(2024, time.March, 31, 0, 0, 0, 0, time.Local))
+		})).Return(nil)
+
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
+		err := useCase.CreateTransactionsForAllUsers()
+
+		assert.NoError(t, err)
+		transRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failed - No loans found", func(t *testing.T) {
 		transRepo := new(mocks.MockTransRepository)
 		loanRepo := new(mocks.MockLoanRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 
 		loanRepo.On("GetAllLoansByStatus", []string{"In_Progress", "Paused"}).Return([]entities.Loan{}, nil)
 
-		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, notiRepo)
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
 		err := useCase.CreateTransactionsForAllUsers()
 
 		assert.Error(t, err)
@@ -80,11 +123,11 @@ func TestCreateTransactionsForAllUsers(t *testing.T) {
 	t.Run("Failed - Error getting loans", func(t *testing.T) {
 		transRepo := new(mocks.MockTransRepository)
 		loanRepo := new(mocks.MockLoanRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 
 		loanRepo.On("GetAllLoansByStatus", []string{"In_Progress", "Paused"}).Return([]entities.Loan{}, errors.New("db error"))
 
-		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, notiRepo)
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
 		err := useCase.CreateTransactionsForAllUsers()
 
 		assert.Error(t, err)
@@ -92,51 +135,86 @@ func TestCreateTransactionsForAllUsers(t *testing.T) {
 		loanRepo.AssertExpectations(t)
 	})
 
-	t.Run("Success - Delete paid and paused transactions", func(t *testing.T) {
+	t.Run("Success - Keeps paused loan with its paused transaction", func(t *testing.T) {
 		transRepo := new(mocks.MockTransRepository)
 		loanRepo := new(mocks.MockLoanRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 
 		loans := []entities.Loan{
 			{
 				ID:              "loan1",
 				UserID:          "user1",
 				Name:            "Loan 1",
-				Status:          "In_Progress",
+				Status:          "Paused",
 				RemainingMonths: 5,
 			},
 		}
 
-		existingTransactions := []entities.Transaction{
+		loanRepo.On("GetAllLoansByStatus", []string{"In_Progress", "Paused"}).Return(loans, nil)
+		transRepo.On("GetUnpaidTransactionsByLoanIDs", []string{"loan1"}).Return([]entities.Transaction{
+			{ID: "trans1", Status: entities.TransactionPaused, UserID: "user1", LoanID: "loan1", Loan: loans[0]},
+		}, nil)
+
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
+		err := useCase.CreateTransactionsForAllUsers()
+
+		assert.NoError(t, err)
+		transRepo.AssertNotCalled(t, "DeleteTransaction", mock.Anything)
+		transRepo.AssertNotCalled(t, "CreateTransaction", mock.Anything)
+		transRepo.AssertNotCalled(t, "UpdateTransaction", mock.Anything)
+	})
+
+	t.Run("Success - Leaves a bill that is not due yet as due", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		loans := []entities.Loan{
 			{
-				ID:     "trans1",
-				Status: "ชำระแล้ว",
-				UserID: "user1",
-				LoanID: "loan1",
-				Loan:   loans[0],
+				ID:              "loan1",
+				UserID:          "user1",
+				Status:          "In_Progress",
+				RemainingMonths: 1,
 			},
+		}
+
+		loanRepo.On("GetAllLoansByStatus", []string{"In_Progress", "Paused"}).Return(loans, nil)
+		transRepo.On("GetUnpaidTransactionsByLoanIDs", []string{"loan1"}).Return([]entities.Transaction{
+			{ID: "trans1", Status: entities.TransactionDue, DueDate: time.Now().AddDate(0, 0, 16), UserID: "user1", LoanID: "loan1", Loan: loans[0]},
+		}, nil)
+
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
+		err := useCase.CreateTransactionsForAllUsers()
+
+		assert.NoError(t, err)
+		transRepo.AssertNotCalled(t, "UpdateTransaction", mock.Anything)
+		dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything)
+	})
+
+	t.Run("Success - Stops billing once every remaining month is billed", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		loans := []entities.Loan{
 			{
-				ID:     "trans2",
-				Status: "หยุดพัก",
-				UserID: "user1",
-				LoanID: "loan1",
-				Loan:   loans[0],
+				ID:              "loan1",
+				UserID:          "user1",
+				Status:          "In_Progress",
+				RemainingMonths: 1,
 			},
 		}
 
 		loanRepo.On("GetAllLoansByStatus", []string{"In_Progress", "Paused"}).Return(loans, nil)
-		transRepo.On("GetTransactionByLoanIDs", []string{"loan1"}).Return(existingTransactions, nil)
-		transRepo.On("DeleteTransaction", "trans1").Return(nil)
-		transRepo.On("DeleteTransaction", "trans2").Return(nil)
-		transRepo.On("CountTransactionsByLoanID", "loan1").Return(0, nil)
-		transRepo.On("CreateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(nil)
+		transRepo.On("GetUnpaidTransactionsByLoanIDs", []string{"loan1"}).Return([]entities.Transaction{
+			{ID: "trans1", Status: entities.TransactionOverdue, UserID: "user1", LoanID: "loan1", Loan: loans[0]},
+		}, nil)
 
-		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, notiRepo)
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
 		err := useCase.CreateTransactionsForAllUsers()
 
 		assert.NoError(t, err)
-		transRepo.AssertExpectations(t)
-		loanRepo.AssertExpectations(t)
+		transRepo.AssertNotCalled(t, "CreateTransaction", mock.Anything)
 	})
 }
 
@@ -144,11 +222,11 @@ func TestMarkTransactiontoPaid(t *testing.T) {
 	t.Run("Success - Mark transaction as paid", func(t *testing.T) {
 		transRepo := new(mocks.MockTransRepository)
 		loanRepo := new(mocks.MockLoanRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 
 		transaction := &entities.Transaction{
 			ID:     "trans1",
-			Status: "ชำระ",
+			Status: entities.TransactionDue,
 			UserID: "user1",
 			LoanID: "loan1",
 		}
@@ -162,15 +240,18 @@ func TestMarkTransactiontoPaid(t *testing.T) {
 		}
 
 		transRepo.On("GetTransactionByID", "trans1").Return(transaction, nil)
+		transRepo.On("GetTransactionByIDForUpdate", "trans1").Return(transaction, nil)
 		transRepo.On("UpdateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(nil)
-		loanRepo.On("GetLoanByID", "loan1").Return(loan, nil)
+		loanRepo.On("GetLoanByIDForUpdate", "loan1").Return(loan, nil)
 		loanRepo.On("UpdateLoanByID", mock.AnythingOfType("*entities.Loan")).Return(loan, nil)
+		transRepo.On("CreatePayment", mock.AnythingOfType("*entities.LoanPayment")).Return(nil)
 
-		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, notiRepo)
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
 		err := useCase.MarkTransactiontoPaid("trans1", "user1")
 
 		assert.NoError(t, err)
-		assert.Equal(t, "ชำระแล้ว", transaction.Status)
+		assert.Equal(t, entities.TransactionPaid, transaction.Status)
+		assert.NotNil(t, transaction.PaidAt)
 		assert.Equal(t, 1, loan.RemainingMonths)
 		transRepo.AssertExpectations(t)
 		loanRepo.AssertExpectations(t)
@@ -179,11 +260,11 @@ func TestMarkTransactiontoPaid(t *testing.T) {
 	t.Run("Success - Mark transaction as paid and complete loan", func(t *testing.T) {
 		transRepo := new(mocks.MockTransRepository)
 		loanRepo := new(mocks.MockLoanRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 
 		transaction := &entities.Transaction{
 			ID:     "trans1",
-			Status: "ชำระ",
+			Status: entities.TransactionDue,
 			UserID: "user1",
 			LoanID: "loan1",
 		}
@@ -197,32 +278,75 @@ func TestMarkTransactiontoPaid(t *testing.T) {
 		}
 
 		transRepo.On("GetTransactionByID", "trans1").Return(transaction, nil)
+		transRepo.On("GetTransactionByIDForUpdate", "trans1").Return(transaction, nil)
 		transRepo.On("UpdateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(nil)
-		loanRepo.On("GetLoanByID", "loan1").Return(loan, nil)
+		loanRepo.On("GetLoanByIDForUpdate", "loan1").Return(loan, nil)
 		loanRepo.On("UpdateLoanByID", mock.AnythingOfType("*entities.Loan")).Return(loan, nil)
-		notiRepo.On("CreateNotification", mock.AnythingOfType("*entities.Notification")).Return(nil)
+		transRepo.On("CreatePayment", mock.AnythingOfType("*entities.LoanPayment")).Return(nil)
+		dispatcher.On("Dispatch", mock.AnythingOfType("*entities.Notification")).Return(nil)
 
-		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, notiRepo)
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
 		err := useCase.MarkTransactiontoPaid("trans1", "user1")
 
 		assert.NoError(t, err)
-		assert.Equal(t, "ชำระแล้ว", transaction.Status)
+		assert.Equal(t, entities.TransactionPaid, transaction.Status)
 		assert.Equal(t, 0, loan.RemainingMonths)
 		assert.Equal(t, "Completed", loan.Status)
 		transRepo.AssertExpectations(t)
 		loanRepo.AssertExpectations(t)
-		notiRepo.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("Success - Paying an installment reduces the balance", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		transaction := &entities.Transaction{
+			ID:            "trans1",
+			Status:        entities.TransactionDue,
+			InstallmentNo: 1,
+			Amount:        entities.Baht(7000),
+			Interest:      entities.Baht(750),
+			Principal:     entities.Baht(6250),
+			UserID:        "user1",
+			LoanID:        "loan1",
+		}
+
+		loan := &entities.Loan{
+			ID:              "loan1",
+			UserID:          "user1",
+			Status:          "In_Progress",
+			Principal:       entities.Baht(300000),
+			Balance:         entities.Baht(300000),
+			TermMonths:      48,
+			RemainingMonths: 48,
+		}
+
+		transRepo.On("GetTransactionByID", "trans1").Return(transaction, nil)
+		transRepo.On("GetTransactionByIDForUpdate", "trans1").Return(transaction, nil)
+		transRepo.On("UpdateTransaction", mock.AnythingOfType("*entities.Transaction")).Return(nil)
+		loanRepo.On("GetLoanByIDForUpdate", "loan1").Return(loan, nil)
+		loanRepo.On("UpdateLoanByID", mock.AnythingOfType("*entities.Loan")).Return(loan, nil)
+		transRepo.On("CreatePayment", mock.AnythingOfType("*entities.LoanPayment")).Return(nil)
+
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
+		err := useCase.MarkTransactiontoPaid("trans1", "user1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 47, loan.RemainingMonths)
+		assert.Equal(t, entities.Baht(293750), loan.Balance)
 	})
 
 	t.Run("Failed - Transaction not found", func(t *testing.T) {
 		transRepo := new(mocks.MockTransRepository)
 		loanRepo := new(mocks.MockLoanRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 
 		var nilTransaction *entities.Transaction = nil
 		transRepo.On("GetTransactionByID", "trans1").Return(nilTransaction, errors.New("transaction not found"))
 
-		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, notiRepo)
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
 		err := useCase.MarkTransactiontoPaid("trans1", "user1")
 
 		assert.Error(t, err)
@@ -233,63 +357,109 @@ func TestMarkTransactiontoPaid(t *testing.T) {
 	t.Run("Failed - Transaction is paused", func(t *testing.T) {
 		transRepo := new(mocks.MockTransRepository)
 		loanRepo := new(mocks.MockLoanRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 
 		transaction := &entities.Transaction{
 			ID:     "trans1",
-			Status: "หยุดพัก",
+			Status: entities.TransactionPaused,
 			UserID: "user1",
 			LoanID: "loan1",
 		}
 
 		transRepo.On("GetTransactionByID", "trans1").Return(transaction, nil)
+		transRepo.On("GetTransactionByIDForUpdate", "trans1").Return(transaction, nil)
+		loanRepo.On("GetLoanByIDForUpdate", "loan1").Return(&entities.Loan{ID: "loan1", UserID: "user1"}, nil)
 
-		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, notiRepo)
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
 		err := useCase.MarkTransactiontoPaid("trans1", "user1")
 
 		assert.Error(t, err)
 		assert.Equal(t, "transaction is not in a payable state", err.Error())
 		transRepo.AssertExpectations(t)
 	})
+
+	t.Run("Failed - Transaction belongs to another user", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		transaction := &entities.Transaction{
+			ID:     "trans1",
+			Status: entities.TransactionDue,
+			UserID: "user1",
+			LoanID: "loan1",
+		}
+
+		transRepo.On("GetTransactionByID", "trans1").Return(transaction, nil)
+
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
+		err := useCase.MarkTransactiontoPaid("trans1", "user2")
+
+		assert.Error(t, err)
+		assert.Equal(t, "transaction not found", err.Error())
+		assert.Equal(t, entities.TransactionDue, transaction.Status)
+		transRepo.AssertNotCalled(t, "UpdateTransaction", mock.Anything)
+		loanRepo.AssertNotCalled(t, "GetLoanByIDForUpdate", mock.Anything)
+	})
 }
 
 func TestGetTransactionByUserID(t *testing.T) {
 	t.Run("Success - Get transactions by user ID", func(t *testing.T) {
 		transRepo := new(mocks.MockTransRepository)
 		loanRepo := new(mocks.MockLoanRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		userRepo := new(mocks.MockUserRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 
 		expectedTransactions := []map[string]interface{}{
 			{
 				"id":     "trans1",
-				"status": "ชำระ",
+				"status": entities.TransactionDue,
 				"loan":   map[string]interface{}{"name": "Loan 1"},
 			},
 			{
 				"id":     "trans2",
-				"status": "ชำระแล้ว",
+				"status": entities.TransactionPaid,
 				"loan":   map[string]interface{}{"name": "Loan 2"},
 			},
 		}
 
 		transRepo.On("GetTransactionByUserID", "user1").Return(expectedTransactions, nil)
+		userRepo.On("GetUserByID", "user1").Return(&entities.User{ID: "user1"}, nil)
 
-		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, notiRepo)
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, userRepo, dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
 		transactions, err := useCase.GetTransactionByUserID("user1")
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedTransactions, transactions)
+		assert.Equal(t, "ชำระ", transactions[0]["status_label"])
+		assert.Equal(t, "ชำระแล้ว", transactions[1]["status_label"])
 		transRepo.AssertExpectations(t)
 	})
 
+	t.Run("Success - Labels follow the user's locale", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		userRepo := new(mocks.MockUserRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		transRepo.On("GetTransactionByUserID", "user1").Return([]map[string]interface{}{{"id": "trans1", "status": entities.TransactionOverdue}}, nil)
+		userRepo.On("GetUserByID", "user1").Return(&entities.User{ID: "user1", Locale: "en"}, nil)
+
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, userRepo, dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
+		transactions, err := useCase.GetTransactionByUserID("user1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Overdue", transactions[0]["status_label"])
+	})
+
 	t.Run("Failed - Error getting transactions", func(t *testing.T) {
 		transRepo := new(mocks.MockTransRepository)
 		loanRepo := new(mocks.MockLoanRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 
 		transRepo.On("GetTransactionByUserID", "user1").Return([]map[string]interface{}(nil), errors.New("db error"))
 
-		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, notiRepo)
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
 		transactions, err := useCase.GetTransactionByUserID("user1")
 
 		assert.Error(t, err)
@@ -298,3 +468,246 @@ func TestGetTransactionByUserID(t *testing.T) {
 		transRepo.AssertExpectations(t)
 	})
 }
+
+func TestGetPaymentStats(t *testing.T) {
+	t.Run("Success - Summarises the user's history", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		onTime := time.Date(2024, time.January, 30, 0, 0, 0, 0, time.Local)
+		late := time.Date(2024, time.March, 2, 0, 0, 0, 0, time.Local)
+		transRepo.On("GetTransactionHistoryByUserID", "user1").Return([]entities.Transaction{
+			{ID: "trans1", Status: entities.TransactionPaid, DueDate: time.Date(2024, time.January, 31, 0, 0, 0, 0, time.Local), PaidAt: &onTime, PaidAmount: entities.Baht(5000)},
+			{ID: "trans2", Status: entities.TransactionPaid, DueDate: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.Local), PaidAt: &late, PaidAmount: entities.Baht(5000)},
+			{ID: "trans3", Status: entities.TransactionOverdue, DueDate: time.Date(2024, time.March, 31, 0, 0, 0, 0, time.Local)},
+		}, nil)
+
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
+		stats, err := useCase.GetPaymentStats("user1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, stats.Paid)
+		assert.Equal(t, 1, stats.OnTime)
+		assert.Equal(t, 1, stats.Late)
+		assert.Equal(t, 1, stats.Overdue)
+		assert.Equal(t, 50.0, stats.OnTimeRate)
+		assert.Equal(t, 2.0, stats.AverageDaysLate)
+		assert.Equal(t, entities.Baht(10000), stats.TotalPaid)
+	})
+
+	t.Run("Success - Bill paid before its due date is on time and never overdue", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		loans := []entities.Loan{{ID: "loan1", UserID: "user1", Status: "In_Progress", RemainingMonths: 1}}
+		bill := entities.Transaction{ID: "trans1", Status: entities.TransactionDue, DueDate: time.Now().AddDate(0, 0, 7), UserID: "user1", LoanID: "loan1", Loan: loans[0]}
+		loanRepo.On("GetAllLoansByStatus", []string{"In_Progress", "Paused"}).Return(loans, nil)
+		transRepo.On("GetUnpaidTransactionsByLoanIDs", []string{"loan1"}).Return([]entities.Transaction{bill}, nil)
+
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
+		assert.NoError(t, useCase.CreateTransactionsForAllUsers())
+		transRepo.AssertNotCalled(t, "UpdateTransaction", mock.Anything)
+
+		paidAt := time.Now()
+		bill.Status = entities.TransactionPaid
+		bill.PaidAt = &paidAt
+		bill.PaidAmount = entities.Baht(5000)
+		transRepo.On("GetTransactionHistoryByUserID", "user1").Return([]entities.Transaction{bill}, nil)
+
+		stats, err := useCase.GetPaymentStats("user1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, stats.OnTime)
+		assert.Equal(t, 0, stats.Late)
+		assert.Equal(t, 0, stats.Overdue)
+	})
+
+	t.Run("Failed - Error getting history", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		transRepo.On("GetTransactionHistoryByUserID", "user1").Return([]entities.Transaction(nil), errors.New("db error"))
+
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
+		stats, err := useCase.GetPaymentStats("user1")
+
+		assert.Nil(t, stats)
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestPayTransaction(t *testing.T) {
+	newTransaction := func() *entities.Transaction {
+		return &entities.Transaction{
+			ID:            "trans1",
+			Status:        entities.TransactionDue,
+			InstallmentNo: 1,
+			Amount:        entities.Baht(7000),
+			Interest:      entities.Baht(750),
+			Principal:     entities.Baht(6250),
+			UserID:        "user1",
+			LoanID:        "loan1",
+		}
+	}
+
+	newLoan := func() *entities.Loan {
+		return &entities.Loan{
+			ID:              "loan1",
+			UserID:          "user1",
+			Status:          "In_Progress",
+			Principal:       entities.Baht(300000),
+			Balance:         entities.Baht(300000),
+			TermMonths:      48,
+			RemainingMonths: 48,
+		}
+	}
+
+	t.Run("Success - Partial payment leaves the installment due", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		transaction, loan := newTransaction(), newLoan()
+		var payment *entities.LoanPayment
+		transRepo.On("GetTransactionByID", "trans1").Return(transaction, nil)
+		transRepo.On("GetTransactionByIDForUpdate", "trans1").Return(transaction, nil)
+		transRepo.On("UpdateTransaction", transaction).Return(nil)
+		loanRepo.On("GetLoanByIDForUpdate", "loan1").Return(loan, nil)
+		transRepo.On("CreatePayment", mock.AnythingOfType("*entities.LoanPayment")).Run(func(args mock.Arguments) {
+			payment = args.Get(0).(*entities.LoanPayment)
+		}).Return(nil)
+
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
+		result, err := useCase.PayTransaction("trans1", "user1", entities.Baht(3000))
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TransactionDue, result.Status)
+		assert.Nil(t, result.PaidAt)
+		assert.Equal(t, entities.Baht(3000), result.PaidAmount)
+		assert.Equal(t, entities.LoanPaymentPartial, payment.Kind)
+		assert.Equal(t, entities.Baht(750), payment.Interest)
+		assert.Equal(t, entities.Baht(2250), payment.Principal)
+		assert.Equal(t, entities.Baht(300000), payment.Balance)
+		assert.Equal(t, 48, loan.RemainingMonths)
+		loanRepo.AssertNotCalled(t, "UpdateLoanByID", mock.Anything)
+	})
+
+	t.Run("Success - Last partial payment pays the installment", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		transaction, loan := newTransaction(), newLoan()
+		transaction.PaidAmount = entities.Baht(3000)
+		var payment *entities.LoanPayment
+		transRepo.On("GetTransactionByID", "trans1").Return(transaction, nil)
+		transRepo.On("GetTransactionByIDForUpdate", "trans1").Return(transaction, nil)
+		transRepo.On("UpdateTransaction", transaction).Return(nil)
+		loanRepo.On("GetLoanByIDForUpdate", "loan1").Return(loan, nil)
+		loanRepo.On("UpdateLoanByID", loan).Return(loan, nil)
+		transRepo.On("CreatePayment", mock.AnythingOfType("*entities.LoanPayment")).Run(func(args mock.Arguments) {
+			payment = args.Get(0).(*entities.LoanPayment)
+		}).Return(nil)
+
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
+		result, err := useCase.PayTransaction("trans1", "user1", entities.Baht(4000))
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.TransactionPaid, result.Status)
+		assert.Equal(t, entities.LoanPaymentInstallment, payment.Kind)
+		assert.Equal(t, entities.Money(0), payment.Interest)
+		assert.Equal(t, entities.Baht(293750), payment.Balance)
+		assert.Equal(t, 47, loan.RemainingMonths)
+	})
+
+	t.Run("Failed - Payment exceeds amount due", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		transaction := newTransaction()
+		transaction.PaidAmount = entities.Baht(3000)
+		transRepo.On("GetTransactionByID", "trans1").Return(transaction, nil)
+		transRepo.On("GetTransactionByIDForUpdate", "trans1").Return(transaction, nil)
+		loanRepo.On("GetLoanByIDForUpdate", "loan1").Return(&entities.Loan{ID: "loan1", UserID: "user1"}, nil)
+
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
+		result, err := useCase.PayTransaction("trans1", "user1", entities.Baht(5000))
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "payment exceeds amount due")
+	})
+
+	t.Run("Failed - Checks the amount due on the locked transaction", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		locked := newTransaction()
+		locked.PaidAmount = entities.Baht(3000)
+		transRepo.On("GetTransactionByID", "trans1").Return(newTransaction(), nil)
+		loanRepo.On("GetLoanByIDForUpdate", "loan1").Return(newLoan(), nil)
+		transRepo.On("GetTransactionByIDForUpdate", "trans1").Return(locked, nil)
+
+		uow := mocks.NewMockLoanUnitOfWork(loanRepo, transRepo)
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, uow)
+		result, err := useCase.PayTransaction("trans1", "user1", entities.Baht(5000))
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "payment exceeds amount due")
+		assert.Equal(t, 1, uow.RolledBack)
+		transRepo.AssertNotCalled(t, "UpdateTransaction", mock.Anything)
+	})
+
+	t.Run("Failed - Rolls back when the payment cannot be recorded", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		transaction, loan := newTransaction(), newLoan()
+		transRepo.On("GetTransactionByID", "trans1").Return(transaction, nil)
+		loanRepo.On("GetLoanByIDForUpdate", "loan1").Return(loan, nil)
+		transRepo.On("GetTransactionByIDForUpdate", "trans1").Return(transaction, nil)
+		transRepo.On("UpdateTransaction", transaction).Return(nil)
+		loanRepo.On("UpdateLoanByID", loan).Return(loan, nil)
+		transRepo.On("CreatePayment", mock.AnythingOfType("*entities.LoanPayment")).Return(errors.New("db error"))
+
+		uow := mocks.NewMockLoanUnitOfWork(loanRepo, transRepo)
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, uow)
+		result, err := useCase.PayTransaction("trans1", "user1", entities.Baht(7000))
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "db error")
+		assert.Equal(t, 1, uow.RolledBack)
+		assert.Equal(t, 0, uow.Committed)
+	})
+
+	t.Run("Failed - Amount is not positive", func(t *testing.T) {
+		useCase := usecases.NewTransactionUseCase(new(mocks.MockTransRepository), new(mocks.MockLoanRepository), new(mocks.MockUserRepository), new(usecaseMocks.MockNotiDispatcher), new(mocks.MockUnitOfWork))
+		result, err := useCase.PayTransaction("trans1", "user1", 0)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "payment amount must be greater than zero")
+	})
+
+	t.Run("Failed - Transaction is already paid", func(t *testing.T) {
+		transRepo := new(mocks.MockTransRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+
+		transaction := newTransaction()
+		transaction.Status = entities.TransactionPaid
+		transRepo.On("GetTransactionByID", "trans1").Return(transaction, nil)
+		transRepo.On("GetTransactionByIDForUpdate", "trans1").Return(transaction, nil)
+		loanRepo.On("GetLoanByIDForUpdate", "loan1").Return(&entities.Loan{ID: "loan1", UserID: "user1"}, nil)
+
+		useCase := usecases.NewTransactionUseCase(transRepo, loanRepo, new(mocks.MockUserRepository), dispatcher, mocks.NewMockLoanUnitOfWork(loanRepo, transRepo))
+		result, err := useCase.PayTransaction("trans1", "user1", entities.Baht(100))
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "transaction is already paid")
+	})
+}
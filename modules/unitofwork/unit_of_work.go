@@ -0,0 +1,52 @@
+package unitofwork
+
+import (
+	assetRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/asset/repositories"
+	ledgerRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/ledger/repositories"
+	loanRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/loan/repositories"
+	portfolioRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/portfolio/repositories"
+	retirementRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/retirement_plan/repositories"
+	transRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/transaction/repositories"
+	userRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/repositories"
+	vehicleRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/vehicle/repositories"
+
+	"gorm.io/gorm"
+)
+
+type Repositories struct {
+	Users        userRepo.UserRepository
+	Assets       assetRepo.AssetRepository
+	Retirements  retirementRepo.RetirementRepository
+	Ledger       ledgerRepo.LedgerRepository
+	Vehicles     vehicleRepo.VehicleRepository
+	Portfolio    portfolioRepo.PortfolioRepository
+	Loans        loanRepo.LoanRepository
+	Transactions transRepo.TransRepository
+}
+
+type UnitOfWork interface {
+	Do(fn func(repos Repositories) error) error
+}
+
+type GormUnitOfWork struct {
+	db *gorm.DB
+}
+
+func NewGormUnitOfWork(db *gorm.DB) *GormUnitOfWork {
+	return &GormUnitOfWork{db: db}
+}
+
+func (u *GormUnitOfWork) Do(fn func(repos Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(Repositories{
+			Users:        userRepo.NewGormUserRepository(tx),
+			Assets:       assetRepo.NewGormAssetRepository(tx),
+			Retirements:  retirementRepo.NewGormRetirementRepository(tx),
+			Ledger:       ledgerRepo.NewGormLedgerRepository(tx),
+			Vehicles:     vehicleRepo.NewGormVehicleRepository(tx),
+			Portfolio:    portfolioRepo.NewGormPortfolioRepository(tx),
+			Loans:        loanRepo.NewGormLoanRepository(tx),
+			Transactions: transRepo.NewGormTransRepository(tx),
+		})
+	})
+}
@@ -3,7 +3,6 @@ package controllers
 import (
 	"fmt"
 	"mime/multipart"
-	"strconv"
 
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/user/usecases"
@@ -144,10 +143,12 @@ func (c *UserController) LoginHandler(ctx *fiber.Ctx) error {
 		"status_code": fiber.StatusOK,
 		"message":     "Login successful",
 		"result": fiber.Map{
-			"token": token,
-			"u_id":  user.ID,
-			"uname": user.Username,
-			"role":  user.Role.RoleName,
+			"token":         token.AccessToken,
+			"refresh_token": token.RefreshToken,
+			"expires_at":    token.ExpiresAt,
+			"u_id":          user.ID,
+			"uname":         user.Username,
+			"role":          user.Role.RoleName,
 		},
 	})
 }
@@ -200,10 +201,12 @@ func (c *UserController) LoginAdminHandler(ctx *fiber.Ctx) error {
 		"status_code": fiber.StatusOK,
 		"message":     "Login successful",
 		"result": fiber.Map{
-			"token": token,
-			"u_id":  user.ID,
-			"uname": user.Username,
-			"role":  user.Role.RoleName,
+			"token":         token.AccessToken,
+			"refresh_token": token.RefreshToken,
+			"expires_at":    token.ExpiresAt,
+			"u_id":          user.ID,
+			"uname":         user.Username,
+			"role":          user.Role.RoleName,
 		},
 	})
 }
@@ -262,10 +265,12 @@ func (c *UserController) LoginWithGoogleHandler(ctx *fiber.Ctx) error {
 		"status_code": fiber.StatusOK,
 		"message":     "Login successful",
 		"result": fiber.Map{
-			"token": token,
-			"u_id":  user.ID,
-			"uname": user.Username,
-			"role":  user.Role.RoleName,
+			"token":         token.AccessToken,
+			"refresh_token": token.RefreshToken,
+			"expires_at":    token.ExpiresAt,
+			"u_id":          user.ID,
+			"uname":         user.Username,
+			"role":          user.Role.RoleName,
 		},
 	})
 }
@@ -464,7 +469,67 @@ func (c *UserController) ChangedPasswordHandler(ctx *fiber.Ctx) error {
 	})
 }
 
+func (c *UserController) RefreshTokenHandler(ctx *fiber.Ctx) error {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      fiber.ErrBadRequest.Message,
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	if req.RefreshToken == "" {
+		return ctx.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.ErrBadRequest.Code,
+			"message":     "Refresh token is missing",
+			"result":      nil,
+		})
+	}
+
+	token, err := c.userusecase.RefreshToken(req.RefreshToken)
+	if err != nil {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Unauthorized",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Token refreshed successfully",
+		"result":      token,
+	})
+}
+
 func (c *UserController) LogoutHandler(ctx *fiber.Ctx) error {
+	sessionID, ok := ctx.Locals("session_id").(string)
+	if !ok || sessionID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing session ID",
+			"result":      nil,
+		})
+	}
+
+	if err := c.userusecase.Logout(sessionID); err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":      "Success",
 		"status_code": fiber.StatusOK,
@@ -473,6 +538,34 @@ func (c *UserController) LogoutHandler(ctx *fiber.Ctx) error {
 	})
 }
 
+func (c *UserController) LogoutAllHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	if err := c.userusecase.LogoutAll(userID); err != nil {
+		return ctx.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
+			"status":      fiber.ErrInternalServerError.Message,
+			"status_code": fiber.ErrInternalServerError.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Logged out from all devices successfully",
+		"result":      nil,
+	})
+}
+
 func (c *UserController) GetUserByIDHandler(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(string)
 	if !ok || userID == "" {
@@ -640,7 +733,7 @@ func (c *UserController) UpdateSelectedHouseHandler(ctx *fiber.Ctx) error {
 
 		transfers = make([]entities.TransferRequest, 0, len(types))
 		for i := 0; i < len(types); i++ {
-			amount, err := strconv.ParseFloat(amounts[i], 64)
+			amount, err := entities.ParseMoney(amounts[i])
 			if err != nil {
 				return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 					"status":      "Error",
@@ -654,7 +747,7 @@ func (c *UserController) UpdateSelectedHouseHandler(ctx *fiber.Ctx) error {
 				return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 					"status":      "Error",
 					"status_code": fiber.StatusBadRequest,
-					"message":     fmt.Sprintf("Amount cannot be negative at index %d: %f", i, amount),
+					"message":     fmt.Sprintf("Amount cannot be negative at index %d: %s", i, amount),
 					"result":      nil,
 				})
 			}
@@ -714,6 +807,35 @@ func (c *UserController) GetRetirementPlanHandler(ctx *fiber.Ctx) error {
 	})
 }
 
+func (c *UserController) GetReadinessReportHandler(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("user_id").(string)
+	if !ok || userID == "" {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":      "Error",
+			"status_code": fiber.StatusUnauthorized,
+			"message":     "Unauthorized: Missing user ID",
+			"result":      nil,
+		})
+	}
+
+	report, err := c.userusecase.GetReadinessReport(userID)
+	if err != nil {
+		return ctx.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"status":      fiber.ErrNotFound.Message,
+			"status_code": fiber.ErrNotFound.Code,
+			"message":     err.Error(),
+			"result":      nil,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":      "Success",
+		"status_code": fiber.StatusOK,
+		"message":     "Retirement readiness report retrieved successfully",
+		"result":      report,
+	})
+}
+
 func (c *UserController) CreateHistoryHandler(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals("user_id").(string)
 	if !ok || userID == "" {
@@ -127,7 +127,7 @@ func TestLoginHandler(t *testing.T) {
 			Role:     entities.Role{RoleName: "user"},
 		}
 
-		mockUseCase.On("Login", "test@example.com", "password123").Return("testtoken", user, nil).Once()
+		mockUseCase.On("Login", "test@example.com", "password123").Return(&entities.AuthToken{AccessToken: "testtoken"}, user, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(jsonBody))
 		req.Header.Set("Content-Type", "application/json")
@@ -176,7 +176,7 @@ func TestLoginHandler(t *testing.T) {
 		}
 		jsonBody, _ := json.Marshal(requestBody)
 
-		mockUseCase.On("Login", "test@example.com", "password123").Return("", &entities.User{}, errors.New("login failed")).Once()
+		mockUseCase.On("Login", "test@example.com", "password123").Return((*entities.AuthToken)(nil), &entities.User{}, errors.New("login failed")).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(jsonBody))
 		req.Header.Set("Content-Type", "application/json")
@@ -206,7 +206,7 @@ func TestLoginAdminHandler(t *testing.T) {
 			Role:     entities.Role{RoleName: "admin"},
 		}
 
-		mockUseCase.On("LoginAdmin", "admin@example.com", "adminpass").Return("admintoken", admin, nil).Once()
+		mockUseCase.On("LoginAdmin", "admin@example.com", "adminpass").Return(&entities.AuthToken{AccessToken: "admintoken"}, admin, nil).Once()
 
 		req := httptest.NewRequest(http.MethodPost, "/login-admin", bytes.NewReader(jsonBody))
 		req.Header.Set("Content-Type", "application/json")
@@ -241,7 +241,7 @@ func TestLoginWithGoogleHandler(t *testing.T) {
 			Username: "johndoe",
 			Role:     entities.Role{RoleName: "user"},
 		}
-		mockUserUsecase.On("LoginWithGoogle", mock.Anything).Return("token123", returnedUser, nil).Once()
+		mockUserUsecase.On("LoginWithGoogle", mock.Anything).Return(&entities.AuthToken{AccessToken: "token123"}, returnedUser, nil).Once()
 
 		reqBody := map[string]interface{}{
 			"fname":      "John",
@@ -279,7 +279,7 @@ func TestLoginWithGoogleHandler(t *testing.T) {
 			Username: "johndoe",
 			Role:     entities.Role{RoleName: "user"},
 		}
-		mockUserUsecase.On("LoginWithGoogle", mock.Anything).Return("token123", returnedUser, nil).Once()
+		mockUserUsecase.On("LoginWithGoogle", mock.Anything).Return(&entities.AuthToken{AccessToken: "token123"}, returnedUser, nil).Once()
 
 		reqBody := map[string]interface{}{
 			"fname":      "John",
@@ -325,7 +325,7 @@ func TestLoginWithGoogleHandler(t *testing.T) {
 	t.Run("Usecase Error", func(t *testing.T) {
 		mockUserUsecase.ExpectedCalls = nil
 
-		mockUserUsecase.On("LoginWithGoogle", mock.Anything).Return("", &entities.User{}, errors.New("database error")).Once()
+		mockUserUsecase.On("LoginWithGoogle", mock.Anything).Return((*entities.AuthToken)(nil), &entities.User{}, errors.New("database error")).Once()
 
 		reqBody := map[string]interface{}{
 			"fname":      "John",
@@ -559,12 +559,75 @@ func TestChangedPasswordHandler(t *testing.T) {
 	})
 }
 
+func TestRefreshTokenHandler(t *testing.T) {
+	controller, mockUseCase, app := setupTest(t)
+
+	app.Post("/refresh", controller.RefreshTokenHandler)
+
+	t.Run("Success", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(map[string]string{"refresh_token": "refresh123"})
+		token := &entities.AuthToken{AccessToken: "newtoken", RefreshToken: "newrefresh"}
+
+		mockUseCase.On("RefreshToken", "refresh123").Return(token, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewReader(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+
+		assert.Equal(t, "Success", result["status"])
+		resultData := result["result"].(map[string]interface{})
+		assert.Equal(t, "newtoken", resultData["token"])
+		assert.Equal(t, "newrefresh", resultData["refresh_token"])
+
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Missing Refresh Token", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(map[string]string{})
+
+		req := httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewReader(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, fiber.ErrBadRequest.Code, resp.StatusCode)
+
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+
+		assert.Equal(t, "Refresh token is missing", result["message"])
+	})
+
+	t.Run("Revoked Session", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(map[string]string{"refresh_token": "revoked"})
+
+		mockUseCase.On("RefreshToken", "revoked").Return((*entities.AuthToken)(nil), errors.New("session has been revoked")).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/refresh", bytes.NewReader(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
 func TestLogoutHandler(t *testing.T) {
-	controller, _, app := setupTest(t)
+	controller, mockUseCase, app := setupTest(t)
 
-	app.Post("/logout", controller.LogoutHandler)
+	app.Post("/logout", func(c *fiber.Ctx) error {
+		c.Locals("session_id", "session-123")
+		return controller.LogoutHandler(c)
+	})
 
 	t.Run("Success", func(t *testing.T) {
+		mockUseCase.On("Logout", "session-123").Return(nil).Once()
+
 		req := httptest.NewRequest(http.MethodPost, "/logout", nil)
 		resp, _ := app.Test(req)
 
@@ -575,6 +638,54 @@ func TestLogoutHandler(t *testing.T) {
 
 		assert.Equal(t, "Success", result["status"])
 		assert.Equal(t, "Logout successful", result["message"])
+
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Missing Session", func(t *testing.T) {
+		app := fiber.New()
+		app.Post("/logout", controller.LogoutHandler)
+
+		req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestLogoutAllHandler(t *testing.T) {
+	controller, mockUseCase, app := setupTest(t)
+
+	app.Post("/logout/all", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return controller.LogoutAllHandler(c)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockUseCase.On("LogoutAll", "user-123").Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/logout/all", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result map[string]interface{}
+		json.NewDecoder(resp.Body).Decode(&result)
+
+		assert.Equal(t, "Logged out from all devices successfully", result["message"])
+
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		mockUseCase.On("LogoutAll", "user-123").Return(errors.New("database error")).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/logout/all", nil)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, fiber.ErrInternalServerError.Code, resp.StatusCode)
+
+		mockUseCase.AssertExpectations(t)
 	})
 }
 
@@ -889,8 +1000,8 @@ func TestUpdateSelectedHouseHandler(t *testing.T) {
 		req.Header.Set("Content-Type", writer.FormDataContentType())
 
 		expectedTransfers := []entities.TransferRequest{
-			{Type: "cash", Name: "Donation", Amount: 1000.50},
-			{Type: "goods", Name: "Food", Amount: 500.25},
+			{Type: "cash", Name: "Donation", Amount: entities.MoneyFromFloat(1000.50)},
+			{Type: "goods", Name: "Food", Amount: entities.MoneyFromFloat(500.25)},
 		}
 
 		expectedHouse := entities.SelectedHouse{
@@ -1117,7 +1228,25 @@ func TestGetRetirementPlanHandler(t *testing.T) {
 	})
 
 	t.Run("Success", func(t *testing.T) {
-		expectedRetirementPlan := fiber.Map{
+		summary := &entities.RetirementSummary{
+			PlanName:               "My Retirement Plan",
+			AllRequiredFund:        entities.Baht(3000000),
+			StillNeed:              entities.Baht(1500000),
+			AllRetirementFund:      entities.Baht(2000000),
+			MonthlyExpenses:        entities.Baht(5000),
+			PlanSaving:             entities.Baht(300000),
+			AllMoney:               entities.Baht(1500000),
+			Saving:                 entities.Baht(800000),
+			Investment:             entities.Baht(700000),
+			AllAssetsExpense:       entities.Baht(2000),
+			NursingHouseExpense:    entities.Baht(1500),
+			PlanExpense:            entities.Baht(1500),
+			AnnualSavingsReturn:    3.5,
+			AnnualInvestmentReturn: 7.0,
+			Readiness:              &entities.ReadinessReport{Score: 50},
+		}
+
+		expectedRetirementPlan := map[string]interface{}{
 			"plan_name":                "My Retirement Plan",
 			"allRequiredFund":          float64(3000000),
 			"stillneed":                float64(1500000),
@@ -1134,7 +1263,7 @@ func TestGetRetirementPlanHandler(t *testing.T) {
 			"annual_investment_return": float64(7.0),
 		}
 
-		mockUseCase.On("CalculateRetirement", "test-user-id").Return(expectedRetirementPlan, nil).Once()
+		mockUseCase.On("CalculateRetirement", "test-user-id").Return(summary, nil).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/user/retirement-plan", nil)
 
@@ -1166,6 +1295,7 @@ func TestGetRetirementPlanHandler(t *testing.T) {
 		assert.Equal(t, expectedRetirementPlan["plan_expense"], resultData["plan_expense"])
 		assert.Equal(t, expectedRetirementPlan["annual_savings_return"], resultData["annual_savings_return"])
 		assert.Equal(t, expectedRetirementPlan["annual_investment_return"], resultData["annual_investment_return"])
+		assert.Equal(t, float64(50), resultData["readiness"].(map[string]interface{})["score"])
 
 		mockUseCase.AssertExpectations(t)
 	})
@@ -1188,7 +1318,7 @@ func TestGetRetirementPlanHandler(t *testing.T) {
 	})
 
 	t.Run("Error - Calculation Failed", func(t *testing.T) {
-		mockUseCase.On("CalculateRetirement", "test-user-id").Return(fiber.Map{}, errors.New("calculation error")).Once()
+		mockUseCase.On("CalculateRetirement", "test-user-id").Return(nil, errors.New("calculation error")).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/user/retirement-plan", nil)
 
@@ -1208,6 +1338,60 @@ func TestGetRetirementPlanHandler(t *testing.T) {
 	})
 }
 
+func TestGetReadinessReportHandler(t *testing.T) {
+	controller, mockUseCase, app := setupTest(t)
+
+	app.Get("/user/readiness", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "test-user-id")
+		return controller.GetReadinessReportHandler(c)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		report := &entities.ReadinessReport{
+			Score:          72,
+			MonthlySurplus: entities.Baht(20000),
+			Gaps: []entities.ReadinessGap{
+				{Goal: entities.ReadinessGoalAsset, ID: "asset-1", BehindBy: entities.MoneyFromFloat(6000.5), BehindSchedule: true},
+			},
+			Allocations: []entities.SurplusAllocation{
+				{Goal: entities.ReadinessGoalAsset, ID: "asset-1", Required: entities.Baht(1000), Amount: entities.Baht(7000)},
+			},
+		}
+		mockUseCase.On("GetReadinessReport", "test-user-id").Return(report, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/user/readiness", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var result map[string]interface{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		body := result["result"].(map[string]interface{})
+		assert.Equal(t, float64(72), body["score"])
+		assert.Equal(t, float64(20000), body["monthly_surplus"])
+		gap := body["gaps"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, 6000.5, gap["behind_by"])
+		assert.Equal(t, true, gap["behind_schedule"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Plan Not Found", func(t *testing.T) {
+		mockUseCase.On("GetReadinessReport", "test-user-id").Return(nil, errors.New("retirement plan not found")).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/user/readiness", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Unauthorized - Missing User ID", func(t *testing.T) {
+		app.Get("/user/readiness/unauthorized", controller.GetReadinessReportHandler)
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/user/readiness/unauthorized", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
 func TestCreateHistoryHandler(t *testing.T) {
 	controller, mockUseCase, app := setupTest(t)
 
@@ -1346,14 +1530,14 @@ func TestGetHistoryByUserIDHandler(t *testing.T) {
 					ID:     "history-123",
 					Method: "deposit",
 					Type:   "saving",
-					Money:  1000.0,
+					Money:  entities.Baht(1000),
 					UserID: "user-123",
 				},
 				{
 					ID:     "history-124",
 					Method: "withdraw",
 					Type:   "expense",
-					Money:  500.0,
+					Money:  entities.Baht(500),
 					UserID: "user-123",
 				},
 			},
@@ -1428,12 +1612,12 @@ func TestGetSummaryHistoryByUserIDHandler(t *testing.T) {
 	})
 
 	t.Run("Success", func(t *testing.T) {
-		expectedResponse := map[string]float64{
-			"January":  800.0,
-			"February": 950.0,
-			"March":    1200.0,
-			"April":    500.0,
-			"Total":    3450.0,
+		expectedResponse := map[string]entities.Money{
+			"January":  entities.Baht(800),
+			"February": entities.Baht(950),
+			"March":    entities.Baht(1200),
+			"April":    entities.Baht(500),
+			"Total":    entities.Baht(3450),
 		}
 
 		mockUseCase.On("GetHistoryByMonth", "user-123").Return(expectedResponse, nil).Once()
@@ -1479,7 +1663,7 @@ func TestGetSummaryHistoryByUserIDHandler(t *testing.T) {
 	})
 
 	t.Run("History Not Found", func(t *testing.T) {
-		mockUseCase.On("GetHistoryByMonth", "user-123").Return(map[string]float64{}, errors.New("history not found")).Once()
+		mockUseCase.On("GetHistoryByMonth", "user-123").Return(map[string]entities.Money{}, errors.New("history not found")).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/history/summary", nil)
 		resp, _ := app.Test(req)
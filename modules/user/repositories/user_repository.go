@@ -6,6 +6,7 @@ import (
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GormUserRepository struct {
@@ -27,14 +28,23 @@ type UserRepository interface {
 	GetOTPByUserID(userID string) (*entities.OTP, error)
 	DeleteOTP(userID string) error
 
+	CreateSession(session *entities.Session) error
+	GetSessionByID(id string) (*entities.Session, error)
+	GetSessionByRefreshToken(refreshToken string) (*entities.Session, error)
+	RotateRefreshToken(id, oldToken, newToken string, expiresAt time.Time) error
+	RevokeSession(id string) error
+	RevokeSessionsByUserID(userID string) error
+
 	GetSelectedHouse(userID string) (*entities.SelectedHouse, error)
+	GetSelectedHouseForUpdate(userID string) (*entities.SelectedHouse, error)
 	UpdateSelectedHouse(selectedHouse *entities.SelectedHouse) (*entities.SelectedHouse, error)
 
 	CreateHistory(history *entities.History) (*entities.History, error)
 	GetHistoryByUserID(userID string) ([]entities.History, error)
 	GetHistoryInRange(userID string, startDate, endDate time.Time) ([]entities.History, error)
 	GetUserDepositsInRange(userID string, startDate, endDate time.Time) ([]entities.History, error)
-	GetUserHistoryByMonth(userID string) (map[string]float64, error)
+	GetUserHistoryByMonth(userID string) (map[string]entities.Money, error)
+	GetPortfolioSnapshots(userID string) ([]entities.PortfolioSnapshot, error)
 }
 
 func (r *GormUserRepository) CreateUser(user *entities.User) (*entities.User, error) {
@@ -80,7 +90,7 @@ func (r *GormUserRepository) FindUserByEmail(email string) (*entities.User, erro
 
 func (r *GormUserRepository) GetUserByID(id string) (*entities.User, error) {
 	var user entities.User
-	err := r.db.Preload("Quiz.Risk").Preload("Role").Preload("Assets").Preload("RetirementPlan").Preload("House.NursingHouse.Images").Where("id = ?", id).First(&user).Error
+	err := r.db.Preload("Quiz.Risk").Preload("Role").Preload("Assets").Preload("Vehicles").Preload("Holdings").Preload("RetirementPlan", "is_active = ?", true).Preload("House.NursingHouse.Images").Where("id = ?", id).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -98,6 +108,16 @@ func (r *GormUserRepository) GetSelectedHouse(userID string) (*entities.Selected
 	return &selectedHouse, nil
 }
 
+func (r *GormUserRepository) GetSelectedHouseForUpdate(userID string) (*entities.SelectedHouse, error) {
+	var selectedHouse entities.SelectedHouse
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("NursingHouse.Images").Where("user_id = ?", userID).First(&selectedHouse).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &selectedHouse, nil
+}
+
 func (r *GormUserRepository) GetRoleByName(name string) (entities.Role, error) {
 	var role entities.Role
 	err := r.db.Where("role_name = ?", name).First(&role).Error
@@ -155,6 +175,53 @@ func (r *GormUserRepository) DeleteOTP(userID string) error {
 	return nil
 }
 
+func (r *GormUserRepository) CreateSession(session *entities.Session) error {
+	return r.db.Create(session).Error
+}
+
+func (r *GormUserRepository) GetSessionByID(id string) (*entities.Session, error) {
+	var session entities.Session
+	if err := r.db.Where("id = ?", id).First(&session).Error; err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *GormUserRepository) GetSessionByRefreshToken(refreshToken string) (*entities.Session, error) {
+	var session entities.Session
+	if err := r.db.Where("refresh_token = ?", refreshToken).First(&session).Error; err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *GormUserRepository) RotateRefreshToken(id, oldToken, newToken string, expiresAt time.Time) error {
+	result := r.db.Model(&entities.Session{}).Where("id = ? AND refresh_token = ? AND revoked_at IS NULL", id, oldToken).Updates(map[string]interface{}{
+		"refresh_token": newToken,
+		"expires_at":    expiresAt,
+	})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *GormUserRepository) RevokeSession(id string) error {
+	return r.db.Model(&entities.Session{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now()).Error
+}
+
+func (r *GormUserRepository) RevokeSessionsByUserID(userID string) error {
+	return r.db.Model(&entities.Session{}).Where("user_id = ? AND revoked_at IS NULL", userID).Update("revoked_at", time.Now()).Error
+}
+
 func (r *GormUserRepository) CreateHistory(history *entities.History) (*entities.History, error) {
 	if err := r.db.Create(&history).Error; err != nil {
 		return nil, err
@@ -190,13 +257,22 @@ func (r *GormUserRepository) GetUserDepositsInRange(userID string, startDate, en
 	return histories, nil
 }
 
-func (r *GormUserRepository) GetUserHistoryByMonth(userID string) (map[string]float64, error) {
+func (r *GormUserRepository) GetPortfolioSnapshots(userID string) ([]entities.PortfolioSnapshot, error) {
+	var snapshots []entities.PortfolioSnapshot
+	if err := r.db.Where("user_id = ?", userID).Order("date ASC").Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+func (r *GormUserRepository) GetUserHistoryByMonth(userID string) (map[string]entities.Money, error) {
 	var histories []entities.History
 	if err := r.db.Where("user_id = ?", userID).Find(&histories).Error; err != nil {
 		return nil, err
 	}
 
-	historyByMonth := make(map[string]float64)
+	historyByMonth := make(map[string]entities.Money)
 	for _, history := range histories {
 		monthKey := history.TrackDate.Format("2006-01")
 		if history.Method == "deposit" {
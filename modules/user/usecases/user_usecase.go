@@ -2,7 +2,6 @@ package usecases
 
 import (
 	"errors"
-	"math"
 	"mime/multipart"
 	"os"
 	"time"
@@ -10,10 +9,12 @@ import (
 	"github.com/XzerozZ/Kasian_Phrom_BE/configs"
 	assetRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/asset/repositories"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
-	notiRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/notification/repositories"
+	ledger "github.com/XzerozZ/Kasian_Phrom_BE/modules/ledger/usecases"
+	loanRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/loan/repositories"
+	notiUsecase "github.com/XzerozZ/Kasian_Phrom_BE/modules/notification/usecases"
 	nhRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/nursing_house/repositories"
 	retirementRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/retirement_plan/repositories"
-	"github.com/XzerozZ/Kasian_Phrom_BE/modules/socket"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/unitofwork"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/user/repositories"
 	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
 
@@ -21,60 +22,131 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 type UserUseCase interface {
 	Register(user *entities.User, roleName string) (*entities.User, error)
-	Login(email, password string) (string, *entities.User, error)
-	LoginAdmin(email, password string) (string, *entities.User, error)
-	LoginWithGoogle(user *entities.User) (string, *entities.User, error)
+	Login(email, password string) (*entities.AuthToken, *entities.User, error)
+	LoginAdmin(email, password string) (*entities.AuthToken, *entities.User, error)
+	LoginWithGoogle(user *entities.User) (*entities.AuthToken, *entities.User, error)
+	RefreshToken(refreshToken string) (*entities.AuthToken, error)
+	Logout(sessionID string) error
+	LogoutAll(userID string) error
 	ResetPassword(userID, oldPassword, newPassword string) error
 	GetUserByID(userID string) (*entities.User, error)
 	UpdateUserByID(id string, user entities.User, files *multipart.FileHeader, ctx *fiber.Ctx) (*entities.User, error)
 	ForgotPassword(email string) error
 	VerifyOTP(email, otpCode string) error
 	ChangedPassword(email, newPassword string) error
-	CalculateRetirement(userID string) (fiber.Map, error)
+	CalculateRetirement(userID string) (*entities.RetirementSummary, error)
+	GetReadinessReport(userID string) (*entities.ReadinessReport, error)
 
 	GetSelectedHouse(userID string) (*entities.SelectedHouse, error)
 	UpdateSelectedHouse(userID, nursingHouseID string, transfers []entities.TransferRequest) (*entities.SelectedHouse, error)
 
 	CreateHistory(history entities.History) (*entities.History, error)
 	GetHistoryByUserID(userID string) (fiber.Map, error)
-	GetHistoryByMonth(userID string) (map[string]float64, error)
+	GetHistoryByMonth(userID string) (map[string]entities.Money, error)
 }
 
 type UserUseCaseImpl struct {
 	userrepo       repositories.UserRepository
 	retirementrepo retirementRepo.RetirementRepository
 	assetrepo      assetRepo.AssetRepository
-	notirepo       notiRepo.NotiRepository
+	dispatcher     notiUsecase.NotiDispatcher
 	nhrepo         nhRepo.NhRepository
-	jwtSecret      string
+	loanrepo       loanRepo.LoanRepository
+	jwt            configs.JWT
 	supa           configs.Supabase
 	mail           configs.Mail
+	uow            unitofwork.UnitOfWork
 }
 
-func NewUserUseCase(userrepo repositories.UserRepository, retirementrepo retirementRepo.RetirementRepository, assetrepo assetRepo.AssetRepository, notirepo notiRepo.NotiRepository, nhrepo nhRepo.NhRepository, jwt configs.JWT, supa configs.Supabase, mail configs.Mail) *UserUseCaseImpl {
+func NewUserUseCase(userrepo repositories.UserRepository, retirementrepo retirementRepo.RetirementRepository, assetrepo assetRepo.AssetRepository, dispatcher notiUsecase.NotiDispatcher, nhrepo nhRepo.NhRepository, loanrepo loanRepo.LoanRepository, jwt configs.JWT, supa configs.Supabase, mail configs.Mail, uow unitofwork.UnitOfWork) *UserUseCaseImpl {
 	return &UserUseCaseImpl{
 		userrepo:       userrepo,
 		retirementrepo: retirementrepo,
 		assetrepo:      assetrepo,
-		notirepo:       notirepo,
+		dispatcher:     dispatcher,
 		nhrepo:         nhrepo,
-		jwtSecret:      jwt.Secret,
+		loanrepo:       loanrepo,
+		jwt:            jwt,
 		supa:           supa,
 		mail:           mail,
+		uow:            uow,
 	}
 }
 
 const (
-	defaultHouseID   = "00001"
-	dateLayout       = "02-01-2006"
-	statusCompleted  = "Completed"
-	statusInProgress = "In_Progress"
+	dateLayout         = "02-01-2006"
+	refreshTokenLength = 64
 )
 
+func (u *UserUseCaseImpl) accessExpiration() time.Duration {
+	if u.jwt.AccessExpiration <= 0 {
+		return configs.DefaultAccessExpiration
+	}
+
+	return u.jwt.AccessExpiration
+}
+
+func (u *UserUseCaseImpl) refreshExpiration() time.Duration {
+	if u.jwt.RefreshExpiration <= 0 {
+		return configs.DefaultRefreshExpiration
+	}
+
+	return u.jwt.RefreshExpiration
+}
+
+func (u *UserUseCaseImpl) signAccessToken(user *entities.User, sessionID string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(u.accessExpiration())
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": user.ID,
+		"role":    user.Role.RoleName,
+		"sid":     sessionID,
+		"iat":     now.Unix(),
+		"exp":     expiresAt.Unix(),
+	})
+
+	tokenString, err := token.SignedString([]byte(u.jwt.Secret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expiresAt, nil
+}
+
+func (u *UserUseCaseImpl) createSession(user *entities.User) (*entities.AuthToken, error) {
+	refreshToken, err := utils.GenerateRandomOTP(refreshTokenLength, false)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &entities.Session{
+		ID:           uuid.New().String(),
+		UserID:       user.ID,
+		RefreshToken: utils.HashToken(refreshToken),
+		ExpiresAt:    time.Now().Add(u.refreshExpiration()),
+	}
+
+	accessToken, expiresAt, err := u.signAccessToken(user, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.userrepo.CreateSession(session); err != nil {
+		return nil, err
+	}
+
+	return &entities.AuthToken{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
 func (u *UserUseCaseImpl) Register(user *entities.User, roleName string) (*entities.User, error) {
 	normalizedEmail, err := utils.NormalizeEmail(user.Email)
 	if err != nil {
@@ -109,88 +181,78 @@ func (u *UserUseCaseImpl) Register(user *entities.User, roleName string) (*entit
 	return createdUser, nil
 }
 
-func (u *UserUseCaseImpl) LoginAdmin(email, password string) (string, *entities.User, error) {
+func (u *UserUseCaseImpl) LoginAdmin(email, password string) (*entities.AuthToken, *entities.User, error) {
 	normalizedEmail, err := utils.NormalizeEmail(email)
 	if err != nil {
-		return "", nil, errors.New("invalid email format")
+		return nil, nil, errors.New("invalid email format")
 	}
 
 	email = normalizedEmail
 	user, err := u.userrepo.FindUserByEmail(email)
 	if err != nil {
-		return "", nil, errors.New("invalid email")
+		return nil, nil, errors.New("invalid email")
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", nil, errors.New("invalid password")
+		return nil, nil, errors.New("invalid password")
 	}
 
 	if user.Role.RoleName != "Admin" {
-		return "", nil, errors.New("access denied: only admins can login")
+		return nil, nil, errors.New("access denied: only admins can login")
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"role":    user.Role.RoleName,
-	})
-
-	tokenString, err := token.SignedString([]byte(u.jwtSecret))
+	token, err := u.createSession(user)
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
 	}
 
-	return tokenString, user, nil
+	return token, user, nil
 }
 
-func (u *UserUseCaseImpl) Login(email, password string) (string, *entities.User, error) {
+func (u *UserUseCaseImpl) Login(email, password string) (*entities.AuthToken, *entities.User, error) {
 	normalizedEmail, err := utils.NormalizeEmail(email)
 	if err != nil {
-		return "", nil, errors.New("invalid email format")
+		return nil, nil, errors.New("invalid email format")
 	}
 
 	email = normalizedEmail
 	user, err := u.userrepo.FindUserByEmail(email)
 	if err != nil {
-		return "", nil, errors.New("invalid email")
+		return nil, nil, errors.New("invalid email")
 	}
 
 	if user.Provider != "Credentials" {
-		return "", nil, errors.New("this email is already registered with another authentication method")
+		return nil, nil, errors.New("this email is already registered with another authentication method")
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", nil, errors.New("invalid password")
+		return nil, nil, errors.New("invalid password")
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"role":    user.Role.RoleName,
-	})
-
-	tokenString, err := token.SignedString([]byte(u.jwtSecret))
+	token, err := u.createSession(user)
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
 	}
 
-	return tokenString, user, nil
+	return token, user, nil
 }
 
-func (u *UserUseCaseImpl) LoginWithGoogle(user *entities.User) (string, *entities.User, error) {
+func (u *UserUseCaseImpl) LoginWithGoogle(user *entities.User) (*entities.AuthToken, *entities.User, error) {
 	normalizedEmail, err := utils.NormalizeEmail(user.Email)
 	if err != nil {
-		return "", nil, errors.New("invalid email format")
+		return nil, nil, errors.New("invalid email format")
 	}
 
 	user.Email = normalizedEmail
 	account, err := u.userrepo.FindUserByEmail(user.Email)
 	if err == nil {
 		if account.Provider != "Google" {
-			return "", nil, errors.New("this email is already registered with another authentication method")
+			return nil, nil, errors.New("this email is already registered with another authentication method")
 		}
 	} else {
 		role, err := u.userrepo.GetRoleByName("User")
 		if err != nil {
-			return "", nil, errors.New("role not found")
+			return nil, nil, errors.New("role not found")
 		}
 
 		user.ID = uuid.New().String()
@@ -198,22 +260,70 @@ func (u *UserUseCaseImpl) LoginWithGoogle(user *entities.User) (string, *entitie
 		user.Role = role
 		user.Provider = "Google"
 
-		if _, err := u.userrepo.CreateUser(user); err != nil {
-			return "", nil, err
+		account, err = u.userrepo.CreateUser(user)
+		if err != nil {
+			return nil, nil, err
 		}
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": account.ID,
-		"role":    account.Role.RoleName,
-	})
+	token, err := u.createSession(account)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return token, account, nil
+}
+
+func (u *UserUseCaseImpl) RefreshToken(refreshToken string) (*entities.AuthToken, error) {
+	session, err := u.userrepo.GetSessionByRefreshToken(utils.HashToken(refreshToken))
+	if err != nil {
+		return nil, errors.New("invalid refresh token")
+	}
+
+	if session.RevokedAt != nil {
+		return nil, errors.New("session has been revoked")
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return nil, errors.New("refresh token is expired")
+	}
 
-	tokenString, err := token.SignedString([]byte(u.jwtSecret))
+	user, err := u.userrepo.GetUserByID(session.UserID)
 	if err != nil {
-		return "", nil, err
+		return nil, err
+	}
+
+	newRefreshToken, err := utils.GenerateRandomOTP(refreshTokenLength, false)
+	if err != nil {
+		return nil, err
 	}
 
-	return tokenString, account, nil
+	accessToken, expiresAt, err := u.signAccessToken(user, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.userrepo.RotateRefreshToken(session.ID, session.RefreshToken, utils.HashToken(newRefreshToken), time.Now().Add(u.refreshExpiration())); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid refresh token")
+		}
+
+		return nil, err
+	}
+
+	return &entities.AuthToken{
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+func (u *UserUseCaseImpl) Logout(sessionID string) error {
+	return u.userrepo.RevokeSession(sessionID)
+}
+
+func (u *UserUseCaseImpl) LogoutAll(userID string) error {
+	return u.userrepo.RevokeSessionsByUserID(userID)
 }
 
 func (u *UserUseCaseImpl) ResetPassword(userID, oldPassword, newPassword string) error {
@@ -241,7 +351,7 @@ func (u *UserUseCaseImpl) ResetPassword(userID, oldPassword, newPassword string)
 		return err
 	}
 
-	return nil
+	return u.userrepo.RevokeSessionsByUserID(user.ID)
 }
 
 func (u *UserUseCaseImpl) GetUserByID(userID string) (*entities.User, error) {
@@ -255,7 +365,7 @@ func (u *UserUseCaseImpl) GetSelectedHouse(userID string) (*entities.SelectedHou
 	}
 
 	currentYear, currentMonth := time.Now().Year(), int(time.Now().Month())
-	if house.NursingHouseID == defaultHouseID {
+	if house.NursingHouseID == entities.DefaultNursingHouseID {
 		house.MonthlyExpenses = 0
 		house.LastCalculatedMonth = 0
 		return u.userrepo.UpdateSelectedHouse(house)
@@ -267,7 +377,7 @@ func (u *UserUseCaseImpl) GetSelectedHouse(userID string) (*entities.SelectedHou
 			return nil, err
 		}
 
-		monthlyExpenses, err := utils.CalculateNursingHouseMonthlyExpense(user, float64(house.NursingHouse.Price), int(currentYear), currentMonth)
+		monthlyExpenses, err := utils.CalculateNursingHouseMonthlyExpense(user, house.NursingHouse.Price, int(currentYear), currentMonth)
 		if err != nil {
 			return nil, err
 		}
@@ -289,6 +399,14 @@ func (u *UserUseCaseImpl) UpdateUserByID(id string, user entities.User, file *mu
 	existingUser.Firstname = user.Firstname
 	existingUser.Lastname = user.Lastname
 	existingUser.Username = user.Username
+	if user.Locale != "" {
+		if !utils.IsSupportedLocale(user.Locale) {
+			return nil, errors.New("unsupported locale")
+		}
+
+		existingUser.Locale = user.Locale
+	}
+
 	if file != nil {
 		fileName := uuid.New().String() + ".jpg"
 		if err := ctx.SaveFile(file, "./uploads/"+fileName); err != nil {
@@ -317,158 +435,179 @@ func (u *UserUseCaseImpl) UpdateUserByID(id string, user entities.User, file *mu
 }
 
 func (u *UserUseCaseImpl) UpdateSelectedHouse(userID, nursingHouseID string, transfers []entities.TransferRequest) (*entities.SelectedHouse, error) {
-	selectedHouse, err := u.userrepo.GetSelectedHouse(userID)
-	if err != nil {
-		return nil, err
-	}
-
-	user, err := u.userrepo.GetUserByID(userID)
-	if err != nil {
-		return nil, err
-	}
-
-	if nursingHouseID == defaultHouseID {
-		totalTransfer := 0.0
-		for _, transfer := range transfers {
-			totalTransfer += transfer.Amount
+	var updatedHouse *entities.SelectedHouse
+	var notifications []*entities.Notification
+	err := u.uow.Do(func(repos unitofwork.Repositories) error {
+		selectedHouse, err := repos.Users.GetSelectedHouseForUpdate(userID)
+		if err != nil {
+			return err
 		}
 
-		if totalTransfer > selectedHouse.CurrentMoney {
-			return nil, errors.New("transfer amount exceeds House's current money")
+		user, err := repos.Users.GetUserByID(userID)
+		if err != nil {
+			return err
 		}
 
-		for _, transfer := range transfers {
-			switch transfer.Type {
-			case "asset":
-				selectedItem, err := u.assetrepo.FindAssetByNameandUserID(transfer.Name, userID)
-				if err != nil {
-					return nil, err
-				}
+		if nursingHouseID == entities.DefaultNursingHouseID {
+			var totalTransfer entities.Money
+			for _, transfer := range transfers {
+				totalTransfer += transfer.Amount
+			}
+
+			if totalTransfer > selectedHouse.CurrentMoney {
+				return errors.New("transfer amount exceeds House's current money")
+			}
+
+			legs := []ledger.Leg{{Kind: entities.LedgerAccountHouse, RefID: userID, Name: selectedHouse.NursingHouse.Name, Amount: -selectedHouse.CurrentMoney}}
+
+			for _, transfer := range transfers {
+				switch transfer.Type {
+				case "asset":
+					selectedItem, err := repos.Assets.FindAssetByNameandUserIDForUpdate(transfer.Name, userID)
+					if err != nil {
+						return err
+					}
 
-				if selectedItem.Status == "In_Progress" {
-					selectedItem.CurrentMoney += transfer.Amount
+					if selectedItem.Status == "In_Progress" {
+						selectedItem.CurrentMoney += transfer.Amount
+						his := entities.History{
+							ID:           uuid.New().String(),
+							Method:       "deposit",
+							Type:         "saving_money",
+							Category:     "asset",
+							Name:         selectedItem.Name,
+							Money:        transfer.Amount,
+							TransferFrom: selectedHouse.NursingHouse.Name,
+							UserID:       userID,
+							TrackDate:    time.Now(),
+						}
+
+						_, err = repos.Users.CreateHistory(&his)
+						if err != nil {
+							return err
+						}
+
+						if selectedItem.CurrentMoney >= selectedItem.TotalCost {
+							selectedItem.Status = "Completed"
+							selectedItem.MonthlyExpenses = 0
+							selectedItem.LastCalculatedMonth = 0
+							notifications = append(notifications, utils.SuccessNotification("asset", userID, selectedItem.Name, selectedItem.ID, selectedItem.CurrentMoney))
+						}
+
+						_, err = repos.Assets.UpdateAssetByID(selectedItem)
+						if err != nil {
+							return err
+						}
+
+						legs = append(legs, ledger.Leg{Kind: entities.LedgerAccountAsset, RefID: selectedItem.ID, Name: selectedItem.Name, Amount: transfer.Amount, Balance: selectedItem.CurrentMoney})
+					} else {
+						return errors.New("cannot update completed or paused asset")
+					}
+
+				case "retirementplan":
+					retirement, err := repos.Retirements.GetRetirementByUserIDForUpdate(userID)
+					if err != nil {
+						return err
+					}
+
+					retirement.CurrentSavings += transfer.Amount
 					his := entities.History{
 						ID:           uuid.New().String(),
 						Method:       "deposit",
 						Type:         "saving_money",
-						Category:     "asset",
-						Name:         selectedItem.Name,
+						Category:     "retirementplan",
+						Name:         retirement.PlanName,
 						Money:        transfer.Amount,
 						TransferFrom: selectedHouse.NursingHouse.Name,
 						UserID:       userID,
 						TrackDate:    time.Now(),
 					}
 
-					_, err = u.userrepo.CreateHistory(&his)
+					_, err = repos.Users.CreateHistory(&his)
 					if err != nil {
-						return nil, err
+						return err
 					}
 
-					if selectedItem.CurrentMoney >= selectedItem.TotalCost {
-						selectedItem.Status = "Completed"
-						selectedItem.MonthlyExpenses = 0
-						selectedItem.LastCalculatedMonth = 0
-						notification := utils.SuccessNotification("asset", userID, selectedItem.Name, selectedItem.ID, selectedItem.CurrentMoney)
-						_ = u.notirepo.CreateNotification(notification)
-						socket.SendNotificationToUser(userID, *notification)
+					allMoney := retirement.CurrentSavings + retirement.CurrentTotalInvestment
+					if allMoney >= retirement.LastRequiredFunds {
+						retirement.Status = "Completed"
+						retirement.LastMonthlyExpenses = 0
+						retirement.LastMonthlyExpenses = 0
+						notifications = append(notifications, utils.SuccessNotification("retirementplan", userID, retirement.PlanName, retirement.ID, allMoney))
 					}
-
-					_, err = u.assetrepo.UpdateAssetByID(selectedItem)
+					_, err = repos.Retirements.UpdateRetirementPlan(retirement)
 					if err != nil {
-						return nil, err
+						return err
 					}
-				} else {
-					return nil, errors.New("cannot update completed or paused asset")
-				}
 
-			case "retirementplan":
-				retirement, err := u.retirementrepo.GetRetirementByUserID(userID)
-				if err != nil {
-					return nil, err
+					legs = append(legs, ledger.Leg{Kind: entities.LedgerAccountRetirementSavings, RefID: userID, Name: retirement.PlanName, Amount: transfer.Amount, Balance: retirement.CurrentSavings})
+				default:
+					continue
 				}
+			}
 
-				retirement.CurrentSavings += transfer.Amount
-				his := entities.History{
-					ID:           uuid.New().String(),
-					Method:       "deposit",
-					Type:         "saving_money",
-					Category:     "retirementplan",
-					Name:         retirement.PlanName,
-					Money:        transfer.Amount,
-					TransferFrom: selectedHouse.NursingHouse.Name,
-					UserID:       userID,
-					TrackDate:    time.Now(),
-				}
+			his := entities.History{
+				ID:        uuid.New().String(),
+				Method:    "withdraw",
+				Type:      "saving_money",
+				Category:  "house",
+				Name:      selectedHouse.NursingHouse.Name,
+				Money:     selectedHouse.CurrentMoney - totalTransfer,
+				UserID:    userID,
+				TrackDate: time.Now(),
+			}
 
-				_, err = u.userrepo.CreateHistory(&his)
-				if err != nil {
-					return nil, err
-				}
+			_, err = repos.Users.CreateHistory(&his)
+			if err != nil {
+				return err
+			}
 
-				allMoney := retirement.CurrentSavings + retirement.CurrentTotalInvestment
-				if allMoney >= retirement.LastRequiredFunds {
-					retirement.Status = "Completed"
-					retirement.LastMonthlyExpenses = 0
-					retirement.LastMonthlyExpenses = 0
-					notification := utils.SuccessNotification("retirementplan", userID, retirement.PlanName, retirement.ID, allMoney)
-					_ = u.notirepo.CreateNotification(notification)
-					socket.SendNotificationToUser(userID, *notification)
-				}
-				_, err = u.retirementrepo.UpdateRetirementPlan(retirement)
-				if err != nil {
-					return nil, err
-				}
-			default:
-				continue
+			if _, err := ledger.PostEntry(repos.Ledger, userID, "Close house plan "+selectedHouse.NursingHouse.Name, time.Now(), legs...); err != nil {
+				return err
 			}
-		}
 
-		his := entities.History{
-			ID:        uuid.New().String(),
-			Method:    "withdraw",
-			Type:      "saving_money",
-			Category:  "house",
-			Name:      selectedHouse.NursingHouse.Name,
-			Money:     selectedHouse.CurrentMoney - totalTransfer,
-			UserID:    userID,
-			TrackDate: time.Now(),
+			selectedHouse.NursingHouseID = nursingHouseID
+			selectedHouse.Status = entities.StatusCompleted
+			selectedHouse.LastCalculatedMonth = 0
+			selectedHouse.CurrentMoney = 0
+			selectedHouse.MonthlyExpenses = 0
 		}
 
-		_, err = u.userrepo.CreateHistory(&his)
-		if err != nil {
-			return nil, err
-		}
+		if nursingHouseID != selectedHouse.NursingHouseID || selectedHouse.LastCalculatedMonth != int(time.Now().Month()) {
+			nursingHouse, err := u.nhrepo.GetNhByID(nursingHouseID)
+			if err != nil {
+				return err
+			}
 
-		selectedHouse.NursingHouseID = nursingHouseID
-		selectedHouse.Status = statusCompleted
-		selectedHouse.LastCalculatedMonth = 0
-		selectedHouse.CurrentMoney = 0
-		selectedHouse.MonthlyExpenses = 0
-	}
+			currentYear, currentMonth := time.Now().Year(), int(time.Now().Month())
+			selectedHouse.Status = "In_Progress"
+			monthlyExpenses, err := utils.CalculateNursingHouseMonthlyExpense(user, nursingHouse.Price, int(currentYear), currentMonth)
+			if err != nil {
+				return err
+			}
 
-	if nursingHouseID != selectedHouse.NursingHouseID || selectedHouse.LastCalculatedMonth != int(time.Now().Month()) {
-		nursingHouse, err := u.nhrepo.GetNhByID(nursingHouseID)
-		if err != nil {
-			return nil, err
+			selectedHouse.MonthlyExpenses = monthlyExpenses
+			selectedHouse.NursingHouseID = nursingHouseID
+			selectedHouse.LastCalculatedMonth = currentMonth
+			requiredMoney := nursingHouse.Price.Mul((user.RetirementPlan.ExpectLifespan - user.RetirementPlan.RetirementAge) * 12)
+			if requiredMoney < user.House.CurrentMoney {
+				selectedHouse.Status = entities.StatusCompleted
+			}
 		}
 
-		currentYear, currentMonth := time.Now().Year(), int(time.Now().Month())
-		selectedHouse.Status = "In_Progress"
-		monthlyExpenses, err := utils.CalculateNursingHouseMonthlyExpense(user, float64(nursingHouse.Price), int(currentYear), currentMonth)
-		if err != nil {
-			return nil, err
-		}
+		updatedHouse, err = repos.Users.UpdateSelectedHouse(selectedHouse)
+		return err
+	})
 
-		selectedHouse.MonthlyExpenses = monthlyExpenses
-		selectedHouse.NursingHouseID = nursingHouseID
-		selectedHouse.LastCalculatedMonth = currentMonth
-		requiredMoney := (user.RetirementPlan.ExpectLifespan - user.RetirementPlan.RetirementAge) * 12 * nursingHouse.Price
-		if float64(requiredMoney) < user.House.CurrentMoney {
-			selectedHouse.Status = statusCompleted
-		}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, notification := range notifications {
+		_ = u.dispatcher.Dispatch(notification)
 	}
 
-	return u.userrepo.UpdateSelectedHouse(selectedHouse)
+	return updatedHouse, nil
 }
 
 func (u *UserUseCaseImpl) ForgotPassword(email string) error {
@@ -555,13 +694,13 @@ func (u *UserUseCaseImpl) ChangedPassword(email, newPassword string) error {
 		return err
 	}
 
-	return nil
+	return u.userrepo.RevokeSessionsByUserID(user.ID)
 }
 
-func (u *UserUseCaseImpl) CalculateRetirement(userID string) (fiber.Map, error) {
+func (u *UserUseCaseImpl) CalculateRetirement(userID string) (*entities.RetirementSummary, error) {
 	user, err := u.userrepo.GetUserByID(userID)
 	if err != nil {
-		return fiber.Map{}, err
+		return nil, err
 	}
 
 	plan := user.RetirementPlan
@@ -571,7 +710,7 @@ func (u *UserUseCaseImpl) CalculateRetirement(userID string) (fiber.Map, error)
 		return nil, err
 	}
 
-	var allAssetsExpense, allTotalCost float64
+	var allAssetsExpense, allTotalCost entities.Money
 	assetSavingsforAll := utils.CalculateAllAssetSavings(user, "All")
 	assetSavingsforPlan := utils.CalculateAllAssetSavings(user, "Plan")
 	for _, asset := range user.Assets {
@@ -589,16 +728,16 @@ func (u *UserUseCaseImpl) CalculateRetirement(userID string) (fiber.Map, error)
 		}
 	}
 
-	var nursingHousePrice float64
+	var nursingHousePrice entities.Money
 	cost := user.House.CurrentMoney
 	if user.House.Status == "Completed" {
-		cost = float64((plan.ExpectLifespan - plan.RetirementAge) * 12 * user.House.NursingHouse.Price)
+		cost = user.House.NursingHouse.Price.Mul((plan.ExpectLifespan - plan.RetirementAge) * 12)
 	}
 
 	if user.House.LastCalculatedMonth == currentMonth {
 		nursingHousePrice = user.House.MonthlyExpenses
 	} else if user.House.LastCalculatedMonth != currentMonth {
-		nursingHousePrice, err = utils.CalculateNursingHouseMonthlyExpense(user, float64(user.House.NursingHouse.Price), currentYear, currentMonth)
+		nursingHousePrice, err = utils.CalculateNursingHouseMonthlyExpense(user, user.House.NursingHouse.Price, currentYear, currentMonth)
 		if err != nil {
 			return nil, err
 		}
@@ -611,7 +750,7 @@ func (u *UserUseCaseImpl) CalculateRetirement(userID string) (fiber.Map, error)
 		}
 	}
 
-	var planExpense float64
+	var planExpense entities.Money
 	if plan.LastCalculatedMonth == currentMonth {
 		planExpense = plan.LastMonthlyExpenses
 	} else if plan.LastCalculatedMonth != currentMonth {
@@ -637,205 +776,388 @@ func (u *UserUseCaseImpl) CalculateRetirement(userID string) (fiber.Map, error)
 	currentMonthEnd := currentMonthStart.AddDate(0, 1, 0).Add(-time.Nanosecond)
 	deposits, err := u.userrepo.GetUserDepositsInRange(userID, currentMonthStart, currentMonthEnd)
 	if err != nil {
-		return fiber.Map{}, err
+		return nil, err
 	}
 
-	totalDeposits := 0.0
+	var totalDeposits entities.Money
 	for _, history := range deposits {
 		totalDeposits += history.Money
 	}
 
-	totalNursingHouseCost := float64((plan.ExpectLifespan - plan.RetirementAge) * 12 * user.House.NursingHouse.Price)
+	var vehicleSavings, vehiclePayout, vehiclePension entities.Money
+	if len(user.Vehicles) > 0 {
+		contributions, err := u.userrepo.GetUserDepositsInRange(userID, time.Time{}, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		projections, err := utils.ProjectVehicles(&plan, user.Vehicles, contributions, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, projection := range projections {
+			vehicleSavings += projection.CurrentMoney
+			vehiclePayout += projection.TotalPayout
+			vehiclePension += projection.MonthlyPension
+		}
+	}
+
+	totalNursingHouseCost := user.House.NursingHouse.Price.Mul((plan.ExpectLifespan - plan.RetirementAge) * 12)
 	allRequiredFund := plan.LastRequiredFunds + totalNursingHouseCost + allTotalCost
 	adjustedMonthlyExpenses := (planExpense + nursingHousePrice + allAssetsExpense) - totalDeposits
 	savingforPlan := moneyForPlan + assetSavingsforPlan + cost
 	savingforAll := assetSavingsforAll + user.House.CurrentMoney + plan.CurrentSavings
-	allMoney := savingforAll + plan.CurrentTotalInvestment
-	stillNeed := allRequiredFund - savingforPlan
-	response := fiber.Map{
-		"plan_name":                plan.PlanName,
-		"allRequiredFund":          math.Round(allRequiredFund),             //จำนวนเงินที่ต้องการทั้งหมด
-		"stillneed":                math.Round(stillNeed),                   //ขาดอีก
-		"allretirementfund":        math.Round(plan.LastRequiredFunds),      //เงินเกษียณที่ต้องการทั้งหมด
-		"monthly_expenses":         math.Round(adjustedMonthlyExpenses),     //เงินที่ต้องผ่อนเดือนนี้ทั้งหมด - เงินที่เก็บเดือนนี้ทั้งหมด
-		"plan_saving":              math.Round(plan.CurrentSavings),         //เงินออมของแผน
-		"all_money":                math.Round(allMoney),                    //เงินสุทธิ
-		"saving":                   math.Round(savingforAll),                //เงินออมทั้งหมด
-		"investment":               math.Round(plan.CurrentTotalInvestment), //เงินลงทุน
-		"all_assets_expense":       math.Round(allAssetsExpense),            //ราคาบ้านพักต่อเดือน
-		"nursingHouse_expense":     math.Round(nursingHousePrice),           //ราคาของทรัพย์สินที่ต้องผ่อนต่อเดือนทั้งหมด
-		"plan_expense":             math.Round(planExpense),
-		"annual_savings_return":    plan.AnnualSavingsReturn,
-		"annual_investment_return": plan.AnnualInvestmentReturn,
+	allMoney := savingforAll + plan.CurrentTotalInvestment + vehicleSavings
+	stillNeed := allRequiredFund - savingforPlan - vehiclePayout
+	loans, _, err := u.loanrepo.GetLoanByUserID(userID)
+	if err != nil {
+		return nil, err
 	}
 
-	return response, nil
+	readiness, err := utils.CalculateReadiness(user, loans, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.RetirementSummary{
+		PlanName:               plan.PlanName,
+		AllRequiredFund:        allRequiredFund.Round(entities.OneBaht),
+		StillNeed:              stillNeed.Round(entities.OneBaht),
+		AllRetirementFund:      plan.LastRequiredFunds.Round(entities.OneBaht),
+		MonthlyExpenses:        adjustedMonthlyExpenses.Round(entities.OneBaht),
+		PlanSaving:             plan.CurrentSavings.Round(entities.OneBaht),
+		AllMoney:               allMoney.Round(entities.OneBaht),
+		Saving:                 savingforAll.Round(entities.OneBaht),
+		Investment:             plan.CurrentTotalInvestment.Round(entities.OneBaht),
+		AllAssetsExpense:       allAssetsExpense.Round(entities.OneBaht),
+		NursingHouseExpense:    nursingHousePrice.Round(entities.OneBaht),
+		PlanExpense:            planExpense.Round(entities.OneBaht),
+		AnnualSavingsReturn:    plan.AnnualSavingsReturn,
+		AnnualInvestmentReturn: plan.AnnualInvestmentReturn,
+		VehicleSavings:         vehicleSavings.Round(entities.OneBaht),
+		VehiclePayout:          vehiclePayout.Round(entities.OneBaht),
+		VehiclePension:         vehiclePension.Round(entities.OneBaht),
+		Readiness:              readiness,
+	}, nil
 }
 
-func (u *UserUseCaseImpl) CreateHistory(history entities.History) (*entities.History, error) {
-	user, err := u.userrepo.GetUserByID(history.UserID)
+func (u *UserUseCaseImpl) GetReadinessReport(userID string) (*entities.ReadinessReport, error) {
+	user, err := u.userrepo.GetUserByID(userID)
 	if err != nil {
 		return nil, err
 	}
 
-	if history.Money <= 0 {
-		return nil, errors.New("money must be greater than zero")
+	if user.RetirementPlan.ID == "" {
+		return nil, errors.New("retirement plan not found")
 	}
 
-	history.ID = uuid.New().String()
-	history.TrackDate = time.Now()
-	switch history.Method {
-	case "deposit":
-		if history.Type == "saving_money" {
-			switch history.Category {
-			case "spread":
-				var validAssets []entities.Asset
-				for _, asset := range user.Assets {
-					if asset.Status == "In_Progress" {
-						validAssets = append(validAssets, asset)
-					}
-				}
+	loans, _, err := u.loanrepo.GetLoanByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
 
-				var validHouse *entities.SelectedHouse
-				if user.House.NursingHouseID != "00001" && user.House.Status != "Completed" {
-					validHouse = &user.House
-				}
+	return utils.CalculateReadiness(user, loans, time.Now())
+}
 
-				count := len(validAssets)
-				if validHouse != nil {
-					count++
-				}
+func (u *UserUseCaseImpl) CreateHistory(history entities.History) (*entities.History, error) {
+	var createdHistory *entities.History
+	var notifications []*entities.Notification
+	err := u.uow.Do(func(repos unitofwork.Repositories) error {
+		user, err := repos.Users.GetUserByID(history.UserID)
+		if err != nil {
+			return err
+		}
 
-				var validPlan *entities.RetirementPlan
-				if user.RetirementPlan.Status != "Completed" {
-					validPlan = &user.RetirementPlan
-				}
+		if history.Money <= 0 {
+			return errors.New("money must be greater than zero")
+		}
 
-				if validPlan != nil {
-					count++
-				}
+		if history.Type == "investment" && len(user.Holdings) > 0 {
+			return errors.New("investment is tracked by portfolio holdings")
+		}
+
+		house, err := repos.Users.GetSelectedHouseForUpdate(user.ID)
+		if err != nil {
+			return err
+		}
 
-				amounts := history.Money / float64(count)
-				for i := range validAssets {
-					validAssets[i].CurrentMoney += amounts
-					if validAssets[i].CurrentMoney >= validAssets[i].TotalCost {
-						validAssets[i].Status = "Completed"
-						validAssets[i].MonthlyExpenses = 0
-						validAssets[i].LastCalculatedMonth = 0
-						notification := utils.SuccessNotification("asset", user.ID, validAssets[i].Name, validAssets[i].ID, validAssets[i].CurrentMoney)
-						_ = u.notirepo.CreateNotification(notification)
-						socket.SendNotificationToUser(user.ID, *notification)
+		retirement, err := repos.Retirements.GetRetirementByUserIDForUpdate(user.ID)
+		if err != nil {
+			return err
+		}
+
+		user.House = *house
+		user.RetirementPlan = *retirement
+		var legs []ledger.Leg
+
+		history.ID = uuid.New().String()
+		history.TrackDate = time.Now()
+		switch history.Method {
+		case "deposit":
+			if history.Type == "saving_money" {
+				switch history.Category {
+				case "spread":
+					var validAssets []entities.Asset
+					for _, asset := range user.Assets {
+						if asset.Status != "In_Progress" {
+							continue
+						}
+
+						lockedAsset, err := repos.Assets.GetAssetByIDForUpdate(asset.ID)
+						if err != nil {
+							return err
+						}
+
+						if lockedAsset.Status == "In_Progress" {
+							validAssets = append(validAssets, *lockedAsset)
+						}
 					}
 
-					if _, err := u.assetrepo.UpdateAssetByID(&validAssets[i]); err != nil {
-						return nil, err
+					var validHouse *entities.SelectedHouse
+					if user.House.NursingHouseID != "00001" && user.House.Status != "Completed" {
+						validHouse = &user.House
 					}
-				}
 
-				if validHouse != nil {
-					user.House.CurrentMoney += amounts
-					requiredMoney := (user.RetirementPlan.ExpectLifespan - user.RetirementPlan.RetirementAge) * 12 * user.House.NursingHouse.Price
-					if user.House.CurrentMoney >= float64(requiredMoney) {
-						user.House.Status = "Completed"
-						user.House.MonthlyExpenses = 0
-						user.House.LastCalculatedMonth = 0
-						notification := utils.SuccessNotification("house", user.ID, user.House.NursingHouse.Name, user.House.NursingHouseID, user.House.CurrentMoney)
-						_ = u.notirepo.CreateNotification(notification)
-						socket.SendNotificationToUser(user.ID, *notification)
+					count := len(validAssets)
+					if validHouse != nil {
+						count++
 					}
 
-				}
+					var validPlan *entities.RetirementPlan
+					if user.RetirementPlan.Status != "Completed" {
+						validPlan = &user.RetirementPlan
+					}
 
-				if validPlan != nil {
-					user.RetirementPlan.CurrentSavings += amounts
+					if validPlan != nil {
+						count++
+					}
+
+					shares := history.Money.Split(count)
+					for i := range validAssets {
+						validAssets[i].CurrentMoney += shares[i]
+						if validAssets[i].CurrentMoney >= validAssets[i].TotalCost {
+							validAssets[i].Status = "Completed"
+							validAssets[i].MonthlyExpenses = 0
+							validAssets[i].LastCalculatedMonth = 0
+							notifications = append(notifications, utils.SuccessNotification("asset", user.ID, validAssets[i].Name, validAssets[i].ID, validAssets[i].CurrentMoney))
+						}
+
+						if _, err := repos.Assets.UpdateAssetByID(&validAssets[i]); err != nil {
+							return err
+						}
+
+						legs = append(legs, ledger.Leg{Kind: entities.LedgerAccountAsset, RefID: validAssets[i].ID, Name: validAssets[i].Name, Amount: shares[i], Balance: validAssets[i].CurrentMoney})
+					}
+
+					if validHouse != nil {
+						share := shares[len(validAssets)]
+						user.House.CurrentMoney += share
+						legs = append(legs, houseLeg(user, share))
+						requiredMoney := user.House.NursingHouse.Price.Mul((user.RetirementPlan.ExpectLifespan - user.RetirementPlan.RetirementAge) * 12)
+						if user.House.CurrentMoney >= requiredMoney {
+							user.House.Status = "Completed"
+							user.House.MonthlyExpenses = 0
+							user.House.LastCalculatedMonth = 0
+							notifications = append(notifications, utils.SuccessNotification("house", user.ID, user.House.NursingHouse.Name, user.House.NursingHouseID, user.House.CurrentMoney))
+						}
+
+					}
+
+					if validPlan != nil {
+						share := shares[count-1]
+						user.RetirementPlan.CurrentSavings += share
+						legs = append(legs, savingsLeg(user, share))
+						allMoney := user.RetirementPlan.CurrentSavings + user.RetirementPlan.CurrentTotalInvestment
+						if allMoney >= user.RetirementPlan.LastRequiredFunds {
+							user.RetirementPlan.Status = "Completed"
+							user.RetirementPlan.LastMonthlyExpenses = 0
+							user.RetirementPlan.LastMonthlyExpenses = 0
+							notifications = append(notifications, utils.SuccessNotification("retirementplan", user.ID, user.RetirementPlan.PlanName, user.RetirementPlan.ID, allMoney))
+						}
+					}
+				case "retirementplan":
+					user.RetirementPlan.CurrentSavings += history.Money
+					legs = append(legs, savingsLeg(user, history.Money))
 					allMoney := user.RetirementPlan.CurrentSavings + user.RetirementPlan.CurrentTotalInvestment
 					if allMoney >= user.RetirementPlan.LastRequiredFunds {
 						user.RetirementPlan.Status = "Completed"
 						user.RetirementPlan.LastMonthlyExpenses = 0
 						user.RetirementPlan.LastMonthlyExpenses = 0
-						notification := utils.SuccessNotification("retirementplan", user.ID, user.RetirementPlan.PlanName, user.RetirementPlan.ID, allMoney)
-						_ = u.notirepo.CreateNotification(notification)
-						socket.SendNotificationToUser(user.ID, *notification)
+						notifications = append(notifications, utils.SuccessNotification("retirementplan", user.ID, user.RetirementPlan.PlanName, user.RetirementPlan.ID, allMoney))
+					}
+
+				case "house":
+					if user.House.NursingHouseID != "00001" || user.House.Status != "Completed" {
+						user.House.CurrentMoney += history.Money
+						legs = append(legs, houseLeg(user, history.Money))
+						requiredMoney := user.House.NursingHouse.Price.Mul((user.RetirementPlan.ExpectLifespan - user.RetirementPlan.RetirementAge) * 12)
+						if user.House.CurrentMoney >= requiredMoney {
+							user.House.Status = "Completed"
+							user.House.MonthlyExpenses = 0
+							user.House.LastCalculatedMonth = 0
+							notifications = append(notifications, utils.SuccessNotification("house", user.ID, user.House.NursingHouse.Name, user.House.NursingHouseID, user.House.CurrentMoney))
+						}
+					} else {
+						return errors.New("cannot update completed nursing house")
+					}
+
+				case "asset":
+					asset, err := repos.Assets.FindAssetByNameandUserIDForUpdate(history.Name, history.UserID)
+					if err != nil {
+						return err
 					}
+
+					if asset.Status == "In_Progress" {
+						asset.CurrentMoney += history.Money
+						if asset.CurrentMoney >= asset.TotalCost {
+							asset.Status = "Completed"
+							asset.MonthlyExpenses = 0
+							asset.LastCalculatedMonth = 0
+							notifications = append(notifications, utils.SuccessNotification("asset", user.ID, asset.Name, asset.ID, asset.CurrentMoney))
+						}
+
+						_, err = repos.Assets.UpdateAssetByID(asset)
+						if err != nil {
+							return err
+						}
+
+						legs = append(legs, ledger.Leg{Kind: entities.LedgerAccountAsset, RefID: asset.ID, Name: asset.Name, Amount: history.Money, Balance: asset.CurrentMoney})
+					} else {
+						return errors.New("cannot update completed or paused asset")
+					}
+
+				default:
+					return errors.New("invalid category for saving_money")
+				}
+			} else if history.Type == entities.HistoryTypeVehicle {
+				vehicle, err := repos.Vehicles.FindVehicleByNameAndUserIDForUpdate(history.Name, history.UserID)
+				if err != nil {
+					return err
+				}
+
+				yearStart := time.Date(history.TrackDate.Year(), time.January, 1, 0, 0, 0, 0, history.TrackDate.Location())
+				contributions, err := repos.Users.GetUserDepositsInRange(user.ID, yearStart, history.TrackDate)
+				if err != nil {
+					return err
 				}
-			case "retirementplan":
-				user.RetirementPlan.CurrentSavings += history.Money
+
+				if err := utils.CheckVehicleContribution(vehicle, history.Money, user.RetirementPlan.MonthlyIncome.Mul(12), contributions, history.TrackDate); err != nil {
+					return err
+				}
+
+				vehicle.CurrentMoney += history.Money
+				if _, err := repos.Vehicles.UpdateVehicle(vehicle); err != nil {
+					return err
+				}
+
+				history.Category = vehicle.Type
+				legs = append(legs, vehicleLeg(vehicle, history.Money))
+			} else if history.Type == "investment" {
+				user.RetirementPlan.CurrentTotalInvestment += history.Money
+				legs = append(legs, investmentLeg(user, history.Money))
 				allMoney := user.RetirementPlan.CurrentSavings + user.RetirementPlan.CurrentTotalInvestment
 				if allMoney >= user.RetirementPlan.LastRequiredFunds {
 					user.RetirementPlan.Status = "Completed"
 					user.RetirementPlan.LastMonthlyExpenses = 0
 					user.RetirementPlan.LastMonthlyExpenses = 0
-					notification := utils.SuccessNotification("retirementplan", user.ID, user.RetirementPlan.PlanName, user.RetirementPlan.ID, allMoney)
-					_ = u.notirepo.CreateNotification(notification)
-					socket.SendNotificationToUser(user.ID, *notification)
+					notifications = append(notifications, utils.SuccessNotification("retirementplan", user.ID, user.RetirementPlan.PlanName, user.RetirementPlan.ID, allMoney))
 				}
+			}
 
-			case "house":
-				if user.House.NursingHouseID != "00001" || user.House.Status != "Completed" {
-					user.House.CurrentMoney += history.Money
-					requiredMoney := (user.RetirementPlan.ExpectLifespan - user.RetirementPlan.RetirementAge) * 12 * user.House.NursingHouse.Price
-					if user.House.CurrentMoney >= float64(requiredMoney) {
-						user.House.Status = "Completed"
-						user.House.MonthlyExpenses = 0
-						user.House.LastCalculatedMonth = 0
-						notification := utils.SuccessNotification("house", user.ID, user.House.NursingHouse.Name, user.House.NursingHouseID, user.House.CurrentMoney)
-						_ = u.notirepo.CreateNotification(notification)
-						socket.SendNotificationToUser(user.ID, *notification)
+		case "withdraw":
+			if history.Type == "saving_money" {
+				switch history.Category {
+				case "retirementplan":
+					if user.RetirementPlan.CurrentSavings < history.Money {
+						return errors.New("insufficient funds in retirement savings")
 					}
-				} else {
-					return nil, errors.New("cannot update completed nursing house")
+
+					user.RetirementPlan.CurrentSavings -= history.Money
+					legs = append(legs, savingsLeg(user, -history.Money))
+					allMoney := user.RetirementPlan.CurrentSavings + user.RetirementPlan.CurrentTotalInvestment
+					if allMoney >= user.RetirementPlan.LastRequiredFunds {
+						user.RetirementPlan.Status = "Completed"
+						user.RetirementPlan.LastMonthlyExpenses = 0
+						user.RetirementPlan.LastMonthlyExpenses = 0
+					} else {
+						user.RetirementPlan.Status = "In_Progress"
+					}
+				case "house":
+					if user.House.NursingHouseID != "00001" || user.House.Status != "Completed" {
+						if user.House.CurrentMoney < history.Money {
+							return errors.New("insufficient funds for house savings")
+						}
+
+						user.House.CurrentMoney -= history.Money
+						legs = append(legs, houseLeg(user, -history.Money))
+					} else {
+						return errors.New("cannot update completed nursing house")
+					}
+
+				case "asset":
+					asset, err := repos.Assets.FindAssetByNameandUserIDForUpdate(history.Name, history.UserID)
+					if err != nil {
+						return err
+					}
+
+					if asset.Status != "Completed" {
+						if asset.CurrentMoney < history.Money {
+							return errors.New("insufficient funds for asset savings")
+						}
+
+						asset.CurrentMoney -= history.Money
+						_, err = repos.Assets.UpdateAssetByID(asset)
+						if err != nil {
+							return err
+						}
+
+						legs = append(legs, ledger.Leg{Kind: entities.LedgerAccountAsset, RefID: asset.ID, Name: asset.Name, Amount: -history.Money, Balance: asset.CurrentMoney})
+					} else {
+						return errors.New("cannot update completed asset")
+					}
+
+				default:
+					return errors.New("invalid category for saving_money")
+				}
+			} else if history.Type == entities.HistoryTypeVehicle {
+				vehicle, err := repos.Vehicles.FindVehicleByNameAndUserIDForUpdate(history.Name, history.UserID)
+				if err != nil {
+					return err
+				}
+
+				if vehicle.CurrentMoney < history.Money {
+					return errors.New("insufficient funds in savings vehicle")
 				}
 
-			case "asset":
-				asset, err := u.assetrepo.FindAssetByNameandUserID(history.Name, history.UserID)
+				contributions, err := repos.Users.GetUserDepositsInRange(user.ID, time.Time{}, history.TrackDate)
 				if err != nil {
-					return nil, err
+					return err
 				}
 
-				if asset.Status == "In_Progress" {
-					asset.CurrentMoney += history.Money
-					if asset.CurrentMoney >= asset.TotalCost {
-						asset.Status = "Completed"
-						asset.MonthlyExpenses = 0
-						asset.LastCalculatedMonth = 0
-						notification := utils.SuccessNotification("asset", user.ID, asset.Name, asset.ID, asset.CurrentMoney)
-						_ = u.notirepo.CreateNotification(notification)
-						socket.SendNotificationToUser(user.ID, *notification)
-					}
+				withdrawable, _, err := utils.VehicleWithdrawable(vehicle, &user.RetirementPlan, contributions, history.TrackDate)
+				if err != nil {
+					return err
+				}
 
-					_, err = u.assetrepo.UpdateAssetByID(asset)
-					if err != nil {
-						return nil, err
-					}
-				} else {
-					return nil, errors.New("cannot update completed or paused asset")
+				if withdrawable < history.Money {
+					return errors.New("savings vehicle is still locked")
 				}
 
-			default:
-				return nil, errors.New("invalid category for saving_money")
-			}
-		} else if history.Type == "investment" {
-			user.RetirementPlan.CurrentTotalInvestment += history.Money
-			allMoney := user.RetirementPlan.CurrentSavings + user.RetirementPlan.CurrentTotalInvestment
-			if allMoney >= user.RetirementPlan.LastRequiredFunds {
-				user.RetirementPlan.Status = "Completed"
-				user.RetirementPlan.LastMonthlyExpenses = 0
-				user.RetirementPlan.LastMonthlyExpenses = 0
-				notification := utils.SuccessNotification("retirementplan", user.ID, user.RetirementPlan.PlanName, user.RetirementPlan.ID, allMoney)
-				_ = u.notirepo.CreateNotification(notification)
-				socket.SendNotificationToUser(user.ID, *notification)
-			}
-		}
+				vehicle.CurrentMoney -= history.Money
+				if _, err := repos.Vehicles.UpdateVehicle(vehicle); err != nil {
+					return err
+				}
 
-	case "withdraw":
-		if history.Type == "saving_money" {
-			switch history.Category {
-			case "retirementplan":
-				if user.RetirementPlan.CurrentSavings < history.Money {
-					return nil, errors.New("insufficient funds in retirement savings")
+				history.Category = vehicle.Type
+				legs = append(legs, vehicleLeg(vehicle, -history.Money))
+			} else if history.Type == "investment" {
+				if user.RetirementPlan.CurrentTotalInvestment < history.Money {
+					return errors.New("insufficient investment funds")
 				}
 
-				user.RetirementPlan.CurrentSavings -= history.Money
+				user.RetirementPlan.CurrentTotalInvestment -= history.Money
+				legs = append(legs, investmentLeg(user, -history.Money))
 				allMoney := user.RetirementPlan.CurrentSavings + user.RetirementPlan.CurrentTotalInvestment
 				if allMoney >= user.RetirementPlan.LastRequiredFunds {
 					user.RetirementPlan.Status = "Completed"
@@ -844,78 +1166,57 @@ func (u *UserUseCaseImpl) CreateHistory(history entities.History) (*entities.His
 				} else {
 					user.RetirementPlan.Status = "In_Progress"
 				}
-			case "house":
-				if user.House.NursingHouseID != "00001" || user.House.Status != "Completed" {
-					if user.House.CurrentMoney < history.Money {
-						return nil, errors.New("insufficient funds for house savings")
-					}
-
-					user.House.CurrentMoney -= history.Money
-				} else {
-					return nil, errors.New("cannot update completed nursing house")
-				}
-
-			case "asset":
-				asset, err := u.assetrepo.FindAssetByNameandUserID(history.Name, history.UserID)
-				if err != nil {
-					return nil, err
-				}
-
-				if asset.Status != "Completed" {
-					if asset.CurrentMoney < history.Money {
-						return nil, errors.New("insufficient funds for asset savings")
-					}
+			}
 
-					asset.CurrentMoney -= history.Money
-					_, err = u.assetrepo.UpdateAssetByID(asset)
-					if err != nil {
-						return nil, err
-					}
-				} else {
-					return nil, errors.New("cannot update completed asset")
-				}
+		default:
+			return errors.New("invalid method type")
+		}
 
-			default:
-				return nil, errors.New("invalid category for saving_money")
-			}
-		} else if history.Type == "investment" {
-			if user.RetirementPlan.CurrentTotalInvestment < history.Money {
-				return nil, errors.New("insufficient investment funds")
-			}
+		_, err = repos.Users.UpdateSelectedHouse(&user.House)
+		if err != nil {
+			return err
+		}
 
-			user.RetirementPlan.CurrentTotalInvestment -= history.Money
-			allMoney := user.RetirementPlan.CurrentSavings + user.RetirementPlan.CurrentTotalInvestment
-			if allMoney >= user.RetirementPlan.LastRequiredFunds {
-				user.RetirementPlan.Status = "Completed"
-				user.RetirementPlan.LastMonthlyExpenses = 0
-				user.RetirementPlan.LastMonthlyExpenses = 0
-			} else {
-				user.RetirementPlan.Status = "In_Progress"
-			}
+		_, err = repos.Retirements.UpdateRetirementPlan(&user.RetirementPlan)
+		if err != nil {
+			return err
 		}
 
-	default:
-		return nil, errors.New("invalid method type")
-	}
+		if _, err := ledger.PostEntry(repos.Ledger, user.ID, history.Method+" "+history.Type, history.TrackDate, legs...); err != nil {
+			return err
+		}
 
-	_, err = u.userrepo.UpdateSelectedHouse(&user.House)
-	if err != nil {
-		return nil, err
-	}
+		createdHistory, err = repos.Users.CreateHistory(&history)
+		return err
+	})
 
-	_, err = u.retirementrepo.UpdateRetirementPlan(&user.RetirementPlan)
 	if err != nil {
 		return nil, err
 	}
 
-	createdHistory, err := u.userrepo.CreateHistory(&history)
-	if err != nil {
-		return nil, err
+	for _, notification := range notifications {
+		_ = u.dispatcher.Dispatch(notification)
 	}
 
 	return createdHistory, nil
 }
 
+func houseLeg(user *entities.User, amount entities.Money) ledger.Leg {
+	return ledger.Leg{Kind: entities.LedgerAccountHouse, RefID: user.ID, Name: user.House.NursingHouse.Name, Amount: amount, Balance: user.House.CurrentMoney}
+}
+
+func savingsLeg(user *entities.User, amount entities.Money) ledger.Leg {
+	return ledger.Leg{Kind: entities.LedgerAccountRetirementSavings, RefID: user.ID, Name: user.RetirementPlan.PlanName, Amount: amount, Balance: user.RetirementPlan.CurrentSavings}
+}
+
+func investmentLeg(user *entities.User, amount entities.Money) ledger.Leg {
+	return ledger.Leg{Kind: entities.LedgerAccountRetirementInvestment, RefID: user.ID, Name: user.RetirementPlan.PlanName, Amount: amount, Balance: user.RetirementPlan.CurrentTotalInvestment}
+}
+
+func vehicleLeg(vehicle *entities.SavingsVehicle, amount entities.Money) ledger.Leg {
+	return ledger.Leg{Kind: entities.LedgerAccountVehicle, RefID: vehicle.ID, Name: vehicle.Name, Amount: amount, Balance: vehicle.CurrentMoney}
+}
+
 func (u *UserUseCaseImpl) GetHistoryByUserID(userID string) (fiber.Map, error) {
 	data, err := u.userrepo.GetHistoryByUserID(userID)
 	if err != nil {
@@ -929,7 +1230,7 @@ func (u *UserUseCaseImpl) GetHistoryByUserID(userID string) (fiber.Map, error) {
 		return fiber.Map{}, err
 	}
 
-	total := 0.0
+	var total entities.Money
 	for _, history := range histories {
 		if history.Method == "deposit" {
 			total += history.Money
@@ -946,11 +1247,20 @@ func (u *UserUseCaseImpl) GetHistoryByUserID(userID string) (fiber.Map, error) {
 	return response, nil
 }
 
-func (u *UserUseCaseImpl) GetHistoryByMonth(userID string) (map[string]float64, error) {
+func (u *UserUseCaseImpl) GetHistoryByMonth(userID string) (map[string]entities.Money, error) {
 	historyByMonth, err := u.userrepo.GetUserHistoryByMonth(userID)
 	if err != nil {
 		return nil, err
 	}
 
+	snapshots, err := u.userrepo.GetPortfolioSnapshots(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for month, change := range utils.ValuationChangeByMonth(snapshots) {
+		historyByMonth[month] += change
+	}
+
 	return historyByMonth, nil
 }
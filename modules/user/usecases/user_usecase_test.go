@@ -9,27 +9,31 @@ import (
 	"github.com/XzerozZ/Kasian_Phrom_BE/configs"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/user/usecases"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
 	"github.com/XzerozZ/Kasian_Phrom_BE/testing/repositories/mocks"
+	usecaseMocks "github.com/XzerozZ/Kasian_Phrom_BE/testing/usecases/mocks"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/valyala/fasthttp"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 func TestRegister(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
 	supaConfig := configs.Supabase{}
 	mailConfig := configs.Mail{}
 
-	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 	t.Run("Success", func(t *testing.T) {
 		user := &entities.User{
@@ -98,15 +102,16 @@ func TestRegister(t *testing.T) {
 func TestLogin(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
 	supaConfig := configs.Supabase{}
 	mailConfig := configs.Mail{}
 
-	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 	t.Run("Success", func(t *testing.T) {
 		password := "password123"
@@ -123,14 +128,18 @@ func TestLogin(t *testing.T) {
 		}
 
 		userRepo.On("FindUserByEmail", "test@example.com").Return(user, nil)
+		userRepo.On("CreateSession", mock.MatchedBy(func(s *entities.Session) bool {
+			return s.UserID == "user-id-1" && s.RefreshToken != "" && s.ExpiresAt.After(time.Now())
+		})).Return(nil).Once()
 
 		token, result, err := useCase.Login("test@example.com", password)
 
 		assert.NoError(t, err)
-		assert.NotEmpty(t, token)
+		assert.NotEmpty(t, token.AccessToken)
+		assert.NotEmpty(t, token.RefreshToken)
 		assert.Equal(t, user, result)
 
-		parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+		parsedToken, err := jwt.Parse(token.AccessToken, func(token *jwt.Token) (interface{}, error) {
 			return []byte(jwtConfig.Secret), nil
 		})
 
@@ -141,6 +150,8 @@ func TestLogin(t *testing.T) {
 		assert.True(t, ok)
 		assert.Equal(t, "user-id-1", claims["user_id"])
 		assert.Equal(t, "User", claims["role"])
+		assert.NotEmpty(t, claims["sid"])
+		assert.NotNil(t, claims["exp"])
 
 		userRepo.AssertExpectations(t)
 	})
@@ -185,15 +196,16 @@ func TestLogin(t *testing.T) {
 func TestLoginAdmin(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
 	supaConfig := configs.Supabase{}
 	mailConfig := configs.Mail{}
 
-	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 	t.Run("Success", func(t *testing.T) {
 		password := "password123"
@@ -209,6 +221,7 @@ func TestLoginAdmin(t *testing.T) {
 		}
 
 		userRepo.On("FindUserByEmail", "admin@example.com").Return(user, nil)
+		userRepo.On("CreateSession", mock.AnythingOfType("*entities.Session")).Return(nil).Once()
 
 		token, result, err := useCase.LoginAdmin("admin@example.com", password)
 
@@ -248,15 +261,16 @@ func TestLoginAdmin(t *testing.T) {
 func TestLoginWithGoogle(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
 	supaConfig := configs.Supabase{}
 	mailConfig := configs.Mail{}
 
-	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 	t.Run("Existing User", func(t *testing.T) {
 		existingUser := &entities.User{
@@ -273,6 +287,7 @@ func TestLoginWithGoogle(t *testing.T) {
 		}
 
 		userRepo.On("FindUserByEmail", "google@example.com").Return(existingUser, nil)
+		userRepo.On("CreateSession", mock.AnythingOfType("*entities.Session")).Return(nil).Once()
 
 		token, user, err := useCase.LoginWithGoogle(googleUser)
 
@@ -310,15 +325,16 @@ func TestLoginWithGoogle(t *testing.T) {
 func TestResetPassword(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
 	supaConfig := configs.Supabase{}
 	mailConfig := configs.Mail{}
 
-	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 	t.Run("Success", func(t *testing.T) {
 		userID := "user-123"
@@ -337,6 +353,7 @@ func TestResetPassword(t *testing.T) {
 			err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(newPassword))
 			return u.ID == userID && err == nil
 		})).Return(user, nil)
+		userRepo.On("RevokeSessionsByUserID", userID).Return(nil).Once()
 
 		err := useCase.ResetPassword(userID, oldPassword, newPassword)
 
@@ -423,18 +440,188 @@ func TestResetPassword(t *testing.T) {
 	})
 }
 
+func TestRefreshToken(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
+	assetRepo := new(mocks.MockAssetRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
+	nhRepo := new(mocks.MockNhRepository)
+
+	jwtConfig := configs.JWT{Secret: "test-secret"}
+	supaConfig := configs.Supabase{}
+	mailConfig := configs.Mail{}
+
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
+
+	t.Run("Success", func(t *testing.T) {
+		session := &entities.Session{
+			ID:           "session-1",
+			UserID:       "user-id-1",
+			RefreshToken: utils.HashToken("refresh-token"),
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}
+
+		user := &entities.User{
+			ID:   "user-id-1",
+			Role: entities.Role{RoleName: "User"},
+		}
+
+		userRepo.On("GetSessionByRefreshToken", utils.HashToken("refresh-token")).Return(session, nil).Once()
+		userRepo.On("GetUserByID", "user-id-1").Return(user, nil).Once()
+		userRepo.On("RotateRefreshToken", "session-1", utils.HashToken("refresh-token"), mock.MatchedBy(func(newToken string) bool {
+			return newToken != utils.HashToken("refresh-token")
+		}), mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		token, err := useCase.RefreshToken("refresh-token")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token.AccessToken)
+		assert.NotEqual(t, "refresh-token", token.RefreshToken)
+
+		parsedToken, err := jwt.Parse(token.AccessToken, func(token *jwt.Token) (interface{}, error) {
+			return []byte(jwtConfig.Secret), nil
+		})
+
+		assert.NoError(t, err)
+		claims := parsedToken.Claims.(jwt.MapClaims)
+		assert.Equal(t, "session-1", claims["sid"])
+
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Token", func(t *testing.T) {
+		userRepo.On("GetSessionByRefreshToken", utils.HashToken("unknown")).Return((*entities.Session)(nil), errors.New("record not found")).Once()
+
+		token, err := useCase.RefreshToken("unknown")
+
+		assert.Nil(t, token)
+		assert.EqualError(t, err, "invalid refresh token")
+	})
+
+	t.Run("Revoked Session", func(t *testing.T) {
+		revokedAt := time.Now()
+		session := &entities.Session{
+			ID:        "session-2",
+			ExpiresAt: time.Now().Add(time.Hour),
+			RevokedAt: &revokedAt,
+		}
+
+		userRepo.On("GetSessionByRefreshToken", utils.HashToken("revoked")).Return(session, nil).Once()
+
+		token, err := useCase.RefreshToken("revoked")
+
+		assert.Nil(t, token)
+		assert.EqualError(t, err, "session has been revoked")
+	})
+
+	t.Run("Expired Session", func(t *testing.T) {
+		session := &entities.Session{
+			ID:        "session-3",
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}
+
+		userRepo.On("GetSessionByRefreshToken", utils.HashToken("expired")).Return(session, nil).Once()
+
+		token, err := useCase.RefreshToken("expired")
+
+		assert.Nil(t, token)
+		assert.EqualError(t, err, "refresh token is expired")
+	})
+
+	t.Run("Token Already Rotated", func(t *testing.T) {
+		session := &entities.Session{
+			ID:           "session-4",
+			UserID:       "user-id-1",
+			RefreshToken: utils.HashToken("reused"),
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}
+
+		user := &entities.User{
+			ID:   "user-id-1",
+			Role: entities.Role{RoleName: "User"},
+		}
+
+		userRepo.On("GetSessionByRefreshToken", utils.HashToken("reused")).Return(session, nil).Once()
+		userRepo.On("GetUserByID", "user-id-1").Return(user, nil).Once()
+		userRepo.On("RotateRefreshToken", "session-4", utils.HashToken("reused"), mock.Anything, mock.AnythingOfType("time.Time")).Return(gorm.ErrRecordNotFound).Once()
+
+		token, err := useCase.RefreshToken("reused")
+
+		assert.Nil(t, token)
+		assert.EqualError(t, err, "invalid refresh token")
+	})
+
+	t.Run("Rotate Error", func(t *testing.T) {
+		session := &entities.Session{
+			ID:           "session-5",
+			UserID:       "user-id-1",
+			RefreshToken: utils.HashToken("db-down"),
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}
+
+		user := &entities.User{
+			ID:   "user-id-1",
+			Role: entities.Role{RoleName: "User"},
+		}
+
+		userRepo.On("GetSessionByRefreshToken", utils.HashToken("db-down")).Return(session, nil).Once()
+		userRepo.On("GetUserByID", "user-id-1").Return(user, nil).Once()
+		userRepo.On("RotateRefreshToken", "session-5", utils.HashToken("db-down"), mock.Anything, mock.AnythingOfType("time.Time")).Return(errors.New("connection refused")).Once()
+
+		token, err := useCase.RefreshToken("db-down")
+
+		assert.Nil(t, token)
+		assert.EqualError(t, err, "connection refused")
+	})
+}
+
+func TestLogout(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
+	assetRepo := new(mocks.MockAssetRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
+	nhRepo := new(mocks.MockNhRepository)
+
+	jwtConfig := configs.JWT{Secret: "test-secret"}
+	supaConfig := configs.Supabase{}
+	mailConfig := configs.Mail{}
+
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
+
+	t.Run("Revoke Session", func(t *testing.T) {
+		userRepo.On("RevokeSession", "session-1").Return(nil).Once()
+
+		err := useCase.Logout("session-1")
+
+		assert.NoError(t, err)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("Revoke All Sessions", func(t *testing.T) {
+		userRepo.On("RevokeSessionsByUserID", "user-id-1").Return(nil).Once()
+
+		err := useCase.LogoutAll("user-id-1")
+
+		assert.NoError(t, err)
+		userRepo.AssertExpectations(t)
+	})
+}
+
 func TestGetUserByID(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
 	supaConfig := configs.Supabase{}
 	mailConfig := configs.Mail{}
 
-	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 	t.Run("Success", func(t *testing.T) {
 		expectedUser := &entities.User{
@@ -457,8 +644,9 @@ func TestGetUserByID(t *testing.T) {
 func TestGetSelectedHouse(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
@@ -467,13 +655,13 @@ func TestGetSelectedHouse(t *testing.T) {
 
 	defaultHouseID := "default-house-id"
 
-	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 	t.Run("Default House", func(t *testing.T) {
 		inputHouse := &entities.SelectedHouse{
 			UserID:              "user-123",
 			NursingHouseID:      defaultHouseID,
-			MonthlyExpenses:     1000.0,
+			MonthlyExpenses:     entities.Baht(1000),
 			LastCalculatedMonth: 2,
 		}
 
@@ -512,15 +700,16 @@ func TestGetSelectedHouse(t *testing.T) {
 func TestUpdateUserByID(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
 	supaConfig := configs.Supabase{}
 	mailConfig := configs.Mail{}
 
-	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 	app := fiber.New()
 
@@ -568,6 +757,25 @@ func TestUpdateUserByID(t *testing.T) {
 		userRepo.AssertExpectations(t)
 	})
 
+	t.Run("Unsupported Locale", func(t *testing.T) {
+		existingUser := &entities.User{
+			ID:       "user-123",
+			Username: "oldusername",
+			Locale:   "th",
+		}
+
+		userRepo.On("GetUserByID", "user-123").Return(existingUser, nil).Once()
+
+		fiberCtx := app.AcquireCtx(&fasthttp.RequestCtx{})
+		defer app.ReleaseCtx(fiberCtx)
+
+		updatedUser, err := useCase.UpdateUserByID("user-123", entities.User{Username: "newusername", Locale: "jp"}, nil, fiberCtx)
+
+		assert.Error(t, err)
+		assert.Nil(t, updatedUser)
+		assert.Equal(t, "unsupported locale", err.Error())
+	})
+
 	t.Run("Successful Update With File", func(t *testing.T) {
 		existingUser := &entities.User{
 			ID:        "user-123",
@@ -660,8 +868,9 @@ func TestUpdateUserByID(t *testing.T) {
 func TestForgotPassword(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
@@ -673,7 +882,7 @@ func TestForgotPassword(t *testing.T) {
 	createUserUseCase := func(
 		generateOTP otpGenerator,
 	) *usecases.UserUseCaseImpl {
-		uc := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+		uc := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 		ucValue := reflect.ValueOf(uc).Elem()
 
 		if generateOTP != nil {
@@ -733,15 +942,16 @@ func TestForgotPassword(t *testing.T) {
 func TestVerifyOTP(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
 	supaConfig := configs.Supabase{}
 	mailConfig := configs.Mail{}
 
-	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 	t.Run("Successful OTP Verification", func(t *testing.T) {
 		email := "test@example.com"
@@ -875,15 +1085,16 @@ func TestVerifyOTP(t *testing.T) {
 func TestChangedPassword(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
 	supaConfig := configs.Supabase{}
 	mailConfig := configs.Mail{}
 
-	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 	t.Run("Successful Password Change", func(t *testing.T) {
 		email := "test@example.com"
@@ -904,6 +1115,7 @@ func TestChangedPassword(t *testing.T) {
 				u.Email == email &&
 				u.Password != string(hashedOldPassword)
 		})).Return(user, nil).Once()
+		userRepo.On("RevokeSessionsByUserID", "user-123").Return(nil).Once()
 
 		err := useCase.ChangedPassword(email, newPassword)
 
@@ -977,8 +1189,9 @@ func TestUpdateSelectedHouse(t *testing.T) {
 	t.Run("should return error when GetSelectedHouse fails", func(t *testing.T) {
 		userRepo := new(mocks.MockUserRepository)
 		retirementRepo := new(mocks.MockRetirementRepository)
+		ledgerRepo := new(mocks.MockLedgerRepository)
 		assetRepo := new(mocks.MockAssetRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 		nhRepo := new(mocks.MockNhRepository)
 
 		jwtConfig := configs.JWT{Secret: "test-secret"}
@@ -989,9 +1202,9 @@ func TestUpdateSelectedHouse(t *testing.T) {
 		nursingHouseID := "house-123"
 		expectedError := errors.New("selected house not found")
 
-		userRepo.On("GetSelectedHouse", userID).Return((*entities.SelectedHouse)(nil), expectedError)
+		userRepo.On("GetSelectedHouseForUpdate", userID).Return((*entities.SelectedHouse)(nil), expectedError)
 
-		useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+		useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 		result, err := useCase.UpdateSelectedHouse(userID, nursingHouseID, []entities.TransferRequest{})
 
@@ -1003,8 +1216,9 @@ func TestUpdateSelectedHouse(t *testing.T) {
 	t.Run("should return error when GetUserByID fails", func(t *testing.T) {
 		userRepo := new(mocks.MockUserRepository)
 		retirementRepo := new(mocks.MockRetirementRepository)
+		ledgerRepo := new(mocks.MockLedgerRepository)
 		assetRepo := new(mocks.MockAssetRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 		nhRepo := new(mocks.MockNhRepository)
 
 		jwtConfig := configs.JWT{Secret: "test-secret"}
@@ -1016,15 +1230,15 @@ func TestUpdateSelectedHouse(t *testing.T) {
 		selectedHouse := &entities.SelectedHouse{
 			UserID:         userID,
 			NursingHouseID: "house-456",
-			CurrentMoney:   1000,
+			CurrentMoney:   entities.Baht(1000),
 			Status:         "In_Progress",
 		}
 		expectedError := errors.New("user not found")
 
-		userRepo.On("GetSelectedHouse", userID).Return(selectedHouse, nil)
+		userRepo.On("GetSelectedHouseForUpdate", userID).Return(selectedHouse, nil)
 		userRepo.On("GetUserByID", userID).Return((*entities.User)(nil), expectedError)
 
-		useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+		useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 		result, err := useCase.UpdateSelectedHouse(userID, nursingHouseID, []entities.TransferRequest{})
 
@@ -1036,8 +1250,9 @@ func TestUpdateSelectedHouse(t *testing.T) {
 	t.Run("should return error when FindAssetByNameandUserID fails", func(t *testing.T) {
 		userRepo := new(mocks.MockUserRepository)
 		retirementRepo := new(mocks.MockRetirementRepository)
+		ledgerRepo := new(mocks.MockLedgerRepository)
 		assetRepo := new(mocks.MockAssetRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 		nhRepo := new(mocks.MockNhRepository)
 
 		jwtConfig := configs.JWT{Secret: "test-secret"}
@@ -1049,7 +1264,7 @@ func TestUpdateSelectedHouse(t *testing.T) {
 		selectedHouse := &entities.SelectedHouse{
 			UserID:         userID,
 			NursingHouseID: "house-456",
-			CurrentMoney:   1000,
+			CurrentMoney:   entities.Baht(1000),
 			Status:         "In_Progress",
 			NursingHouse:   entities.NursingHouse{Name: "Premium House"},
 		}
@@ -1064,18 +1279,18 @@ func TestUpdateSelectedHouse(t *testing.T) {
 		}
 
 		transfers := []entities.TransferRequest{
-			{Type: "asset", Name: "Car", Amount: 500},
+			{Type: "asset", Name: "Car", Amount: entities.Baht(500)},
 		}
 
 		expectedError := errors.New("asset not found")
 
-		userRepo.On("GetSelectedHouse", userID).Return(selectedHouse, nil)
+		userRepo.On("GetSelectedHouseForUpdate", userID).Return(selectedHouse, nil)
 		userRepo.On("GetUserByID", userID).Return(user, nil)
-		assetRepo.On("FindAssetByNameandUserID", "Car", userID).Return(nil, expectedError)
+		assetRepo.On("FindAssetByNameandUserIDForUpdate", "Car", userID).Return(nil, expectedError)
 		nhRepo.On("GetNhByID", nursingHouseID).Return(&entities.NursingHouse{ID: nursingHouseID}, nil)
 		userRepo.On("UpdateSelectedHouse", mock.Anything).Return((*entities.SelectedHouse)(nil), expectedError).Times(0)
 
-		useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+		useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 		result, err := useCase.UpdateSelectedHouse(userID, nursingHouseID, transfers)
 
@@ -1087,22 +1302,23 @@ func TestUpdateSelectedHouse(t *testing.T) {
 	t.Run("should return error when GetNhByID fails", func(t *testing.T) {
 		userRepo := new(mocks.MockUserRepository)
 		retirementRepo := new(mocks.MockRetirementRepository)
+		ledgerRepo := new(mocks.MockLedgerRepository)
 		assetRepo := new(mocks.MockAssetRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 		nhRepo := new(mocks.MockNhRepository)
 
 		jwtConfig := configs.JWT{Secret: "test-secret"}
 		supaConfig := configs.Supabase{}
 		mailConfig := configs.Mail{}
 
-		useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+		useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 		userID := "user-123"
 		nursingHouseID := "new-house-123"
 		selectedHouse := &entities.SelectedHouse{
 			UserID:         userID,
 			NursingHouseID: "house-456",
-			CurrentMoney:   1000,
+			CurrentMoney:   entities.Baht(1000),
 			Status:         "In_Progress",
 		}
 		user := &entities.User{
@@ -1117,7 +1333,7 @@ func TestUpdateSelectedHouse(t *testing.T) {
 
 		expectedError := errors.New("nursing house not found")
 
-		userRepo.On("GetSelectedHouse", userID).Return(selectedHouse, nil)
+		userRepo.On("GetSelectedHouseForUpdate", userID).Return(selectedHouse, nil)
 		userRepo.On("GetUserByID", userID).Return(user, nil)
 		nhRepo.On("GetNhByID", nursingHouseID).Return(nil, expectedError)
 
@@ -1132,22 +1348,23 @@ func TestUpdateSelectedHouse(t *testing.T) {
 	t.Run("should return error when UpdateSelectedHouse fails", func(t *testing.T) {
 		userRepo := new(mocks.MockUserRepository)
 		retirementRepo := new(mocks.MockRetirementRepository)
+		ledgerRepo := new(mocks.MockLedgerRepository)
 		assetRepo := new(mocks.MockAssetRepository)
-		notiRepo := new(mocks.MockNotiRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
 		nhRepo := new(mocks.MockNhRepository)
 
 		jwtConfig := configs.JWT{Secret: "test-secret"}
 		supaConfig := configs.Supabase{}
 		mailConfig := configs.Mail{}
 
-		useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+		useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 		userID := "user-123"
 		nursingHouseID := "new-house-123"
 		selectedHouse := &entities.SelectedHouse{
 			UserID:              userID,
 			NursingHouseID:      "house-456",
-			CurrentMoney:        1000,
+			CurrentMoney:        entities.Baht(1000),
 			Status:              "In_Progress",
 			LastCalculatedMonth: 0,
 		}
@@ -1160,18 +1377,18 @@ func TestUpdateSelectedHouse(t *testing.T) {
 				RetirementAge:  60,
 			},
 			House: entities.SelectedHouse{
-				CurrentMoney: 1000,
+				CurrentMoney: entities.Baht(1000),
 			},
 		}
 		nursingHouse := &entities.NursingHouse{
 			ID:    nursingHouseID,
 			Name:  "New Premium House",
-			Price: 1500,
+			Price: entities.Baht(1500),
 		}
 
 		expectedError := errors.New("failed to update selected house")
 
-		userRepo.On("GetSelectedHouse", userID).Return(selectedHouse, nil)
+		userRepo.On("GetSelectedHouseForUpdate", userID).Return(selectedHouse, nil)
 		userRepo.On("GetUserByID", userID).Return(user, nil)
 		nhRepo.On("GetNhByID", nursingHouseID).Return(nursingHouse, nil)
 		userRepo.On("UpdateSelectedHouse", mock.AnythingOfType("*entities.SelectedHouse")).Return((*entities.SelectedHouse)(nil), expectedError)
@@ -1188,15 +1405,16 @@ func TestUpdateSelectedHouse(t *testing.T) {
 func TestCalculateRetirement(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
 	supaConfig := configs.Supabase{}
 	mailConfig := configs.Mail{}
 
-	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 	t.Run("GetUserByID Error", func(t *testing.T) {
 		expectedError := errors.New("user not found")
@@ -1207,7 +1425,7 @@ func TestCalculateRetirement(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
-		assert.Equal(t, fiber.Map{}, result)
+		assert.Nil(t, result)
 
 		userRepo.AssertExpectations(t)
 	})
@@ -1232,17 +1450,17 @@ func TestCalculateRetirement(t *testing.T) {
 				{
 					ID:                  "asset-123",
 					LastCalculatedMonth: currentMonth,
-					TotalCost:           50000,
-					MonthlyExpenses:     500,
+					TotalCost:           entities.Baht(50000),
+					MonthlyExpenses:     entities.Baht(500),
 				},
 			},
 			House: entities.SelectedHouse{
 				Status:              "Owned",
-				CurrentMoney:        100000,
+				CurrentMoney:        entities.Baht(100000),
 				LastCalculatedMonth: currentMonth,
-				MonthlyExpenses:     2000,
+				MonthlyExpenses:     entities.Baht(2000),
 				NursingHouse: entities.NursingHouse{
-					Price: 5000,
+					Price: entities.Baht(5000),
 				},
 			},
 		}
@@ -1261,20 +1479,164 @@ func TestCalculateRetirement(t *testing.T) {
 		userRepo.AssertExpectations(t)
 		retirementRepo.AssertExpectations(t)
 	})
+
+	t.Run("Includes Savings Vehicles", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		retirementRepo := new(mocks.MockRetirementRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, loanRepo, jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
+		currentMonth := int(time.Now().Month())
+		user := &entities.User{
+			ID: "user-456",
+			RetirementPlan: entities.RetirementPlan{
+				BirthDate:           "01-02-2006",
+				CreatedAt:           time.Now().AddDate(0, -1, 0),
+				LastCalculatedMonth: currentMonth,
+				RetirementAge:       65,
+				ExpectLifespan:      85,
+				LastRequiredFunds:   entities.Baht(1000000),
+			},
+			House: entities.SelectedHouse{LastCalculatedMonth: currentMonth},
+			Vehicles: []entities.SavingsVehicle{
+				{ID: "vehicle-1", Type: entities.VehicleTypeProvidentFund, CurrentMoney: entities.Baht(100000), OpenedAt: time.Now()},
+				{ID: "vehicle-2", Type: entities.VehicleTypeSocialSecurity, ContributionMonths: 180, AverageWage: entities.Baht(15000)},
+			},
+		}
+
+		userRepo.On("GetUserByID", "user-456").Return(user, nil).Once()
+		userRepo.On("GetUserDepositsInRange", "user-456", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]entities.History{}, nil)
+		loanRepo.On("GetLoanByUserID", "user-456").Return([]entities.Loan{}, map[string]interface{}{}, nil).Once()
+
+		result, err := useCase.CalculateRetirement("user-456")
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(100000), result.VehicleSavings)
+		assert.Equal(t, entities.Baht(3000), result.VehiclePension)
+		assert.Equal(t, entities.Baht(100000)+entities.Baht(3000).Mul(20*12), result.VehiclePayout)
+		assert.Equal(t, entities.Baht(1000000)-entities.Baht(100000)-entities.Baht(3000).Mul(20*12), result.StillNeed)
+		assert.NotNil(t, result.Readiness)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("Vehicle Payout Reduces Still Need Only", func(t *testing.T) {
+		userRepo := new(mocks.MockUserRepository)
+		retirementRepo := new(mocks.MockRetirementRepository)
+		loanRepo := new(mocks.MockLoanRepository)
+		useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, loanRepo, jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
+		currentMonth := int(time.Now().Month())
+		user := &entities.User{
+			ID: "user-789",
+			RetirementPlan: entities.RetirementPlan{
+				BirthDate:              "01-02-2006",
+				CreatedAt:              time.Now().AddDate(0, -1, 0),
+				LastCalculatedMonth:    currentMonth,
+				RetirementAge:          65,
+				ExpectLifespan:         85,
+				CurrentSavings:         entities.Baht(50000),
+				CurrentTotalInvestment: entities.Baht(20000),
+				LastRequiredFunds:      entities.Baht(1000000),
+			},
+			House: entities.SelectedHouse{LastCalculatedMonth: currentMonth},
+			Vehicles: []entities.SavingsVehicle{
+				{ID: "vehicle-1", Type: entities.VehicleTypeProvidentFund, CurrentMoney: entities.Baht(100000), OpenedAt: time.Now()},
+				{ID: "vehicle-2", Type: entities.VehicleTypeSocialSecurity, ContributionMonths: 180, AverageWage: entities.Baht(15000)},
+			},
+		}
+
+		userRepo.On("GetUserByID", "user-789").Return(user, nil).Once()
+		userRepo.On("GetUserDepositsInRange", "user-789", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]entities.History{}, nil)
+		loanRepo.On("GetLoanByUserID", "user-789").Return([]entities.Loan{}, map[string]interface{}{}, nil).Once()
+
+		result, err := useCase.CalculateRetirement("user-789")
+
+		payout := entities.Baht(100000) + entities.Baht(3000).Mul(20*12)
+		assert.NoError(t, err)
+		assert.Equal(t, payout, result.VehiclePayout)
+		assert.Equal(t, entities.Baht(1000000)-entities.Baht(70000)-payout, result.StillNeed)
+		assert.Equal(t, entities.Baht(50000)+entities.Baht(20000)+entities.Baht(100000), result.AllMoney)
+	})
+}
+
+func TestGetReadinessReport(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
+	assetRepo := new(mocks.MockAssetRepository)
+	loanRepo := new(mocks.MockLoanRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
+	nhRepo := new(mocks.MockNhRepository)
+
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, loanRepo, configs.JWT{}, configs.Supabase{}, configs.Mail{}, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
+
+	t.Run("Success", func(t *testing.T) {
+		user := &entities.User{
+			ID: "user-123",
+			RetirementPlan: entities.RetirementPlan{
+				ID:                "plan-1",
+				BirthDate:         time.Now().AddDate(-30, 0, 0).Format("02-01-2006"),
+				RetirementAge:     60,
+				ExpectLifespan:    80,
+				CurrentSavings:    entities.Baht(100000),
+				MonthlyIncome:     entities.Baht(50000),
+				MonthlyExpenses:   entities.Baht(30000),
+				LastRequiredFunds: entities.Baht(3000000),
+				Status:            "In_Progress",
+				CreatedAt:         time.Now(),
+			},
+		}
+		loans := []entities.Loan{{ID: "loan-1", MonthlyExpenses: entities.Baht(2000), RemainingMonths: 5, Status: "In_Progress"}}
+
+		userRepo.On("GetUserByID", "user-123").Return(user, nil).Once()
+		loanRepo.On("GetLoanByUserID", "user-123").Return(loans, map[string]interface{}{}, nil).Once()
+
+		report, err := useCase.GetReadinessReport("user-123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(10000), report.OutstandingLoans)
+		assert.Equal(t, entities.Baht(20000), report.MonthlySurplus)
+		assert.Len(t, report.Gaps, 1)
+		assert.Equal(t, entities.ReadinessGoalLoan, report.Allocations[0].Goal)
+		userRepo.AssertExpectations(t)
+		loanRepo.AssertExpectations(t)
+	})
+
+	t.Run("No Retirement Plan", func(t *testing.T) {
+		userRepo.On("GetUserByID", "user-456").Return(&entities.User{ID: "user-456"}, nil).Once()
+
+		report, err := useCase.GetReadinessReport("user-456")
+
+		assert.Nil(t, report)
+		assert.EqualError(t, err, "retirement plan not found")
+		loanRepo.AssertNotCalled(t, "GetLoanByUserID", "user-456")
+	})
+
+	t.Run("Loan Repository Error", func(t *testing.T) {
+		user := &entities.User{ID: "user-789", RetirementPlan: entities.RetirementPlan{ID: "plan-2"}}
+		userRepo.On("GetUserByID", "user-789").Return(user, nil).Once()
+		loanRepo.On("GetLoanByUserID", "user-789").Return([]entities.Loan(nil), map[string]interface{}(nil), errors.New("database error")).Once()
+
+		report, err := useCase.GetReadinessReport("user-789")
+
+		assert.Nil(t, report)
+		assert.EqualError(t, err, "database error")
+	})
 }
 
 func TestCreateHistory(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
 	supaConfig := configs.Supabase{}
 	mailConfig := configs.Mail{}
 
-	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+	loanRepo := new(mocks.MockLoanRepository)
+
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, loanRepo, jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 	t.Run("Success", func(t *testing.T) {
 		currentMonth := int(time.Now().Month())
@@ -1296,17 +1658,18 @@ func TestCreateHistory(t *testing.T) {
 				{
 					ID:                  "asset-123",
 					LastCalculatedMonth: currentMonth,
-					TotalCost:           50000,
-					MonthlyExpenses:     500,
+					TotalCost:           entities.Baht(50000),
+					MonthlyExpenses:     entities.Baht(500),
+					EndYear:             "2040",
 				},
 			},
 			House: entities.SelectedHouse{
 				Status:              "Owned",
-				CurrentMoney:        100000,
+				CurrentMoney:        entities.Baht(100000),
 				LastCalculatedMonth: currentMonth,
-				MonthlyExpenses:     2000,
+				MonthlyExpenses:     entities.Baht(2000),
 				NursingHouse: entities.NursingHouse{
-					Price: 5000,
+					Price: entities.Baht(5000),
 				},
 			},
 		}
@@ -1315,6 +1678,7 @@ func TestCreateHistory(t *testing.T) {
 		retirementRepo.On("UpdateRetirementPlan", mock.AnythingOfType("*entities.RetirementPlan")).Return(&user.RetirementPlan, nil)
 
 		userRepo.On("GetUserDepositsInRange", mock.Anything, mock.Anything, mock.Anything).Return([]entities.History{}, nil)
+		loanRepo.On("GetLoanByUserID", "user-123").Return([]entities.Loan{}, map[string]interface{}{}, nil).Once()
 
 		result, err := useCase.CalculateRetirement("user-123")
 
@@ -1323,6 +1687,7 @@ func TestCreateHistory(t *testing.T) {
 
 		userRepo.AssertExpectations(t)
 		retirementRepo.AssertExpectations(t)
+		loanRepo.AssertExpectations(t)
 	})
 
 	t.Run("Negative Case - User Not Found", func(t *testing.T) {
@@ -1332,7 +1697,7 @@ func TestCreateHistory(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Equal(t, "user not found", err.Error())
-		assert.NotNil(t, result)
+		assert.Nil(t, result)
 
 		userRepo.AssertExpectations(t)
 	})
@@ -1367,18 +1732,293 @@ func TestCreateHistory(t *testing.T) {
 	})
 }
 
+func TestCreateHistoryTransaction(t *testing.T) {
+	setup := func() (*usecases.UserUseCaseImpl, *mocks.MockUserRepository, *mocks.MockRetirementRepository, *mocks.MockLedgerRepository, *usecaseMocks.MockNotiDispatcher, *mocks.MockUnitOfWork) {
+		userRepo := new(mocks.MockUserRepository)
+		retirementRepo := new(mocks.MockRetirementRepository)
+		ledgerRepo := new(mocks.MockLedgerRepository)
+		assetRepo := new(mocks.MockAssetRepository)
+		dispatcher := new(usecaseMocks.MockNotiDispatcher)
+		nhRepo := new(mocks.MockNhRepository)
+		uow := mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo)
+
+		useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), configs.JWT{}, configs.Supabase{}, configs.Mail{}, uow)
+		return useCase, userRepo, retirementRepo, ledgerRepo, dispatcher, uow
+	}
+
+	history := entities.History{
+		UserID:   "user-123",
+		Method:   "deposit",
+		Type:     "saving_money",
+		Category: "retirementplan",
+		Money:    entities.Baht(5000),
+	}
+
+	house := &entities.SelectedHouse{UserID: "user-123", NursingHouseID: "00001", Status: "Completed"}
+	plan := func() *entities.RetirementPlan {
+		return &entities.RetirementPlan{
+			ID:                "plan-123",
+			UserID:            "user-123",
+			PlanName:          "My Plan",
+			CurrentSavings:    entities.Baht(96000),
+			LastRequiredFunds: entities.Baht(100000),
+			Status:            "In_Progress",
+		}
+	}
+
+	t.Run("Commits and notifies after success", func(t *testing.T) {
+		useCase, userRepo, retirementRepo, ledgerRepo, dispatcher, uow := setup()
+
+		userRepo.On("GetUserByID", "user-123").Return(&entities.User{ID: "user-123"}, nil)
+		userRepo.On("GetSelectedHouseForUpdate", "user-123").Return(house, nil)
+		retirementRepo.On("GetRetirementByUserIDForUpdate", "user-123").Return(plan(), nil)
+		userRepo.On("UpdateSelectedHouse", mock.AnythingOfType("*entities.SelectedHouse")).Return(house, nil)
+		retirementRepo.On("UpdateRetirementPlan", mock.MatchedBy(func(r *entities.RetirementPlan) bool {
+			return r.CurrentSavings == entities.Baht(101000) && r.Status == "Completed"
+		})).Return(plan(), nil)
+		ledgerRepo.On("GetAccount", "user-123", entities.LedgerAccountRetirementSavings, "user-123").Return(&entities.LedgerAccount{ID: "savings"}, nil)
+		ledgerRepo.On("GetAccount", "user-123", entities.LedgerAccountExternal, "user-123").Return(&entities.LedgerAccount{ID: "external"}, nil)
+		ledgerRepo.On("CreateEntry", mock.MatchedBy(func(e *entities.LedgerEntry) bool {
+			return len(e.Postings) == 2 &&
+				e.Postings[0].AccountID == "savings" && e.Postings[0].Amount == entities.Baht(5000) &&
+				e.Postings[1].AccountID == "external" && e.Postings[1].Amount == entities.Baht(-5000)
+		})).Return(&entities.LedgerEntry{}, nil).Once()
+		userRepo.On("CreateHistory", mock.AnythingOfType("*entities.History")).Return(&entities.History{ID: "history-1"}, nil)
+		dispatcher.On("Dispatch", mock.MatchedBy(func(n *entities.Notification) bool {
+			return n.Type == "retirementplan"
+		})).Return(nil).Once()
+
+		result, err := useCase.CreateHistory(history)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "history-1", result.ID)
+		assert.Equal(t, 1, uow.Committed)
+		ledgerRepo.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("Spreads deposit without losing satang", func(t *testing.T) {
+		useCase, userRepo, retirementRepo, ledgerRepo, dispatcher, uow := setup()
+		assetRepo := uow.Repositories.Assets.(*mocks.MockAssetRepository)
+		asset := entities.Asset{ID: "asset-1", Name: "Car", TotalCost: entities.Baht(500000), Status: "In_Progress", UserID: "user-123"}
+		spreadHouse := &entities.SelectedHouse{
+			UserID:         "user-123",
+			NursingHouseID: "00002",
+			Status:         "In_Progress",
+			NursingHouse:   entities.NursingHouse{Name: "Home", Price: entities.Baht(20000)},
+		}
+
+		userRepo.On("GetUserByID", "user-123").Return(&entities.User{ID: "user-123", Assets: []entities.Asset{asset}}, nil)
+		userRepo.On("GetSelectedHouseForUpdate", "user-123").Return(spreadHouse, nil)
+		retirementRepo.On("GetRetirementByUserIDForUpdate", "user-123").Return(plan(), nil)
+		assetRepo.On("GetAssetByIDForUpdate", "asset-1").Return(&asset, nil)
+		assetRepo.On("UpdateAssetByID", mock.MatchedBy(func(a *entities.Asset) bool {
+			return a.CurrentMoney == entities.MoneyFromFloat(33.34)
+		})).Return(&asset, nil).Once()
+		userRepo.On("UpdateSelectedHouse", mock.MatchedBy(func(h *entities.SelectedHouse) bool {
+			return h.CurrentMoney == entities.MoneyFromFloat(33.33)
+		})).Return(spreadHouse, nil).Once()
+		retirementRepo.On("UpdateRetirementPlan", mock.MatchedBy(func(r *entities.RetirementPlan) bool {
+			return r.CurrentSavings == entities.Baht(96000)+entities.MoneyFromFloat(33.33)
+		})).Return(plan(), nil).Once()
+		ledgerRepo.On("GetAccount", "user-123", mock.Anything, mock.Anything).Return(&entities.LedgerAccount{ID: "account"}, nil)
+		ledgerRepo.On("CreateEntry", mock.MatchedBy(func(e *entities.LedgerEntry) bool {
+			var total entities.Money
+			for _, posting := range e.Postings {
+				total += posting.Amount
+			}
+
+			return len(e.Postings) == 4 && total == 0
+		})).Return(&entities.LedgerEntry{}, nil).Once()
+		userRepo.On("CreateHistory", mock.AnythingOfType("*entities.History")).Return(&entities.History{ID: "history-1"}, nil)
+		dispatcher.On("Dispatch", mock.AnythingOfType("*entities.Notification")).Return(nil)
+
+		spread := history
+		spread.Category = "spread"
+		spread.Money = entities.Baht(100)
+		_, err := useCase.CreateHistory(spread)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, uow.Committed)
+		assetRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+		retirementRepo.AssertExpectations(t)
+		ledgerRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rolls back without notifying when history insert fails", func(t *testing.T) {
+		useCase, userRepo, retirementRepo, ledgerRepo, dispatcher, uow := setup()
+
+		userRepo.On("GetUserByID", "user-123").Return(&entities.User{ID: "user-123"}, nil)
+		userRepo.On("GetSelectedHouseForUpdate", "user-123").Return(house, nil)
+		retirementRepo.On("GetRetirementByUserIDForUpdate", "user-123").Return(plan(), nil)
+		userRepo.On("UpdateSelectedHouse", mock.AnythingOfType("*entities.SelectedHouse")).Return(house, nil)
+		retirementRepo.On("UpdateRetirementPlan", mock.AnythingOfType("*entities.RetirementPlan")).Return(plan(), nil)
+		ledgerRepo.On("GetAccount", "user-123", mock.Anything, "user-123").Return(&entities.LedgerAccount{ID: "account"}, nil)
+		ledgerRepo.On("CreateEntry", mock.AnythingOfType("*entities.LedgerEntry")).Return(&entities.LedgerEntry{}, nil)
+		userRepo.On("CreateHistory", mock.AnythingOfType("*entities.History")).Return((*entities.History)(nil), errors.New("database error"))
+
+		result, err := useCase.CreateHistory(history)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, 1, uow.RolledBack)
+		dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything)
+	})
+
+	t.Run("Rejects withdraw larger than locked balance", func(t *testing.T) {
+		useCase, userRepo, retirementRepo, _, _, uow := setup()
+
+		userRepo.On("GetUserByID", "user-123").Return(&entities.User{
+			ID:             "user-123",
+			RetirementPlan: entities.RetirementPlan{CurrentSavings: entities.Baht(1000000)},
+		}, nil)
+		userRepo.On("GetSelectedHouseForUpdate", "user-123").Return(house, nil)
+		retirementRepo.On("GetRetirementByUserIDForUpdate", "user-123").Return(plan(), nil)
+
+		withdraw := history
+		withdraw.Method = "withdraw"
+		withdraw.Money = entities.Baht(200000)
+		result, err := useCase.CreateHistory(withdraw)
+
+		assert.Error(t, err)
+		assert.Equal(t, "insufficient funds in retirement savings", err.Error())
+		assert.Nil(t, result)
+		assert.Equal(t, 1, uow.RolledBack)
+		userRepo.AssertNotCalled(t, "CreateHistory", mock.Anything)
+	})
+
+	t.Run("Rejects investment tracked by holdings", func(t *testing.T) {
+		useCase, userRepo, retirementRepo, ledgerRepo, _, uow := setup()
+
+		userRepo.On("GetUserByID", "user-123").Return(&entities.User{
+			ID:       "user-123",
+			Holdings: []entities.Holding{{ID: "h1", Name: "K-SET50"}},
+		}, nil)
+
+		investment := history
+		investment.Type = "investment"
+		investment.Category = ""
+		result, err := useCase.CreateHistory(investment)
+
+		assert.EqualError(t, err, "investment is tracked by portfolio holdings")
+		assert.Nil(t, result)
+		assert.Equal(t, 1, uow.RolledBack)
+		retirementRepo.AssertNotCalled(t, "UpdateRetirementPlan", mock.Anything)
+		ledgerRepo.AssertNotCalled(t, "CreateEntry", mock.Anything)
+	})
+
+	vehicleHistory := entities.History{
+		UserID: "user-123",
+		Method: "deposit",
+		Type:   entities.HistoryTypeVehicle,
+		Name:   "RMF",
+		Money:  entities.Baht(5000),
+	}
+
+	incomePlan := func() *entities.RetirementPlan {
+		retirement := plan()
+		retirement.MonthlyIncome = entities.Baht(50000)
+		return retirement
+	}
+
+	t.Run("Deposits into savings vehicle", func(t *testing.T) {
+		useCase, userRepo, retirementRepo, ledgerRepo, _, uow := setup()
+		vehicleRepo := new(mocks.MockVehicleRepository)
+		uow.Repositories.Vehicles = vehicleRepo
+		vehicle := &entities.SavingsVehicle{ID: "vehicle-1", Name: "RMF", Type: entities.VehicleTypeRMF, CurrentMoney: entities.Baht(10000), UserID: "user-123"}
+
+		userRepo.On("GetUserByID", "user-123").Return(&entities.User{ID: "user-123"}, nil)
+		userRepo.On("GetSelectedHouseForUpdate", "user-123").Return(house, nil)
+		retirementRepo.On("GetRetirementByUserIDForUpdate", "user-123").Return(incomePlan(), nil)
+		vehicleRepo.On("FindVehicleByNameAndUserIDForUpdate", "RMF", "user-123").Return(vehicle, nil)
+		userRepo.On("GetUserDepositsInRange", "user-123", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]entities.History{
+			{Method: "deposit", Type: entities.HistoryTypeVehicle, Category: entities.VehicleTypeRMF, Name: "RMF", Money: entities.Baht(170000), TrackDate: time.Now()},
+		}, nil)
+		vehicleRepo.On("UpdateVehicle", mock.MatchedBy(func(v *entities.SavingsVehicle) bool {
+			return v.CurrentMoney == entities.Baht(15000)
+		})).Return(vehicle, nil).Once()
+		userRepo.On("UpdateSelectedHouse", mock.AnythingOfType("*entities.SelectedHouse")).Return(house, nil)
+		retirementRepo.On("UpdateRetirementPlan", mock.MatchedBy(func(r *entities.RetirementPlan) bool {
+			return r.CurrentSavings == entities.Baht(96000)
+		})).Return(plan(), nil)
+		ledgerRepo.On("GetAccount", "user-123", entities.LedgerAccountVehicle, "vehicle-1").Return(&entities.LedgerAccount{ID: "vehicle"}, nil)
+		ledgerRepo.On("GetAccount", "user-123", entities.LedgerAccountExternal, "user-123").Return(&entities.LedgerAccount{ID: "external"}, nil)
+		ledgerRepo.On("CreateEntry", mock.MatchedBy(func(e *entities.LedgerEntry) bool {
+			return len(e.Postings) == 2 && e.Postings[0].AccountID == "vehicle" && e.Postings[0].Amount == entities.Baht(5000)
+		})).Return(&entities.LedgerEntry{}, nil).Once()
+		userRepo.On("CreateHistory", mock.MatchedBy(func(h *entities.History) bool {
+			return h.Category == entities.VehicleTypeRMF
+		})).Return(&entities.History{ID: "history-1"}, nil)
+
+		result, err := useCase.CreateHistory(vehicleHistory)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "history-1", result.ID)
+		assert.Equal(t, 1, uow.Committed)
+		vehicleRepo.AssertExpectations(t)
+		ledgerRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects vehicle deposit above annual limit", func(t *testing.T) {
+		useCase, userRepo, retirementRepo, _, _, uow := setup()
+		vehicleRepo := new(mocks.MockVehicleRepository)
+		uow.Repositories.Vehicles = vehicleRepo
+		vehicle := &entities.SavingsVehicle{ID: "vehicle-1", Name: "RMF", Type: entities.VehicleTypeRMF, UserID: "user-123"}
+
+		userRepo.On("GetUserByID", "user-123").Return(&entities.User{ID: "user-123"}, nil)
+		userRepo.On("GetSelectedHouseForUpdate", "user-123").Return(house, nil)
+		retirementRepo.On("GetRetirementByUserIDForUpdate", "user-123").Return(incomePlan(), nil)
+		vehicleRepo.On("FindVehicleByNameAndUserIDForUpdate", "RMF", "user-123").Return(vehicle, nil)
+		userRepo.On("GetUserDepositsInRange", "user-123", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]entities.History{
+			{Method: "deposit", Type: entities.HistoryTypeVehicle, Category: entities.VehicleTypeRMF, Name: "RMF", Money: entities.Baht(178000), TrackDate: time.Now()},
+		}, nil)
+
+		result, err := useCase.CreateHistory(vehicleHistory)
+
+		assert.EqualError(t, err, "contribution exceeds annual limit for this vehicle type")
+		assert.Nil(t, result)
+		assert.Equal(t, 1, uow.RolledBack)
+		vehicleRepo.AssertNotCalled(t, "UpdateVehicle", mock.Anything)
+	})
+
+	t.Run("Rejects withdraw from locked vehicle", func(t *testing.T) {
+		useCase, userRepo, retirementRepo, _, _, uow := setup()
+		vehicleRepo := new(mocks.MockVehicleRepository)
+		uow.Repositories.Vehicles = vehicleRepo
+		vehicle := &entities.SavingsVehicle{ID: "vehicle-2", Name: "SSF", Type: entities.VehicleTypeSSF, CurrentMoney: entities.Baht(50000), OpenedAt: time.Now().AddDate(-2, 0, 0), UserID: "user-123"}
+
+		userRepo.On("GetUserByID", "user-123").Return(&entities.User{ID: "user-123"}, nil)
+		userRepo.On("GetSelectedHouseForUpdate", "user-123").Return(house, nil)
+		retirementRepo.On("GetRetirementByUserIDForUpdate", "user-123").Return(incomePlan(), nil)
+		vehicleRepo.On("FindVehicleByNameAndUserIDForUpdate", "SSF", "user-123").Return(vehicle, nil)
+		userRepo.On("GetUserDepositsInRange", "user-123", time.Time{}, mock.AnythingOfType("time.Time")).Return([]entities.History{}, nil)
+
+		withdraw := vehicleHistory
+		withdraw.Method = "withdraw"
+		withdraw.Name = "SSF"
+		result, err := useCase.CreateHistory(withdraw)
+
+		assert.EqualError(t, err, "savings vehicle is still locked")
+		assert.Nil(t, result)
+		assert.Equal(t, 1, uow.RolledBack)
+		vehicleRepo.AssertNotCalled(t, "UpdateVehicle", mock.Anything)
+	})
+}
+
 func TestGetHistoryByUserID(t *testing.T) {
 	userRepo := new(mocks.MockUserRepository)
 	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
 	assetRepo := new(mocks.MockAssetRepository)
-	notiRepo := new(mocks.MockNotiRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
 	nhRepo := new(mocks.MockNhRepository)
 
 	jwtConfig := configs.JWT{Secret: "test-secret"}
 	supaConfig := configs.Supabase{}
 	mailConfig := configs.Mail{}
 
-	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, notiRepo, nhRepo, jwtConfig, supaConfig, mailConfig)
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
 
 	t.Run("Positive Case - Retrieve History Successfully", func(t *testing.T) {
 		mockHistories := []entities.History{
@@ -1388,7 +2028,7 @@ func TestGetHistoryByUserID(t *testing.T) {
 				Method:    "deposit",
 				Type:      "saving_money",
 				Category:  "retirementplan",
-				Money:     1000,
+				Money:     entities.Baht(1000),
 				TrackDate: time.Now(),
 			},
 			{
@@ -1397,7 +2037,7 @@ func TestGetHistoryByUserID(t *testing.T) {
 				Method:    "withdraw",
 				Type:      "saving_money",
 				Category:  "retirementplan",
-				Money:     500,
+				Money:     entities.Baht(500),
 				TrackDate: time.Now(),
 			},
 		}
@@ -1410,7 +2050,7 @@ func TestGetHistoryByUserID(t *testing.T) {
 		assert.Nil(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, mockHistories, result["data"])
-		assert.Equal(t, 500.0, result["total"])
+		assert.Equal(t, entities.Baht(500), result["total"])
 
 		userRepo.AssertCalled(t, "GetHistoryByUserID", "user123")
 		userRepo.AssertCalled(t, "GetHistoryInRange", "user123", mock.Anything, mock.Anything)
@@ -1430,3 +2070,41 @@ func TestGetHistoryByUserID(t *testing.T) {
 		userRepo.AssertNotCalled(t, "GetHistoryInRange")
 	})
 }
+
+func TestGetHistoryByMonth(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	retirementRepo := new(mocks.MockRetirementRepository)
+	ledgerRepo := new(mocks.MockLedgerRepository)
+	assetRepo := new(mocks.MockAssetRepository)
+	dispatcher := new(usecaseMocks.MockNotiDispatcher)
+	nhRepo := new(mocks.MockNhRepository)
+
+	jwtConfig := configs.JWT{Secret: "test-secret"}
+	supaConfig := configs.Supabase{}
+	mailConfig := configs.Mail{}
+
+	useCase := usecases.NewUserUseCase(userRepo, retirementRepo, assetRepo, dispatcher, nhRepo, new(mocks.MockLoanRepository), jwtConfig, supaConfig, mailConfig, mocks.NewMockUnitOfWork(userRepo, assetRepo, retirementRepo, ledgerRepo))
+
+	t.Run("Includes Portfolio Snapshots", func(t *testing.T) {
+		userRepo.On("GetUserHistoryByMonth", "user123").Return(map[string]entities.Money{"2024-05": entities.Baht(1000)}, nil).Once()
+		userRepo.On("GetPortfolioSnapshots", "user123").Return([]entities.PortfolioSnapshot{
+			{Date: time.Date(2024, time.May, 31, 0, 0, 0, 0, time.Local), MarketValue: entities.Baht(5000)},
+			{Date: time.Date(2024, time.June, 30, 0, 0, 0, 0, time.Local), MarketValue: entities.Baht(5500)},
+		}, nil).Once()
+
+		result, err := useCase.GetHistoryByMonth("user123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]entities.Money{"2024-05": entities.Baht(6000), "2024-06": entities.Baht(500)}, result)
+	})
+
+	t.Run("Snapshot Error", func(t *testing.T) {
+		userRepo.On("GetUserHistoryByMonth", "user456").Return(map[string]entities.Money{}, nil).Once()
+		userRepo.On("GetPortfolioSnapshots", "user456").Return(nil, errors.New("database error")).Once()
+
+		result, err := useCase.GetHistoryByMonth("user456")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "database error")
+	})
+}
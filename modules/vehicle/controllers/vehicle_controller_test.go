@@ -0,0 +1,252 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/vehicle/controllers"
+	"github.com/XzerozZ/Kasian_Phrom_BE/testing/usecases/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTest(_ *testing.T) (*controllers.VehicleController, *mocks.MockVehicleUseCase, *fiber.App) {
+	mockUseCase := new(mocks.MockVehicleUseCase)
+	controller := controllers.NewVehicleController(mockUseCase)
+	app := fiber.New()
+	return controller, mockUseCase, app
+}
+
+func TestGetRulesHandler(t *testing.T) {
+	controller, mockUseCase, app := setupTest(t)
+	app.Get("/vehicle/rules", controller.GetRulesHandler)
+
+	mockUseCase.On("GetRules").Return([]entities.VehicleRule{{Type: entities.VehicleTypeRMF, AnnualCap: entities.Baht(500000)}}).Once()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/vehicle/rules", nil), -1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var responseMap map[string]interface{}
+	responseBody, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(responseBody, &responseMap)
+	result := responseMap["result"].([]interface{})
+	assert.Equal(t, "rmf", result[0].(map[string]interface{})["type"])
+	assert.Equal(t, 500000.0, result[0].(map[string]interface{})["annual_cap"])
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestCreateVehicleHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/vehicle", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.CreateVehicleHandler(c)
+		})
+
+		mockUseCase.On("CreateVehicle", "user-123", mock.MatchedBy(func(vehicle entities.SavingsVehicle) bool {
+			return vehicle.Name == "RMF หุ้นไทย" && vehicle.Type == entities.VehicleTypeRMF && vehicle.MonthlyContribution == entities.Baht(5000)
+		})).Return(&entities.SavingsVehicle{ID: "vehicle-1", Name: "RMF หุ้นไทย", Type: entities.VehicleTypeRMF}, nil).Once()
+
+		req := httptest.NewRequest("POST", "/vehicle", strings.NewReader(`{"name":"RMF หุ้นไทย","type":"rmf","monthly_contribution":5000}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "Vehicle created successfully", responseMap["message"])
+		assert.Equal(t, "vehicle-1", responseMap["result"].(map[string]interface{})["vehicle_id"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Missing Fields", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/vehicle", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.CreateVehicleHandler(c)
+		})
+
+		req := httptest.NewRequest("POST", "/vehicle", strings.NewReader(`{"name":"RMF"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "Name or Type is missing.", responseMap["message"])
+		mockUseCase.AssertNotCalled(t, "CreateVehicle", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid Type", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Post("/vehicle", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.CreateVehicleHandler(c)
+		})
+
+		mockUseCase.On("CreateVehicle", "user-123", mock.Anything).Return(nil, errors.New("invalid vehicle type")).Once()
+
+		req := httptest.NewRequest("POST", "/vehicle", strings.NewReader(`{"name":"กองทุน","type":"ltf"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req, -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "invalid vehicle type", responseMap["message"])
+	})
+}
+
+func TestGetVehiclesHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/vehicle", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetVehiclesHandler(c)
+		})
+
+		summaries := []entities.VehicleSummary{{
+			SavingsVehicle: entities.SavingsVehicle{ID: "vehicle-1", Type: entities.VehicleTypeSSF},
+			RemainingLimit: entities.Baht(150000),
+		}}
+		mockUseCase.On("GetVehiclesByUserID", "user-123").Return(summaries, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/vehicle", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		result := responseMap["result"].([]interface{})
+		assert.Equal(t, "vehicle-1", result[0].(map[string]interface{})["vehicle_id"])
+		assert.Equal(t, 150000.0, result[0].(map[string]interface{})["remaining_limit"])
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/vehicle", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetVehiclesHandler(c)
+		})
+
+		mockUseCase.On("GetVehiclesByUserID", "user-123").Return(nil, errors.New("retirement plan not found")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/vehicle", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	})
+}
+
+func TestGetVehicleByIDHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/vehicle/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetVehicleByIDHandler(c)
+		})
+
+		mockUseCase.On("GetVehicleByID", "user-123", "vehicle-1").Return(&entities.VehicleSummary{SavingsVehicle: entities.SavingsVehicle{ID: "vehicle-1"}}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/vehicle/vehicle-1", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Get("/vehicle/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.GetVehicleByIDHandler(c)
+		})
+
+		mockUseCase.On("GetVehicleByID", "user-123", "vehicle-2").Return(nil, errors.New("vehicle not found")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/vehicle/vehicle-2", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "vehicle not found", responseMap["message"])
+	})
+}
+
+func TestUpdateVehicleHandler(t *testing.T) {
+	controller, mockUseCase, app := setupTest(t)
+	app.Put("/vehicle/:id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return controller.UpdateVehicleHandler(c)
+	})
+
+	mockUseCase.On("UpdateVehicle", "user-123", "vehicle-1", mock.MatchedBy(func(vehicle entities.SavingsVehicle) bool {
+		return vehicle.ExpectedReturn == 6
+	})).Return(&entities.SavingsVehicle{ID: "vehicle-1", ExpectedReturn: 6}, nil).Once()
+
+	req := httptest.NewRequest("PUT", "/vehicle/vehicle-1", strings.NewReader(`{"expected_return":6}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	var responseMap map[string]interface{}
+	responseBody, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(responseBody, &responseMap)
+	assert.Equal(t, "Vehicle updated successfully", responseMap["message"])
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestDeleteVehicleHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Delete("/vehicle/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.DeleteVehicleHandler(c)
+		})
+
+		mockUseCase.On("DeleteVehicle", "user-123", "vehicle-1").Return(nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/vehicle/vehicle-1", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("Remaining Money", func(t *testing.T) {
+		controller, mockUseCase, app := setupTest(t)
+		app.Delete("/vehicle/:id", func(c *fiber.Ctx) error {
+			c.Locals("user_id", "user-123")
+			return controller.DeleteVehicleHandler(c)
+		})
+
+		mockUseCase.On("DeleteVehicle", "user-123", "vehicle-1").Return(errors.New("cannot delete vehicle with remaining money")).Once()
+
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/vehicle/vehicle-1", nil), -1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		var responseMap map[string]interface{}
+		responseBody, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(responseBody, &responseMap)
+		assert.Equal(t, "cannot delete vehicle with remaining money", responseMap["message"])
+	})
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormVehicleRepository struct {
+	db *gorm.DB
+}
+
+func NewGormVehicleRepository(db *gorm.DB) *GormVehicleRepository {
+	return &GormVehicleRepository{db: db}
+}
+
+type VehicleRepository interface {
+	CreateVehicle(vehicle *entities.SavingsVehicle) (*entities.SavingsVehicle, error)
+	GetVehicleByID(id string) (*entities.SavingsVehicle, error)
+	GetVehiclesByUserID(userID string) ([]entities.SavingsVehicle, error)
+	FindVehicleByNameAndUserID(name, userID string) (*entities.SavingsVehicle, error)
+	FindVehicleByNameAndUserIDForUpdate(name, userID string) (*entities.SavingsVehicle, error)
+	UpdateVehicle(vehicle *entities.SavingsVehicle) (*entities.SavingsVehicle, error)
+	DeleteVehicle(id string) error
+}
+
+func (r *GormVehicleRepository) CreateVehicle(vehicle *entities.SavingsVehicle) (*entities.SavingsVehicle, error) {
+	if err := r.db.Create(&vehicle).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetVehicleByID(vehicle.ID)
+}
+
+func (r *GormVehicleRepository) GetVehicleByID(id string) (*entities.SavingsVehicle, error) {
+	var vehicle entities.SavingsVehicle
+	if err := r.db.First(&vehicle, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return &vehicle, nil
+}
+
+func (r *GormVehicleRepository) GetVehiclesByUserID(userID string) ([]entities.SavingsVehicle, error) {
+	var vehicles []entities.SavingsVehicle
+	if err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&vehicles).Error; err != nil {
+		return nil, err
+	}
+
+	return vehicles, nil
+}
+
+func (r *GormVehicleRepository) FindVehicleByNameAndUserID(name, userID string) (*entities.SavingsVehicle, error) {
+	var vehicle entities.SavingsVehicle
+	if err := r.db.Where("name = ? AND user_id = ?", name, userID).First(&vehicle).Error; err != nil {
+		return nil, err
+	}
+
+	return &vehicle, nil
+}
+
+func (r *GormVehicleRepository) FindVehicleByNameAndUserIDForUpdate(name, userID string) (*entities.SavingsVehicle, error) {
+	var vehicle entities.SavingsVehicle
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ? AND user_id = ?", name, userID).First(&vehicle).Error; err != nil {
+		return nil, err
+	}
+
+	return &vehicle, nil
+}
+
+func (r *GormVehicleRepository) UpdateVehicle(vehicle *entities.SavingsVehicle) (*entities.SavingsVehicle, error) {
+	if err := r.db.Save(&vehicle).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetVehicleByID(vehicle.ID)
+}
+
+func (r *GormVehicleRepository) DeleteVehicle(id string) error {
+	return r.db.Where("id = ?", id).Delete(&entities.SavingsVehicle{}).Error
+}
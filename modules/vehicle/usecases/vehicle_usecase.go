@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"errors"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	userRepo "github.com/XzerozZ/Kasian_Phrom_BE/modules/user/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/vehicle/repositories"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+type VehicleUseCase interface {
+	GetRules() []entities.VehicleRule
+	CreateVehicle(userID string, vehicle entities.SavingsVehicle) (*entities.SavingsVehicle, error)
+	GetVehicleByID(userID, id string) (*entities.VehicleSummary, error)
+	GetVehiclesByUserID(userID string) ([]entities.VehicleSummary, error)
+	UpdateVehicle(userID, id string, vehicle entities.SavingsVehicle) (*entities.SavingsVehicle, error)
+	DeleteVehicle(userID, id string) error
+}
+
+type VehicleUseCaseImpl struct {
+	vehiclerepo repositories.VehicleRepository
+	userrepo    userRepo.UserRepository
+}
+
+func NewVehicleUseCase(vehiclerepo repositories.VehicleRepository, userrepo userRepo.UserRepository) *VehicleUseCaseImpl {
+	return &VehicleUseCaseImpl{
+		vehiclerepo: vehiclerepo,
+		userrepo:    userrepo,
+	}
+}
+
+func validateVehicle(vehicle *entities.SavingsVehicle) error {
+	if vehicle.MonthlyContribution < 0 || vehicle.AverageWage < 0 || vehicle.ContributionMonths < 0 {
+		return errors.New("vehicle values must not be negative")
+	}
+
+	if vehicle.ExpectedReturn <= -100 {
+		return errors.New("expected return must be greater than -100")
+	}
+
+	return nil
+}
+
+func (u *VehicleUseCaseImpl) GetRules() []entities.VehicleRule {
+	return utils.VehicleRules()
+}
+
+func (u *VehicleUseCaseImpl) CreateVehicle(userID string, vehicle entities.SavingsVehicle) (*entities.SavingsVehicle, error) {
+	rule, err := utils.GetVehicleRule(vehicle.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if vehicle.CurrentMoney < 0 {
+		return nil, errors.New("vehicle values must not be negative")
+	}
+
+	if err := validateVehicle(&vehicle); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.vehiclerepo.FindVehicleByNameAndUserID(vehicle.Name, userID); err == nil {
+		return nil, errors.New("vehicle name already exists")
+	}
+
+	if vehicle.ExpectedReturn == 0 {
+		vehicle.ExpectedReturn = rule.DefaultReturn
+	}
+
+	if vehicle.OpenedAt.IsZero() {
+		vehicle.OpenedAt = time.Now()
+	}
+
+	vehicle.ID = uuid.New().String()
+	vehicle.UserID = userID
+	return u.vehiclerepo.CreateVehicle(&vehicle)
+}
+
+func (u *VehicleUseCaseImpl) getOwnedVehicle(userID, id string) (*entities.SavingsVehicle, error) {
+	vehicle, err := u.vehiclerepo.GetVehicleByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if vehicle.UserID != userID {
+		return nil, errors.New("vehicle not found")
+	}
+
+	return vehicle, nil
+}
+
+func (u *VehicleUseCaseImpl) summarize(userID string, vehicles []entities.SavingsVehicle) ([]entities.VehicleSummary, error) {
+	user, err := u.userrepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.RetirementPlan.ID == "" {
+		return nil, errors.New("retirement plan not found")
+	}
+
+	now := time.Now()
+	contributions, err := u.userrepo.GetUserDepositsInRange(userID, time.Time{}, now)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]entities.VehicleSummary, 0, len(vehicles))
+	for i := range vehicles {
+		summary, err := utils.SummarizeVehicle(&vehicles[i], &user.RetirementPlan, contributions, now)
+		if err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, *summary)
+	}
+
+	return summaries, nil
+}
+
+func (u *VehicleUseCaseImpl) GetVehicleByID(userID, id string) (*entities.VehicleSummary, error) {
+	vehicle, err := u.getOwnedVehicle(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := u.summarize(userID, []entities.SavingsVehicle{*vehicle})
+	if err != nil {
+		return nil, err
+	}
+
+	return &summaries[0], nil
+}
+
+func (u *VehicleUseCaseImpl) GetVehiclesByUserID(userID string) ([]entities.VehicleSummary, error) {
+	vehicles, err := u.vehiclerepo.GetVehiclesByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.summarize(userID, vehicles)
+}
+
+func (u *VehicleUseCaseImpl) UpdateVehicle(userID, id string, vehicle entities.SavingsVehicle) (*entities.SavingsVehicle, error) {
+	existing, err := u.getOwnedVehicle(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateVehicle(&vehicle); err != nil {
+		return nil, err
+	}
+
+	existing.MonthlyContribution = vehicle.MonthlyContribution
+	existing.ExpectedReturn = vehicle.ExpectedReturn
+	existing.ContributionMonths = vehicle.ContributionMonths
+	existing.AverageWage = vehicle.AverageWage
+	if !vehicle.OpenedAt.IsZero() {
+		existing.OpenedAt = vehicle.OpenedAt
+	}
+
+	return u.vehiclerepo.UpdateVehicle(existing)
+}
+
+func (u *VehicleUseCaseImpl) DeleteVehicle(userID, id string) error {
+	vehicle, err := u.getOwnedVehicle(userID, id)
+	if err != nil {
+		return err
+	}
+
+	if vehicle.CurrentMoney > 0 {
+		return errors.New("cannot delete vehicle with remaining money")
+	}
+
+	return u.vehiclerepo.DeleteVehicle(id)
+}
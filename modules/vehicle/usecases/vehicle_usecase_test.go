@@ -0,0 +1,200 @@
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/vehicle/usecases"
+	"github.com/XzerozZ/Kasian_Phrom_BE/testing/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupVehicleUseCase() (*usecases.VehicleUseCaseImpl, *mocks.MockVehicleRepository, *mocks.MockUserRepository) {
+	vehicleRepo := new(mocks.MockVehicleRepository)
+	userRepo := new(mocks.MockUserRepository)
+	return usecases.NewVehicleUseCase(vehicleRepo, userRepo), vehicleRepo, userRepo
+}
+
+func vehicleUser() *entities.User {
+	return &entities.User{
+		ID: "user-123",
+		RetirementPlan: entities.RetirementPlan{
+			ID:             "plan-1",
+			BirthDate:      time.Now().AddDate(-40, 0, 0).Format("02-01-2006"),
+			RetirementAge:  60,
+			ExpectLifespan: 80,
+			MonthlyIncome:  entities.Baht(50000),
+		},
+	}
+}
+
+func TestCreateVehicle(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		useCase, vehicleRepo, _ := setupVehicleUseCase()
+
+		vehicleRepo.On("FindVehicleByNameAndUserID", "RMF", "user-123").Return(nil, gorm.ErrRecordNotFound).Once()
+		vehicleRepo.On("CreateVehicle", mock.MatchedBy(func(v *entities.SavingsVehicle) bool {
+			return v.ID != "" && v.UserID == "user-123" && v.ExpectedReturn == 5 && !v.OpenedAt.IsZero()
+		})).Return(&entities.SavingsVehicle{ID: "vehicle-1"}, nil).Once()
+
+		result, err := useCase.CreateVehicle("user-123", entities.SavingsVehicle{Name: "RMF", Type: entities.VehicleTypeRMF})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "vehicle-1", result.ID)
+		vehicleRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Type", func(t *testing.T) {
+		useCase, vehicleRepo, _ := setupVehicleUseCase()
+
+		result, err := useCase.CreateVehicle("user-123", entities.SavingsVehicle{Name: "LTF", Type: "ltf"})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "invalid vehicle type")
+		vehicleRepo.AssertNotCalled(t, "CreateVehicle", mock.Anything)
+	})
+
+	t.Run("Duplicate Name", func(t *testing.T) {
+		useCase, vehicleRepo, _ := setupVehicleUseCase()
+
+		vehicleRepo.On("FindVehicleByNameAndUserID", "RMF", "user-123").Return(&entities.SavingsVehicle{ID: "vehicle-1"}, nil).Once()
+
+		result, err := useCase.CreateVehicle("user-123", entities.SavingsVehicle{Name: "RMF", Type: entities.VehicleTypeRMF})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "vehicle name already exists")
+	})
+
+	t.Run("Negative Values", func(t *testing.T) {
+		useCase, _, _ := setupVehicleUseCase()
+
+		result, err := useCase.CreateVehicle("user-123", entities.SavingsVehicle{Name: "RMF", Type: entities.VehicleTypeRMF, MonthlyContribution: entities.Baht(-1)})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "vehicle values must not be negative")
+	})
+}
+
+func TestGetVehiclesByUserID(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		useCase, vehicleRepo, userRepo := setupVehicleUseCase()
+		vehicles := []entities.SavingsVehicle{
+			{ID: "vehicle-1", Name: "SSF", Type: entities.VehicleTypeSSF, CurrentMoney: entities.Baht(40000), OpenedAt: time.Now().AddDate(-1, 0, 0), UserID: "user-123"},
+		}
+
+		vehicleRepo.On("GetVehiclesByUserID", "user-123").Return(vehicles, nil).Once()
+		userRepo.On("GetUserByID", "user-123").Return(vehicleUser(), nil).Once()
+		userRepo.On("GetUserDepositsInRange", "user-123", time.Time{}, mock.AnythingOfType("time.Time")).Return([]entities.History{
+			{Method: "deposit", Type: entities.HistoryTypeVehicle, Category: entities.VehicleTypeSSF, Name: "SSF", Money: entities.Baht(40000), TrackDate: time.Now()},
+		}, nil).Once()
+
+		result, err := useCase.GetVehiclesByUserID("user-123")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, entities.Baht(180000), result[0].AnnualLimit)
+		assert.Equal(t, entities.Baht(40000), result[0].ContributedThisYear)
+		assert.Equal(t, entities.Baht(140000), result[0].RemainingLimit)
+		assert.Equal(t, entities.Money(0), result[0].Withdrawable)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("No Retirement Plan", func(t *testing.T) {
+		useCase, vehicleRepo, userRepo := setupVehicleUseCase()
+
+		vehicleRepo.On("GetVehiclesByUserID", "user-123").Return([]entities.SavingsVehicle{}, nil).Once()
+		userRepo.On("GetUserByID", "user-123").Return(&entities.User{ID: "user-123"}, nil).Once()
+
+		result, err := useCase.GetVehiclesByUserID("user-123")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "retirement plan not found")
+	})
+}
+
+func TestGetVehicleByID(t *testing.T) {
+	t.Run("Other User", func(t *testing.T) {
+		useCase, vehicleRepo, _ := setupVehicleUseCase()
+
+		vehicleRepo.On("GetVehicleByID", "vehicle-1").Return(&entities.SavingsVehicle{ID: "vehicle-1", UserID: "user-999"}, nil).Once()
+
+		result, err := useCase.GetVehicleByID("user-123", "vehicle-1")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "vehicle not found")
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		useCase, vehicleRepo, _ := setupVehicleUseCase()
+
+		vehicleRepo.On("GetVehicleByID", "vehicle-2").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, err := useCase.GetVehicleByID("user-123", "vehicle-2")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestUpdateVehicle(t *testing.T) {
+	useCase, vehicleRepo, _ := setupVehicleUseCase()
+	opened := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	existing := &entities.SavingsVehicle{ID: "vehicle-1", Name: "RMF", Type: entities.VehicleTypeRMF, CurrentMoney: entities.Baht(1000), OpenedAt: opened, UserID: "user-123"}
+
+	vehicleRepo.On("GetVehicleByID", "vehicle-1").Return(existing, nil).Once()
+	vehicleRepo.On("UpdateVehicle", mock.MatchedBy(func(v *entities.SavingsVehicle) bool {
+		return v.Name == "RMF" && v.Type == entities.VehicleTypeRMF && v.CurrentMoney == entities.Baht(1000) &&
+			v.ExpectedReturn == 7 && v.MonthlyContribution == entities.Baht(3000) && v.OpenedAt.Equal(opened)
+	})).Return(existing, nil).Once()
+
+	_, err := useCase.UpdateVehicle("user-123", "vehicle-1", entities.SavingsVehicle{
+		Name:                "ชื่อใหม่",
+		Type:                entities.VehicleTypeSSF,
+		CurrentMoney:        entities.Baht(999999),
+		ExpectedReturn:      7,
+		MonthlyContribution: entities.Baht(3000),
+	})
+
+	assert.NoError(t, err)
+	vehicleRepo.AssertExpectations(t)
+}
+
+func TestDeleteVehicle(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		useCase, vehicleRepo, _ := setupVehicleUseCase()
+
+		vehicleRepo.On("GetVehicleByID", "vehicle-1").Return(&entities.SavingsVehicle{ID: "vehicle-1", UserID: "user-123"}, nil).Once()
+		vehicleRepo.On("DeleteVehicle", "vehicle-1").Return(nil).Once()
+
+		err := useCase.DeleteVehicle("user-123", "vehicle-1")
+
+		assert.NoError(t, err)
+		vehicleRepo.AssertExpectations(t)
+	})
+
+	t.Run("Remaining Money", func(t *testing.T) {
+		useCase, vehicleRepo, _ := setupVehicleUseCase()
+
+		vehicleRepo.On("GetVehicleByID", "vehicle-1").Return(&entities.SavingsVehicle{ID: "vehicle-1", UserID: "user-123", CurrentMoney: entities.Baht(1)}, nil).Once()
+
+		err := useCase.DeleteVehicle("user-123", "vehicle-1")
+
+		assert.EqualError(t, err, "cannot delete vehicle with remaining money")
+		vehicleRepo.AssertNotCalled(t, "DeleteVehicle", mock.Anything)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		useCase, vehicleRepo, _ := setupVehicleUseCase()
+
+		vehicleRepo.On("GetVehicleByID", "vehicle-1").Return(&entities.SavingsVehicle{ID: "vehicle-1", UserID: "user-123"}, nil).Once()
+		vehicleRepo.On("DeleteVehicle", "vehicle-1").Return(errors.New("database error")).Once()
+
+		err := useCase.DeleteVehicle("user-123", "vehicle-1")
+
+		assert.EqualError(t, err, "database error")
+	})
+}
@@ -1,12 +1,16 @@
 package database
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/XzerozZ/Kasian_Phrom_BE/configs"
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
 
+	"github.com/google/uuid"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -40,19 +44,40 @@ func InitDB(config configs.PostgreSQL) {
 		&entities.Favorite{},
 		&entities.Asset{},
 		&entities.RetirementPlan{},
+		&entities.RetirementPlanRevision{},
 		&entities.SelectedHouse{},
 		&entities.OTP{},
 		&entities.Loan{},
+		&entities.LoanPayment{},
 		&entities.History{},
 		&entities.Risk{},
 		&entities.Quiz{},
 		&entities.Transaction{},
 		&entities.Notification{},
 		&entities.NursingHouseHistory{},
+		&entities.Session{},
+		&entities.NotificationPreference{},
+		&entities.QueuedEmail{},
+		&entities.LedgerAccount{},
+		&entities.LedgerEntry{},
+		&entities.LedgerPosting{},
+		&entities.Scenario{},
+		&entities.ScenarioAsset{},
+		&entities.SavingsVehicle{},
+		&entities.TaxTable{},
+		&entities.TaxBracket{},
+		&entities.TaxDeduction{},
+		&entities.Holding{},
+		&entities.PriceQuote{},
+		&entities.PortfolioSnapshot{},
 	)
 
 	insertRoles()
 	insertRisk()
+	insertTaxTables()
+	migrateRetirementPlans()
+	migrateTransactionStatuses()
+	migrateTransactionHistory()
 	log.Println("Database connection established successfully!")
 }
 
@@ -64,6 +89,109 @@ func GetDB() *gorm.DB {
 	return db
 }
 
+func migrateRetirementPlans() {
+	for _, name := range []string{"uni_retirement_plans_user_id", "retirement_plans_user_id_key"} {
+		if db.Migrator().HasConstraint(&entities.RetirementPlan{}, name) {
+			if err := db.Migrator().DropConstraint(&entities.RetirementPlan{}, name); err != nil {
+				log.Fatalf("Failed to drop constraint %s: %v", name, err)
+			}
+		}
+	}
+
+	err := db.Exec(`UPDATE retirement_plans SET is_active = true WHERE id IN (
+		SELECT DISTINCT ON (user_id) id FROM retirement_plans
+		WHERE user_id NOT IN (SELECT user_id FROM retirement_plans WHERE is_active)
+		ORDER BY user_id, updated_at DESC)`).Error
+	if err != nil {
+		log.Fatalf("Failed to activate retirement plans: %v", err)
+	}
+
+	err = db.Exec(`INSERT INTO retirement_plan_revisions (id, plan_id, revision, user_id, plan_name, birth_date,
+		retirement_age, expect_lifespan, current_savings, current_savings_returns, monthly_income, monthly_expenses,
+		current_total_investment, investment_return, expected_monthly_expenses, expected_inflation,
+		annual_expense_increase, annual_savings_return, annual_investment_return, last_required_funds,
+		last_monthly_expenses, status, is_active, created_at)
+		SELECT gen_random_uuid(), id, 1, user_id, plan_name, birth_date,
+		retirement_age, expect_lifespan, current_savings, current_savings_returns, monthly_income, monthly_expenses,
+		current_total_investment, investment_return, expected_monthly_expenses, expected_inflation,
+		annual_expense_increase, annual_savings_return, annual_investment_return, last_required_funds,
+		last_monthly_expenses, status, is_active, NOW()
+		FROM retirement_plans WHERE id NOT IN (SELECT plan_id FROM retirement_plan_revisions)`).Error
+	if err != nil {
+		log.Fatalf("Failed to create retirement plan revisions: %v", err)
+	}
+}
+
+func migrateTransactionStatuses() {
+	statuses := map[string]entities.TransactionStatus{
+		"ชำระ":     entities.TransactionDue,
+		"ค้างชำระ": entities.TransactionOverdue,
+		"ชำระแล้ว": entities.TransactionPaid,
+		"หยุดพัก":  entities.TransactionPaused,
+	}
+
+	for old, status := range statuses {
+		if err := db.Model(&entities.Transaction{}).Where("status = ?", old).Update("status", status).Error; err != nil {
+			log.Fatalf("Failed to migrate transaction status %s: %v", old, err)
+		}
+	}
+}
+
+func migrateTransactionHistory() {
+	queries := map[string]string{
+		"amount":      `UPDATE transactions SET amount = loans.monthly_expenses FROM loans WHERE loans.id = transactions.loan_id AND transactions.amount = 0`,
+		"due date":    `UPDATE transactions SET due_date = date_trunc('month', created_at) + interval '1 month' - interval '1 day' WHERE due_date IS NULL OR due_date < '1900-01-01'`,
+		"paid amount": `UPDATE transactions SET paid_amount = amount WHERE status = 'PAID' AND paid_amount = 0`,
+	}
+
+	for _, name := range []string{"amount", "due date", "paid amount"} {
+		if err := db.Exec(queries[name]).Error; err != nil {
+			log.Fatalf("Failed to migrate transaction %s: %v", name, err)
+		}
+	}
+}
+
+const taxTablesFile = "./assets/TaxTables.json"
+
+func insertTaxTables() {
+	data, err := os.ReadFile(taxTablesFile)
+	if err != nil {
+		log.Printf("Skipping tax tables: %v", err)
+		return
+	}
+
+	var tables []entities.TaxTable
+	if err := json.Unmarshal(data, &tables); err != nil {
+		log.Fatalf("Failed to parse tax tables: %v", err)
+	}
+
+	for _, table := range tables {
+		var count int64
+		if err := db.Model(&entities.TaxTable{}).Where("tax_year = ? AND version = ?", table.TaxYear, table.Version).Count(&count).Error; err != nil {
+			log.Fatalf("Error checking tax table: %v", err)
+		}
+
+		if count > 0 {
+			continue
+		}
+
+		if err := utils.ValidateTaxTable(&table); err != nil {
+			log.Fatalf("Invalid tax table %d version %d: %v", table.TaxYear, table.Version, err)
+		}
+
+		table.ID = uuid.New().String()
+		for i := range table.Brackets {
+			table.Brackets[i].ID = uuid.New().String()
+		}
+
+		if err := db.Create(&table).Error; err != nil {
+			log.Fatalf("Failed to insert tax table: %v", err)
+		}
+
+		log.Printf("Tax table %d version %d created successfully!", table.TaxYear, table.Version)
+	}
+}
+
 func insertRoles() {
 	var adminRole entities.Role
 	var userRole entities.Role
@@ -1,13 +1,20 @@
 package middlewares
 
 import (
+	"time"
+
 	"github.com/XzerozZ/Kasian_Phrom_BE/configs"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func JWTMiddleware(config configs.JWT) fiber.Handler {
+type SessionStore interface {
+	GetSessionByID(id string) (*entities.Session, error)
+}
+
+func JWTMiddleware(config configs.JWT, sessions SessionStore) fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 		tokenString := ctx.Get("Authorization")
 		if tokenString == "" || len(tokenString) < 8 {
@@ -25,7 +32,7 @@ func JWTMiddleware(config configs.JWT) fiber.Handler {
 				return nil, fiber.ErrUnauthorized
 			}
 			return []byte(config.Secret), nil
-		})
+		}, jwt.WithExpirationRequired())
 
 		if err != nil || !token.Valid {
 			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
@@ -46,8 +53,29 @@ func JWTMiddleware(config configs.JWT) fiber.Handler {
 			})
 		}
 
+		sessionID, ok := claims["sid"].(string)
+		if !ok || sessionID == "" {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status":      "Unauthorized",
+				"status_code": fiber.StatusUnauthorized,
+				"message":     "Invalid token claims",
+				"result":      nil,
+			})
+		}
+
+		session, err := sessions.GetSessionByID(sessionID)
+		if err != nil || session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status":      "Unauthorized",
+				"status_code": fiber.StatusUnauthorized,
+				"message":     "Session has been revoked",
+				"result":      nil,
+			})
+		}
+
 		ctx.Locals("user_id", claims["user_id"])
 		ctx.Locals("role", claims["role"])
+		ctx.Locals("session_id", sessionID)
 		return ctx.Next()
 	}
 }
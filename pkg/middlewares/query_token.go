@@ -0,0 +1,17 @@
+package middlewares
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+func TokenFromQuery(param string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if ctx.Get("Authorization") == "" {
+			if token := ctx.Query(param); token != "" {
+				ctx.Request().Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+
+		return ctx.Next()
+	}
+}
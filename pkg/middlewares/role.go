@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+func RequireRole(roles ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		role, ok := ctx.Locals("role").(string)
+		if !ok || role == "" {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status":      "Unauthorized",
+				"status_code": fiber.StatusUnauthorized,
+				"message":     "Missing or invalid token",
+				"result":      nil,
+			})
+		}
+
+		for _, allowed := range roles {
+			if role == allowed {
+				return ctx.Next()
+			}
+		}
+
+		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"status":      "Forbidden",
+			"status_code": fiber.StatusForbidden,
+			"message":     "You do not have permission to access this resource",
+			"result":      nil,
+		})
+	}
+}
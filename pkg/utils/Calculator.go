@@ -39,7 +39,7 @@ func CalculateAge(birthDateStr string) (int, error) {
 	return years, nil
 }
 
-func CalculateRetirementFunds(plan *entities.RetirementPlan, age int) (float64, error) {
+func CalculateRetirementFunds(plan *entities.RetirementPlan, age int) (entities.Money, error) {
 	yearsUntilRetirement := plan.RetirementAge - age
 	yearsInRetirement := plan.ExpectLifespan - plan.RetirementAge
 	if yearsUntilRetirement <= 0 {
@@ -50,18 +50,17 @@ func CalculateRetirementFunds(plan *entities.RetirementPlan, age int) (float64,
 		return 0, errors.New("expected lifespan must be greater than retirement age")
 	}
 
-	var totalRequiredFunds float64
-	annualExpenses := plan.ExpectedMonthlyExpenses * 12
+	var totalFactor float64
+	annualExpenses := plan.ExpectedMonthlyExpenses.Mul(12)
 	for year := 1; year <= yearsInRetirement; year++ {
 		remainingYears := yearsUntilRetirement + year
-		compoundingFactor := math.Pow(1+(plan.ExpectedInflation/100), float64(remainingYears))
-		totalRequiredFunds += annualExpenses * compoundingFactor
+		totalFactor += math.Pow(1+(plan.ExpectedInflation/100), float64(remainingYears))
 	}
 
-	return math.Round(totalRequiredFunds), nil
+	return annualExpenses.MulRate(totalFactor).Round(entities.OneBaht), nil
 }
 
-func CalculateMonthlySavings(plan *entities.RetirementPlan, age, currentYear, currentMonth int) (float64, error) {
+func CalculateMonthlySavings(plan *entities.RetirementPlan, age, currentYear, currentMonth int) (entities.Money, error) {
 	requiredFunds, err := CalculateRetirementFunds(plan, age)
 	if err != nil {
 		return 0, err
@@ -80,10 +79,38 @@ func CalculateMonthlySavings(plan *entities.RetirementPlan, age, currentYear, cu
 	}
 
 	remainingMoney := requiredFunds - (plan.CurrentSavings + plan.CurrentTotalInvestment)
-	return math.Round(remainingMoney / float64(remainingMonths)), nil
+	return remainingMoney.DivRound(remainingMonths, entities.OneBaht), nil
 }
 
-func CalculateMonthlyExpenses(asset *entities.Asset, currentYear, currentMonth int) float64 {
+func RecalculateRetirementPlan(plan *entities.RetirementPlan, now time.Time) error {
+	age, err := CalculateRetirementPlanAge(plan.BirthDate, now)
+	if err != nil {
+		return err
+	}
+
+	requiredFunds, err := CalculateRetirementFunds(plan, age)
+	if err != nil {
+		return err
+	}
+
+	monthlySavings, err := CalculateMonthlySavings(plan, age, now.Year(), int(now.Month()))
+	if err != nil {
+		return err
+	}
+
+	plan.LastRequiredFunds = requiredFunds
+	plan.LastMonthlyExpenses = monthlySavings
+	plan.LastCalculatedMonth = int(now.Month())
+	plan.Status = entities.StatusInProgress
+	if plan.CurrentSavings+plan.CurrentTotalInvestment >= requiredFunds {
+		plan.Status = entities.StatusCompleted
+		plan.LastMonthlyExpenses = 0
+	}
+
+	return nil
+}
+
+func CalculateMonthlyExpenses(asset *entities.Asset, currentYear, currentMonth int) entities.Money {
 	endYear, err := strconv.Atoi(asset.EndYear)
 	if err != nil {
 		return 0
@@ -95,11 +122,11 @@ func CalculateMonthlyExpenses(asset *entities.Asset, currentYear, currentMonth i
 	}
 
 	remainingCost := asset.TotalCost - asset.CurrentMoney
-	return math.Round(remainingCost / float64(remainingMonths))
+	return remainingCost.DivRound(remainingMonths, entities.OneBaht)
 }
 
-func CalculateAllAssetsMonthlyExpenses(user *entities.User) (float64, error) {
-	var total float64
+func CalculateAllAssetsMonthlyExpenses(user *entities.User) (entities.Money, error) {
+	var total entities.Money
 	currentMonth := int(time.Now().Month())
 	for _, asset := range user.Assets {
 		if asset.Status == "In_Progress" && asset.LastCalculatedMonth == currentMonth {
@@ -109,12 +136,12 @@ func CalculateAllAssetsMonthlyExpenses(user *entities.User) (float64, error) {
 		}
 	}
 
-	total = math.Round(total)
+	total = total.Round(entities.OneBaht)
 	return total, nil
 }
 
-func CalculateAllAssetSavings(user *entities.User, method string) float64 {
-	var total float64
+func CalculateAllAssetSavings(user *entities.User, method string) entities.Money {
+	var total entities.Money
 	if method == "All" {
 		for _, asset := range user.Assets {
 			total += asset.CurrentMoney
@@ -129,11 +156,11 @@ func CalculateAllAssetSavings(user *entities.User, method string) float64 {
 		}
 	}
 
-	total = math.Round(total)
+	total = total.Round(entities.OneBaht)
 	return total
 }
 
-func CalculateNursingHouseMonthlyExpense(user *entities.User, nursingHousePrice float64, currentYear, currentMonth int) (float64, error) {
+func CalculateNursingHouseMonthlyExpense(user *entities.User, nursingHousePrice entities.Money, currentYear, currentMonth int) (entities.Money, error) {
 	birthDate, err := time.Parse("02-01-2006", user.RetirementPlan.BirthDate)
 	if err != nil {
 		return 0, errors.New("invalid BirthDate format, expected DD-MM-YYYY")
@@ -146,11 +173,11 @@ func CalculateNursingHouseMonthlyExpense(user *entities.User, nursingHousePrice
 		return 0, nil
 	}
 
-	totalCost := float64((user.RetirementPlan.ExpectLifespan-user.RetirementPlan.RetirementAge)*12) * nursingHousePrice
+	totalCost := nursingHousePrice.Mul((user.RetirementPlan.ExpectLifespan - user.RetirementPlan.RetirementAge) * 12)
 	remainingCost := totalCost - user.House.CurrentMoney
 	if remainingCost <= 0 {
 		return 0, nil
 	}
 
-	return math.Round(remainingCost / float64(remainingMonths)), nil
+	return remainingCost.DivRound(remainingMonths, entities.OneBaht), nil
 }
@@ -0,0 +1,267 @@
+package utils
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+)
+
+const maxPayoffMonths = 1200
+
+type debt struct {
+	loan    *entities.Loan
+	balance entities.Money
+	minimum entities.Money
+	number  int
+	payoff  entities.LoanPayoff
+}
+
+type payoffOrder struct {
+	strategy string
+	debts    []*debt
+}
+
+func newDebts(loans []entities.Loan) []*debt {
+	debts := []*debt{}
+	for i := range loans {
+		loan := &loans[i]
+		balance := LoanOutstanding(loan)
+		if balance <= 0 || loan.MonthlyExpenses <= 0 {
+			continue
+		}
+
+		debts = append(debts, &debt{
+			loan:    loan,
+			balance: balance,
+			minimum: loan.MonthlyExpenses,
+			number:  loan.TermMonths - loan.RemainingMonths + 1,
+			payoff:  entities.LoanPayoff{LoanID: loan.ID, Name: loan.Name},
+		})
+	}
+
+	return debts
+}
+
+func (d *debt) interest() entities.Money {
+	if !IsAmortizing(d.loan) {
+		return 0
+	}
+
+	if d.loan.RateType == entities.LoanRateFlat {
+		return flatInterest(d.loan, min(max(d.number, 1), d.loan.TermMonths))
+	}
+
+	return d.balance.MulRate(loanMonthlyRate(d.loan.InterestRate))
+}
+
+func (d *debt) cost() float64 {
+	return d.interest().Float64() / d.balance.Float64()
+}
+
+func avalancheOrder(debts []*debt) []*debt {
+	ordered := append([]*debt{}, debts...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].cost() != ordered[j].cost() {
+			return ordered[i].cost() > ordered[j].cost()
+		}
+
+		return ordered[i].balance < ordered[j].balance
+	})
+
+	return ordered
+}
+
+func snowballOrder(debts []*debt) []*debt {
+	ordered := append([]*debt{}, debts...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].balance != ordered[j].balance {
+			return ordered[i].balance < ordered[j].balance
+		}
+
+		return ordered[i].cost() > ordered[j].cost()
+	})
+
+	return ordered
+}
+
+func customOrder(debts []*debt, ids []string) ([]*debt, error) {
+	byID := make(map[string]*debt)
+	for _, d := range debts {
+		byID[d.loan.ID] = d
+	}
+
+	ordered := []*debt{}
+	listed := make(map[string]bool)
+	for _, id := range ids {
+		d, ok := byID[id]
+		if !ok {
+			return nil, errors.New("loan not found")
+		}
+
+		if listed[id] {
+			return nil, errors.New("loan is listed more than once")
+		}
+
+		listed[id] = true
+		ordered = append(ordered, d)
+	}
+
+	for _, d := range debts {
+		if !listed[d.loan.ID] {
+			ordered = append(ordered, d)
+		}
+	}
+
+	return ordered, nil
+}
+
+func payOff(strategy string, ordered []*debt, budget entities.Money, rollover bool, now time.Time) (*entities.PayoffStrategy, error) {
+	debts := make([]*debt, len(ordered))
+	result := &entities.PayoffStrategy{
+		Strategy: strategy,
+		Order:    []string{},
+		Loans:    []entities.LoanPayoff{},
+		Schedule: []entities.PayoffMonth{},
+	}
+
+	var owed entities.Money
+	for i, d := range ordered {
+		copied := *d
+		debts[i] = &copied
+		owed += copied.balance
+		result.Order = append(result.Order, copied.loan.ID)
+	}
+
+	for month := 1; owed > 0; month++ {
+		if month > maxPayoffMonths {
+			return nil, errors.New("loans cannot be paid off with this budget")
+		}
+
+		schedule := entities.PayoffMonth{
+			Month:    month,
+			Date:     loanDueDate(now, month),
+			Payments: []entities.PayoffPayment{},
+		}
+
+		available := budget
+		payments := make([]*entities.PayoffPayment, len(debts))
+		for i, d := range debts {
+			if d.balance <= 0 {
+				continue
+			}
+
+			interest := d.interest()
+			d.balance += interest
+			d.payoff.Interest += interest
+			d.number++
+			payment := min(d.minimum, d.balance)
+			d.balance -= payment
+			available -= payment
+			payments[i] = &entities.PayoffPayment{LoanID: d.loan.ID, Payment: payment, Interest: interest}
+		}
+
+		for i, d := range debts {
+			if !rollover || available <= 0 {
+				break
+			}
+
+			if d.balance <= 0 {
+				continue
+			}
+
+			extra := min(available, d.balance)
+			d.balance -= extra
+			available -= extra
+			payments[i].Payment += extra
+		}
+
+		owed = 0
+		for i, d := range debts {
+			if payments[i] == nil {
+				continue
+			}
+
+			payments[i].Principal = payments[i].Payment - payments[i].Interest
+			payments[i].Balance = d.balance
+			schedule.Payment += payments[i].Payment
+			schedule.Payments = append(schedule.Payments, *payments[i])
+			result.TotalPayment += payments[i].Payment
+			result.TotalInterest += payments[i].Interest
+			if d.balance <= 0 {
+				d.payoff.Months = month
+				d.payoff.PayoffDate = schedule.Date
+			}
+
+			owed += d.balance
+		}
+
+		schedule.Balance = owed
+		result.Schedule = append(result.Schedule, schedule)
+		result.Months = month
+		result.PayoffDate = schedule.Date
+	}
+
+	for _, d := range debts {
+		result.Loans = append(result.Loans, d.payoff)
+	}
+
+	return result, nil
+}
+
+func PlanDebtPayoff(loans []entities.Loan, options entities.PayoffOptions, now time.Time) (*entities.PayoffPlan, error) {
+	if options.ExtraBudget < 0 {
+		return nil, errors.New("extra budget must not be negative")
+	}
+
+	debts := newDebts(loans)
+	if len(debts) == 0 {
+		return nil, errors.New("no active loans")
+	}
+
+	plan := &entities.PayoffPlan{
+		ExtraBudget: options.ExtraBudget,
+		Strategies:  []entities.PayoffStrategy{},
+	}
+
+	for _, d := range debts {
+		plan.MonthlyBudget += d.minimum
+		plan.Balance += d.balance
+	}
+
+	plan.MonthlyBudget += options.ExtraBudget
+	minimum, err := payOff("", debts, 0, false, now)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.MinimumMonths = minimum.Months
+	plan.MinimumInterest = minimum.TotalInterest
+
+	orders := []payoffOrder{
+		{entities.PayoffStrategyAvalanche, avalancheOrder(debts)},
+		{entities.PayoffStrategySnowball, snowballOrder(debts)},
+	}
+
+	if len(options.Order) > 0 {
+		ordered, err := customOrder(debts, options.Order)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, payoffOrder{entities.PayoffStrategyCustom, ordered})
+	}
+
+	for _, order := range orders {
+		strategy, err := payOff(order.strategy, order.debts, plan.MonthlyBudget, true, now)
+		if err != nil {
+			return nil, err
+		}
+
+		strategy.InterestSaved = plan.MinimumInterest - strategy.TotalInterest
+		plan.Strategies = append(plan.Strategies, *strategy)
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+)
+
+const DefaultWithdrawalRate = 4.0
+
+func blendedReturn(plan *entities.RetirementPlan) float64 {
+	total := plan.CurrentSavings + plan.CurrentTotalInvestment
+	if total <= 0 {
+		return plan.AnnualSavingsReturn
+	}
+
+	weight := plan.CurrentTotalInvestment.Float64() / total.Float64()
+	return math.Round((plan.AnnualSavingsReturn*(1-weight)+plan.AnnualInvestmentReturn*weight)*100) / 100
+}
+
+func sustainableWithdrawal(balance entities.Money, rate float64, months int, growth float64) entities.Money {
+	var factor float64
+	step, discount := 1.0, 1.0
+	for m := 0; m < months; m++ {
+		if m > 0 && m%12 == 0 {
+			step *= 1 + growth
+		}
+
+		discount /= 1 + rate
+		factor += step * discount
+	}
+
+	if factor <= 0 || balance <= 0 {
+		return 0
+	}
+
+	return entities.MoneyFromFloat(math.Floor(balance.Float64() / factor))
+}
+
+func drawDown(strategy *entities.DecumulationStrategy, timeline *planTimeline, start time.Time, balance entities.Money, rate float64, needs []entities.Money, withdrawal func(month int, yearStart entities.Money) entities.Money) {
+	var year entities.DecumulationYear
+	var yearStart entities.Money
+	strategy.Years = make([]entities.DecumulationYear, 0, (len(needs)+11)/12)
+	for m, need := range needs {
+		month := start.AddDate(0, m, 0)
+		if m%12 == 0 {
+			yearStart = balance
+			year = entities.DecumulationYear{Year: month.Year(), Age: timeline.age(month), StartBalance: balance}
+		}
+
+		growth := balance.MulRate(1+rate) - balance
+		balance += growth
+		want := withdrawal(m, yearStart)
+		if m == 0 {
+			strategy.FirstMonthWithdrawal = want
+		}
+
+		take := min(want, balance)
+		balance -= take
+		if take < want && strategy.DepletionYear == nil {
+			depletionYear, depletionAge := month.Year(), timeline.age(month)
+			strategy.DepletionYear, strategy.DepletionAge = &depletionYear, &depletionAge
+		}
+
+		year.Growth += growth
+		year.Withdrawal += take
+		year.Needs += need
+		if take < need {
+			year.Shortfall += need - take
+		}
+
+		if m%12 == 11 || m == len(needs)-1 {
+			year.EndBalance = balance
+			strategy.Years = append(strategy.Years, year)
+			strategy.TotalWithdrawn += year.Withdrawal
+			strategy.TotalShortfall += year.Shortfall
+		}
+	}
+
+	strategy.EndingBalance = balance
+	strategy.CoversNeeds = strategy.TotalShortfall == 0
+}
+
+func PlanDecumulation(plan *entities.RetirementPlan, house *entities.SelectedHouse, options entities.DecumulationOptions, now time.Time) (*entities.DecumulationPlan, error) {
+	if options.WithdrawalRate == 0 {
+		options.WithdrawalRate = DefaultWithdrawalRate
+	}
+
+	if options.WithdrawalRate < 0 || options.WithdrawalRate > 100 {
+		return nil, errors.New("withdrawal rate must be between 0 and 100")
+	}
+
+	annualReturn := blendedReturn(plan)
+	if options.AnnualReturn != nil {
+		annualReturn = *options.AnnualReturn
+	}
+
+	if annualReturn <= -100 {
+		return nil, errors.New("annual return must be greater than -100")
+	}
+
+	timeline, err := newPlanTimeline(plan, now)
+	if err != nil {
+		return nil, err
+	}
+
+	start := timeline.retirementMonth
+	if timeline.retirementIndex < 0 {
+		start = timeline.start
+	}
+
+	months := monthsBetween(start, timeline.endMonth)
+	housePrice := house.NursingHouse.Price
+	result := &entities.DecumulationPlan{
+		StartMonth:          start,
+		EndMonth:            timeline.endMonth,
+		Months:              months,
+		AnnualReturn:        annualReturn,
+		WithdrawalRate:      options.WithdrawalRate,
+		ExpectedInflation:   plan.ExpectedInflation,
+		NursingHouseMonthly: housePrice,
+		Strategies:          make([]entities.DecumulationStrategy, 0, 3),
+	}
+
+	if timeline.retirementIndex > 0 {
+		result.StartingBalance = plan.LastRequiredFunds + housePrice.Mul((plan.ExpectLifespan-plan.RetirementAge)*12)
+	} else {
+		result.StartingBalance = plan.CurrentSavings + plan.CurrentTotalInvestment + house.CurrentMoney
+	}
+
+	retiredMonths := monthsBetween(timeline.retirementMonth, start)
+	baseExpense := plan.ExpectedMonthlyExpenses.MulRate(math.Pow(1+plan.ExpectedInflation/100, float64(timeline.retiredFrom)/12))
+	needs := make([]entities.Money, months)
+	for m := range needs {
+		retiredYears := (retiredMonths + m) / 12
+		needs[m] = baseExpense.MulRate(math.Pow(1+plan.AnnualExpenseIncrease/100, float64(retiredYears))) + housePrice
+	}
+
+	if months > 0 {
+		result.MonthlyNeeds = needs[0]
+		result.LivingExpenses = needs[0] - housePrice
+	}
+
+	rate := monthlyRate(annualReturn)
+	inflation := plan.ExpectedInflation / 100
+	fixedAmount := entities.DecumulationStrategy{
+		Strategy:                     entities.WithdrawalStrategyFixedAmount,
+		SustainableMonthlyWithdrawal: sustainableWithdrawal(result.StartingBalance, rate, months, 0),
+	}
+	drawDown(&fixedAmount, timeline, start, result.StartingBalance, rate, needs, func(int, entities.Money) entities.Money {
+		return result.MonthlyNeeds
+	})
+
+	percentage := options.WithdrawalRate / 100 / 12
+	fixedPercentage := entities.DecumulationStrategy{
+		Strategy:                     entities.WithdrawalStrategyFixedPercentage,
+		SustainableMonthlyWithdrawal: result.StartingBalance.MulRate(percentage).Round(entities.OneBaht),
+	}
+	drawDown(&fixedPercentage, timeline, start, result.StartingBalance, rate, needs, func(_ int, yearStart entities.Money) entities.Money {
+		return yearStart.MulRate(percentage).Round(entities.OneBaht)
+	})
+
+	inflationAdjusted := entities.DecumulationStrategy{
+		Strategy:                     entities.WithdrawalStrategyInflationAdjusted,
+		SustainableMonthlyWithdrawal: sustainableWithdrawal(result.StartingBalance, rate, months, inflation),
+	}
+	drawDown(&inflationAdjusted, timeline, start, result.StartingBalance, rate, needs, func(m int, _ entities.Money) entities.Money {
+		return result.MonthlyNeeds.MulRate(math.Pow(1+inflation, float64(m/12)))
+	})
+
+	result.Strategies = append(result.Strategies, fixedAmount, fixedPercentage, inflationAdjusted)
+	return result, nil
+}
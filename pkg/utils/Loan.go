@@ -0,0 +1,245 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+)
+
+func loanMonthlyRate(annualPercent float64) float64 {
+	return annualPercent / 100 / 12
+}
+
+func loanDueDate(start time.Time, number int) time.Time {
+	first := time.Date(start.Year(), start.Month()+time.Month(number), 1, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(start.Day(), lastDay)-1)
+}
+
+func IsAmortizing(loan *entities.Loan) bool {
+	return loan.Principal > 0
+}
+
+func LoanOutstanding(loan *entities.Loan) entities.Money {
+	if IsAmortizing(loan) {
+		return loan.Balance
+	}
+
+	return loan.MonthlyExpenses.Mul(loan.RemainingMonths)
+}
+
+func ValidateLoanTerms(loan *entities.Loan) error {
+	if loan.Principal <= 0 {
+		return errors.New("principal must be greater than zero")
+	}
+
+	if loan.InterestRate < 0 || loan.InterestRate > 100 {
+		return errors.New("interest rate must be between 0 and 100")
+	}
+
+	if loan.TermMonths <= 0 {
+		return errors.New("term months must be greater than zero")
+	}
+
+	switch loan.RateType {
+	case entities.LoanRateFixed, entities.LoanRateFloating, entities.LoanRateFlat:
+		return nil
+	default:
+		return errors.New("invalid rate type")
+	}
+}
+
+func flatInterest(loan *entities.Loan, number int) entities.Money {
+	total := loan.Principal.MulRate(loan.InterestRate / 100 * float64(loan.TermMonths) / 12)
+	return total.Split(loan.TermMonths)[number-1]
+}
+
+func amortizedPayment(balance entities.Money, annualPercent float64, months int) entities.Money {
+	rate := loanMonthlyRate(annualPercent)
+	if rate == 0 {
+		return balance.Div(months)
+	}
+
+	return entities.MoneyFromFloat(balance.Float64() * rate / (1 - math.Pow(1+rate, -float64(months))))
+}
+
+func LoanSchedule(loan *entities.Loan) (*entities.LoanSchedule, error) {
+	if !IsAmortizing(loan) {
+		return nil, errors.New("loan has no principal")
+	}
+
+	schedule := &entities.LoanSchedule{
+		LoanID:       loan.ID,
+		Balance:      loan.Balance,
+		Installments: []entities.LoanInstallment{},
+	}
+
+	months := loan.RemainingMonths
+	if months <= 0 {
+		return schedule, nil
+	}
+
+	balance := loan.Balance
+	payment := amortizedPayment(balance, loan.InterestRate, months)
+	principals := balance.Split(months)
+	first := loan.TermMonths - months + 1
+	for i := 0; i < months; i++ {
+		installment := entities.LoanInstallment{
+			Number:  first + i,
+			DueDate: loanDueDate(loan.StartDate, first+i),
+		}
+
+		if loan.RateType == entities.LoanRateFlat {
+			installment.Interest = flatInterest(loan, installment.Number)
+			installment.Principal = principals[i]
+		} else {
+			installment.Interest = balance.MulRate(loanMonthlyRate(loan.InterestRate))
+			installment.Principal = min(max(payment-installment.Interest, 0), balance)
+			if i == months-1 {
+				installment.Principal = balance
+			}
+		}
+
+		installment.Payment = installment.Principal + installment.Interest
+		balance -= installment.Principal
+		installment.Balance = balance
+		schedule.TotalPayment += installment.Payment
+		schedule.TotalInterest += installment.Interest
+		schedule.Installments = append(schedule.Installments, installment)
+	}
+
+	schedule.PayoffDate = schedule.Installments[months-1].DueDate
+	return schedule, nil
+}
+
+func OpenLoan(loan *entities.Loan, now time.Time) error {
+	if err := ValidateLoanTerms(loan); err != nil {
+		return err
+	}
+
+	if loan.StartDate.IsZero() {
+		loan.StartDate = now
+	}
+
+	loan.Balance = loan.Principal
+	loan.RemainingMonths = loan.TermMonths
+	schedule, err := LoanSchedule(loan)
+	if err != nil {
+		return err
+	}
+
+	paid := 0
+	for _, installment := range schedule.Installments {
+		if installment.DueDate.After(now) {
+			break
+		}
+
+		loan.Balance = installment.Balance
+		paid++
+	}
+
+	if paid == loan.TermMonths {
+		return errors.New("loan term has already ended")
+	}
+
+	loan.RemainingMonths -= paid
+	loan.MonthlyExpenses = schedule.Installments[paid].Payment
+	return nil
+}
+
+func NextInstallment(loan *entities.Loan, outstanding int) entities.LoanInstallment {
+	next := entities.LoanInstallment{Payment: loan.MonthlyExpenses}
+	if !IsAmortizing(loan) {
+		return next
+	}
+
+	schedule, err := LoanSchedule(loan)
+	if err != nil || outstanding >= len(schedule.Installments) {
+		return next
+	}
+
+	return schedule.Installments[outstanding]
+}
+
+func ApplyPrepayment(loan *entities.Loan, prepayment entities.Prepayment) error {
+	if !IsAmortizing(loan) {
+		return errors.New("loan has no principal")
+	}
+
+	if loan.RemainingMonths <= 0 {
+		return errors.New("loan has no remaining installments")
+	}
+
+	if prepayment.Amount <= 0 {
+		return errors.New("prepayment must be greater than zero")
+	}
+
+	if prepayment.Amount > loan.Balance {
+		return errors.New("prepayment exceeds loan balance")
+	}
+
+	if prepayment.Mode != entities.PrepaymentShortenTerm && prepayment.Mode != entities.PrepaymentReduceInstallment {
+		return errors.New("invalid prepayment mode")
+	}
+
+	loan.Balance -= prepayment.Amount
+	if loan.Balance == 0 {
+		loan.RemainingMonths = 0
+		return nil
+	}
+
+	if prepayment.Mode == entities.PrepaymentShortenTerm {
+		for months := 1; months < loan.RemainingMonths; months++ {
+			shortened := *loan
+			shortened.TermMonths -= loan.RemainingMonths - months
+			shortened.RemainingMonths = months
+			schedule, err := LoanSchedule(&shortened)
+			if err != nil {
+				return err
+			}
+
+			if schedule.Installments[0].Payment <= loan.MonthlyExpenses {
+				loan.TermMonths = shortened.TermMonths
+				loan.RemainingMonths = months
+				break
+			}
+		}
+	}
+
+	schedule, err := LoanSchedule(loan)
+	if err != nil {
+		return err
+	}
+
+	loan.MonthlyExpenses = schedule.Installments[0].Payment
+	return nil
+}
+
+func ChangeLoanRate(loan *entities.Loan, annualPercent float64) error {
+	if loan.RateType != entities.LoanRateFloating {
+		return errors.New("loan rate is not floating")
+	}
+
+	if annualPercent < 0 || annualPercent > 100 {
+		return errors.New("interest rate must be between 0 and 100")
+	}
+
+	if !IsAmortizing(loan) {
+		return errors.New("loan has no principal")
+	}
+
+	if loan.RemainingMonths <= 0 {
+		return errors.New("loan has no remaining installments")
+	}
+
+	loan.InterestRate = annualPercent
+	schedule, err := LoanSchedule(loan)
+	if err != nil {
+		return err
+	}
+
+	loan.MonthlyExpenses = schedule.Installments[0].Payment
+	return nil
+}
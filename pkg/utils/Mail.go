@@ -14,32 +14,34 @@ import (
 )
 
 func SendMail(templatePath string, user *entities.User, otp string, config configs.Mail) error {
-	var body bytes.Buffer
-	t, err := template.ParseFiles(templatePath)
-	if err != nil {
-		return err
-	}
-
-	if templatePath == "" {
-		return errors.New("template is empty")
-	}
-
-	err = t.Execute(&body, struct {
+	return SendTemplateMail(user.Email, "Recovery Your Password", templatePath, struct {
 		Username string
 		OTP      string
 	}{
 		Username: user.Username,
 		OTP:      otp,
-	})
+	}, config)
+}
+
+func SendTemplateMail(to, subject, templatePath string, data interface{}, config configs.Mail) error {
+	if templatePath == "" {
+		return errors.New("template is empty")
+	}
 
+	var body bytes.Buffer
+	t, err := template.ParseFiles(templatePath)
 	if err != nil {
 		return err
 	}
 
+	if err := t.Execute(&body, data); err != nil {
+		return err
+	}
+
 	m := gomail.NewMessage()
 	m.SetHeader("From", config.Sender)
-	m.SetHeader("To", user.Email)
-	m.SetHeader("Subject", "Recovery Your Password")
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", subject)
 	m.SetBody("text/html", body.String())
 	port, err := strconv.Atoi(config.Port)
 	if err != nil {
@@ -66,3 +68,15 @@ func NormalizeEmail(email string) (string, error) {
 	email = localPart + "@" + domain
 	return email, nil
 }
+
+type SMTPMailer struct {
+	config configs.Mail
+}
+
+func NewSMTPMailer(config configs.Mail) *SMTPMailer {
+	return &SMTPMailer{config: config}
+}
+
+func (m *SMTPMailer) Send(to, subject, templatePath string, data interface{}) error {
+	return SendTemplateMail(to, subject, templatePath, data, m.config)
+}
@@ -0,0 +1,273 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"golang.org/x/exp/rand"
+)
+
+const (
+	DefaultRiskLevel        = 3
+	DefaultSimulationPaths  = 1000
+	MaxSimulationPaths      = 10000
+	DefaultTargetConfidence = 0.9
+	inflationStdDev         = 1.0
+	minAnnualReturn         = -95.0
+	maxMonthlySaving        = entities.Money(1_000_000_000) * entities.OneBaht
+)
+
+type ReturnAssumption struct {
+	Mean   float64
+	StdDev float64
+}
+
+var RiskReturnAssumptions = map[int]ReturnAssumption{
+	1: {Mean: 2.5, StdDev: 3},
+	2: {Mean: 4, StdDev: 6},
+	3: {Mean: 5.5, StdDev: 10},
+	4: {Mean: 7, StdDev: 14},
+	5: {Mean: 8.5, StdDev: 18},
+}
+
+type yearDraw struct {
+	investmentRate float64
+	inflationRate  float64
+	inflation      float64
+}
+
+type simulation struct {
+	plan      *entities.RetirementPlan
+	timeline  *planTimeline
+	draws     [][]yearDraw
+	bandSlots []int
+}
+
+func newSimulation(plan *entities.RetirementPlan, timeline *planTimeline, assumption ReturnAssumption, paths int, seed uint64) *simulation {
+	rng := rand.New(rand.NewSource(seed))
+	years := (timeline.totalMonths + 11) / 12
+	s := &simulation{
+		plan:      plan,
+		timeline:  timeline,
+		draws:     make([][]yearDraw, paths),
+		bandSlots: make([]int, timeline.totalMonths),
+	}
+
+	for path := range s.draws {
+		s.draws[path] = make([]yearDraw, years)
+		for year := range s.draws[path] {
+			annualReturn := math.Max(assumption.Mean+assumption.StdDev*rng.NormFloat64(), minAnnualReturn)
+			inflation := plan.ExpectedInflation + inflationStdDev*rng.NormFloat64()
+			s.draws[path][year] = yearDraw{
+				investmentRate: monthlyRate(annualReturn),
+				inflationRate:  monthlyRate(inflation),
+				inflation:      inflation,
+			}
+		}
+	}
+
+	slot := 0
+	for i := range s.bandSlots {
+		s.bandSlots[i] = -1
+		if i%12 == 0 || i == timeline.totalMonths-1 {
+			s.bandSlots[i] = slot
+			slot++
+		}
+	}
+
+	return s
+}
+
+func (s *simulation) run(path int, contribution entities.Money, balances []entities.Money) bool {
+	plan, timeline := s.plan, s.timeline
+	savingsRate, retiredSavingsRate := monthlyRate(plan.CurrentSavingsReturns), monthlyRate(plan.AnnualSavingsReturn)
+	savings, investment := plan.CurrentSavings, plan.CurrentTotalInvestment
+	priceLevel := 1.0
+	success := true
+	var expense entities.Money
+	for i := 0; i < timeline.totalMonths; i++ {
+		draw := s.draws[path][i/12]
+		if i < timeline.retirementIndex {
+			savings = savings.MulRate(1+savingsRate) + contribution
+			investment = investment.MulRate(1 + draw.investmentRate)
+			priceLevel *= 1 + draw.inflationRate
+		} else {
+			if i == timeline.retiredFrom {
+				retiredYears := (timeline.retiredFrom - timeline.retirementIndex) / 12
+				expense = plan.ExpectedMonthlyExpenses.MulRate(priceLevel * math.Pow(1+plan.AnnualExpenseIncrease/100, float64(retiredYears)))
+			} else if (i-timeline.retirementIndex)%12 == 0 {
+				expense = expense.MulRate(1 + (plan.AnnualExpenseIncrease+draw.inflation-plan.ExpectedInflation)/100)
+			}
+
+			savings = savings.MulRate(1 + retiredSavingsRate)
+			investment = investment.MulRate(1 + draw.investmentRate)
+			withdraw := expense
+			if withdraw > savings {
+				withdraw -= savings
+				savings = 0
+				if withdraw > investment {
+					investment = 0
+					success = false
+					if balances == nil {
+						return false
+					}
+				} else {
+					investment -= withdraw
+				}
+			} else {
+				savings -= withdraw
+			}
+		}
+
+		if slot := s.bandSlots[i]; slot >= 0 && balances != nil {
+			balances[slot] = savings + investment
+		}
+	}
+
+	return success
+}
+
+func (s *simulation) successRate(contribution entities.Money) float64 {
+	successes := 0
+	for path := range s.draws {
+		if s.run(path, contribution, nil) {
+			successes++
+		}
+	}
+
+	return float64(successes) / float64(len(s.draws))
+}
+
+func (s *simulation) requiredSaving(target float64) *entities.Money {
+	if s.timeline.retirementIndex <= 0 {
+		return nil
+	}
+
+	var low entities.Money
+	if s.successRate(low) >= target {
+		return &low
+	}
+
+	high := entities.Baht(1000)
+	for s.successRate(high) < target {
+		if high >= maxMonthlySaving {
+			return nil
+		}
+
+		low, high = high, high*2
+	}
+
+	for high-low > entities.OneBaht {
+		mid := ((low + high) / 2).Round(entities.OneBaht)
+		if s.successRate(mid) >= target {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+
+	return &high
+}
+
+func percentile(sorted []entities.Money, p float64) entities.Money {
+	index := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+
+	return sorted[index]
+}
+
+func SimulateRetirement(plan *entities.RetirementPlan, riskLevel int, options entities.MonteCarloOptions, now time.Time) (*entities.MonteCarloResult, error) {
+	timeline, err := newPlanTimeline(plan, now)
+	if err != nil {
+		return nil, err
+	}
+
+	assumption, ok := RiskReturnAssumptions[riskLevel]
+	if !ok {
+		riskLevel = DefaultRiskLevel
+		assumption = RiskReturnAssumptions[riskLevel]
+	}
+
+	paths := options.Paths
+	if paths == 0 {
+		paths = DefaultSimulationPaths
+	}
+
+	if paths < 0 || paths > MaxSimulationPaths {
+		return nil, errors.New("paths must be between 1 and 10000")
+	}
+
+	target := options.TargetConfidence
+	if target == 0 {
+		target = DefaultTargetConfidence
+	}
+
+	if target < 0 || target >= 1 {
+		return nil, errors.New("target confidence must be between 0 and 1")
+	}
+
+	seed := uint64(now.UnixNano())
+	if options.Seed != nil {
+		seed = *options.Seed
+	}
+
+	s := newSimulation(plan, timeline, assumption, paths, seed)
+	result := &entities.MonteCarloResult{
+		Paths:               paths,
+		Seed:                seed,
+		RiskLevel:           riskLevel,
+		ReturnMean:          assumption.Mean,
+		ReturnStdDev:        assumption.StdDev,
+		InflationMean:       plan.ExpectedInflation,
+		InflationStdDev:     inflationStdDev,
+		MonthlyContribution: plan.LastMonthlyExpenses,
+		TargetConfidence:    target,
+	}
+
+	slots := s.bandSlots[len(s.bandSlots)-1] + 1
+	bandValues := make([][]entities.Money, slots)
+	for slot := range bandValues {
+		bandValues[slot] = make([]entities.Money, paths)
+	}
+
+	successes := 0
+	balances := make([]entities.Money, slots)
+	for path := 0; path < paths; path++ {
+		if s.run(path, plan.LastMonthlyExpenses, balances) {
+			successes++
+		}
+
+		for slot, balance := range balances {
+			bandValues[slot][path] = balance
+		}
+	}
+
+	result.SuccessProbability = float64(successes) / float64(paths)
+	result.Bands = make([]entities.BalanceBand, 0, slots)
+	for i, slot := range s.bandSlots {
+		if slot < 0 {
+			continue
+		}
+
+		values := bandValues[slot]
+		sort.Slice(values, func(a, b int) bool { return values[a] < values[b] })
+		month := timeline.month(i)
+		result.Bands = append(result.Bands, entities.BalanceBand{
+			Month: month,
+			Age:   timeline.age(month),
+			P10:   percentile(values, 10),
+			P25:   percentile(values, 25),
+			P50:   percentile(values, 50),
+			P75:   percentile(values, 75),
+			P90:   percentile(values, 90),
+		})
+	}
+
+	result.RequiredMonthlySaving = s.requiredSaving(target)
+	return result, nil
+}
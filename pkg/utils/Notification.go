@@ -1,7 +1,7 @@
 package utils
 
 import (
-	"fmt"
+	"strings"
 	"time"
 
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
@@ -9,129 +9,189 @@ import (
 	"golang.org/x/exp/rand"
 )
 
-var assetMessages = []string{
-	"🎯 สำเร็จแล้ว! คุณสะสมเงินครบเป้าหมาย %s เก่งมาก!",
-	"🌟 ยอดเยี่ยม! สินทรัพย์ %s ถึงเป้าหมายแล้ว ก้าวต่อไปรออยู่!",
-	"💪 เยี่ยมจริงๆ! สินทรัพย์ %s สำเร็จแล้ว คุณทำได้ดีมาก!",
-	"✨ สุดปัง! สินทรัพย์ %s เข้าเป้าแล้วจ้า #ชีวิตรุ่ง",
-	"🔥 เลเวลอัพ! สินทรัพย์ %s คอมพลีทแล้ว เก่งมากนะ",
-	"✨ ความสำเร็จ! เป้าหมาย %s บรรลุแล้ว — ก้าวสำคัญของคุณ",
+const (
+	LocaleThai    = "th"
+	LocaleEnglish = "en"
+	DefaultLocale = LocaleThai
+)
+
+var notificationTemplates = map[string]map[string][]string{
+	"asset.success": {
+		LocaleThai: {
+			"🎯 สำเร็จแล้ว! คุณสะสมเงินครบเป้าหมาย {name} เก่งมาก!",
+			"🌟 ยอดเยี่ยม! สินทรัพย์ {name} ถึงเป้าหมายแล้ว ก้าวต่อไปรออยู่!",
+			"💪 เยี่ยมจริงๆ! สินทรัพย์ {name} สำเร็จแล้ว คุณทำได้ดีมาก!",
+			"✨ สุดปัง! สินทรัพย์ {name} เข้าเป้าแล้วจ้า #ชีวิตรุ่ง",
+			"🔥 เลเวลอัพ! สินทรัพย์ {name} คอมพลีทแล้ว เก่งมากนะ",
+			"✨ ความสำเร็จ! เป้าหมาย {name} บรรลุแล้ว — ก้าวสำคัญของคุณ",
+		},
+		LocaleEnglish: {
+			"🎯 Done! You have fully saved for {name}. Great job!",
+			"🌟 Excellent! Your asset {name} has reached its goal. On to the next one!",
+			"💪 Well done! Your asset {name} is complete. You did great!",
+			"✨ Awesome! {name} hit its target #goodlife",
+			"🔥 Level up! Your asset {name} is complete. Nice work",
+			"✨ Achievement unlocked! Goal {name} reached — a big step for you",
+		},
+	},
+	"house.success": {
+		LocaleThai: {
+			"🏠 ชัยชนะ! แผนบ้านพักของคุณ บรรลุเป้าหมายแล้ว ภูมิใจในตัวคุณ!",
+			"🏆 วิน! แผนบ้านพักของคุณ สำเร็จแล้ว #เงินทองต้องวางแผน",
+			"🏡 เป้าหมายสำเร็จ! แผนบ้านพักของคุณ เสร็จสมบูรณ์ — อนาคตที่มั่นคงรออยู่",
+		},
+		LocaleEnglish: {
+			"🏠 Victory! Your nursing house plan has reached its goal. We're proud of you!",
+			"🏆 Win! Your nursing house plan is complete #plannedmoney",
+			"🏡 Goal reached! Your nursing house plan is complete — a secure future awaits",
+		},
+	},
+	"retirementplan.success": {
+		LocaleThai: {
+			"🏠 ชัยชนะ! แผนเกษียณ {name} บรรลุเป้าหมายแล้ว ภูมิใจในตัวคุณ!",
+			"🏆 วิน! แผนเกษียณ {name} สำเร็จแล้ว #เงินทองต้องวางแผน",
+			"🏡 เป้าหมายสำเร็จ! แผนเกษียณ {name} เสร็จสมบูรณ์ — อนาคตที่มั่นคงรออยู่",
+		},
+		LocaleEnglish: {
+			"🏠 Victory! Retirement plan {name} has reached its goal. We're proud of you!",
+			"🏆 Win! Retirement plan {name} is complete #plannedmoney",
+			"🏡 Goal reached! Retirement plan {name} is complete — a secure future awaits",
+		},
+	},
+	"loan.success": {
+		LocaleThai: {
+			"🎉 หมดหนี้! คุณชำระ{name}ครบถ้วนแล้ว อิสรภาพทางการเงินใกล้เข้ามา!",
+			"💸 ฟรีแล้ว! ปลดหนี้ {name} เรียบร้อย อิสระทางการเงินมาแล้วจ้า",
+			"🔓 ปลดล็อคสำเร็จ! หนี้ {name} ชำระครบถ้วน — ก้าวสู่อิสรภาพทางการเงิน",
+		},
+		LocaleEnglish: {
+			"🎉 Debt free! You have fully paid off {name}. Financial freedom is near!",
+			"💸 Free at last! {name} is paid off. Financial freedom is here",
+			"🔓 Unlocked! {name} is fully repaid — a step toward financial freedom",
+		},
+	},
+	"loan.alert": {
+		LocaleThai: {
+			"🔔 ยังมีรายการหนี้ชำระของเดือนก่อนที่ยังไม่เสร็จสมบูรณ์",
+			"🌟 เพื่อประโยชน์ของคุณ: ขอแจ้งว่ายังมีรายการชำระเดือนที่แล้วรออยู่",
+			"🌼 ขอเรียนเตือนด้วยความห่วงใย: การชำระเดือนที่ผ่านมายังรอการดำเนินการ",
+			"📋 แจ้งเตือนสถานะ: การชำระหนี้ของเดือนที่แล้วยังรอการดำเนินการ",
+			"🍀 เพื่อสุขภาพทางการเงินที่ดี: รายการชำระหนี้ประจำเดือนที่ผ่านมายังรออยู่",
+			"⭐ เพื่อการเงินที่ราบรื่น: การชำระหนี้เดือนที่ผ่านมายังไม่เสร็จสมบูรณ์",
+		},
+		LocaleEnglish: {
+			"🔔 A loan payment from last month is still incomplete",
+			"🌟 For your benefit: a payment from last month is still waiting",
+			"🌼 A friendly reminder: last month's payment is still pending",
+			"📋 Status alert: last month's loan payment is still pending",
+			"🍀 For healthy finances: last month's loan payment is still waiting",
+			"⭐ For smooth finances: last month's loan payment is not yet complete",
+		},
+	},
+	"asset.alert": {
+		LocaleThai: {
+			"✨ เพื่อการวางแผนที่ดี: มีสินทรัพย์ของคุณอยู่ในโหมดรอดำเนินการ",
+			"🔔 ข้อมูลสำคัญ: มีสินทรัพย์ต้องการการอัปเดต (ถึงวันที่ครบกำหนดแล้ว)",
+			"📝 แจ้งให้ทราบ: สินทรัพย์ของคุณถูกพักการทำงานชั่วคราวเนื่องจากถึงวันที่กำหนด",
+		},
+		LocaleEnglish: {
+			"✨ For better planning: one of your assets is waiting for action",
+			"🔔 Important: an asset needs an update (its due date has passed)",
+			"📝 Notice: your asset has been paused because it reached its end date",
+		},
+	},
+	"portfolio.alert": {
+		LocaleThai: {
+			"⚖️ สัดส่วนพอร์ตการลงทุนของคุณ ({name}) เบี่ยงจากเป้าหมายตามระดับความเสี่ยงแล้ว ลองปรับสมดุลพอร์ตดูนะ",
+			"📊 แจ้งเตือนพอร์ต: สัดส่วน {name} ห่างจากเป้าหมายเกินเกณฑ์ที่กำหนด",
+		},
+		LocaleEnglish: {
+			"⚖️ Your portfolio allocation ({name}) has drifted from the target for your risk level. Consider rebalancing",
+			"📊 Portfolio alert: {name} is outside its target allocation",
+		},
+	},
 }
 
-var houseMessages = []string{
-	"🏠 ชัยชนะ! แผนบ้านพักของคุณ บรรลุเป้าหมายแล้ว ภูมิใจในตัวคุณ!",
-	"🏆 วิน! แผนบ้านพักของคุณ สำเร็จแล้ว #เงินทองต้องวางแผน",
-	"🏡 เป้าหมายสำเร็จ! แผนบ้านพักของคุณ เสร็จสมบูรณ์ — อนาคตที่มั่นคงรออยู่",
+var notificationRand = newNotificationRand(uint64(time.Now().UnixNano()))
+
+func newNotificationRand(seed uint64) *rand.Rand {
+	source := &rand.LockedSource{}
+	source.Seed(seed)
+	return rand.New(source)
 }
 
-var retirementPlanMessages = []string{
-	"🏠 ชัยชนะ! แผนเกษียณ %s บรรลุเป้าหมายแล้ว ภูมิใจในตัวคุณ!",
-	"🏆 วิน! แผนเกษียณ %s สำเร็จแล้ว #เงินทองต้องวางแผน",
-	"🏡 เป้าหมายสำเร็จ! แผนเกษียณ %s เสร็จสมบูรณ์ — อนาคตที่มั่นคงรออยู่",
+func SeedNotificationMessages(seed uint64) {
+	notificationRand = newNotificationRand(seed)
 }
 
-var loanMessages = []string{
-	"🎉 หมดหนี้! คุณชำระ%sครบถ้วนแล้ว อิสรภาพทางการเงินใกล้เข้ามา!",
-	"💸 ฟรีแล้ว! ปลดหนี้ %s เรียบร้อย อิสระทางการเงินมาแล้วจ้า",
-	"🔓 ปลดล็อคสำเร็จ! หนี้ %s ชำระครบถ้วน — ก้าวสู่อิสรภาพทางการเงิน",
+func IsSupportedLocale(locale string) bool {
+	return locale == LocaleThai || locale == LocaleEnglish
 }
 
-var alertLoanMessages = []string{
-	"🔔 ยังมีรายการหนี้ชำระของเดือนก่อนที่ยังไม่เสร็จสมบูรณ์",
-	"🌟 เพื่อประโยชน์ของคุณ: ขอแจ้งว่ายังมีรายการชำระเดือนที่แล้วรออยู่",
-	"🌼 ขอเรียนเตือนด้วยความห่วงใย: การชำระเดือนที่ผ่านมายังรอการดำเนินการ",
-	"📋 แจ้งเตือนสถานะ: การชำระหนี้ของเดือนที่แล้วยังรอการดำเนินการ",
-	"🍀 เพื่อสุขภาพทางการเงินที่ดี: รายการชำระหนี้ประจำเดือนที่ผ่านมายังรออยู่",
-	"⭐ เพื่อการเงินที่ราบรื่น: การชำระหนี้เดือนที่ผ่านมายังไม่เสร็จสมบูรณ์",
+func RenderNotification(key string, variant int, params map[string]string, locale string) string {
+	templates, ok := notificationTemplates[key]
+	if !ok {
+		return ""
+	}
+
+	messages, ok := templates[locale]
+	if !ok || len(messages) == 0 {
+		messages = templates[DefaultLocale]
+	}
+
+	if variant < 0 {
+		variant = -variant
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", value)
+	}
+
+	return strings.NewReplacer(replacements...).Replace(messages[variant%len(messages)])
 }
 
-var alertAssetMessages = []string{
-	"✨ เพื่อการวางแผนที่ดี: มีสินทรัพย์ของคุณอยู่ในโหมดรอดำเนินการ",
-	"🔔 ข้อมูลสำคัญ: มีสินทรัพย์ต้องการการอัปเดต (ถึงวันที่ครบกำหนดแล้ว)",
-	"📝 แจ้งให้ทราบ: สินทรัพย์ของคุณถูกพักการทำงานชั่วคราวเนื่องจากถึงวันที่กำหนด",
+func LocalizeNotification(notification *entities.Notification, locale string) {
+	if notification == nil || notification.TemplateKey == "" {
+		return
+	}
+
+	if message := RenderNotification(notification.TemplateKey, notification.TemplateVariant, notification.TemplateParams, locale); message != "" {
+		notification.Message = message
+	}
 }
 
-func SuccessNotification(itemType, userID, itemName, objectID string, balance float64) *entities.Notification {
-	switch itemType {
-	case "asset":
-		notification := &entities.Notification{
-			ID:        uuid.New().String(),
-			UserID:    userID,
-			Message:   fmt.Sprintf(assetMessages[rand.Intn(len(assetMessages))], itemName),
-			Type:      itemType,
-			ObjectID:  objectID,
-			Balance:   balance,
-			CreatedAt: time.Now(),
-		}
-
-		return notification
-	case "house":
-		notification := &entities.Notification{
-			ID:        uuid.New().String(),
-			UserID:    userID,
-			Message:   houseMessages[rand.Intn(len(houseMessages))],
-			Type:      itemType,
-			ObjectID:  objectID,
-			Balance:   balance,
-			CreatedAt: time.Now(),
-		}
-
-		return notification
-	case "retirementplan":
-		notification := &entities.Notification{
-			ID:        uuid.New().String(),
-			UserID:    userID,
-			Message:   fmt.Sprintf(retirementPlanMessages[rand.Intn(len(retirementPlanMessages))], itemName),
-			Type:      itemType,
-			ObjectID:  objectID,
-			Balance:   balance,
-			CreatedAt: time.Now(),
-		}
-
-		return notification
-	case "loan":
-		notification := &entities.Notification{
-			ID:        uuid.New().String(),
-			UserID:    userID,
-			Message:   fmt.Sprintf(loanMessages[rand.Intn(len(loanMessages))], itemName),
-			Type:      itemType,
-			ObjectID:  objectID,
-			Balance:   balance,
-			CreatedAt: time.Now(),
-		}
-
-		return notification
-	default:
+func newTemplateNotification(key, itemType, userID, itemName, objectID string, balance entities.Money) *entities.Notification {
+	templates, ok := notificationTemplates[key]
+	if !ok {
 		return nil
 	}
+
+	params := entities.NotificationParams{"name": itemName}
+	variant := notificationRand.Intn(len(templates[DefaultLocale]))
+	return &entities.Notification{
+		ID:              uuid.New().String(),
+		UserID:          userID,
+		Message:         RenderNotification(key, variant, params, DefaultLocale),
+		Type:            itemType,
+		TemplateKey:     key,
+		TemplateVariant: variant,
+		TemplateParams:  params,
+		ObjectID:        objectID,
+		Balance:         balance,
+		CreatedAt:       time.Now(),
+	}
 }
 
-func AlertNoti(itemType, userID, itemName, objectID string, balance float64) *entities.Notification {
-	switch itemType {
-	case "asset":
-		notification := &entities.Notification{
-			ID:        uuid.New().String(),
-			UserID:    userID,
-			Message:   alertAssetMessages[rand.Intn(len(alertAssetMessages))],
-			Type:      itemType,
-			ObjectID:  objectID,
-			CreatedAt: time.Now(),
-		}
-
-		return notification
-	case "loan":
-		notification := &entities.Notification{
-			ID:        uuid.New().String(),
-			UserID:    userID,
-			Message:   alertLoanMessages[rand.Intn(len(alertLoanMessages))],
-			Type:      itemType,
-			ObjectID:  objectID,
-			Balance:   balance,
-			CreatedAt: time.Now(),
-		}
-
-		return notification
-	default:
-		return nil
+func SuccessNotification(itemType, userID, itemName, objectID string, balance entities.Money) *entities.Notification {
+	return newTemplateNotification(itemType+".success", itemType, userID, itemName, objectID, balance)
+}
+
+func AlertNoti(itemType, userID, itemName, objectID string, balance entities.Money) *entities.Notification {
+	notification := newTemplateNotification(itemType+".alert", itemType, userID, itemName, objectID, balance)
+	if notification != nil && itemType == "asset" {
+		notification.Balance = 0
 	}
+
+	return notification
 }
@@ -0,0 +1,242 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+)
+
+const PortfolioDriftThreshold = 5.0
+
+var assetClasses = []string{
+	entities.AssetClassEquity,
+	entities.AssetClassFixedIncome,
+	entities.AssetClassCash,
+}
+
+var holdingKindClasses = map[string]string{
+	entities.HoldingKindFund:    "",
+	entities.HoldingKindStock:   entities.AssetClassEquity,
+	entities.HoldingKindBond:    entities.AssetClassFixedIncome,
+	entities.HoldingKindDeposit: entities.AssetClassCash,
+}
+
+var targetAllocations = map[int][]entities.AllocationTarget{
+	1: {{AssetClass: entities.AssetClassEquity, Percent: 10}, {AssetClass: entities.AssetClassFixedIncome, Percent: 60}, {AssetClass: entities.AssetClassCash, Percent: 30}},
+	2: {{AssetClass: entities.AssetClassEquity, Percent: 30}, {AssetClass: entities.AssetClassFixedIncome, Percent: 55}, {AssetClass: entities.AssetClassCash, Percent: 15}},
+	3: {{AssetClass: entities.AssetClassEquity, Percent: 50}, {AssetClass: entities.AssetClassFixedIncome, Percent: 40}, {AssetClass: entities.AssetClassCash, Percent: 10}},
+	4: {{AssetClass: entities.AssetClassEquity, Percent: 70}, {AssetClass: entities.AssetClassFixedIncome, Percent: 25}, {AssetClass: entities.AssetClassCash, Percent: 5}},
+	5: {{AssetClass: entities.AssetClassEquity, Percent: 85}, {AssetClass: entities.AssetClassFixedIncome, Percent: 10}, {AssetClass: entities.AssetClassCash, Percent: 5}},
+}
+
+func TargetAllocation(riskID int) ([]entities.AllocationTarget, error) {
+	targets, ok := targetAllocations[riskID]
+	if !ok {
+		return nil, errors.New("invalid risk level")
+	}
+
+	return append([]entities.AllocationTarget(nil), targets...), nil
+}
+
+func HoldingAssetClass(kind, assetClass string) (string, error) {
+	class, ok := holdingKindClasses[kind]
+	if !ok {
+		return "", errors.New("invalid holding kind")
+	}
+
+	if assetClass == "" {
+		if class == "" {
+			return "", errors.New("asset class is required for funds")
+		}
+
+		return class, nil
+	}
+
+	for _, valid := range assetClasses {
+		if assetClass == valid {
+			return assetClass, nil
+		}
+	}
+
+	return "", errors.New("invalid asset class")
+}
+
+func HoldingMarketValue(holding *entities.Holding) entities.Money {
+	return entities.MoneyFromFloat(holding.Quantity * holding.Price)
+}
+
+func percentOf(part, total entities.Money) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return math.Round(part.Float64()/total.Float64()*10000) / 100
+}
+
+func SummarizePortfolio(holdings []entities.Holding, risk *entities.Risk, planInvestment entities.Money) *entities.PortfolioSummary {
+	summary := &entities.PortfolioSummary{
+		Risk:           risk,
+		Holdings:       []entities.HoldingValue{},
+		PlanInvestment: planInvestment,
+		Allocation:     []entities.AllocationSlice{},
+		DriftThreshold: PortfolioDriftThreshold,
+	}
+
+	values := make(map[string]entities.Money)
+	for i := range holdings {
+		marketValue := HoldingMarketValue(&holdings[i])
+		summary.Holdings = append(summary.Holdings, entities.HoldingValue{
+			Holding:     holdings[i],
+			MarketValue: marketValue,
+			Gain:        marketValue - holdings[i].CostBasis,
+			GainPercent: percentOf(marketValue-holdings[i].CostBasis, holdings[i].CostBasis),
+		})
+
+		summary.MarketValue += marketValue
+		summary.CostBasis += holdings[i].CostBasis
+		values[holdings[i].AssetClass] += marketValue
+	}
+
+	summary.Gain = summary.MarketValue - summary.CostBasis
+	summary.GainPercent = percentOf(summary.Gain, summary.CostBasis)
+
+	targets := make(map[string]float64)
+	if risk != nil {
+		if allocation, err := TargetAllocation(risk.ID); err == nil {
+			for _, target := range allocation {
+				targets[target.AssetClass] = target.Percent
+			}
+		}
+	}
+
+	for _, class := range assetClasses {
+		slice := entities.AllocationSlice{
+			AssetClass:  class,
+			MarketValue: values[class],
+			Percent:     percentOf(values[class], summary.MarketValue),
+			Target:      targets[class],
+		}
+
+		if len(targets) > 0 && summary.MarketValue > 0 {
+			slice.Drift = math.Round((slice.Percent-slice.Target)*100) / 100
+			slice.Alert = math.Abs(slice.Drift) > PortfolioDriftThreshold
+			summary.Drifted = summary.Drifted || slice.Alert
+		}
+
+		summary.Allocation = append(summary.Allocation, slice)
+	}
+
+	return summary
+}
+
+func ValuationChangeByMonth(snapshots []entities.PortfolioSnapshot) map[string]entities.Money {
+	changes := make(map[string]entities.Money)
+	var previous entities.Money
+	for _, snapshot := range snapshots {
+		changes[snapshot.Date.Format("2006-01")] += snapshot.MarketValue - previous
+		previous = snapshot.MarketValue
+	}
+
+	return changes
+}
+
+func ValidatePriceQuote(quote *entities.PriceQuote) error {
+	quote.Symbol = NormalizeSymbol(quote.Symbol)
+	if quote.Symbol == "" {
+		return errors.New("price symbol is missing")
+	}
+
+	if quote.Price <= 0 {
+		return errors.New("price must be greater than zero")
+	}
+
+	return nil
+}
+
+func ParsePriceDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, errors.New("invalid date")
+	}
+
+	return date, nil
+}
+
+func NormalizeSymbol(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
+
+func ParsePriceCSV(r io.Reader) ([]entities.PriceQuote, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("price file is empty")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	symbolColumn, hasSymbol := columns["symbol"]
+	priceColumn, hasPrice := columns["price"]
+	dateColumn, hasDate := columns["date"]
+	if !hasSymbol || !hasPrice {
+		return nil, errors.New("price file must have symbol and price columns")
+	}
+
+	var quotes []entities.PriceQuote
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		price, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(record[priceColumn]), ",", ""), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid price", line)
+		}
+
+		quote := entities.PriceQuote{Symbol: record[symbolColumn], Price: price}
+		if hasDate {
+			quote.PricedAt, err = ParsePriceDate(record[dateColumn])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+
+		if err := ValidatePriceQuote(&quote); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		quotes = append(quotes, quote)
+	}
+
+	if len(quotes) == 0 {
+		return nil, errors.New("price file is empty")
+	}
+
+	return quotes, nil
+}
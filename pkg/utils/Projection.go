@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+)
+
+func monthlyRate(annualPercent float64) float64 {
+	return math.Pow(1+annualPercent/100, 1.0/12) - 1
+}
+
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+type planTimeline struct {
+	birthDate       time.Time
+	start           time.Time
+	retirementMonth time.Time
+	endMonth        time.Time
+	totalMonths     int
+	retirementIndex int
+	retiredFrom     int
+}
+
+func newPlanTimeline(plan *entities.RetirementPlan, now time.Time) (*planTimeline, error) {
+	birthDate, err := time.Parse("02-01-2006", plan.BirthDate)
+	if err != nil {
+		return nil, errors.New("invalid BirthDate format, expected DD-MM-YYYY")
+	}
+
+	if plan.ExpectLifespan <= plan.RetirementAge {
+		return nil, errors.New("expected lifespan must be greater than retirement age")
+	}
+
+	timeline := &planTimeline{
+		birthDate:       birthDate,
+		start:           time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()),
+		retirementMonth: time.Date(birthDate.Year()+plan.RetirementAge, birthDate.Month(), 1, 0, 0, 0, 0, now.Location()),
+		endMonth:        time.Date(birthDate.Year()+plan.ExpectLifespan, birthDate.Month(), 1, 0, 0, 0, 0, now.Location()),
+	}
+
+	timeline.totalMonths = monthsBetween(timeline.start, timeline.endMonth)
+	if timeline.totalMonths <= 0 {
+		return nil, errors.New("expected lifespan has already been reached")
+	}
+
+	timeline.retirementIndex = monthsBetween(timeline.start, timeline.retirementMonth)
+	if timeline.retirementIndex > 0 {
+		timeline.retiredFrom = timeline.retirementIndex
+	}
+
+	return timeline, nil
+}
+
+func (t *planTimeline) month(i int) time.Time {
+	return t.start.AddDate(0, i, 0)
+}
+
+func (t *planTimeline) age(month time.Time) int {
+	age := month.Year() - t.birthDate.Year()
+	if month.Month() < t.birthDate.Month() {
+		age--
+	}
+
+	return age
+}
+
+func ProjectRetirement(plan *entities.RetirementPlan, vehicles []entities.VehicleProjection, now time.Time) (*entities.RetirementProjection, error) {
+	timeline, err := newPlanTimeline(plan, now)
+	if err != nil {
+		return nil, err
+	}
+
+	totalMonths, retirementIndex, retiredFrom := timeline.totalMonths, timeline.retirementIndex, timeline.retiredFrom
+	baseExpense := plan.ExpectedMonthlyExpenses.MulRate(math.Pow(1+plan.ExpectedInflation/100, float64(retiredFrom)/12))
+	expenses := make([]entities.Money, totalMonths)
+	for i := range expenses {
+		if i < retirementIndex {
+			continue
+		}
+
+		retiredYears := (i - retirementIndex) / 12
+		expenses[i] = baseExpense.MulRate(math.Pow(1+plan.AnnualExpenseIncrease/100, float64(retiredYears)))
+	}
+
+	inflows := make([]entities.Money, totalMonths)
+	for _, vehicle := range vehicles {
+		from := max(monthsBetween(timeline.start, vehicle.PayoutMonth), 0)
+		if from >= totalMonths {
+			continue
+		}
+
+		if vehicle.MonthlyPension > 0 {
+			for i := from; i < totalMonths; i++ {
+				inflows[i] += vehicle.MonthlyPension
+			}
+		} else {
+			inflows[from] += vehicle.ProjectedBalance
+		}
+	}
+
+	remaining := make([]entities.Money, totalMonths)
+	for i := totalMonths - 2; i >= 0; i-- {
+		remaining[i] = max(remaining[i+1]+expenses[i+1]-inflows[i+1], 0)
+	}
+
+	projection := &entities.RetirementProjection{
+		StartMonth:           timeline.start,
+		RetirementMonth:      timeline.retirementMonth,
+		EndMonth:             timeline.endMonth,
+		MonthlyContribution:  plan.LastMonthlyExpenses,
+		BalanceAtRetirement:  plan.CurrentSavings + plan.CurrentTotalInvestment,
+		RequiredAtRetirement: max(expenses[retiredFrom]-inflows[retiredFrom]+remaining[retiredFrom], 0),
+		Vehicles:             vehicles,
+		Points:               make([]entities.ProjectionPoint, 0, totalMonths),
+	}
+
+	savingsRate, investmentRate := monthlyRate(plan.CurrentSavingsReturns), monthlyRate(plan.InvestmentReturn)
+	retiredSavingsRate, retiredInvestmentRate := monthlyRate(plan.AnnualSavingsReturn), monthlyRate(plan.AnnualInvestmentReturn)
+	savings, investment := plan.CurrentSavings, plan.CurrentTotalInvestment
+	for i := 0; i < totalMonths; i++ {
+		month := timeline.month(i)
+		point := entities.ProjectionPoint{
+			Month:         month,
+			Age:           timeline.age(month),
+			VehicleInflow: inflows[i],
+		}
+
+		savings += inflows[i]
+
+		if i < retirementIndex {
+			point.Phase = entities.ProjectionPhaseAccumulation
+			savings = savings.MulRate(1+savingsRate) + plan.LastMonthlyExpenses
+			investment = investment.MulRate(1 + investmentRate)
+			point.Contribution = plan.LastMonthlyExpenses
+		} else {
+			point.Phase = entities.ProjectionPhaseRetirement
+			savings = savings.MulRate(1 + retiredSavingsRate)
+			investment = investment.MulRate(1 + retiredInvestmentRate)
+			point.Expense = expenses[i]
+			withdraw := expenses[i]
+			if withdraw > savings {
+				withdraw -= savings
+				savings = 0
+				if withdraw > investment {
+					investment = 0
+					if projection.DepletionMonth == nil {
+						depleted := month
+						projection.DepletionMonth = &depleted
+					}
+				} else {
+					investment -= withdraw
+				}
+			} else {
+				savings -= withdraw
+			}
+		}
+
+		point.Savings = savings
+		point.Investment = investment
+		point.Balance = savings + investment
+		point.RequiredFunds = remaining[i]
+		if point.Balance < point.RequiredFunds {
+			point.Shortfall = point.RequiredFunds - point.Balance
+		}
+
+		if i == retirementIndex-1 {
+			projection.BalanceAtRetirement = point.Balance
+		}
+
+		projection.Points = append(projection.Points, point)
+	}
+
+	if projection.BalanceAtRetirement < projection.RequiredAtRetirement {
+		projection.ShortfallAtRetirement = projection.RequiredAtRetirement - projection.BalanceAtRetirement
+	}
+
+	return projection, nil
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"time"
+)
+
+func ParseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func InQuietHours(start, end string, now time.Time) bool {
+	if start == "" || end == "" {
+		return false
+	}
+
+	startMinute, err := ParseClock(start)
+	if err != nil {
+		return false
+	}
+
+	endMinute, err := ParseClock(end)
+	if err != nil {
+		return false
+	}
+
+	current := now.Hour()*60 + now.Minute()
+	if startMinute == endMinute {
+		return false
+	}
+
+	if startMinute < endMinute {
+		return current >= startMinute && current < endMinute
+	}
+
+	return current >= startMinute || current < endMinute
+}
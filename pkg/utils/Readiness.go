@@ -0,0 +1,208 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+)
+
+func scheduleGap(gap *entities.ReadinessGap, start, now time.Time) {
+	if gap.Target > gap.Current {
+		gap.Remaining = gap.Target - gap.Current
+	}
+
+	if gap.MonthsRemaining > 0 {
+		gap.MonthlyRequired = gap.Remaining.DivRound(gap.MonthsRemaining, entities.OneBaht)
+	}
+
+	gap.Expected = gap.Target
+	elapsed := monthsBetween(start, now)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	if gap.MonthsRemaining > 0 {
+		progress := float64(elapsed) / float64(elapsed+gap.MonthsRemaining)
+		gap.Expected = gap.Target.MulRate(progress).Round(entities.OneBaht)
+	}
+
+	if gap.Expected > gap.Current {
+		gap.BehindBy = gap.Expected - gap.Current
+		gap.BehindSchedule = true
+	}
+}
+
+func clampRatio(value float64) float64 {
+	return math.Round(math.Max(0, math.Min(1, value))*100) / 100
+}
+
+func CalculateReadiness(user *entities.User, loans []entities.Loan, now time.Time) (*entities.ReadinessReport, error) {
+	plan := user.RetirementPlan
+	birthDate, err := time.Parse("02-01-2006", plan.BirthDate)
+	if err != nil {
+		return nil, errors.New("invalid BirthDate format, expected DD-MM-YYYY")
+	}
+
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	retirementMonth := time.Date(birthDate.Year()+plan.RetirementAge, birthDate.Month(), 1, 0, 0, 0, 0, now.Location())
+	monthsToRetirement := monthsBetween(currentMonth, retirementMonth) + 1
+	if monthsToRetirement < 0 {
+		monthsToRetirement = 0
+	}
+
+	report := &entities.ReadinessReport{
+		MonthlyIncome:   plan.MonthlyIncome,
+		MonthlyExpenses: plan.MonthlyExpenses,
+		MonthlySurplus:  plan.MonthlyIncome - plan.MonthlyExpenses,
+		Gaps:            []entities.ReadinessGap{},
+		Allocations:     []entities.SurplusAllocation{},
+	}
+
+	retirement := entities.ReadinessGap{
+		Goal:            entities.ReadinessGoalRetirement,
+		ID:              plan.ID,
+		Name:            plan.PlanName,
+		Status:          plan.Status,
+		Deadline:        retirementMonth,
+		MonthsRemaining: monthsToRetirement,
+		Target:          plan.LastRequiredFunds,
+		Current:         plan.CurrentSavings + plan.CurrentTotalInvestment,
+	}
+	scheduleGap(&retirement, plan.CreatedAt, now)
+	report.Gaps = append(report.Gaps, retirement)
+
+	if price := user.House.NursingHouse.Price; price > 0 {
+		house := entities.ReadinessGap{
+			Goal:            entities.ReadinessGoalNursingHouse,
+			ID:              user.House.NursingHouseID,
+			Name:            user.House.NursingHouse.Name,
+			Status:          user.House.Status,
+			Deadline:        retirementMonth,
+			MonthsRemaining: monthsToRetirement,
+			Target:          price.Mul((plan.ExpectLifespan - plan.RetirementAge) * 12),
+			Current:         user.House.CurrentMoney,
+		}
+		scheduleGap(&house, user.House.CreatedAt, now)
+		report.Gaps = append(report.Gaps, house)
+	}
+
+	for _, asset := range user.Assets {
+		if asset.Status == "Completed" {
+			report.RequiredFunds += asset.TotalCost
+			report.CurrentMoney += asset.TotalCost
+			continue
+		}
+
+		endYear, err := strconv.Atoi(asset.EndYear)
+		if err != nil {
+			return nil, errors.New("invalid EndYear format, expected YYYY")
+		}
+
+		deadline := time.Date(endYear, time.January, 1, 0, 0, 0, 0, now.Location())
+		gap := entities.ReadinessGap{
+			Goal:            entities.ReadinessGoalAsset,
+			ID:              asset.ID,
+			Name:            asset.Name,
+			Status:          asset.Status,
+			Deadline:        deadline,
+			MonthsRemaining: max(monthsBetween(currentMonth, deadline), 0),
+			Target:          asset.TotalCost,
+			Current:         asset.CurrentMoney,
+		}
+		scheduleGap(&gap, asset.CreatedAt, now)
+		if asset.Status != "In_Progress" {
+			gap.MonthlyRequired = 0
+		}
+
+		report.Gaps = append(report.Gaps, gap)
+	}
+
+	for _, gap := range report.Gaps {
+		report.RequiredFunds += gap.Target
+		report.CurrentMoney += min(gap.Current, gap.Target)
+		report.MonthlyRequired += gap.MonthlyRequired
+	}
+
+	var loanAllocations []entities.SurplusAllocation
+	for _, loan := range loans {
+		report.OutstandingLoans += LoanOutstanding(&loan)
+		if loan.Status != "In_Progress" || loan.RemainingMonths <= 0 {
+			continue
+		}
+
+		report.LoanPayments += loan.MonthlyExpenses
+		loanAllocations = append(loanAllocations, entities.SurplusAllocation{
+			Goal:     entities.ReadinessGoalLoan,
+			ID:       loan.ID,
+			Name:     loan.Name,
+			Required: loan.MonthlyExpenses,
+		})
+	}
+
+	report.FundedRatio = 1
+	if report.RequiredFunds > 0 {
+		report.FundedRatio = clampRatio((report.CurrentMoney - report.OutstandingLoans).Float64() / report.RequiredFunds.Float64())
+	}
+
+	commitments := report.LoanPayments + report.MonthlyRequired
+	report.Affordability = 1
+	if commitments > 0 {
+		report.Affordability = clampRatio(report.MonthlySurplus.Float64() / commitments.Float64())
+	}
+
+	if commitments > report.MonthlySurplus {
+		report.MonthlyShortfall = commitments - report.MonthlySurplus
+	}
+
+	report.Score = int(math.Round(50*report.FundedRatio + 50*report.Affordability))
+
+	goals := make([]entities.ReadinessGap, len(report.Gaps))
+	copy(goals, report.Gaps)
+	sort.SliceStable(goals, func(i, j int) bool {
+		return goals[i].Deadline.Before(goals[j].Deadline)
+	})
+
+	allocations := loanAllocations
+	for _, gap := range goals {
+		allocations = append(allocations, entities.SurplusAllocation{
+			Goal:     gap.Goal,
+			ID:       gap.ID,
+			Name:     gap.Name,
+			Required: gap.MonthlyRequired,
+		})
+	}
+
+	left := max(report.MonthlySurplus, 0)
+	for i := range allocations {
+		amount := min(allocations[i].Required, left)
+		allocations[i].Amount = amount
+		left -= amount
+	}
+
+	for i, gap := range goals {
+		if left <= 0 {
+			break
+		}
+
+		if gap.Status != "In_Progress" || !gap.BehindSchedule {
+			continue
+		}
+
+		extra := min(gap.BehindBy, left)
+		allocations[len(loanAllocations)+i].Amount += extra
+		left -= extra
+	}
+
+	for _, allocation := range allocations {
+		if allocation.Required > 0 || allocation.Amount > 0 {
+			report.Allocations = append(report.Allocations, allocation)
+		}
+	}
+
+	report.UnallocatedSurplus = left
+	return report, nil
+}
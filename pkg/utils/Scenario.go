@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+)
+
+func CalculatePlanSummary(user *entities.User, now time.Time) (*entities.ScenarioSummary, error) {
+	plan := user.RetirementPlan
+	currentYear, currentMonth := now.Year(), int(now.Month())
+	age, err := CalculateRetirementPlanAge(plan.BirthDate, now)
+	if err != nil {
+		return nil, err
+	}
+
+	retirementFunds, err := CalculateRetirementFunds(&plan, age)
+	if err != nil {
+		return nil, err
+	}
+
+	planMonthly, err := CalculateMonthlySavings(&plan, age, currentYear, currentMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	houseMonthly, err := CalculateNursingHouseMonthlyExpense(user, user.House.NursingHouse.Price, currentYear, currentMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &entities.ScenarioSummary{
+		RetirementAge:       plan.RetirementAge,
+		ExpectLifespan:      plan.ExpectLifespan,
+		NursingHouseID:      user.House.NursingHouseID,
+		RetirementFunds:     retirementFunds,
+		NursingHouseCost:    user.House.NursingHouse.Price.Mul((plan.ExpectLifespan - plan.RetirementAge) * 12),
+		NursingHouseMonthly: houseMonthly,
+	}
+
+	if planMonthly > 0 {
+		summary.PlanMonthlySaving = planMonthly
+	}
+
+	for _, asset := range user.Assets {
+		summary.AssetsCost += asset.TotalCost
+		if asset.Status != "In_Progress" {
+			continue
+		}
+
+		if monthly := CalculateMonthlyExpenses(&asset, currentYear, currentMonth); monthly > 0 {
+			summary.AssetsMonthly += monthly
+		}
+	}
+
+	planMoney := plan.CurrentSavings + plan.CurrentTotalInvestment
+	if planMoney > retirementFunds {
+		planMoney = retirementFunds
+	}
+
+	houseMoney := user.House.CurrentMoney
+	if houseMoney > summary.NursingHouseCost {
+		houseMoney = summary.NursingHouseCost
+	}
+
+	summary.CurrentMoney = planMoney + houseMoney + CalculateAllAssetSavings(user, "Plan")
+	summary.RequiredFunds = summary.RetirementFunds + summary.NursingHouseCost + summary.AssetsCost
+	summary.MonthlySaving = summary.PlanMonthlySaving + summary.NursingHouseMonthly + summary.AssetsMonthly
+	if summary.RequiredFunds > summary.CurrentMoney {
+		summary.Shortfall = summary.RequiredFunds - summary.CurrentMoney
+	}
+
+	return summary, nil
+}
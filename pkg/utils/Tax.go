@@ -0,0 +1,204 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+)
+
+var taxSavingVehicles = []string{
+	entities.VehicleTypeThaiESG,
+	entities.VehicleTypeRMF,
+	entities.VehicleTypeProvidentFund,
+}
+
+func ValidateTaxTable(table *entities.TaxTable) error {
+	if table.TaxYear <= 0 {
+		return errors.New("invalid tax year")
+	}
+
+	if table.ExpenseRate < 0 || table.ExpenseRate > 100 {
+		return errors.New("expense rate must be between 0 and 100")
+	}
+
+	if table.ExpenseCap < 0 || table.PersonalAllowance < 0 {
+		return errors.New("tax table values must not be negative")
+	}
+
+	if len(table.Brackets) == 0 {
+		return errors.New("tax table must have at least one bracket")
+	}
+
+	sort.Slice(table.Brackets, func(i, j int) bool {
+		return table.Brackets[i].Threshold < table.Brackets[j].Threshold
+	})
+
+	if table.Brackets[0].Threshold != 0 {
+		return errors.New("first tax bracket must start at zero")
+	}
+
+	for i, bracket := range table.Brackets {
+		if bracket.Rate < 0 || bracket.Rate > 100 {
+			return errors.New("tax rate must be between 0 and 100")
+		}
+
+		if i > 0 && bracket.Threshold == table.Brackets[i-1].Threshold {
+			return errors.New("tax bracket thresholds must be unique")
+		}
+	}
+
+	return nil
+}
+
+func IncomeTax(table *entities.TaxTable, taxable entities.Money) entities.Money {
+	var tax entities.Money
+	for i, bracket := range table.Brackets {
+		if taxable <= bracket.Threshold {
+			break
+		}
+
+		upper := taxable
+		if i+1 < len(table.Brackets) {
+			upper = min(upper, table.Brackets[i+1].Threshold)
+		}
+
+		tax += (upper - bracket.Threshold).MulRate(bracket.Rate / 100)
+	}
+
+	return tax
+}
+
+func taxFreeIncome(table *entities.TaxTable) entities.Money {
+	for _, bracket := range table.Brackets {
+		if bracket.Rate > 0 {
+			return bracket.Threshold
+		}
+	}
+
+	return entities.Money(math.MaxInt64)
+}
+
+func marginalRate(table *entities.TaxTable, taxable entities.Money) float64 {
+	var rate float64
+	for _, bracket := range table.Brackets {
+		if bracket.Threshold > 0 && taxable <= bracket.Threshold {
+			break
+		}
+
+		rate = bracket.Rate
+	}
+
+	return rate
+}
+
+func EstimateTax(table *entities.TaxTable, year int, annualIncome, deductions, vehicleDeductions entities.Money) entities.TaxEstimate {
+	estimate := entities.TaxEstimate{
+		TaxYear:           year,
+		TableYear:         table.TaxYear,
+		TableVersion:      table.Version,
+		AnnualIncome:      annualIncome,
+		ExpenseDeduction:  min(annualIncome.MulRate(table.ExpenseRate/100), table.ExpenseCap),
+		PersonalAllowance: table.PersonalAllowance,
+		Deductions:        deductions,
+		VehicleDeductions: vehicleDeductions,
+	}
+
+	estimate.TaxableIncome = max(annualIncome-estimate.ExpenseDeduction-estimate.PersonalAllowance-deductions-vehicleDeductions, 0)
+	estimate.Tax = IncomeTax(table, estimate.TaxableIncome)
+	estimate.MarginalRate = marginalRate(table, estimate.TaxableIncome)
+	if annualIncome > 0 {
+		estimate.EffectiveRate = math.Round(estimate.Tax.Float64()/annualIncome.Float64()*10000) / 100
+	}
+
+	return estimate
+}
+
+func OptimizeTax(table *entities.TaxTable, plan *entities.RetirementPlan, year int, deductions entities.Money, contributions []entities.History, now time.Time) (*entities.TaxOptimization, error) {
+	timeline, err := newPlanTimeline(plan, now)
+	if err != nil {
+		return nil, err
+	}
+
+	annualIncome := plan.MonthlyIncome.Mul(12)
+	contributed := make(map[string]entities.Money)
+	for _, history := range contributions {
+		if history.Type == entities.HistoryTypeVehicle && history.Method == "deposit" && history.TrackDate.Year() == year {
+			contributed[history.Category] += history.Money
+		}
+	}
+
+	var own, shared entities.Money
+	for _, rule := range vehicleRules {
+		deductible := min(contributed[rule.Type], AnnualContributionLimit(rule, annualIncome))
+		if rule.SharesRetirementCap {
+			shared += deductible
+		} else {
+			own += deductible
+		}
+	}
+
+	estimate := EstimateTax(table, year, annualIncome, deductions, own+min(shared, CombinedRetirementCap))
+	optimization := &entities.TaxOptimization{
+		Estimate:    estimate,
+		Suggestions: []entities.TaxSaving{},
+	}
+
+	sharedRoom := max(CombinedRetirementCap-shared, 0)
+	remainingSharedRoom := sharedRoom
+	taxable, tax := estimate.TaxableIncome, estimate.Tax
+	useful := max(taxable-taxFreeIncome(table), 0)
+	for _, vehicleType := range taxSavingVehicles {
+		rule, err := GetVehicleRule(vehicleType)
+		if err != nil {
+			return nil, err
+		}
+
+		room := max(AnnualContributionLimit(rule, annualIncome)-contributed[vehicleType], 0)
+		contribution := min(room, useful)
+		if rule.SharesRetirementCap {
+			room = min(room, sharedRoom)
+			contribution = min(contribution, remainingSharedRoom)
+			remainingSharedRoom -= contribution
+		}
+
+		useful -= contribution
+		taxable -= contribution
+		after := IncomeTax(table, taxable)
+		optimization.Suggestions = append(optimization.Suggestions, entities.TaxSaving{
+			VehicleType:  vehicleType,
+			Contributed:  contributed[vehicleType],
+			Room:         room,
+			Contribution: contribution,
+			TaxSaving:    tax - after,
+		})
+
+		optimization.TotalContribution += contribution
+		tax = after
+	}
+
+	optimization.OptimizedTax = tax
+	optimization.TotalSaving = estimate.Tax - tax
+
+	redirect := &optimization.Redirect
+	redirect.MonthlySaving = optimization.TotalSaving.DivRound(12, entities.OneBaht)
+	redirect.MonthsToRetirement = max(timeline.retirementIndex, 0)
+	redirect.RetirementRemaining = max(plan.LastRequiredFunds-plan.CurrentSavings-plan.CurrentTotalInvestment, 0)
+	redirect.RetirementMonthlyRequired = redirect.RetirementRemaining.DivRound(redirect.MonthsToRetirement, entities.OneBaht)
+
+	rate := monthlyRate(plan.CurrentSavingsReturns)
+	var projected float64
+	for i := 0; i < redirect.MonthsToRetirement; i++ {
+		projected = projected*(1+rate) + redirect.MonthlySaving.Float64()
+	}
+
+	redirect.ProjectedValue = entities.MoneyFromFloat(projected).Round(entities.OneBaht)
+	redirect.CoveredRatio = 1
+	if redirect.RetirementRemaining > 0 {
+		redirect.CoveredRatio = clampRatio(redirect.ProjectedValue.Float64() / redirect.RetirementRemaining.Float64())
+	}
+
+	return optimization, nil
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+)
+
+var transactionTransitions = map[entities.TransactionStatus][]entities.TransactionStatus{
+	entities.TransactionDue:     {entities.TransactionOverdue, entities.TransactionPaid, entities.TransactionPaused},
+	entities.TransactionOverdue: {entities.TransactionPaid},
+	entities.TransactionPaused:  {entities.TransactionDue},
+	entities.TransactionPaid:    {},
+}
+
+var transactionStatusLabels = map[string]map[entities.TransactionStatus]string{
+	LocaleThai: {
+		entities.TransactionDue:     "ชำระ",
+		entities.TransactionOverdue: "ค้างชำระ",
+		entities.TransactionPaid:    "ชำระแล้ว",
+		entities.TransactionPaused:  "หยุดพัก",
+	},
+	LocaleEnglish: {
+		entities.TransactionDue:     "Due",
+		entities.TransactionOverdue: "Overdue",
+		entities.TransactionPaid:    "Paid",
+		entities.TransactionPaused:  "Paused",
+	},
+}
+
+func CanTransitionTransaction(from, to entities.TransactionStatus) bool {
+	for _, status := range transactionTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TransitionTransaction(transaction *entities.Transaction, status entities.TransactionStatus) error {
+	if !CanTransitionTransaction(transaction.Status, status) {
+		return errors.New("invalid transaction status transition")
+	}
+
+	transaction.Status = status
+	return nil
+}
+
+func TransactionStatusLabel(status entities.TransactionStatus, locale string) string {
+	labels, ok := transactionStatusLabels[locale]
+	if !ok {
+		labels = transactionStatusLabels[DefaultLocale]
+	}
+
+	return labels[status]
+}
+
+func BillingDueDate(billed time.Time) time.Time {
+	return time.Date(billed.Year(), billed.Month()+1, 0, 0, 0, 0, 0, billed.Location())
+}
+
+func IsOverdue(transaction *entities.Transaction, now time.Time) bool {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return transaction.DueDate.Before(today)
+}
+
+func daysLate(transaction *entities.Transaction) int {
+	due := transaction.DueDate
+	deadline := time.Date(due.Year(), due.Month(), due.Day()+1, 0, 0, 0, 0, due.Location())
+	if transaction.PaidAt.Before(deadline) {
+		return 0
+	}
+
+	return int(transaction.PaidAt.Sub(deadline).Hours()/24) + 1
+}
+
+func PaymentStatistics(transactions []entities.Transaction) entities.PaymentStats {
+	var stats entities.PaymentStats
+	var totalDaysLate int
+	for i := range transactions {
+		transaction := &transactions[i]
+		switch transaction.Status {
+		case entities.TransactionDue:
+			stats.Due++
+		case entities.TransactionOverdue:
+			stats.Overdue++
+		case entities.TransactionPaid:
+			stats.Paid++
+			stats.TotalPaid += transaction.PaidAmount
+			if transaction.PaidAt == nil || transaction.DueDate.IsZero() {
+				continue
+			}
+
+			if days := daysLate(transaction); days > 0 {
+				stats.Late++
+				totalDaysLate += days
+			} else {
+				stats.OnTime++
+			}
+		}
+	}
+
+	if timed := stats.OnTime + stats.Late; timed > 0 {
+		stats.OnTimeRate = math.Round(float64(stats.OnTime)/float64(timed)*10000) / 100
+	}
+
+	if stats.Late > 0 {
+		stats.AverageDaysLate = math.Round(float64(totalDaysLate)/float64(stats.Late)*100) / 100
+	}
+
+	return stats
+}
@@ -0,0 +1,262 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+)
+
+const (
+	socialSecurityWageCap       = 15000
+	socialSecurityPensionMonths = 180
+)
+
+var CombinedRetirementCap = entities.Baht(500000)
+
+var vehicleRules = []entities.VehicleRule{
+	{Type: entities.VehicleTypeRMF, IncomeRate: 30, AnnualCap: entities.Baht(500000), SharesRetirementCap: true, LockYears: 5, UnlockAge: 55, DefaultReturn: 5},
+	{Type: entities.VehicleTypeSSF, IncomeRate: 30, AnnualCap: entities.Baht(200000), SharesRetirementCap: true, LockYears: 10, DefaultReturn: 5},
+	{Type: entities.VehicleTypeThaiESG, IncomeRate: 30, AnnualCap: entities.Baht(300000), LockYears: 5, DefaultReturn: 4},
+	{Type: entities.VehicleTypeProvidentFund, IncomeRate: 15, AnnualCap: entities.Baht(500000), SharesRetirementCap: true, UnlockAge: 55, DefaultReturn: 4},
+	{Type: entities.VehicleTypeSocialSecurity, AnnualCap: entities.Baht(9000), UnlockAge: 55},
+}
+
+func VehicleRules() []entities.VehicleRule {
+	return append([]entities.VehicleRule(nil), vehicleRules...)
+}
+
+func GetVehicleRule(vehicleType string) (entities.VehicleRule, error) {
+	for _, rule := range vehicleRules {
+		if rule.Type == vehicleType {
+			return rule, nil
+		}
+	}
+
+	return entities.VehicleRule{}, errors.New("invalid vehicle type")
+}
+
+func AnnualContributionLimit(rule entities.VehicleRule, annualIncome entities.Money) entities.Money {
+	if rule.IncomeRate == 0 {
+		return rule.AnnualCap
+	}
+
+	return min(annualIncome.MulRate(rule.IncomeRate/100), rule.AnnualCap)
+}
+
+func vehicleDeposits(contributions []entities.History, match func(history entities.History) bool) entities.Money {
+	var total entities.Money
+	for _, history := range contributions {
+		if history.Type == entities.HistoryTypeVehicle && history.Method == "deposit" && match(history) {
+			total += history.Money
+		}
+	}
+
+	return total
+}
+
+func CheckVehicleContribution(vehicle *entities.SavingsVehicle, amount, annualIncome entities.Money, contributions []entities.History, now time.Time) error {
+	rule, err := GetVehicleRule(vehicle.Type)
+	if err != nil {
+		return err
+	}
+
+	yearStart := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+	contributed := vehicleDeposits(contributions, func(history entities.History) bool {
+		return history.Category == vehicle.Type && !history.TrackDate.Before(yearStart)
+	})
+
+	if contributed+amount > AnnualContributionLimit(rule, annualIncome) {
+		return errors.New("contribution exceeds annual limit for this vehicle type")
+	}
+
+	if !rule.SharesRetirementCap {
+		return nil
+	}
+
+	shared := vehicleDeposits(contributions, func(history entities.History) bool {
+		other, err := GetVehicleRule(history.Category)
+		return err == nil && other.SharesRetirementCap && !history.TrackDate.Before(yearStart)
+	})
+
+	if shared+amount > CombinedRetirementCap {
+		return errors.New("contribution exceeds combined retirement savings limit")
+	}
+
+	return nil
+}
+
+func vehicleBirthDate(plan *entities.RetirementPlan) (time.Time, error) {
+	birthDate, err := time.Parse("02-01-2006", plan.BirthDate)
+	if err != nil {
+		return time.Time{}, errors.New("invalid BirthDate format, expected DD-MM-YYYY")
+	}
+
+	return birthDate, nil
+}
+
+func VehicleWithdrawable(vehicle *entities.SavingsVehicle, plan *entities.RetirementPlan, contributions []entities.History, now time.Time) (entities.Money, time.Time, error) {
+	rule, err := GetVehicleRule(vehicle.Type)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	if rule.UnlockAge == 0 {
+		lockedFrom := now.AddDate(-rule.LockYears, 0, 0)
+		unlockDate := vehicle.OpenedAt.AddDate(rule.LockYears, 0, 0)
+		locked := vehicleDeposits(contributions, func(history entities.History) bool {
+			if history.Category != vehicle.Type || history.Name != vehicle.Name || !history.TrackDate.After(lockedFrom) {
+				return false
+			}
+
+			if lotUnlock := history.TrackDate.AddDate(rule.LockYears, 0, 0); lotUnlock.After(unlockDate) {
+				unlockDate = lotUnlock
+			}
+
+			return true
+		})
+
+		if vehicle.OpenedAt.After(lockedFrom) {
+			return 0, unlockDate, nil
+		}
+
+		return max(vehicle.CurrentMoney-locked, 0), unlockDate, nil
+	}
+
+	birthDate, err := vehicleBirthDate(plan)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	unlockDate := birthDate.AddDate(rule.UnlockAge, 0, 0)
+	if lockEnd := vehicle.OpenedAt.AddDate(rule.LockYears, 0, 0); rule.LockYears > 0 && lockEnd.After(unlockDate) {
+		unlockDate = lockEnd
+	}
+
+	if now.Before(unlockDate) {
+		return 0, unlockDate, nil
+	}
+
+	return vehicle.CurrentMoney, unlockDate, nil
+}
+
+func ProjectVehicle(vehicle *entities.SavingsVehicle, plan *entities.RetirementPlan, contributions []entities.History, now time.Time) (*entities.VehicleProjection, error) {
+	timeline, err := newPlanTimeline(plan, now)
+	if err != nil {
+		return nil, err
+	}
+
+	_, unlockDate, err := VehicleWithdrawable(vehicle, plan, contributions, now)
+	if err != nil {
+		return nil, err
+	}
+
+	payoutMonth := time.Date(unlockDate.Year(), unlockDate.Month(), 1, 0, 0, 0, 0, now.Location())
+	if unlockDate.Day() > 1 {
+		payoutMonth = payoutMonth.AddDate(0, 1, 0)
+	}
+
+	payoutMonth = maxTime(payoutMonth, timeline.retirementMonth, timeline.start)
+	contributingMonths := max(monthsBetween(timeline.start, timeline.retirementMonth), 0)
+	growthMonths := monthsBetween(timeline.start, payoutMonth)
+	rate := monthlyRate(vehicle.ExpectedReturn)
+	balance := vehicle.CurrentMoney
+	for m := 0; m < growthMonths; m++ {
+		balance = balance.MulRate(1 + rate)
+		if m < contributingMonths {
+			balance += vehicle.MonthlyContribution
+		}
+	}
+
+	projection := &entities.VehicleProjection{
+		VehicleID:        vehicle.ID,
+		Type:             vehicle.Type,
+		Name:             vehicle.Name,
+		PayoutMonth:      payoutMonth,
+		CurrentMoney:     vehicle.CurrentMoney,
+		ProjectedBalance: balance,
+	}
+
+	payoutMonths := monthsBetween(payoutMonth, timeline.endMonth)
+	if payoutMonths <= 0 {
+		return projection, nil
+	}
+
+	if vehicle.Type == entities.VehicleTypeSocialSecurity {
+		months := vehicle.ContributionMonths
+		if vehicle.MonthlyContribution > 0 {
+			months += contributingMonths
+		}
+
+		if months >= socialSecurityPensionMonths {
+			wage := min(vehicle.AverageWage, entities.Baht(socialSecurityWageCap))
+			accrual := 20 + 1.5*float64((months-socialSecurityPensionMonths)/12)
+			projection.MonthlyPension = wage.MulRate(accrual / 100).Round(entities.OneBaht)
+			projection.ProjectedBalance = 0
+			projection.TotalPayout = projection.MonthlyPension.Mul(payoutMonths)
+			return projection, nil
+		}
+	}
+
+	projection.TotalPayout = projection.ProjectedBalance
+	return projection, nil
+}
+
+func maxTime(first time.Time, rest ...time.Time) time.Time {
+	for _, t := range rest {
+		if t.After(first) {
+			first = t
+		}
+	}
+
+	return first
+}
+
+func ProjectVehicles(plan *entities.RetirementPlan, vehicles []entities.SavingsVehicle, contributions []entities.History, now time.Time) ([]entities.VehicleProjection, error) {
+	projections := make([]entities.VehicleProjection, 0, len(vehicles))
+	for i := range vehicles {
+		projection, err := ProjectVehicle(&vehicles[i], plan, contributions, now)
+		if err != nil {
+			return nil, err
+		}
+
+		projections = append(projections, *projection)
+	}
+
+	return projections, nil
+}
+
+func SummarizeVehicle(vehicle *entities.SavingsVehicle, plan *entities.RetirementPlan, contributions []entities.History, now time.Time) (*entities.VehicleSummary, error) {
+	rule, err := GetVehicleRule(vehicle.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawable, unlockDate, err := VehicleWithdrawable(vehicle, plan, contributions, now)
+	if err != nil {
+		return nil, err
+	}
+
+	projection, err := ProjectVehicle(vehicle, plan, contributions, now)
+	if err != nil {
+		return nil, err
+	}
+
+	yearStart := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+	summary := &entities.VehicleSummary{
+		SavingsVehicle: *vehicle,
+		AnnualLimit:    AnnualContributionLimit(rule, plan.MonthlyIncome.Mul(12)),
+		ContributedThisYear: vehicleDeposits(contributions, func(history entities.History) bool {
+			return history.Category == vehicle.Type && history.Name == vehicle.Name && !history.TrackDate.Before(yearStart)
+		}),
+		UnlockDate:   unlockDate,
+		Withdrawable: withdrawable,
+		Projection:   *projection,
+	}
+
+	summary.RemainingLimit = max(summary.AnnualLimit-vehicleDeposits(contributions, func(history entities.History) bool {
+		return history.Category == vehicle.Type && !history.TrackDate.Before(yearStart)
+	}), 0)
+
+	return summary, nil
+}
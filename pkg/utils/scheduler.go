@@ -2,34 +2,34 @@ package utils
 
 import (
 	"log"
-	"net/http"
 	"time"
 
 	"github.com/robfig/cron/v3"
 )
 
-func callTransactionAPI() {
-	_, err := http.Post("http://localhost:5000/transaction/all", "application/json", nil)
-	if err != nil {
-		log.Println("Failed to call transaction API:", err)
-	} else {
-		log.Println("Transaction API called successfully")
-	}
-}
+var scheduler *cron.Cron
 
 func StartScheduler() {
 	loc, _ := time.LoadLocation("Asia/Bangkok")
-	c := cron.New(cron.WithLocation(loc))
+	scheduler = cron.New(cron.WithLocation(loc))
+	scheduler.Start()
+	log.Println("Cron job started...")
+}
 
-	_, err := c.AddFunc("0 0 1 * *", callTransactionAPI)
-	if err != nil {
-		log.Fatal("Failed to schedule job:", err)
+func ScheduleJob(spec string, name string, job func() error) {
+	if scheduler == nil {
+		StartScheduler()
 	}
 
-	c.Start()
-	log.Println("Cron job started...")
+	_, err := scheduler.AddFunc(spec, func() {
+		if err := job(); err != nil {
+			log.Println("Failed to run "+name+":", err)
+		} else {
+			log.Println(name + " ran successfully")
+		}
+	})
 
-	go func() {
-		select {}
-	}()
+	if err != nil {
+		log.Fatal("Failed to schedule job:", err)
+	}
 }
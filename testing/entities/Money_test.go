@@ -0,0 +1,114 @@
+package entities_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    entities.Money
+		expectError bool
+	}{
+		{name: "จำนวนเต็ม", input: "1500", expected: entities.Baht(1500)},
+		{name: "ทศนิยมสองตำแหน่ง", input: "1500.50", expected: 150050},
+		{name: "ปัดครึ่งขึ้น", input: "1.005", expected: 101},
+		{name: "ปัดลง", input: "1.004", expected: 100},
+		{name: "ค่าติดลบปัดออกจากศูนย์", input: "-1.005", expected: -101},
+		{name: "มีช่องว่าง", input: " 20 ", expected: entities.Baht(20)},
+		{name: "ค่าว่าง", input: "", expectError: true},
+		{name: "ไม่ใช่ตัวเลข", input: "abc", expectError: true},
+		{name: "เศษส่วน", input: "1/3", expectError: true},
+		{name: "เลขยกกำลัง", input: "1e3", expectError: true},
+		{name: "ไม่มีเลขหน้าจุด", input: ".5", expectError: true},
+		{name: "จุดท้าย", input: "5.", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			money, err := entities.ParseMoney(tt.input)
+			if tt.expectError {
+				assert.ErrorIs(t, err, entities.ErrInvalidMoney)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, money)
+		})
+	}
+}
+
+func TestMoneyRounding(t *testing.T) {
+	assert.Equal(t, entities.Money(33), entities.Money(100).Div(3))
+	assert.Equal(t, entities.Money(67), entities.Money(200).Div(3))
+	assert.Equal(t, entities.Money(-67), entities.Money(-200).Div(3))
+	assert.Equal(t, entities.Baht(2174), entities.Baht(50000).DivRound(23, entities.OneBaht))
+	assert.Equal(t, entities.Baht(3), entities.MoneyFromFloat(2.5).Round(entities.OneBaht))
+	assert.Equal(t, entities.Baht(-3), entities.MoneyFromFloat(-2.5).Round(entities.OneBaht))
+	assert.Equal(t, entities.MoneyFromFloat(103.00), entities.Baht(100).MulRate(1.03))
+	assert.Equal(t, entities.Money(0), entities.Baht(100).Div(0))
+}
+
+func TestMoneySplit(t *testing.T) {
+	parts := entities.Baht(100).Split(3)
+	assert.Equal(t, []entities.Money{3334, 3333, 3333}, parts)
+
+	negative := entities.Money(-100).Split(3)
+	assert.Equal(t, []entities.Money{-34, -33, -33}, negative)
+
+	var total entities.Money
+	for _, part := range entities.MoneyFromFloat(1234.57).Split(7) {
+		total += part
+	}
+	assert.Equal(t, entities.MoneyFromFloat(1234.57), total)
+	assert.Nil(t, entities.Baht(100).Split(0))
+}
+
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Amount entities.Money `json:"amount"`
+	}{Amount: entities.MoneyFromFloat(-1500.5)})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount": -1500.50}`, string(data))
+
+	var body struct {
+		Number entities.Money `json:"number"`
+		Text   entities.Money `json:"text"`
+		Empty  entities.Money `json:"empty"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"number": 99.999, "text": "12.34", "empty": null}`), &body))
+	assert.Equal(t, entities.Baht(100), body.Number)
+	assert.Equal(t, entities.Money(1234), body.Text)
+	assert.Equal(t, entities.Money(0), body.Empty)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"number": "ten"}`), &body))
+}
+
+func TestMoneyScan(t *testing.T) {
+	var money entities.Money
+	assert.NoError(t, money.Scan([]byte("1500.25")))
+	assert.Equal(t, entities.Money(150025), money)
+
+	assert.NoError(t, money.Scan("7.10"))
+	assert.Equal(t, entities.Money(710), money)
+
+	assert.NoError(t, money.Scan(int64(15000)))
+	assert.Equal(t, entities.Baht(15000), money)
+
+	assert.NoError(t, money.Scan(2999.995))
+	assert.Equal(t, entities.Baht(3000), money)
+
+	assert.NoError(t, money.Scan(nil))
+	assert.Equal(t, entities.Money(0), money)
+
+	assert.ErrorIs(t, money.Scan(true), entities.ErrInvalidMoney)
+
+	value, err := entities.MoneyFromFloat(42.5).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "42.50", value)
+}
@@ -0,0 +1,98 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/configs"
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/middlewares"
+	"github.com/XzerozZ/Kasian_Phrom_BE/testing/repositories/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+var jwtConfig = configs.JWT{Secret: "test-secret"}
+
+func signToken(t *testing.T, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(jwtConfig.Secret))
+	assert.NoError(t, err)
+	return token
+}
+
+func setupJWTApp(sessions middlewares.SessionStore) *fiber.App {
+	app := fiber.New()
+	app.Get("/me", middlewares.JWTMiddleware(jwtConfig, sessions), func(c *fiber.Ctx) error {
+		return c.SendString(c.Locals("user_id").(string))
+	})
+
+	return app
+}
+
+func requestWithToken(app *fiber.App, token string) (int, error) {
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.StatusCode, nil
+}
+
+func TestJWTMiddleware(t *testing.T) {
+	t.Run("Valid session", func(t *testing.T) {
+		sessions := new(mocks.MockUserRepository)
+		sessions.On("GetSessionByID", "session-1").Return(&entities.Session{ID: "session-1", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+		token := signToken(t, jwt.MapClaims{"user_id": "user-1", "role": "User", "sid": "session-1", "exp": time.Now().Add(time.Minute).Unix()})
+		status, err := requestWithToken(setupJWTApp(sessions), token)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, status)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		sessions := new(mocks.MockUserRepository)
+
+		token := signToken(t, jwt.MapClaims{"user_id": "user-1", "role": "User", "sid": "session-1", "exp": time.Now().Add(-time.Minute).Unix()})
+		status, err := requestWithToken(setupJWTApp(sessions), token)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+		sessions.AssertNotCalled(t, "GetSessionByID", "session-1")
+	})
+
+	t.Run("Revoked session", func(t *testing.T) {
+		sessions := new(mocks.MockUserRepository)
+		revokedAt := time.Now().Add(-time.Minute)
+		sessions.On("GetSessionByID", "session-1").Return(&entities.Session{ID: "session-1", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil)
+
+		token := signToken(t, jwt.MapClaims{"user_id": "user-1", "role": "User", "sid": "session-1", "exp": time.Now().Add(time.Minute).Unix()})
+		status, err := requestWithToken(setupJWTApp(sessions), token)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+	})
+
+	t.Run("Unknown session", func(t *testing.T) {
+		sessions := new(mocks.MockUserRepository)
+		sessions.On("GetSessionByID", "session-1").Return((*entities.Session)(nil), errors.New("record not found"))
+
+		token := signToken(t, jwt.MapClaims{"user_id": "user-1", "role": "User", "sid": "session-1", "exp": time.Now().Add(time.Minute).Unix()})
+		status, err := requestWithToken(setupJWTApp(sessions), token)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+	})
+
+	t.Run("Missing session ID", func(t *testing.T) {
+		sessions := new(mocks.MockUserRepository)
+
+		token := signToken(t, jwt.MapClaims{"user_id": "user-1", "role": "User", "exp": time.Now().Add(time.Minute).Unix()})
+		status, err := requestWithToken(setupJWTApp(sessions), token)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+		sessions.AssertNotCalled(t, "GetSessionByID")
+	})
+}
@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenFromQuery(t *testing.T) {
+	app := fiber.New()
+	app.Get("/ws", middlewares.TokenFromQuery("token"), func(c *fiber.Ctx) error {
+		return c.SendString(c.Get("Authorization"))
+	})
+
+	t.Run("Copies query token into header", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/ws?token=abc", nil), -1)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "Bearer abc", string(body))
+	})
+
+	t.Run("Keeps existing header", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/ws?token=abc", nil)
+		req.Header.Set("Authorization", "Bearer xyz")
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "Bearer xyz", string(body))
+	})
+}
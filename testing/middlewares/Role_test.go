@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRoleApp(role interface{}) *fiber.App {
+	app := fiber.New()
+	app.Post("/admin", func(c *fiber.Ctx) error {
+		if role != nil {
+			c.Locals("role", role)
+		}
+		return c.Next()
+	}, middlewares.RequireRole("Admin"), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	return app
+}
+
+func TestRequireRole(t *testing.T) {
+	t.Run("Allowed role", func(t *testing.T) {
+		resp, err := setupRoleApp("Admin").Test(httptest.NewRequest("POST", "/admin", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Forbidden role", func(t *testing.T) {
+		resp, err := setupRoleApp("User").Test(httptest.NewRequest("POST", "/admin", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("Missing role", func(t *testing.T) {
+		resp, err := setupRoleApp(nil).Test(httptest.NewRequest("POST", "/admin", nil), -1)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+}
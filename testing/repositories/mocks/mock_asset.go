@@ -25,6 +25,15 @@ func (m *MockAssetRepository) GetAssetByID(id string) (*entities.Asset, error) {
 	return args.Get(0).(*entities.Asset), args.Error(1)
 }
 
+func (m *MockAssetRepository) GetAssetByIDForUpdate(id string) (*entities.Asset, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Asset), args.Error(1)
+}
+
 func (m *MockAssetRepository) GetAssetByUserID(userID string) ([]entities.Asset, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
@@ -58,3 +67,12 @@ func (m *MockAssetRepository) FindAssetByNameandUserID(name string, userID strin
 	}
 	return args.Get(0).(*entities.Asset), args.Error(1)
 }
+
+func (m *MockAssetRepository) FindAssetByNameandUserIDForUpdate(name string, userID string) (*entities.Asset, error) {
+	args := m.Called(name, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Asset), args.Error(1)
+}
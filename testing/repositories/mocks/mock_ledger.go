@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLedgerRepository struct {
+	mock.Mock
+}
+
+func (m *MockLedgerRepository) CreateAccount(account *entities.LedgerAccount) (*entities.LedgerAccount, error) {
+	args := m.Called(account)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.LedgerAccount), args.Error(1)
+}
+
+func (m *MockLedgerRepository) GetAccount(userID, kind, refID string) (*entities.LedgerAccount, error) {
+	args := m.Called(userID, kind, refID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.LedgerAccount), args.Error(1)
+}
+
+func (m *MockLedgerRepository) GetAccountByID(id string) (*entities.LedgerAccount, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.LedgerAccount), args.Error(1)
+}
+
+func (m *MockLedgerRepository) GetAccountsByUserID(userID string) ([]entities.LedgerAccount, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.LedgerAccount), args.Error(1)
+}
+
+func (m *MockLedgerRepository) CreateEntry(entry *entities.LedgerEntry) (*entities.LedgerEntry, error) {
+	args := m.Called(entry)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.LedgerEntry), args.Error(1)
+}
+
+func (m *MockLedgerRepository) GetEntriesByAccountID(accountID string, asOf time.Time) ([]entities.LedgerEntry, error) {
+	args := m.Called(accountID, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.LedgerEntry), args.Error(1)
+}
+
+func (m *MockLedgerRepository) GetBalance(accountID string, asOf time.Time) (entities.Money, error) {
+	args := m.Called(accountID, asOf)
+	return args.Get(0).(entities.Money), args.Error(1)
+}
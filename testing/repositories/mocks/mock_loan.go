@@ -19,6 +19,15 @@ func (m *MockLoanRepository) GetLoanByID(id string) (*entities.Loan, error) {
 	return args.Get(0).(*entities.Loan), args.Error(1)
 }
 
+func (m *MockLoanRepository) GetLoanByIDForUpdate(id string) (*entities.Loan, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.Loan), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
 func (m *MockLoanRepository) GetLoanByUserID(userID string) ([]entities.Loan, map[string]interface{}, error) {
 	args := m.Called(userID)
 	return args.Get(0).([]entities.Loan), args.Get(1).(map[string]interface{}), args.Error(2)
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"github.com/stretchr/testify/mock"
 )
@@ -14,8 +16,8 @@ func (m *MockNotiRepository) CreateNotification(notification *entities.Notificat
 	return args.Error(0)
 }
 
-func (m *MockNotiRepository) GetNotificationsByUserID(userID string) ([]entities.Notification, error) {
-	args := m.Called(userID)
+func (m *MockNotiRepository) GetNotificationsByUserID(userID string, filter entities.NotificationFilter) ([]entities.Notification, error) {
+	args := m.Called(userID, filter)
 	return args.Get(0).([]entities.Notification), args.Error(1)
 }
 
@@ -23,3 +25,73 @@ func (m *MockNotiRepository) MarkNotificationAsRead(userID string) error {
 	args := m.Called(userID)
 	return args.Error(0)
 }
+
+func (m *MockNotiRepository) GetUnreadNotificationsSince(userID string, since time.Time) ([]entities.Notification, error) {
+	args := m.Called(userID, since)
+	return args.Get(0).([]entities.Notification), args.Error(1)
+}
+
+func (m *MockNotiRepository) MarkNotificationAsReadByID(id, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockNotiRepository) CountUnreadNotifications(userID string) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotiRepository) MarkNotificationsAsReadByIDs(ids []string, userID string) error {
+	args := m.Called(ids, userID)
+	return args.Error(0)
+}
+
+func (m *MockNotiRepository) ArchiveNotificationByID(id, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockNotiRepository) DeleteNotificationByID(id, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockNotiRepository) GetPreferenceByUserID(userID string) (*entities.NotificationPreference, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.NotificationPreference), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotiRepository) SavePreference(preference *entities.NotificationPreference) (*entities.NotificationPreference, error) {
+	args := m.Called(preference)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.NotificationPreference), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotiRepository) GetDigestSubscribers() ([]entities.NotificationPreference, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.NotificationPreference), args.Error(1)
+}
+
+func (m *MockNotiRepository) QueueEmail(email *entities.QueuedEmail) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
+
+func (m *MockNotiRepository) GetQueuedEmails() ([]entities.QueuedEmail, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.QueuedEmail), args.Error(1)
+}
+
+func (m *MockNotiRepository) DeleteQueuedEmail(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
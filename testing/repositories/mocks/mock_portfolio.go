@@ -0,0 +1,86 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPortfolioRepository struct {
+	mock.Mock
+}
+
+func (m *MockPortfolioRepository) CreateHolding(holding *entities.Holding) (*entities.Holding, error) {
+	args := m.Called(holding)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Holding), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) GetHoldingByID(id string) (*entities.Holding, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Holding), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) GetHoldingsByUserID(userID string) ([]entities.Holding, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.Holding), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) UpdateHolding(holding *entities.Holding) (*entities.Holding, error) {
+	args := m.Called(holding)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Holding), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) DeleteHolding(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) GetUserIDsWithHoldings() ([]string, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) UpsertPriceQuotes(quotes []entities.PriceQuote) error {
+	args := m.Called(quotes)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) ApplyPriceQuote(quote *entities.PriceQuote) (int64, error) {
+	args := m.Called(quote)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPortfolioRepository) UpsertSnapshot(snapshot *entities.PortfolioSnapshot) error {
+	args := m.Called(snapshot)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioRepository) GetSnapshotsByUserID(userID string, from, to time.Time) ([]entities.PortfolioSnapshot, error) {
+	args := m.Called(userID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.PortfolioSnapshot), args.Error(1)
+}
@@ -22,6 +22,15 @@ func (m *MockRetirementRepository) GetRetirementByID(id string) (*entities.Retir
 	return args.Get(0).(*entities.RetirementPlan), args.Error(1)
 }
 
+func (m *MockRetirementRepository) GetRetirementByIDForUpdate(id string) (*entities.RetirementPlan, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.RetirementPlan), args.Error(1)
+}
+
 func (m *MockRetirementRepository) GetRetirementByUserID(userID string) (*entities.RetirementPlan, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
@@ -30,7 +39,39 @@ func (m *MockRetirementRepository) GetRetirementByUserID(userID string) (*entiti
 	return args.Get(0).(*entities.RetirementPlan), args.Error(1)
 }
 
+func (m *MockRetirementRepository) GetRetirementByUserIDForUpdate(userID string) (*entities.RetirementPlan, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.RetirementPlan), args.Error(1)
+}
+
 func (m *MockRetirementRepository) UpdateRetirementPlan(retirement *entities.RetirementPlan) (*entities.RetirementPlan, error) {
 	args := m.Called(retirement)
 	return args.Get(0).(*entities.RetirementPlan), args.Error(1)
 }
+
+func (m *MockRetirementRepository) GetRetirementsByUserID(userID string) ([]entities.RetirementPlan, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.RetirementPlan), args.Error(1)
+}
+
+func (m *MockRetirementRepository) AppendRevision(plan *entities.RetirementPlan) error {
+	args := m.Called(plan)
+	return args.Error(0)
+}
+
+func (m *MockRetirementRepository) GetRevisionsByPlanID(planID string) ([]entities.RetirementPlanRevision, error) {
+	args := m.Called(planID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.RetirementPlanRevision), args.Error(1)
+}
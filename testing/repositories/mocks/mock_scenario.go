@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockScenarioRepository struct {
+	mock.Mock
+}
+
+func (m *MockScenarioRepository) CreateScenario(scenario *entities.Scenario) (*entities.Scenario, error) {
+	args := m.Called(scenario)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Scenario), args.Error(1)
+}
+
+func (m *MockScenarioRepository) GetScenarioByID(id string) (*entities.Scenario, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Scenario), args.Error(1)
+}
+
+func (m *MockScenarioRepository) GetScenariosByUserID(userID string) ([]entities.Scenario, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.Scenario), args.Error(1)
+}
+
+func (m *MockScenarioRepository) UpdateScenario(scenario *entities.Scenario) (*entities.Scenario, error) {
+	args := m.Called(scenario)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Scenario), args.Error(1)
+}
+
+func (m *MockScenarioRepository) DeleteScenario(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaxRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaxRepository) CreateTaxTable(table *entities.TaxTable) (*entities.TaxTable, error) {
+	args := m.Called(table)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.TaxTable), args.Error(1)
+}
+
+func (m *MockTaxRepository) GetTaxTables() ([]entities.TaxTable, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.TaxTable), args.Error(1)
+}
+
+func (m *MockTaxRepository) GetTaxTable(year, version int) (*entities.TaxTable, error) {
+	args := m.Called(year, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.TaxTable), args.Error(1)
+}
+
+func (m *MockTaxRepository) GetLatestTaxTableVersion(year int) (int, error) {
+	args := m.Called(year)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTaxRepository) GetDeductionsByUserID(userID string, year int) ([]entities.TaxDeduction, error) {
+	args := m.Called(userID, year)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.TaxDeduction), args.Error(1)
+}
+
+func (m *MockTaxRepository) ReplaceDeductions(userID string, year int, deductions []entities.TaxDeduction) ([]entities.TaxDeduction, error) {
+	args := m.Called(userID, year, deductions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.TaxDeduction), args.Error(1)
+}
@@ -19,6 +19,15 @@ func (m *MockTransRepository) GetTransactionByID(id string) (*entities.Transacti
 	return args.Get(0).(*entities.Transaction), args.Error(1)
 }
 
+func (m *MockTransRepository) GetTransactionByIDForUpdate(id string) (*entities.Transaction, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.Transaction), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
 func (m *MockTransRepository) GetTransactionByUserID(userID string) ([]map[string]interface{}, error) {
 	args := m.Called(userID)
 	return args.Get(0).([]map[string]interface{}), args.Error(1)
@@ -53,7 +62,22 @@ func (m *MockTransRepository) DeleteTransactionsByLoanID(loanID string) error {
 	return args.Error(0)
 }
 
-func (m *MockTransRepository) CountTransactionsByLoanID(loanID string) (int, error) {
-	args := m.Called(loanID)
-	return args.Int(0), args.Error(1)
+func (m *MockTransRepository) CreatePayment(payment *entities.LoanPayment) error {
+	args := m.Called(payment)
+	return args.Error(0)
+}
+
+func (m *MockTransRepository) GetPaymentsByLoanIDs(loanIDs []string) ([]entities.LoanPayment, error) {
+	args := m.Called(loanIDs)
+	return args.Get(0).([]entities.LoanPayment), args.Error(1)
+}
+
+func (m *MockTransRepository) GetUnpaidTransactionsByLoanIDs(loanIDs []string) ([]entities.Transaction, error) {
+	args := m.Called(loanIDs)
+	return args.Get(0).([]entities.Transaction), args.Error(1)
+}
+
+func (m *MockTransRepository) GetTransactionHistoryByUserID(userID string) ([]entities.Transaction, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]entities.Transaction), args.Error(1)
 }
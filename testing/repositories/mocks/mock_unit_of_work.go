@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/unitofwork"
+)
+
+type MockUnitOfWork struct {
+	Repositories unitofwork.Repositories
+	Committed    int
+	RolledBack   int
+}
+
+func NewMockUnitOfWork(userRepo *MockUserRepository, assetRepo *MockAssetRepository, retirementRepo *MockRetirementRepository, ledgerRepo *MockLedgerRepository) *MockUnitOfWork {
+	return &MockUnitOfWork{
+		Repositories: unitofwork.Repositories{
+			Users:       userRepo,
+			Assets:      assetRepo,
+			Retirements: retirementRepo,
+			Ledger:      ledgerRepo,
+		},
+	}
+}
+
+func NewMockLoanUnitOfWork(loanRepo *MockLoanRepository, transRepo *MockTransRepository) *MockUnitOfWork {
+	return &MockUnitOfWork{
+		Repositories: unitofwork.Repositories{
+			Loans:        loanRepo,
+			Transactions: transRepo,
+		},
+	}
+}
+
+func (m *MockUnitOfWork) Do(fn func(repos unitofwork.Repositories) error) error {
+	if err := fn(m.Repositories); err != nil {
+		m.RolledBack++
+		return err
+	}
+
+	m.Committed++
+	return nil
+}
@@ -56,11 +56,50 @@ func (m *MockUserRepository) DeleteOTP(userID string) error {
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) CreateSession(session *entities.Session) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetSessionByID(id string) (*entities.Session, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entities.Session), args.Error(1)
+}
+
+func (m *MockUserRepository) GetSessionByRefreshToken(refreshToken string) (*entities.Session, error) {
+	args := m.Called(refreshToken)
+	return args.Get(0).(*entities.Session), args.Error(1)
+}
+
+func (m *MockUserRepository) RotateRefreshToken(id, oldToken, newToken string, expiresAt time.Time) error {
+	args := m.Called(id, oldToken, newToken, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RevokeSession(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RevokeSessionsByUserID(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) GetSelectedHouse(userID string) (*entities.SelectedHouse, error) {
 	args := m.Called(userID)
 	return args.Get(0).(*entities.SelectedHouse), args.Error(1)
 }
 
+func (m *MockUserRepository) GetSelectedHouseForUpdate(userID string) (*entities.SelectedHouse, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.SelectedHouse), args.Error(1)
+}
+
 func (m *MockUserRepository) UpdateSelectedHouse(selectedHouse *entities.SelectedHouse) (*entities.SelectedHouse, error) {
 	args := m.Called(selectedHouse)
 	return args.Get(0).(*entities.SelectedHouse), args.Error(1)
@@ -86,7 +125,16 @@ func (m *MockUserRepository) GetUserDepositsInRange(userID string, startDate, en
 	return args.Get(0).([]entities.History), args.Error(1)
 }
 
-func (m *MockUserRepository) GetUserHistoryByMonth(userID string) (map[string]float64, error) {
+func (m *MockUserRepository) GetUserHistoryByMonth(userID string) (map[string]entities.Money, error) {
 	args := m.Called(userID)
-	return args.Get(0).(map[string]float64), args.Error(1)
+	return args.Get(0).(map[string]entities.Money), args.Error(1)
+}
+
+func (m *MockUserRepository) GetPortfolioSnapshots(userID string) ([]entities.PortfolioSnapshot, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.PortfolioSnapshot), args.Error(1)
 }
@@ -0,0 +1,69 @@
+package mocks
+
+import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVehicleRepository struct {
+	mock.Mock
+}
+
+func (m *MockVehicleRepository) CreateVehicle(vehicle *entities.SavingsVehicle) (*entities.SavingsVehicle, error) {
+	args := m.Called(vehicle)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.SavingsVehicle), args.Error(1)
+}
+
+func (m *MockVehicleRepository) GetVehicleByID(id string) (*entities.SavingsVehicle, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.SavingsVehicle), args.Error(1)
+}
+
+func (m *MockVehicleRepository) GetVehiclesByUserID(userID string) ([]entities.SavingsVehicle, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.SavingsVehicle), args.Error(1)
+}
+
+func (m *MockVehicleRepository) FindVehicleByNameAndUserID(name, userID string) (*entities.SavingsVehicle, error) {
+	args := m.Called(name, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.SavingsVehicle), args.Error(1)
+}
+
+func (m *MockVehicleRepository) FindVehicleByNameAndUserIDForUpdate(name, userID string) (*entities.SavingsVehicle, error) {
+	args := m.Called(name, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.SavingsVehicle), args.Error(1)
+}
+
+func (m *MockVehicleRepository) UpdateVehicle(vehicle *entities.SavingsVehicle) (*entities.SavingsVehicle, error) {
+	args := m.Called(vehicle)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.SavingsVehicle), args.Error(1)
+}
+
+func (m *MockVehicleRepository) DeleteVehicle(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
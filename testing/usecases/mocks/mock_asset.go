@@ -17,8 +17,8 @@ func (m *MockAssetUseCase) CreateAsset(asset entities.Asset) (*entities.Asset, e
 	return nil, args.Error(1)
 }
 
-func (m *MockAssetUseCase) GetAssetByID(id string) (*entities.Asset, error) {
-	args := m.Called(id)
+func (m *MockAssetUseCase) GetAssetByID(id, userID string) (*entities.Asset, error) {
+	args := m.Called(id, userID)
 	if result := args.Get(0); result != nil {
 		return result.(*entities.Asset), args.Error(1)
 	}
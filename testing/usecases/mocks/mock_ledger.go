@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLedgerUseCase struct {
+	mock.Mock
+}
+
+func (m *MockLedgerUseCase) GetBalances(userID string, asOf time.Time) ([]entities.LedgerBalance, error) {
+	args := m.Called(userID, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.LedgerBalance), args.Error(1)
+}
+
+func (m *MockLedgerUseCase) GetStatement(userID, accountID string, asOf time.Time) (*entities.LedgerStatement, error) {
+	args := m.Called(userID, accountID, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.LedgerStatement), args.Error(1)
+}
+
+func (m *MockLedgerUseCase) Reconcile(userID string) ([]entities.LedgerDrift, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.LedgerDrift), args.Error(1)
+}
+
+func (m *MockLedgerUseCase) AdjustBalances(adminID, userID, reason string) ([]entities.LedgerEntry, error) {
+	args := m.Called(adminID, userID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.LedgerEntry), args.Error(1)
+}
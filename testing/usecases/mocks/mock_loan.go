@@ -17,8 +17,8 @@ func (m *MockLoanUseCase) CreateLoan(loan entities.Loan) (*entities.Loan, error)
 	return nil, args.Error(1)
 }
 
-func (m *MockLoanUseCase) GetLoanByID(id string) (*entities.Loan, error) {
-	args := m.Called(id)
+func (m *MockLoanUseCase) GetLoanByID(id, userID string) (*entities.Loan, error) {
+	args := m.Called(id, userID)
 	if args.Get(0) != nil {
 		return args.Get(0).(*entities.Loan), args.Error(1)
 	}
@@ -38,7 +38,64 @@ func (m *MockLoanUseCase) UpdateLoanStatusByID(id string, loan entities.Loan) (*
 	return nil, args.Error(1)
 }
 
-func (m *MockLoanUseCase) DeleteLoanByID(id string) error {
-	args := m.Called(id)
+func (m *MockLoanUseCase) DeleteLoanByID(id, userID string) error {
+	args := m.Called(id, userID)
 	return args.Error(0)
 }
+
+func (m *MockLoanUseCase) GetLoanSchedule(id, userID string) (*entities.LoanSchedule, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.LoanSchedule), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLoanUseCase) PlanDebtPayoff(userID string, options entities.PayoffOptions) (*entities.PayoffPlan, error) {
+	args := m.Called(userID, options)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.PayoffPlan), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLoanUseCase) PrepayLoan(id, userID string, prepayment entities.Prepayment) (*entities.Loan, error) {
+	args := m.Called(id, userID, prepayment)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.Loan), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLoanUseCase) ChangeLoanRate(id, userID string, annualPercent float64) (*entities.Loan, error) {
+	args := m.Called(id, userID, annualPercent)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.Loan), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLoanUseCase) RefinanceLoan(id, userID string, terms entities.Loan) (*entities.Loan, error) {
+	args := m.Called(id, userID, terms)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.Loan), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLoanUseCase) GetLoanPayments(id, userID string) ([]entities.LoanPayment, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]entities.LoanPayment), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLoanUseCase) GetLoanTransactions(id, userID string) ([]entities.Transaction, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) != nil {
+		return args.Get(0).([]entities.Transaction), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
@@ -0,0 +1,14 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(to, subject, templatePath string, data interface{}) error {
+	args := m.Called(to, subject, templatePath, data)
+	return args.Error(0)
+}
@@ -9,12 +9,83 @@ type MockNotiUseCase struct {
 	mock.Mock
 }
 
-func (m *MockNotiUseCase) GetNotificationsByUserID(userID string) ([]entities.Notification, error) {
+func (m *MockNotiUseCase) GetNotificationsByUserID(userID, notiType, cursor string, limit int) (*entities.NotificationPage, error) {
+	args := m.Called(userID, notiType, cursor, limit)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.NotificationPage), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotiUseCase) GetAllNotificationsByUserID(userID, notiType string) ([]entities.Notification, error) {
+	args := m.Called(userID, notiType)
+	if args.Get(0) != nil {
+		return args.Get(0).([]entities.Notification), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotiUseCase) CountUnreadNotifications(userID string) (int64, error) {
 	args := m.Called(userID)
-	return args.Get(0).([]entities.Notification), args.Error(1)
+	return args.Get(0).(int64), args.Error(1)
 }
 
 func (m *MockNotiUseCase) MarkNotificationsAsRead(userID string) error {
 	args := m.Called(userID)
 	return args.Error(0)
 }
+
+func (m *MockNotiUseCase) MarkNotificationAsReadByID(id, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockNotiUseCase) MarkNotificationsAsReadByIDs(ids []string, userID string) error {
+	args := m.Called(ids, userID)
+	return args.Error(0)
+}
+
+func (m *MockNotiUseCase) ArchiveNotificationByID(id, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockNotiUseCase) DeleteNotificationByID(id, userID string) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockNotiUseCase) GetPreference(userID string) (*entities.NotificationPreference, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.NotificationPreference), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotiUseCase) UpdatePreference(userID string, preference entities.NotificationPreference) (*entities.NotificationPreference, error) {
+	args := m.Called(userID, preference)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.NotificationPreference), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MockNotiDispatcher struct {
+	mock.Mock
+}
+
+func (m *MockNotiDispatcher) Dispatch(notification *entities.Notification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
+
+func (m *MockNotiDispatcher) SendWeeklyDigest() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockNotiDispatcher) SendQueuedEmails() error {
+	args := m.Called()
+	return args.Error(0)
+}
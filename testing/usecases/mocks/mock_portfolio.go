@@ -0,0 +1,76 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPortfolioUseCase struct {
+	mock.Mock
+}
+
+func (m *MockPortfolioUseCase) GetPortfolio(userID string) (*entities.PortfolioSummary, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.PortfolioSummary), args.Error(1)
+}
+
+func (m *MockPortfolioUseCase) CreateHolding(userID string, holding entities.Holding, replaceInvestment bool) (*entities.Holding, error) {
+	args := m.Called(userID, holding, replaceInvestment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Holding), args.Error(1)
+}
+
+func (m *MockPortfolioUseCase) GetHoldingByID(userID, id string) (*entities.Holding, error) {
+	args := m.Called(userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Holding), args.Error(1)
+}
+
+func (m *MockPortfolioUseCase) UpdateHolding(userID, id string, holding entities.Holding) (*entities.Holding, error) {
+	args := m.Called(userID, id, holding)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Holding), args.Error(1)
+}
+
+func (m *MockPortfolioUseCase) DeleteHolding(userID, id string) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockPortfolioUseCase) ImportPrices(quotes []entities.PriceQuote) (*entities.PriceImportResult, error) {
+	args := m.Called(quotes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.PriceImportResult), args.Error(1)
+}
+
+func (m *MockPortfolioUseCase) SnapshotPortfolios() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockPortfolioUseCase) GetValuationHistory(userID string, from, to time.Time) ([]entities.PortfolioSnapshot, error) {
+	args := m.Called(userID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.PortfolioSnapshot), args.Error(1)
+}
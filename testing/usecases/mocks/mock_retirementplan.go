@@ -40,3 +40,68 @@ func (m *MockRetirementUseCase) UpdateRetirementByID(userID string, retirement e
 	}
 	return nil, args.Error(1)
 }
+
+func (m *MockRetirementUseCase) GetProjection(userID string) (*entities.RetirementProjection, error) {
+	args := m.Called(userID)
+	if result := args.Get(0); result != nil {
+		return result.(*entities.RetirementProjection), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRetirementUseCase) SimulateRetirement(userID string, options entities.MonteCarloOptions) (*entities.MonteCarloResult, error) {
+	args := m.Called(userID, options)
+	if result := args.Get(0); result != nil {
+		return result.(*entities.MonteCarloResult), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRetirementUseCase) GetRetirementsByUserID(userID string) ([]entities.RetirementPlan, error) {
+	args := m.Called(userID)
+	if result := args.Get(0); result != nil {
+		return result.([]entities.RetirementPlan), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRetirementUseCase) GetPlanByID(userID, planID string) (*entities.RetirementPlan, error) {
+	args := m.Called(userID, planID)
+	if result := args.Get(0); result != nil {
+		return result.(*entities.RetirementPlan), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRetirementUseCase) UpdatePlanByID(userID, planID string, retirement entities.RetirementPlan) (*entities.RetirementPlan, error) {
+	args := m.Called(userID, planID, retirement)
+	if result := args.Get(0); result != nil {
+		return result.(*entities.RetirementPlan), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRetirementUseCase) ActivatePlan(userID, planID string) (*entities.RetirementPlan, error) {
+	args := m.Called(userID, planID)
+	if result := args.Get(0); result != nil {
+		return result.(*entities.RetirementPlan), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRetirementUseCase) GetPlanRevisions(userID, planID string) ([]entities.RetirementPlanRevision, error) {
+	args := m.Called(userID, planID)
+	if result := args.Get(0); result != nil {
+		return result.([]entities.RetirementPlanRevision), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRetirementUseCase) PlanDecumulation(userID string, options entities.DecumulationOptions) (*entities.DecumulationPlan, error) {
+	args := m.Called(userID, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.DecumulationPlan), args.Error(1)
+}
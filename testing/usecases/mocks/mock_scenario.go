@@ -0,0 +1,69 @@
+package mocks
+
+import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockScenarioUseCase struct {
+	mock.Mock
+}
+
+func (m *MockScenarioUseCase) CreateScenario(userID string, request entities.ScenarioRequest) (*entities.Scenario, error) {
+	args := m.Called(userID, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Scenario), args.Error(1)
+}
+
+func (m *MockScenarioUseCase) GetScenarioByID(userID, id string) (*entities.Scenario, error) {
+	args := m.Called(userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Scenario), args.Error(1)
+}
+
+func (m *MockScenarioUseCase) GetScenariosByUserID(userID string) ([]entities.Scenario, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.Scenario), args.Error(1)
+}
+
+func (m *MockScenarioUseCase) UpdateScenario(userID, id string, request entities.ScenarioRequest) (*entities.Scenario, error) {
+	args := m.Called(userID, id, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Scenario), args.Error(1)
+}
+
+func (m *MockScenarioUseCase) DeleteScenario(userID, id string) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockScenarioUseCase) CompareScenarios(userID string, ids []string) ([]entities.ScenarioSummary, error) {
+	args := m.Called(userID, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.ScenarioSummary), args.Error(1)
+}
+
+func (m *MockScenarioUseCase) PromoteScenario(userID, id string) (*entities.RetirementPlan, error) {
+	args := m.Called(userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.RetirementPlan), args.Error(1)
+}
@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTaxUseCase struct {
+	mock.Mock
+}
+
+func (m *MockTaxUseCase) GetTaxTables() ([]entities.TaxTable, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.TaxTable), args.Error(1)
+}
+
+func (m *MockTaxUseCase) CreateTaxTable(table entities.TaxTable) (*entities.TaxTable, error) {
+	args := m.Called(table)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.TaxTable), args.Error(1)
+}
+
+func (m *MockTaxUseCase) GetDeductions(userID string, year int) ([]entities.TaxDeduction, error) {
+	args := m.Called(userID, year)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.TaxDeduction), args.Error(1)
+}
+
+func (m *MockTaxUseCase) UpdateDeductions(userID string, year int, deductions []entities.TaxDeduction) ([]entities.TaxDeduction, error) {
+	args := m.Called(userID, year, deductions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.TaxDeduction), args.Error(1)
+}
+
+func (m *MockTaxUseCase) GetEstimate(userID string, year, version int) (*entities.TaxOptimization, error) {
+	args := m.Called(userID, year, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.TaxOptimization), args.Error(1)
+}
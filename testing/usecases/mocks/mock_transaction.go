@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -22,3 +23,20 @@ func (m *MockTransactionUseCase) GetTransactionByUserID(userID string) ([]map[st
 	args := m.Called(userID)
 	return args.Get(0).([]map[string]interface{}), args.Error(1)
 }
+
+func (m *MockTransactionUseCase) PayTransaction(transactionID, userID string, amount entities.Money) (*entities.Transaction, error) {
+	args := m.Called(transactionID, userID, amount)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.Transaction), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTransactionUseCase) GetPaymentStats(userID string) (*entities.PaymentStats, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*entities.PaymentStats), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
@@ -17,19 +17,34 @@ func (m *MockUserUseCase) Register(user *entities.User, roleName string) (*entit
 	return args.Get(0).(*entities.User), args.Error(1)
 }
 
-func (m *MockUserUseCase) Login(email, password string) (string, *entities.User, error) {
+func (m *MockUserUseCase) Login(email, password string) (*entities.AuthToken, *entities.User, error) {
 	args := m.Called(email, password)
-	return args.String(0), args.Get(1).(*entities.User), args.Error(2)
+	return args.Get(0).(*entities.AuthToken), args.Get(1).(*entities.User), args.Error(2)
 }
 
-func (m *MockUserUseCase) LoginAdmin(email, password string) (string, *entities.User, error) {
+func (m *MockUserUseCase) LoginAdmin(email, password string) (*entities.AuthToken, *entities.User, error) {
 	args := m.Called(email, password)
-	return args.String(0), args.Get(1).(*entities.User), args.Error(2)
+	return args.Get(0).(*entities.AuthToken), args.Get(1).(*entities.User), args.Error(2)
 }
 
-func (m *MockUserUseCase) LoginWithGoogle(user *entities.User) (string, *entities.User, error) {
+func (m *MockUserUseCase) LoginWithGoogle(user *entities.User) (*entities.AuthToken, *entities.User, error) {
 	args := m.Called(user)
-	return args.String(0), args.Get(1).(*entities.User), args.Error(2)
+	return args.Get(0).(*entities.AuthToken), args.Get(1).(*entities.User), args.Error(2)
+}
+
+func (m *MockUserUseCase) RefreshToken(refreshToken string) (*entities.AuthToken, error) {
+	args := m.Called(refreshToken)
+	return args.Get(0).(*entities.AuthToken), args.Error(1)
+}
+
+func (m *MockUserUseCase) Logout(sessionID string) error {
+	args := m.Called(sessionID)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) LogoutAll(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
 }
 
 func (m *MockUserUseCase) ResetPassword(userID, oldPassword, newPassword string) error {
@@ -62,9 +77,21 @@ func (m *MockUserUseCase) ChangedPassword(email, newPassword string) error {
 	return args.Error(0)
 }
 
-func (m *MockUserUseCase) CalculateRetirement(userID string) (fiber.Map, error) {
+func (m *MockUserUseCase) CalculateRetirement(userID string) (*entities.RetirementSummary, error) {
 	args := m.Called(userID)
-	return args.Get(0).(fiber.Map), args.Error(1)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.RetirementSummary), args.Error(1)
+}
+
+func (m *MockUserUseCase) GetReadinessReport(userID string) (*entities.ReadinessReport, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.ReadinessReport), args.Error(1)
 }
 
 func (m *MockUserUseCase) GetSelectedHouse(userID string) (*entities.SelectedHouse, error) {
@@ -87,7 +114,7 @@ func (m *MockUserUseCase) GetHistoryByUserID(userID string) (fiber.Map, error) {
 	return args.Get(0).(fiber.Map), args.Error(1)
 }
 
-func (m *MockUserUseCase) GetHistoryByMonth(userID string) (map[string]float64, error) {
+func (m *MockUserUseCase) GetHistoryByMonth(userID string) (map[string]entities.Money, error) {
 	args := m.Called(userID)
-	return args.Get(0).(map[string]float64), args.Error(1)
+	return args.Get(0).(map[string]entities.Money), args.Error(1)
 }
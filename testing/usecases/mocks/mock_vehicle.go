@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVehicleUseCase struct {
+	mock.Mock
+}
+
+func (m *MockVehicleUseCase) GetRules() []entities.VehicleRule {
+	args := m.Called()
+	return args.Get(0).([]entities.VehicleRule)
+}
+
+func (m *MockVehicleUseCase) CreateVehicle(userID string, vehicle entities.SavingsVehicle) (*entities.SavingsVehicle, error) {
+	args := m.Called(userID, vehicle)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.SavingsVehicle), args.Error(1)
+}
+
+func (m *MockVehicleUseCase) GetVehicleByID(userID, id string) (*entities.VehicleSummary, error) {
+	args := m.Called(userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.VehicleSummary), args.Error(1)
+}
+
+func (m *MockVehicleUseCase) GetVehiclesByUserID(userID string) ([]entities.VehicleSummary, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]entities.VehicleSummary), args.Error(1)
+}
+
+func (m *MockVehicleUseCase) UpdateVehicle(userID, id string, vehicle entities.SavingsVehicle) (*entities.SavingsVehicle, error) {
+	args := m.Called(userID, id, vehicle)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.SavingsVehicle), args.Error(1)
+}
+
+func (m *MockVehicleUseCase) DeleteVehicle(userID, id string) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
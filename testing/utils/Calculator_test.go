@@ -69,20 +69,20 @@ func TestCalculateRetirementFunds(t *testing.T) {
 		plan        *entities.RetirementPlan
 		age         int
 		expectError bool
-		checkFunds  func(t *testing.T, funds float64)
+		checkFunds  func(t *testing.T, funds entities.Money)
 	}{
 		{
 			name: "คำนวณปกติ",
 			plan: &entities.RetirementPlan{
-				ExpectedMonthlyExpenses: 30000,
+				ExpectedMonthlyExpenses: entities.Baht(30000),
 				ExpectedInflation:       3,
 				RetirementAge:           60,
 				ExpectLifespan:          80,
 			},
 			age:         30,
 			expectError: false,
-			checkFunds: func(t *testing.T, funds float64) {
-				assert.Greater(t, funds, float64(0))
+			checkFunds: func(t *testing.T, funds entities.Money) {
+				assert.Greater(t, funds, entities.Money(0))
 			},
 		},
 		{
@@ -93,7 +93,7 @@ func TestCalculateRetirementFunds(t *testing.T) {
 			},
 			age:         60,
 			expectError: true,
-			checkFunds:  func(t *testing.T, funds float64) {},
+			checkFunds:  func(t *testing.T, funds entities.Money) {},
 		},
 		{
 			name: "อายุคาดหมายเท่ากับอายุเกษียณ",
@@ -103,7 +103,7 @@ func TestCalculateRetirementFunds(t *testing.T) {
 			},
 			age:         30,
 			expectError: true,
-			checkFunds:  func(t *testing.T, funds float64) {},
+			checkFunds:  func(t *testing.T, funds entities.Money) {},
 		},
 		{
 			name: "ค่าใช้จ่ายรายเดือนเป็น 0",
@@ -115,8 +115,8 @@ func TestCalculateRetirementFunds(t *testing.T) {
 			},
 			age:         30,
 			expectError: false,
-			checkFunds: func(t *testing.T, funds float64) {
-				assert.Equal(t, float64(0), funds)
+			checkFunds: func(t *testing.T, funds entities.Money) {
+				assert.Equal(t, entities.Money(0), funds)
 			},
 		},
 	}
@@ -134,6 +134,50 @@ func TestCalculateRetirementFunds(t *testing.T) {
 	}
 }
 
+func TestRecalculateRetirementPlan(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 0, 0, 0, 0, time.Local)
+	newPlan := func() *entities.RetirementPlan {
+		return &entities.RetirementPlan{
+			BirthDate:               "15-03-1996",
+			RetirementAge:           60,
+			ExpectLifespan:          80,
+			CurrentSavings:          entities.Baht(500000),
+			ExpectedMonthlyExpenses: entities.Baht(30000),
+			ExpectedInflation:       3,
+		}
+	}
+
+	t.Run("ยังออมไม่ครบ", func(t *testing.T) {
+		plan := newPlan()
+
+		err := utils.RecalculateRetirementPlan(plan, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.StatusInProgress, plan.Status)
+		assert.Greater(t, plan.LastRequiredFunds, plan.CurrentSavings)
+		assert.Greater(t, plan.LastMonthlyExpenses, entities.Money(0))
+		assert.Equal(t, 3, plan.LastCalculatedMonth)
+	})
+
+	t.Run("ออมครบแล้ว", func(t *testing.T) {
+		plan := newPlan()
+		plan.CurrentTotalInvestment = entities.Baht(100000000)
+
+		err := utils.RecalculateRetirementPlan(plan, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.StatusCompleted, plan.Status)
+		assert.Equal(t, entities.Money(0), plan.LastMonthlyExpenses)
+	})
+
+	t.Run("วันเกิดไม่ถูกต้อง", func(t *testing.T) {
+		plan := newPlan()
+		plan.BirthDate = "1996-03-15"
+
+		assert.Error(t, utils.RecalculateRetirementPlan(plan, now))
+	})
+}
+
 func TestCalculateMonthlyExpenses(t *testing.T) {
 	currentYear := time.Now().Year()
 	currentMonth := int(time.Now().Month())
@@ -143,14 +187,14 @@ func TestCalculateMonthlyExpenses(t *testing.T) {
 		asset            *entities.Asset
 		currentYear      int
 		currentMonth     int
-		expectedExpenses float64
+		expectedExpenses entities.Money
 	}{
 		{
 			name: "สถานะ Completed",
 			asset: &entities.Asset{
 				Status:       "Completed",
-				TotalCost:    60000,
-				CurrentMoney: 60000,
+				TotalCost:    entities.Baht(60000),
+				CurrentMoney: entities.Baht(60000),
 			},
 			expectedExpenses: 0,
 		},
@@ -158,18 +202,18 @@ func TestCalculateMonthlyExpenses(t *testing.T) {
 			name: "กำลังดำเนินการปกติ",
 			asset: &entities.Asset{
 				Status:       "In_Progress",
-				TotalCost:    66000,
-				CurrentMoney: 11000,
+				TotalCost:    entities.Baht(66000),
+				CurrentMoney: entities.Baht(11000),
 				EndYear:      "2026",
 			},
-			expectedExpenses: 5000,
+			expectedExpenses: entities.Baht(5000),
 		},
 		{
 			name: "เงินปัจจุบันเท่ากับค่าใช้จ่ายทั้งหมด",
 			asset: &entities.Asset{
 				Status:       "In_Progress",
-				TotalCost:    60000,
-				CurrentMoney: 60000,
+				TotalCost:    entities.Baht(60000),
+				CurrentMoney: entities.Baht(60000),
 				EndYear:      "2026",
 			},
 			expectedExpenses: 0,
@@ -178,8 +222,8 @@ func TestCalculateMonthlyExpenses(t *testing.T) {
 			name: "ปีสิ้นสุดผิดรูปแบบ",
 			asset: &entities.Asset{
 				Status:       "In_Progress",
-				TotalCost:    60000,
-				CurrentMoney: 10000,
+				TotalCost:    entities.Baht(60000),
+				CurrentMoney: entities.Baht(10000),
 				EndYear:      "invalid",
 			},
 			expectedExpenses: 0,
@@ -188,8 +232,8 @@ func TestCalculateMonthlyExpenses(t *testing.T) {
 			name: "ระยะเวลาสั้นกว่า 1 เดือน",
 			asset: &entities.Asset{
 				Status:       "In_Progress",
-				TotalCost:    60000,
-				CurrentMoney: 10000,
+				TotalCost:    entities.Baht(60000),
+				CurrentMoney: entities.Baht(10000),
 				EndYear:      strconv.Itoa(currentYear),
 			},
 			currentYear:      currentYear,
@@ -200,13 +244,13 @@ func TestCalculateMonthlyExpenses(t *testing.T) {
 			name: "ปีแรกเดือนมกรา",
 			asset: &entities.Asset{
 				Status:       "In_Progress",
-				TotalCost:    60000,
-				CurrentMoney: 10000,
+				TotalCost:    entities.Baht(60000),
+				CurrentMoney: entities.Baht(10000),
 				EndYear:      strconv.Itoa(currentYear + 2),
 			},
 			currentYear:      currentYear,
 			currentMonth:     1,
-			expectedExpenses: 2174,
+			expectedExpenses: entities.Baht(2174),
 		},
 	}
 
@@ -214,7 +258,7 @@ func TestCalculateMonthlyExpenses(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			expenses := utils.CalculateMonthlyExpenses(tt.asset, currentYear, currentMonth)
 			assert.Equal(t, tt.expectedExpenses, expenses,
-				"For asset with total cost %.2f and current money %.2f",
+				"For asset with total cost %s and current money %s",
 				tt.asset.TotalCost, tt.asset.CurrentMoney)
 		})
 	}
@@ -224,7 +268,7 @@ func TestCalculateAllAssetsMonthlyExpenses(t *testing.T) {
 	tests := []struct {
 		name             string
 		user             *entities.User
-		expectedExpenses float64
+		expectedExpenses entities.Money
 		expectError      bool
 	}{
 		{
@@ -233,20 +277,20 @@ func TestCalculateAllAssetsMonthlyExpenses(t *testing.T) {
 				Assets: []entities.Asset{
 					{
 						Status:              "In_Progress",
-						TotalCost:           60000,
-						CurrentMoney:        10000,
+						TotalCost:           entities.Baht(60000),
+						CurrentMoney:        entities.Baht(10000),
 						EndYear:             "2026",
 						LastCalculatedMonth: int(time.Now().Month()),
-						MonthlyExpenses:     1042,
+						MonthlyExpenses:     entities.Baht(1042),
 					},
 					{
 						Status:       "Completed",
-						TotalCost:    30000,
-						CurrentMoney: 30000,
+						TotalCost:    entities.Baht(30000),
+						CurrentMoney: entities.Baht(30000),
 					},
 				},
 			},
-			expectedExpenses: 1042,
+			expectedExpenses: entities.Baht(1042),
 			expectError:      false,
 		},
 		{
@@ -255,13 +299,13 @@ func TestCalculateAllAssetsMonthlyExpenses(t *testing.T) {
 				Assets: []entities.Asset{
 					{
 						Status:       "Completed",
-						TotalCost:    60000,
-						CurrentMoney: 60000,
+						TotalCost:    entities.Baht(60000),
+						CurrentMoney: entities.Baht(60000),
 					},
 					{
 						Status:       "Completed",
-						TotalCost:    30000,
-						CurrentMoney: 30000,
+						TotalCost:    entities.Baht(30000),
+						CurrentMoney: entities.Baht(30000),
 					},
 				},
 			},
@@ -296,7 +340,7 @@ func TestCalculateAllAssetSavings(t *testing.T) {
 		name          string
 		user          *entities.User
 		method        string
-		expectedTotal float64
+		expectedTotal entities.Money
 	}{
 		{
 			name: "method All กับ assets หลายสถานะ",
@@ -304,18 +348,18 @@ func TestCalculateAllAssetSavings(t *testing.T) {
 				Assets: []entities.Asset{
 					{
 						Status:       "In_Progress",
-						CurrentMoney: 10000,
-						TotalCost:    20000,
+						CurrentMoney: entities.Baht(10000),
+						TotalCost:    entities.Baht(20000),
 					},
 					{
 						Status:       "Completed",
-						CurrentMoney: 30000,
-						TotalCost:    30000,
+						CurrentMoney: entities.Baht(30000),
+						TotalCost:    entities.Baht(30000),
 					},
 				},
 			},
 			method:        "All",
-			expectedTotal: 40000,
+			expectedTotal: entities.Baht(40000),
 		},
 		{
 			name: "method Plan กับ assets หลายสถานะ",
@@ -323,18 +367,18 @@ func TestCalculateAllAssetSavings(t *testing.T) {
 				Assets: []entities.Asset{
 					{
 						Status:       "In_Progress",
-						CurrentMoney: 10000,
-						TotalCost:    20000,
+						CurrentMoney: entities.Baht(10000),
+						TotalCost:    entities.Baht(20000),
 					},
 					{
 						Status:       "Completed",
-						CurrentMoney: 30000,
-						TotalCost:    30000,
+						CurrentMoney: entities.Baht(30000),
+						TotalCost:    entities.Baht(30000),
 					},
 				},
 			},
 			method:        "Plan",
-			expectedTotal: 40000,
+			expectedTotal: entities.Baht(40000),
 		},
 		{
 			name: "ไม่มี assets",
@@ -0,0 +1,103 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanDebtPayoff(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.Local)
+
+	t.Run("โปะหนี้ก้อนเล็กก่อน (snowball)", func(t *testing.T) {
+		loans := []entities.Loan{
+			{ID: "loan-b", MonthlyExpenses: entities.Baht(500), RemainingMonths: 10},
+			{ID: "loan-a", MonthlyExpenses: entities.Baht(1000), RemainingMonths: 3},
+		}
+
+		plan, err := utils.PlanDebtPayoff(loans, entities.PayoffOptions{ExtraBudget: entities.Baht(500)}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(2000), plan.MonthlyBudget)
+		assert.Equal(t, entities.Baht(8000), plan.Balance)
+		assert.Equal(t, 10, plan.MinimumMonths)
+
+		snowball := plan.Strategies[1]
+		assert.Equal(t, entities.PayoffStrategySnowball, snowball.Strategy)
+		assert.Equal(t, []string{"loan-a", "loan-b"}, snowball.Order)
+		assert.Equal(t, 4, snowball.Months)
+		assert.Equal(t, time.Date(2024, time.May, 15, 0, 0, 0, 0, time.Local), snowball.PayoffDate)
+		assert.Equal(t, entities.Baht(8000), snowball.TotalPayment)
+		assert.Equal(t, 2, snowball.Loans[0].Months)
+		assert.Equal(t, 4, snowball.Loans[1].Months)
+		assert.Equal(t, entities.Baht(1500), snowball.Schedule[0].Payments[0].Payment)
+		assert.Equal(t, entities.Baht(6000), snowball.Schedule[0].Balance)
+	})
+
+	t.Run("โปะหนี้ดอกเบี้ยสูงก่อน (avalanche)", func(t *testing.T) {
+		loans := []entities.Loan{
+			{ID: "legacy", MonthlyExpenses: entities.Baht(1000), RemainingMonths: 5},
+			*amortizingLoan(entities.LoanRateFixed, entities.Baht(100000), 12, 12),
+		}
+		loans[1].MonthlyExpenses = entities.MoneyFromFloat(8884.88)
+
+		plan, err := utils.PlanDebtPayoff(loans, entities.PayoffOptions{ExtraBudget: entities.Baht(3000)}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.MoneyFromFloat(6618.53), plan.MinimumInterest)
+
+		avalanche, snowball := plan.Strategies[0], plan.Strategies[1]
+		assert.Equal(t, []string{"loan-1", "legacy"}, avalanche.Order)
+		assert.Equal(t, []string{"legacy", "loan-1"}, snowball.Order)
+		assert.Less(t, avalanche.TotalInterest, snowball.TotalInterest)
+		assert.Greater(t, avalanche.InterestSaved, entities.Money(0))
+		assert.Equal(t, plan.MinimumInterest-avalanche.TotalInterest, avalanche.InterestSaved)
+		assert.Equal(t, entities.Money(0), avalanche.Schedule[len(avalanche.Schedule)-1].Balance)
+		assert.Equal(t, avalanche.TotalInterest+plan.Balance, avalanche.TotalPayment)
+	})
+
+	t.Run("ลำดับที่ผู้ใช้กำหนด", func(t *testing.T) {
+		loans := []entities.Loan{
+			{ID: "loan-a", MonthlyExpenses: entities.Baht(1000), RemainingMonths: 3},
+			{ID: "loan-b", MonthlyExpenses: entities.Baht(500), RemainingMonths: 10},
+			{ID: "loan-c", MonthlyExpenses: entities.Baht(200), RemainingMonths: 10},
+		}
+
+		plan, err := utils.PlanDebtPayoff(loans, entities.PayoffOptions{Order: []string{"loan-b"}}, now)
+
+		assert.NoError(t, err)
+		assert.Len(t, plan.Strategies, 3)
+		assert.Equal(t, entities.PayoffStrategyCustom, plan.Strategies[2].Strategy)
+		assert.Equal(t, []string{"loan-b", "loan-a", "loan-c"}, plan.Strategies[2].Order)
+	})
+
+	t.Run("ไม่มีลำดับที่ผู้ใช้กำหนด", func(t *testing.T) {
+		plan, err := utils.PlanDebtPayoff([]entities.Loan{{ID: "loan-a", MonthlyExpenses: entities.Baht(1000), RemainingMonths: 3}}, entities.PayoffOptions{}, now)
+
+		assert.NoError(t, err)
+		assert.Len(t, plan.Strategies, 2)
+	})
+
+	tests := []struct {
+		name     string
+		loans    []entities.Loan
+		options  entities.PayoffOptions
+		expected string
+	}{
+		{"งบเพิ่มติดลบ", []entities.Loan{{ID: "loan-a", MonthlyExpenses: entities.Baht(1000), RemainingMonths: 3}}, entities.PayoffOptions{ExtraBudget: -1}, "extra budget must not be negative"},
+		{"ไม่มีหนี้", []entities.Loan{{ID: "loan-a", MonthlyExpenses: entities.Baht(1000)}}, entities.PayoffOptions{}, "no active loans"},
+		{"ลำดับมีสินเชื่อที่ไม่รู้จัก", []entities.Loan{{ID: "loan-a", MonthlyExpenses: entities.Baht(1000), RemainingMonths: 3}}, entities.PayoffOptions{Order: []string{"loan-x"}}, "loan not found"},
+		{"ลำดับซ้ำ", []entities.Loan{{ID: "loan-a", MonthlyExpenses: entities.Baht(1000), RemainingMonths: 3}}, entities.PayoffOptions{Order: []string{"loan-a", "loan-a"}}, "loan is listed more than once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := utils.PlanDebtPayoff(tt.loans, tt.options, now)
+
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
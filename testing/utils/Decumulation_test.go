@@ -0,0 +1,142 @@
+package utils_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func decumulationPlan() (*entities.RetirementPlan, *entities.SelectedHouse) {
+	plan := &entities.RetirementPlan{
+		BirthDate:               "15-01-1980",
+		RetirementAge:           60,
+		ExpectLifespan:          62,
+		ExpectedMonthlyExpenses: entities.Baht(10000),
+		LastRequiredFunds:       entities.Baht(240000),
+	}
+	house := &entities.SelectedHouse{
+		NursingHouse: entities.NursingHouse{Price: entities.Baht(1000)},
+	}
+
+	return plan, house
+}
+
+func TestPlanDecumulation(t *testing.T) {
+	now := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("เงินพอใช้จนถึงอายุขัย", func(t *testing.T) {
+		plan, house := decumulationPlan()
+
+		result, err := utils.PlanDecumulation(plan, house, entities.DecumulationOptions{}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2040, time.January, 1, 0, 0, 0, 0, time.UTC), result.StartMonth)
+		assert.Equal(t, 24, result.Months)
+		assert.Equal(t, entities.Baht(264000), result.StartingBalance)
+		assert.Equal(t, entities.Baht(11000), result.MonthlyNeeds)
+		assert.Equal(t, entities.Baht(1000), result.NursingHouseMonthly)
+		assert.Equal(t, utils.DefaultWithdrawalRate, result.WithdrawalRate)
+		assert.Len(t, result.Strategies, 3)
+
+		fixed := result.Strategies[0]
+		assert.Equal(t, entities.WithdrawalStrategyFixedAmount, fixed.Strategy)
+		assert.Equal(t, entities.Baht(11000), fixed.SustainableMonthlyWithdrawal)
+		assert.True(t, fixed.CoversNeeds)
+		assert.Nil(t, fixed.DepletionYear)
+		assert.Equal(t, entities.Money(0), fixed.EndingBalance)
+		assert.Equal(t, []entities.DecumulationYear{
+			{Year: 2040, Age: 60, StartBalance: entities.Baht(264000), Withdrawal: entities.Baht(132000), Needs: entities.Baht(132000), EndBalance: entities.Baht(132000)},
+			{Year: 2041, Age: 61, StartBalance: entities.Baht(132000), Withdrawal: entities.Baht(132000), Needs: entities.Baht(132000), EndBalance: 0},
+		}, fixed.Years)
+
+		percentage := result.Strategies[1]
+		assert.Equal(t, entities.WithdrawalStrategyFixedPercentage, percentage.Strategy)
+		assert.Equal(t, entities.Baht(880), percentage.FirstMonthWithdrawal)
+		assert.Equal(t, entities.Baht(10140), percentage.Years[1].Withdrawal)
+		assert.False(t, percentage.CoversNeeds)
+		assert.Nil(t, percentage.DepletionYear)
+	})
+
+	t.Run("เงินหมดก่อนอายุขัย", func(t *testing.T) {
+		plan, house := decumulationPlan()
+		plan.LastRequiredFunds = entities.Baht(120000)
+
+		result, err := utils.PlanDecumulation(plan, house, entities.DecumulationOptions{}, now)
+
+		assert.NoError(t, err)
+		fixed := result.Strategies[0]
+		assert.Equal(t, entities.Baht(6000), fixed.SustainableMonthlyWithdrawal)
+		assert.Equal(t, 2041, *fixed.DepletionYear)
+		assert.Equal(t, 61, *fixed.DepletionAge)
+		assert.Equal(t, entities.Baht(144000), fixed.TotalWithdrawn)
+		assert.Equal(t, entities.Baht(120000), fixed.TotalShortfall)
+		assert.False(t, fixed.CoversNeeds)
+	})
+
+	t.Run("ผลตอบแทนและเงินเฟ้อ", func(t *testing.T) {
+		plan, house := decumulationPlan()
+		plan.ExpectedInflation = 3
+		plan.AnnualSavingsReturn = 2
+		plan.AnnualInvestmentReturn = 6
+		plan.CurrentSavings = entities.Baht(100000)
+		plan.CurrentTotalInvestment = entities.Baht(300000)
+
+		result, err := utils.PlanDecumulation(plan, house, entities.DecumulationOptions{}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5.0, result.AnnualReturn)
+		rate := math.Pow(1.05, 1.0/12) - 1
+		annuity := 264000 * rate / (1 - math.Pow(1+rate, -24))
+		assert.InDelta(t, annuity, result.Strategies[0].SustainableMonthlyWithdrawal.Float64(), 1)
+
+		inflationAdjusted := result.Strategies[2]
+		assert.Equal(t, entities.WithdrawalStrategyInflationAdjusted, inflationAdjusted.Strategy)
+		assert.Less(t, inflationAdjusted.SustainableMonthlyWithdrawal, result.Strategies[0].SustainableMonthlyWithdrawal)
+		assert.Equal(t, result.MonthlyNeeds.Mul(12), inflationAdjusted.Years[0].Withdrawal)
+		assert.Equal(t, 2041, *inflationAdjusted.DepletionYear)
+	})
+
+	t.Run("กำหนดผลตอบแทนเอง", func(t *testing.T) {
+		plan, house := decumulationPlan()
+		annualReturn := 0.0
+		plan.AnnualSavingsReturn = 5
+
+		result, err := utils.PlanDecumulation(plan, house, entities.DecumulationOptions{AnnualReturn: &annualReturn, WithdrawalRate: 6}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, result.AnnualReturn)
+		assert.Equal(t, entities.Baht(1320), result.Strategies[1].FirstMonthWithdrawal)
+	})
+
+	t.Run("เกษียณแล้วใช้เงินที่มีอยู่จริง", func(t *testing.T) {
+		plan, house := decumulationPlan()
+		plan.BirthDate = "15-01-1960"
+		plan.ExpectLifespan = 70
+		plan.AnnualExpenseIncrease = 10
+		plan.CurrentSavings = entities.Baht(500000)
+		plan.CurrentTotalInvestment = entities.Baht(200000)
+		house.CurrentMoney = entities.Baht(50000)
+
+		result, err := utils.PlanDecumulation(plan, house, entities.DecumulationOptions{}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), result.StartMonth)
+		assert.Equal(t, 48, result.Months)
+		assert.Equal(t, entities.Baht(750000), result.StartingBalance)
+		assert.Equal(t, entities.Baht(10000).MulRate(math.Pow(1.1, 6)), result.LivingExpenses)
+		assert.Equal(t, 66, result.Strategies[0].Years[0].Age)
+	})
+
+	t.Run("อัตราถอนไม่ถูกต้อง", func(t *testing.T) {
+		plan, house := decumulationPlan()
+
+		result, err := utils.PlanDecumulation(plan, house, entities.DecumulationOptions{WithdrawalRate: 150}, now)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "withdrawal rate must be between 0 and 100")
+	})
+}
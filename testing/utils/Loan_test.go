@@ -0,0 +1,258 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func amortizingLoan(rateType string, principal entities.Money, rate float64, term int) *entities.Loan {
+	return &entities.Loan{
+		ID:              "loan-1",
+		Principal:       principal,
+		Balance:         principal,
+		InterestRate:    rate,
+		RateType:        rateType,
+		TermMonths:      term,
+		RemainingMonths: term,
+		StartDate:       time.Date(2024, time.January, 31, 0, 0, 0, 0, time.Local),
+	}
+}
+
+func TestValidateLoanTerms(t *testing.T) {
+	tests := []struct {
+		name     string
+		loan     *entities.Loan
+		expected string
+	}{
+		{"ไม่มีเงินต้น", amortizingLoan(entities.LoanRateFixed, 0, 5, 12), "principal must be greater than zero"},
+		{"ดอกเบี้ยติดลบ", amortizingLoan(entities.LoanRateFixed, entities.Baht(1000), -1, 12), "interest rate must be between 0 and 100"},
+		{"ไม่มีระยะเวลา", amortizingLoan(entities.LoanRateFixed, entities.Baht(1000), 5, 0), "term months must be greater than zero"},
+		{"ประเภทดอกเบี้ยไม่ถูกต้อง", amortizingLoan("compound", entities.Baht(1000), 5, 12), "invalid rate type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, utils.ValidateLoanTerms(tt.loan), tt.expected)
+		})
+	}
+
+	t.Run("ข้อมูลถูกต้อง", func(t *testing.T) {
+		assert.NoError(t, utils.ValidateLoanTerms(amortizingLoan(entities.LoanRateFloating, entities.Baht(1000), 5, 12)))
+	})
+}
+
+func TestLoanSchedule(t *testing.T) {
+	t.Run("ดอกเบี้ยคงที่แบบลดต้นลดดอก", func(t *testing.T) {
+		schedule, err := utils.LoanSchedule(amortizingLoan(entities.LoanRateFixed, entities.Baht(100000), 12, 12))
+
+		assert.NoError(t, err)
+		assert.Len(t, schedule.Installments, 12)
+		first := schedule.Installments[0]
+		assert.Equal(t, entities.MoneyFromFloat(8884.88), first.Payment)
+		assert.Equal(t, entities.Baht(1000), first.Interest)
+		assert.Equal(t, entities.MoneyFromFloat(92115.12), first.Balance)
+		assert.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.Local), first.DueDate)
+
+		last := schedule.Installments[11]
+		assert.Equal(t, entities.Money(0), last.Balance)
+		assert.Equal(t, entities.MoneyFromFloat(8884.85), last.Payment)
+		assert.Equal(t, entities.MoneyFromFloat(6618.53), schedule.TotalInterest)
+		assert.Equal(t, schedule.TotalInterest+entities.Baht(100000), schedule.TotalPayment)
+		assert.Equal(t, time.Date(2025, time.January, 31, 0, 0, 0, 0, time.Local), schedule.PayoffDate)
+	})
+
+	t.Run("ดอกเบี้ยแบบเงินต้นคงที่ (flat rate)", func(t *testing.T) {
+		schedule, err := utils.LoanSchedule(amortizingLoan(entities.LoanRateFlat, entities.Baht(300000), 3, 48))
+
+		assert.NoError(t, err)
+		for _, installment := range schedule.Installments {
+			assert.Equal(t, entities.Baht(7000), installment.Payment)
+			assert.Equal(t, entities.Baht(750), installment.Interest)
+		}
+
+		assert.Equal(t, entities.Baht(36000), schedule.TotalInterest)
+	})
+
+	t.Run("ไม่มีดอกเบี้ย", func(t *testing.T) {
+		schedule, err := utils.LoanSchedule(amortizingLoan(entities.LoanRateFixed, entities.Baht(10000), 0, 3))
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.MoneyFromFloat(3333.33), schedule.Installments[0].Payment)
+		assert.Equal(t, entities.MoneyFromFloat(3333.34), schedule.Installments[2].Payment)
+		assert.Equal(t, entities.Baht(10000), schedule.TotalPayment)
+	})
+
+	t.Run("งวดที่เหลือจากยอดคงค้าง", func(t *testing.T) {
+		loan := amortizingLoan(entities.LoanRateFixed, entities.Baht(100000), 12, 12)
+		loan.Balance = entities.MoneyFromFloat(92115.12)
+		loan.RemainingMonths = 11
+
+		schedule, err := utils.LoanSchedule(loan)
+
+		assert.NoError(t, err)
+		assert.Len(t, schedule.Installments, 11)
+		assert.Equal(t, 2, schedule.Installments[0].Number)
+		assert.Equal(t, entities.MoneyFromFloat(921.15), schedule.Installments[0].Interest)
+	})
+
+	t.Run("ไม่มีเงินต้น", func(t *testing.T) {
+		_, err := utils.LoanSchedule(&entities.Loan{MonthlyExpenses: entities.Baht(1000), RemainingMonths: 5})
+
+		assert.EqualError(t, err, "loan has no principal")
+	})
+}
+
+func TestOpenLoan(t *testing.T) {
+	t.Run("เริ่มสัญญาย้อนหลัง", func(t *testing.T) {
+		loan := amortizingLoan(entities.LoanRateFixed, entities.Baht(100000), 12, 12)
+
+		err := utils.OpenLoan(loan, time.Date(2024, time.March, 15, 0, 0, 0, 0, time.Local))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 11, loan.RemainingMonths)
+		assert.Equal(t, entities.MoneyFromFloat(92115.12), loan.Balance)
+		assert.Equal(t, entities.MoneyFromFloat(8884.88), loan.MonthlyExpenses)
+	})
+
+	t.Run("ไม่ระบุวันเริ่มสัญญา", func(t *testing.T) {
+		now := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.Local)
+		loan := amortizingLoan(entities.LoanRateFlat, entities.Baht(300000), 3, 48)
+		loan.StartDate = time.Time{}
+
+		err := utils.OpenLoan(loan, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, now, loan.StartDate)
+		assert.Equal(t, 48, loan.RemainingMonths)
+		assert.Equal(t, entities.Baht(300000), loan.Balance)
+	})
+
+	t.Run("สัญญาสิ้นสุดแล้ว", func(t *testing.T) {
+		loan := amortizingLoan(entities.LoanRateFixed, entities.Baht(100000), 12, 12)
+
+		err := utils.OpenLoan(loan, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.Local))
+
+		assert.EqualError(t, err, "loan term has already ended")
+	})
+}
+
+func TestNextInstallment(t *testing.T) {
+	t.Run("งวดถัดจากงวดที่ค้างชำระ", func(t *testing.T) {
+		installment := utils.NextInstallment(amortizingLoan(entities.LoanRateFixed, entities.Baht(100000), 12, 12), 1)
+
+		assert.Equal(t, 2, installment.Number)
+		assert.Equal(t, entities.MoneyFromFloat(921.15), installment.Interest)
+	})
+
+	t.Run("สินเชื่อที่ไม่มีเงินต้น", func(t *testing.T) {
+		installment := utils.NextInstallment(&entities.Loan{MonthlyExpenses: entities.Baht(2500), RemainingMonths: 5}, 0)
+
+		assert.Equal(t, 0, installment.Number)
+		assert.Equal(t, entities.Baht(2500), installment.Payment)
+	})
+}
+
+func TestLoanOutstanding(t *testing.T) {
+	loan := amortizingLoan(entities.LoanRateFixed, entities.Baht(100000), 12, 12)
+	loan.Balance = entities.Baht(40000)
+
+	assert.Equal(t, entities.Baht(40000), utils.LoanOutstanding(loan))
+	assert.Equal(t, entities.Baht(5000), utils.LoanOutstanding(&entities.Loan{MonthlyExpenses: entities.Baht(1000), RemainingMonths: 5}))
+}
+
+func TestApplyPrepayment(t *testing.T) {
+	newLoan := func() *entities.Loan {
+		loan := amortizingLoan(entities.LoanRateFixed, entities.Baht(100000), 12, 12)
+		loan.MonthlyExpenses = entities.MoneyFromFloat(8884.88)
+		return loan
+	}
+
+	t.Run("ลดระยะเวลาผ่อน", func(t *testing.T) {
+		loan := newLoan()
+
+		err := utils.ApplyPrepayment(loan, entities.Prepayment{Amount: entities.Baht(20000), Mode: entities.PrepaymentShortenTerm})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(80000), loan.Balance)
+		assert.Equal(t, 10, loan.TermMonths)
+		assert.Equal(t, 10, loan.RemainingMonths)
+		assert.Equal(t, entities.MoneyFromFloat(8446.57), loan.MonthlyExpenses)
+
+		schedule, _ := utils.LoanSchedule(loan)
+		assert.Equal(t, 1, schedule.Installments[0].Number)
+		assert.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.Local), schedule.Installments[0].DueDate)
+	})
+
+	t.Run("ลดค่างวด", func(t *testing.T) {
+		loan := newLoan()
+
+		err := utils.ApplyPrepayment(loan, entities.Prepayment{Amount: entities.Baht(20000), Mode: entities.PrepaymentReduceInstallment})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 12, loan.RemainingMonths)
+		assert.Equal(t, entities.MoneyFromFloat(7107.90), loan.MonthlyExpenses)
+	})
+
+	t.Run("ปิดยอดทั้งหมด", func(t *testing.T) {
+		loan := newLoan()
+
+		err := utils.ApplyPrepayment(loan, entities.Prepayment{Amount: entities.Baht(100000), Mode: entities.PrepaymentShortenTerm})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Money(0), loan.Balance)
+		assert.Equal(t, 0, loan.RemainingMonths)
+	})
+
+	tests := []struct {
+		name       string
+		loan       *entities.Loan
+		prepayment entities.Prepayment
+		expected   string
+	}{
+		{"ไม่มีเงินต้น", &entities.Loan{MonthlyExpenses: entities.Baht(1000), RemainingMonths: 5}, entities.Prepayment{Amount: entities.Baht(100), Mode: entities.PrepaymentShortenTerm}, "loan has no principal"},
+		{"จำนวนเงินเป็นศูนย์", newLoan(), entities.Prepayment{Mode: entities.PrepaymentShortenTerm}, "prepayment must be greater than zero"},
+		{"เกินยอดคงค้าง", newLoan(), entities.Prepayment{Amount: entities.Baht(100001), Mode: entities.PrepaymentShortenTerm}, "prepayment exceeds loan balance"},
+		{"รูปแบบไม่ถูกต้อง", newLoan(), entities.Prepayment{Amount: entities.Baht(100), Mode: "skip"}, "invalid prepayment mode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, utils.ApplyPrepayment(tt.loan, tt.prepayment), tt.expected)
+		})
+	}
+}
+
+func TestChangeLoanRate(t *testing.T) {
+	t.Run("ลดอัตราดอกเบี้ยลอยตัว", func(t *testing.T) {
+		loan := amortizingLoan(entities.LoanRateFloating, entities.Baht(100000), 12, 12)
+
+		err := utils.ChangeLoanRate(loan, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, loan.InterestRate)
+		assert.Equal(t, 12, loan.RemainingMonths)
+		assert.Equal(t, entities.Baht(100000), loan.Balance)
+		assert.Equal(t, entities.MoneyFromFloat(8333.33), loan.MonthlyExpenses)
+	})
+
+	tests := []struct {
+		name     string
+		loan     *entities.Loan
+		rate     float64
+		expected string
+	}{
+		{"อัตราคงที่", amortizingLoan(entities.LoanRateFixed, entities.Baht(100000), 12, 12), 6, "loan rate is not floating"},
+		{"อัตราติดลบ", amortizingLoan(entities.LoanRateFloating, entities.Baht(100000), 12, 12), -1, "interest rate must be between 0 and 100"},
+		{"ไม่มีเงินต้น", &entities.Loan{RateType: entities.LoanRateFloating, MonthlyExpenses: entities.Baht(1000), RemainingMonths: 5}, 6, "loan has no principal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, utils.ChangeLoanRate(tt.loan, tt.rate), tt.expected)
+		})
+	}
+}
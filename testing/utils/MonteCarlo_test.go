@@ -0,0 +1,147 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func simulationPlan() *entities.RetirementPlan {
+	return &entities.RetirementPlan{
+		BirthDate:               "15-01-1980",
+		RetirementAge:           60,
+		ExpectLifespan:          80,
+		CurrentSavings:          entities.Baht(200000),
+		CurrentSavingsReturns:   1.5,
+		CurrentTotalInvestment:  entities.Baht(300000),
+		ExpectedInflation:       3,
+		ExpectedMonthlyExpenses: entities.Baht(20000),
+		AnnualExpenseIncrease:   3,
+		AnnualSavingsReturn:     1.5,
+		LastMonthlyExpenses:     entities.Baht(5000),
+	}
+}
+
+func simulationOptions(paths int, seed uint64) entities.MonteCarloOptions {
+	return entities.MonteCarloOptions{Paths: paths, TargetConfidence: 0.8, Seed: &seed}
+}
+
+func TestSimulateRetirement(t *testing.T) {
+	now := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("seed เดิมได้ผลลัพธ์เดิม", func(t *testing.T) {
+		first, err := utils.SimulateRetirement(simulationPlan(), 3, simulationOptions(200, 42), now)
+		assert.NoError(t, err)
+
+		second, err := utils.SimulateRetirement(simulationPlan(), 3, simulationOptions(200, 42), now)
+		assert.NoError(t, err)
+		assert.Equal(t, first, second)
+
+		other, err := utils.SimulateRetirement(simulationPlan(), 3, simulationOptions(200, 7), now)
+		assert.NoError(t, err)
+		assert.NotEqual(t, first.Bands, other.Bands)
+	})
+
+	t.Run("ช่วงเปอร์เซ็นไทล์", func(t *testing.T) {
+		result, err := utils.SimulateRetirement(simulationPlan(), 3, simulationOptions(200, 42), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(42), result.Seed)
+		assert.Len(t, result.Bands, 35)
+		assert.Equal(t, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), result.Bands[0].Month)
+		assert.Equal(t, 46, result.Bands[0].Age)
+		assert.Equal(t, time.Date(2059, time.December, 1, 0, 0, 0, 0, time.UTC), result.Bands[len(result.Bands)-1].Month)
+		for _, band := range result.Bands {
+			assert.LessOrEqual(t, band.P10, band.P25)
+			assert.LessOrEqual(t, band.P25, band.P50)
+			assert.LessOrEqual(t, band.P50, band.P75)
+			assert.LessOrEqual(t, band.P75, band.P90)
+		}
+	})
+
+	t.Run("เงินออมที่ต้องการถึงระดับความมั่นใจ", func(t *testing.T) {
+		result, err := utils.SimulateRetirement(simulationPlan(), 3, simulationOptions(200, 42), now)
+
+		assert.NoError(t, err)
+		assert.Less(t, result.SuccessProbability, 0.8)
+		assert.NotNil(t, result.RequiredMonthlySaving)
+		assert.Equal(t, entities.Money(0), *result.RequiredMonthlySaving%entities.OneBaht)
+
+		plan := simulationPlan()
+		plan.LastMonthlyExpenses = *result.RequiredMonthlySaving
+		enough, err := utils.SimulateRetirement(plan, 3, simulationOptions(200, 42), now)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, enough.SuccessProbability, 0.8)
+
+		plan.LastMonthlyExpenses -= entities.OneBaht
+		short, err := utils.SimulateRetirement(plan, 3, simulationOptions(200, 42), now)
+		assert.NoError(t, err)
+		assert.Less(t, short.SuccessProbability, 0.8)
+	})
+
+	t.Run("เงินพอแล้วไม่ต้องออมเพิ่ม", func(t *testing.T) {
+		plan := simulationPlan()
+		plan.CurrentSavings = entities.Baht(100000000)
+		result, err := utils.SimulateRetirement(plan, 3, simulationOptions(100, 42), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, result.SuccessProbability)
+		assert.Equal(t, entities.Money(0), *result.RequiredMonthlySaving)
+	})
+
+	t.Run("ความเสี่ยงสูงกระจายตัวมากกว่า", func(t *testing.T) {
+		low, err := utils.SimulateRetirement(simulationPlan(), 1, simulationOptions(200, 42), now)
+		assert.NoError(t, err)
+
+		high, err := utils.SimulateRetirement(simulationPlan(), 5, simulationOptions(200, 42), now)
+		assert.NoError(t, err)
+
+		lowBand, highBand := low.Bands[20], high.Bands[20]
+		assert.Greater(t, highBand.P90-highBand.P10, lowBand.P90-lowBand.P10)
+		assert.Equal(t, 2.5, low.ReturnMean)
+		assert.Equal(t, 18.0, high.ReturnStdDev)
+	})
+
+	t.Run("ไม่มีระดับความเสี่ยงใช้ค่าเริ่มต้น", func(t *testing.T) {
+		result, err := utils.SimulateRetirement(simulationPlan(), 0, simulationOptions(10, 42), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, utils.DefaultRiskLevel, result.RiskLevel)
+	})
+
+	t.Run("ค่าเริ่มต้นของจำนวนรอบและความมั่นใจ", func(t *testing.T) {
+		result, err := utils.SimulateRetirement(simulationPlan(), 3, entities.MonteCarloOptions{}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, utils.DefaultSimulationPaths, result.Paths)
+		assert.Equal(t, utils.DefaultTargetConfidence, result.TargetConfidence)
+	})
+
+	t.Run("เกษียณแล้วไม่มีเงินออมที่ต้องการ", func(t *testing.T) {
+		plan := simulationPlan()
+		plan.BirthDate = "15-01-1960"
+		result, err := utils.SimulateRetirement(plan, 3, simulationOptions(10, 42), now)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.RequiredMonthlySaving)
+	})
+
+	t.Run("จำนวนรอบไม่ถูกต้อง", func(t *testing.T) {
+		result, err := utils.SimulateRetirement(simulationPlan(), 3, simulationOptions(utils.MaxSimulationPaths+1, 42), now)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "paths must be between 1 and 10000")
+	})
+
+	t.Run("ระดับความมั่นใจไม่ถูกต้อง", func(t *testing.T) {
+		options := simulationOptions(10, 42)
+		options.TargetConfidence = 1
+		result, err := utils.SimulateRetirement(simulationPlan(), 3, options, now)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "target confidence must be between 0 and 1")
+	})
+}
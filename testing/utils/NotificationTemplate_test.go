@@ -0,0 +1,76 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeedNotificationMessages(t *testing.T) {
+	utils.SeedNotificationMessages(42)
+	first := []*entities.Notification{
+		utils.SuccessNotification("asset", "user1", "Car", "asset1", entities.Baht(1000)),
+		utils.SuccessNotification("loan", "user1", "Home", "loan1", 0),
+		utils.AlertNoti("loan", "user1", "Home", "loan1", entities.Baht(500)),
+	}
+
+	utils.SeedNotificationMessages(42)
+	second := []*entities.Notification{
+		utils.SuccessNotification("asset", "user1", "Car", "asset1", entities.Baht(1000)),
+		utils.SuccessNotification("loan", "user1", "Home", "loan1", 0),
+		utils.AlertNoti("loan", "user1", "Home", "loan1", entities.Baht(500)),
+	}
+
+	for i := range first {
+		assert.Equal(t, first[i].TemplateVariant, second[i].TemplateVariant)
+		assert.Equal(t, first[i].Message, second[i].Message)
+	}
+}
+
+func TestSuccessNotification(t *testing.T) {
+	utils.SeedNotificationMessages(1)
+	notification := utils.SuccessNotification("asset", "user1", "Car", "asset1", entities.Baht(1000))
+
+	assert.Equal(t, "asset.success", notification.TemplateKey)
+	assert.Equal(t, entities.NotificationParams{"name": "Car"}, notification.TemplateParams)
+	assert.Equal(t, utils.RenderNotification("asset.success", notification.TemplateVariant, notification.TemplateParams, "th"), notification.Message)
+	assert.Contains(t, notification.Message, "Car")
+	assert.Equal(t, entities.Baht(1000), notification.Balance)
+
+	assert.Nil(t, utils.SuccessNotification("unknown", "user1", "Car", "asset1", 0))
+}
+
+func TestAlertNoti(t *testing.T) {
+	notification := utils.AlertNoti("asset", "user1", "Car", "asset1", entities.Baht(1000))
+
+	assert.Equal(t, "asset.alert", notification.TemplateKey)
+	assert.Equal(t, entities.Money(0), notification.Balance)
+	assert.Nil(t, utils.AlertNoti("house", "user1", "", "house1", 0))
+}
+
+func TestRenderNotification(t *testing.T) {
+	params := map[string]string{"name": "Car loan"}
+
+	assert.Equal(t, "🎉 Debt free! You have fully paid off Car loan. Financial freedom is near!", utils.RenderNotification("loan.success", 0, params, "en"))
+	assert.Equal(t, "🎉 หมดหนี้! คุณชำระCar loanครบถ้วนแล้ว อิสรภาพทางการเงินใกล้เข้ามา!", utils.RenderNotification("loan.success", 0, params, "jp"))
+	assert.Equal(t, utils.RenderNotification("loan.success", 0, params, "en"), utils.RenderNotification("loan.success", 3, params, "en"))
+	assert.Empty(t, utils.RenderNotification("unknown", 0, params, "en"))
+}
+
+func TestLocalizeNotification(t *testing.T) {
+	notification := &entities.Notification{Message: "legacy"}
+	utils.LocalizeNotification(notification, "en")
+	assert.Equal(t, "legacy", notification.Message)
+
+	notification = utils.SuccessNotification("retirementplan", "user1", "Plan A", "plan1", 0)
+	utils.LocalizeNotification(notification, "en")
+	assert.Contains(t, notification.Message, "Retirement plan Plan A")
+}
+
+func TestIsSupportedLocale(t *testing.T) {
+	assert.True(t, utils.IsSupportedLocale("th"))
+	assert.True(t, utils.IsSupportedLocale("en"))
+	assert.False(t, utils.IsSupportedLocale("jp"))
+}
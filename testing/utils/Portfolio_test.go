@@ -0,0 +1,180 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func portfolioHoldings() []entities.Holding {
+	return []entities.Holding{
+		{ID: "h1", Name: "PTT", Kind: entities.HoldingKindStock, AssetClass: entities.AssetClassEquity, Quantity: 1000, Price: 35, CostBasis: entities.Baht(30000)},
+		{ID: "h2", Name: "K-SET50", Kind: entities.HoldingKindFund, AssetClass: entities.AssetClassEquity, Quantity: 2000, Price: 12.5, CostBasis: entities.Baht(25000)},
+		{ID: "h3", Name: "LB29", Kind: entities.HoldingKindBond, AssetClass: entities.AssetClassFixedIncome, Quantity: 30, Price: 1000, CostBasis: entities.Baht(30000)},
+		{ID: "h4", Name: "ออมทรัพย์", Kind: entities.HoldingKindDeposit, AssetClass: entities.AssetClassCash, Quantity: 10000, Price: 1, CostBasis: entities.Baht(10000)},
+	}
+}
+
+func TestTargetAllocation(t *testing.T) {
+	t.Run("ระดับความเสี่ยงที่รองรับ", func(t *testing.T) {
+		for risk := 1; risk <= 5; risk++ {
+			targets, err := utils.TargetAllocation(risk)
+
+			assert.NoError(t, err)
+			var total float64
+			for _, target := range targets {
+				total += target.Percent
+			}
+
+			assert.Equal(t, 100.0, total)
+		}
+	})
+
+	t.Run("ระดับความเสี่ยงไม่ถูกต้อง", func(t *testing.T) {
+		_, err := utils.TargetAllocation(6)
+
+		assert.EqualError(t, err, "invalid risk level")
+	})
+}
+
+func TestHoldingAssetClass(t *testing.T) {
+	t.Run("ใช้ประเภทสินทรัพย์ตามชนิด", func(t *testing.T) {
+		class, err := utils.HoldingAssetClass(entities.HoldingKindBond, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.AssetClassFixedIncome, class)
+	})
+
+	t.Run("กองทุนต้องระบุประเภทสินทรัพย์", func(t *testing.T) {
+		_, err := utils.HoldingAssetClass(entities.HoldingKindFund, "")
+
+		assert.EqualError(t, err, "asset class is required for funds")
+	})
+
+	t.Run("ชนิดไม่ถูกต้อง", func(t *testing.T) {
+		_, err := utils.HoldingAssetClass("crypto", "")
+
+		assert.EqualError(t, err, "invalid holding kind")
+	})
+
+	t.Run("ประเภทสินทรัพย์ไม่ถูกต้อง", func(t *testing.T) {
+		_, err := utils.HoldingAssetClass(entities.HoldingKindFund, "gold")
+
+		assert.EqualError(t, err, "invalid asset class")
+	})
+}
+
+func TestSummarizePortfolio(t *testing.T) {
+	t.Run("เบี่ยงจากเป้าหมาย", func(t *testing.T) {
+		summary := utils.SummarizePortfolio(portfolioHoldings(), &entities.Risk{ID: 3}, entities.Baht(90000))
+
+		assert.Equal(t, entities.Baht(100000), summary.MarketValue)
+		assert.Equal(t, entities.Baht(95000), summary.CostBasis)
+		assert.Equal(t, entities.Baht(5000), summary.Gain)
+		assert.Equal(t, 5.26, summary.GainPercent)
+		assert.Equal(t, entities.Baht(5000), summary.Holdings[0].Gain)
+		assert.Equal(t, entities.Baht(90000), summary.PlanInvestment)
+
+		assert.Len(t, summary.Allocation, 3)
+		assert.Equal(t, 60.0, summary.Allocation[0].Percent)
+		assert.Equal(t, 50.0, summary.Allocation[0].Target)
+		assert.Equal(t, 10.0, summary.Allocation[0].Drift)
+		assert.True(t, summary.Allocation[0].Alert)
+		assert.Equal(t, -10.0, summary.Allocation[1].Drift)
+		assert.False(t, summary.Allocation[2].Alert)
+		assert.True(t, summary.Drifted)
+	})
+
+	t.Run("อยู่ในเกณฑ์", func(t *testing.T) {
+		summary := utils.SummarizePortfolio(portfolioHoldings(), &entities.Risk{ID: 4}, 0)
+
+		assert.Equal(t, -10.0, summary.Allocation[0].Drift)
+		assert.Equal(t, 5.0, summary.Allocation[1].Drift)
+		assert.False(t, summary.Allocation[1].Alert)
+		assert.True(t, summary.Drifted)
+
+		holdings := portfolioHoldings()
+		holdings[0].Quantity = 1500
+		summary = utils.SummarizePortfolio(holdings, &entities.Risk{ID: 4}, 0)
+
+		assert.False(t, summary.Drifted)
+	})
+
+	t.Run("ยังไม่ได้ทำแบบทดสอบ", func(t *testing.T) {
+		summary := utils.SummarizePortfolio(portfolioHoldings(), nil, 0)
+
+		assert.Nil(t, summary.Risk)
+		assert.Equal(t, 0.0, summary.Allocation[0].Target)
+		assert.Equal(t, 0.0, summary.Allocation[0].Drift)
+		assert.False(t, summary.Drifted)
+	})
+
+	t.Run("ไม่มีการลงทุน", func(t *testing.T) {
+		summary := utils.SummarizePortfolio(nil, &entities.Risk{ID: 3}, 0)
+
+		assert.Empty(t, summary.Holdings)
+		assert.Equal(t, entities.Money(0), summary.MarketValue)
+		assert.False(t, summary.Drifted)
+	})
+}
+
+func TestParsePriceCSV(t *testing.T) {
+	t.Run("อ่านราคาพร้อมวันที่", func(t *testing.T) {
+		quotes, err := utils.ParsePriceCSV(strings.NewReader("\ufeffDate,Symbol,Price\n2024-05-01, k-set50 ,\"1,012.50\"\n,PTT,34.25\n"))
+
+		assert.NoError(t, err)
+		assert.Len(t, quotes, 2)
+		assert.Equal(t, "K-SET50", quotes[0].Symbol)
+		assert.Equal(t, 1012.5, quotes[0].Price)
+		assert.Equal(t, time.Date(2024, time.May, 1, 0, 0, 0, 0, time.Local), quotes[0].PricedAt)
+		assert.True(t, quotes[1].PricedAt.IsZero())
+	})
+
+	t.Run("ไม่มีคอลัมน์ราคา", func(t *testing.T) {
+		_, err := utils.ParsePriceCSV(strings.NewReader("symbol,nav\nPTT,34\n"))
+
+		assert.EqualError(t, err, "price file must have symbol and price columns")
+	})
+
+	t.Run("ราคาไม่ถูกต้อง", func(t *testing.T) {
+		_, err := utils.ParsePriceCSV(strings.NewReader("symbol,price\nPTT,34\nSCB,0\n"))
+
+		assert.EqualError(t, err, "line 3: price must be greater than zero")
+	})
+
+	t.Run("วันที่ไม่ถูกต้อง", func(t *testing.T) {
+		_, err := utils.ParsePriceCSV(strings.NewReader("symbol,price,date\nPTT,34,01/05/2024\n"))
+
+		assert.EqualError(t, err, "line 2: invalid date")
+	})
+
+	t.Run("ไฟล์ว่าง", func(t *testing.T) {
+		_, err := utils.ParsePriceCSV(strings.NewReader("symbol,price\n"))
+
+		assert.EqualError(t, err, "price file is empty")
+	})
+}
+
+func TestValuationChangeByMonth(t *testing.T) {
+	t.Run("เปลี่ยนแปลงรายเดือน", func(t *testing.T) {
+		changes := utils.ValuationChangeByMonth([]entities.PortfolioSnapshot{
+			{Date: time.Date(2024, time.May, 30, 0, 0, 0, 0, time.Local), MarketValue: entities.Baht(100000)},
+			{Date: time.Date(2024, time.May, 31, 0, 0, 0, 0, time.Local), MarketValue: entities.Baht(102000)},
+			{Date: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.Local), MarketValue: entities.Baht(101000)},
+			{Date: time.Date(2024, time.June, 30, 0, 0, 0, 0, time.Local), MarketValue: entities.Baht(99500)},
+		})
+
+		assert.Equal(t, map[string]entities.Money{
+			"2024-05": entities.Baht(102000),
+			"2024-06": entities.Baht(-2500),
+		}, changes)
+	})
+
+	t.Run("ไม่มีข้อมูล", func(t *testing.T) {
+		assert.Empty(t, utils.ValuationChangeByMonth(nil))
+	})
+}
@@ -0,0 +1,160 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func projectionPlan() *entities.RetirementPlan {
+	return &entities.RetirementPlan{
+		BirthDate:               "15-01-1970",
+		RetirementAge:           57,
+		ExpectLifespan:          58,
+		ExpectedMonthlyExpenses: entities.Baht(10000),
+		LastMonthlyExpenses:     entities.Baht(10000),
+	}
+}
+
+func TestProjectRetirement(t *testing.T) {
+	now := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("เงินพอใช้จนถึงอายุขัย", func(t *testing.T) {
+		projection, err := utils.ProjectRetirement(projectionPlan(), nil, now)
+
+		assert.NoError(t, err)
+		assert.Len(t, projection.Points, 24)
+		assert.Equal(t, time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC), projection.RetirementMonth)
+		assert.Equal(t, entities.Baht(120000), projection.BalanceAtRetirement)
+		assert.Equal(t, entities.Baht(120000), projection.RequiredAtRetirement)
+		assert.Equal(t, entities.Money(0), projection.ShortfallAtRetirement)
+		assert.Nil(t, projection.DepletionMonth)
+
+		last := projection.Points[11]
+		assert.Equal(t, entities.ProjectionPhaseAccumulation, last.Phase)
+		assert.Equal(t, 56, last.Age)
+		assert.Equal(t, entities.Baht(10000), last.Contribution)
+
+		first := projection.Points[12]
+		assert.Equal(t, entities.ProjectionPhaseRetirement, first.Phase)
+		assert.Equal(t, 57, first.Age)
+		assert.Equal(t, entities.Baht(10000), first.Expense)
+		assert.Equal(t, entities.Baht(110000), first.Balance)
+		assert.Equal(t, entities.Baht(110000), first.RequiredFunds)
+		assert.Equal(t, entities.Money(0), first.Shortfall)
+		assert.Equal(t, entities.Money(0), projection.Points[23].Balance)
+	})
+
+	t.Run("เงินหมดก่อนอายุขัย", func(t *testing.T) {
+		plan := projectionPlan()
+		plan.LastMonthlyExpenses = entities.Baht(5000)
+
+		projection, err := utils.ProjectRetirement(plan, nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(60000), projection.ShortfallAtRetirement)
+		assert.Equal(t, time.Date(2027, time.July, 1, 0, 0, 0, 0, time.UTC), *projection.DepletionMonth)
+		assert.Equal(t, entities.Baht(60000), projection.Points[12].Shortfall)
+	})
+
+	t.Run("ผลตอบแทนการลงทุน", func(t *testing.T) {
+		plan := projectionPlan()
+		plan.LastMonthlyExpenses = 0
+		plan.CurrentSavings = entities.Baht(50000)
+		plan.CurrentSavingsReturns = 0
+		plan.CurrentTotalInvestment = entities.Baht(100000)
+		plan.InvestmentReturn = 12
+
+		projection, err := utils.ProjectRetirement(plan, nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(50000), projection.Points[11].Savings)
+		assert.InDelta(t, int64(entities.Baht(112000)), int64(projection.Points[11].Investment), float64(entities.OneBaht))
+	})
+
+	t.Run("เงินเฟ้อและค่าใช้จ่ายเพิ่มรายปี", func(t *testing.T) {
+		plan := projectionPlan()
+		plan.ExpectLifespan = 59
+		plan.ExpectedInflation = 10
+		plan.AnnualExpenseIncrease = 5
+
+		projection, err := utils.ProjectRetirement(plan, nil, now)
+
+		assert.NoError(t, err)
+		assert.Len(t, projection.Points, 36)
+		assert.Equal(t, entities.Baht(11000), projection.Points[12].Expense)
+		assert.Equal(t, entities.Baht(11000), projection.Points[23].Expense)
+		assert.Equal(t, entities.Baht(11550), projection.Points[24].Expense)
+		assert.Equal(t, entities.Baht(11000*12+11550*12), projection.RequiredAtRetirement)
+	})
+
+	t.Run("เกษียณแล้ว", func(t *testing.T) {
+		plan := projectionPlan()
+		plan.RetirementAge = 55
+		plan.CurrentSavings = entities.Baht(30000)
+
+		projection, err := utils.ProjectRetirement(plan, nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.ProjectionPhaseRetirement, projection.Points[0].Phase)
+		assert.Equal(t, entities.Baht(30000), projection.BalanceAtRetirement)
+		assert.Equal(t, entities.Baht(240000), projection.RequiredAtRetirement)
+	})
+
+	t.Run("เงินก้อนจากกองทุนช่วยลดเงินที่ต้องเตรียม", func(t *testing.T) {
+		plan := projectionPlan()
+		plan.LastMonthlyExpenses = entities.Baht(5000)
+		vehicles := []entities.VehicleProjection{
+			{VehicleID: "vehicle-1", Type: entities.VehicleTypeProvidentFund, PayoutMonth: time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC), ProjectedBalance: entities.Baht(60000)},
+		}
+
+		projection, err := utils.ProjectRetirement(plan, vehicles, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, vehicles, projection.Vehicles)
+		assert.Equal(t, entities.Baht(60000), projection.BalanceAtRetirement)
+		assert.Equal(t, entities.Baht(60000), projection.RequiredAtRetirement)
+		assert.Equal(t, entities.Money(0), projection.ShortfallAtRetirement)
+		assert.Equal(t, entities.Baht(60000), projection.Points[11].RequiredFunds)
+		assert.Equal(t, entities.Baht(60000), projection.Points[12].VehicleInflow)
+		assert.Equal(t, entities.Baht(110000), projection.Points[12].Balance)
+		assert.Nil(t, projection.DepletionMonth)
+	})
+
+	t.Run("เงินบำนาญประกันสังคม", func(t *testing.T) {
+		plan := projectionPlan()
+		plan.LastMonthlyExpenses = 0
+		vehicles := []entities.VehicleProjection{
+			{Type: entities.VehicleTypeSocialSecurity, PayoutMonth: time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC), MonthlyPension: entities.Baht(10000)},
+		}
+
+		projection, err := utils.ProjectRetirement(plan, vehicles, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Money(0), projection.RequiredAtRetirement)
+		assert.Equal(t, entities.Money(0), projection.Points[0].RequiredFunds)
+		assert.Equal(t, entities.Baht(10000), projection.Points[23].VehicleInflow)
+		assert.Equal(t, entities.Money(0), projection.Points[23].Balance)
+		assert.Nil(t, projection.DepletionMonth)
+	})
+
+	t.Run("วันเกิดไม่ถูกต้อง", func(t *testing.T) {
+		plan := projectionPlan()
+		plan.BirthDate = "1970-01-15"
+
+		_, err := utils.ProjectRetirement(plan, nil, now)
+		assert.EqualError(t, err, "invalid BirthDate format, expected DD-MM-YYYY")
+	})
+
+	t.Run("เลยอายุขัยแล้ว", func(t *testing.T) {
+		plan := projectionPlan()
+		plan.RetirementAge = 50
+		plan.ExpectLifespan = 55
+
+		_, err := utils.ProjectRetirement(plan, nil, now)
+		assert.EqualError(t, err, "expected lifespan has already been reached")
+	})
+}
@@ -0,0 +1,37 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInQuietHours(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name     string
+		start    string
+		end      string
+		now      time.Time
+		expected bool
+	}{
+		{name: "disabled", start: "", end: "", now: at(23, 0), expected: false},
+		{name: "same day inside", start: "12:00", end: "13:00", now: at(12, 30), expected: true},
+		{name: "same day end is exclusive", start: "12:00", end: "13:00", now: at(13, 0), expected: false},
+		{name: "overnight before midnight", start: "22:00", end: "07:00", now: at(23, 15), expected: true},
+		{name: "overnight after midnight", start: "22:00", end: "07:00", now: at(6, 59), expected: true},
+		{name: "overnight outside", start: "22:00", end: "07:00", now: at(12, 0), expected: false},
+		{name: "invalid format", start: "25:00", end: "07:00", now: at(23, 0), expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, utils.InQuietHours(tc.start, tc.end, tc.now))
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func readinessUser() *entities.User {
+	return &entities.User{
+		RetirementPlan: entities.RetirementPlan{
+			ID:                     "plan-1",
+			PlanName:               "เกษียณ 60",
+			BirthDate:              "15-01-1980",
+			RetirementAge:          60,
+			ExpectLifespan:         62,
+			CurrentSavings:         entities.Baht(100000),
+			CurrentTotalInvestment: entities.Baht(200000),
+			MonthlyIncome:          entities.Baht(50000),
+			MonthlyExpenses:        entities.Baht(30000),
+			LastRequiredFunds:      entities.Baht(1990000),
+			Status:                 "In_Progress",
+			CreatedAt:              time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC),
+		},
+		House: entities.SelectedHouse{
+			NursingHouseID: "00002",
+			CurrentMoney:   entities.Baht(7100),
+			Status:         "In_Progress",
+			NursingHouse:   entities.NursingHouse{ID: "00002", Name: "บ้านพัก", Price: entities.Baht(1000)},
+			CreatedAt:      time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Assets: []entities.Asset{
+			{ID: "asset-1", Name: "รถ", TotalCost: entities.Baht(12000), Status: "In_Progress", EndYear: "2027", CreatedAt: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: "asset-2", Name: "ทริป", TotalCost: entities.Baht(5000), CurrentMoney: entities.Baht(1000), Status: "Paused", EndYear: "2028", CreatedAt: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: "asset-3", Name: "โทรศัพท์", TotalCost: entities.Baht(3000), CurrentMoney: entities.Baht(3000), Status: "Completed", EndYear: "2026"},
+		},
+	}
+}
+
+func readinessLoans() []entities.Loan {
+	return []entities.Loan{
+		{ID: "loan-1", Name: "ผ่อนบ้าน", MonthlyExpenses: entities.Baht(2000), RemainingMonths: 10, Status: "In_Progress"},
+		{ID: "loan-2", Name: "ผ่อนมือถือ", MonthlyExpenses: entities.Baht(500), RemainingMonths: 4, Status: "Paused"},
+	}
+}
+
+func TestCalculateReadiness(t *testing.T) {
+	now := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("เงินเหลือพอจ่ายทุกเป้าหมาย", func(t *testing.T) {
+		report, err := utils.CalculateReadiness(readinessUser(), readinessLoans(), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(2034000), report.RequiredFunds)
+		assert.Equal(t, entities.Baht(311100), report.CurrentMoney)
+		assert.Equal(t, entities.Baht(22000), report.OutstandingLoans)
+		assert.Equal(t, entities.Baht(2000), report.LoanPayments)
+		assert.Equal(t, entities.Baht(11100), report.MonthlyRequired)
+		assert.Equal(t, entities.Baht(20000), report.MonthlySurplus)
+		assert.Equal(t, 0.14, report.FundedRatio)
+		assert.Equal(t, 1.0, report.Affordability)
+		assert.Equal(t, 57, report.Score)
+		assert.Equal(t, entities.Money(0), report.MonthlyShortfall)
+
+		assert.Len(t, report.Gaps, 4)
+		retirement := report.Gaps[0]
+		assert.Equal(t, entities.ReadinessGoalRetirement, retirement.Goal)
+		assert.Equal(t, 169, retirement.MonthsRemaining)
+		assert.Equal(t, entities.Baht(1690000), retirement.Remaining)
+		assert.Equal(t, entities.Baht(10000), retirement.MonthlyRequired)
+		assert.False(t, retirement.BehindSchedule)
+
+		car := report.Gaps[2]
+		assert.Equal(t, "asset-1", car.ID)
+		assert.Equal(t, 12, car.MonthsRemaining)
+		assert.Equal(t, entities.Baht(6000), car.Expected)
+		assert.Equal(t, entities.Baht(6000), car.BehindBy)
+		assert.True(t, car.BehindSchedule)
+		assert.Equal(t, entities.Baht(1000), car.MonthlyRequired)
+		assert.Equal(t, entities.Money(0), report.Gaps[3].MonthlyRequired)
+
+		assert.Equal(t, []entities.SurplusAllocation{
+			{Goal: entities.ReadinessGoalLoan, ID: "loan-1", Name: "ผ่อนบ้าน", Required: entities.Baht(2000), Amount: entities.Baht(2000)},
+			{Goal: entities.ReadinessGoalAsset, ID: "asset-1", Name: "รถ", Required: entities.Baht(1000), Amount: entities.Baht(7000)},
+			{Goal: entities.ReadinessGoalRetirement, ID: "plan-1", Name: "เกษียณ 60", Required: entities.Baht(10000), Amount: entities.Baht(10000)},
+			{Goal: entities.ReadinessGoalNursingHouse, ID: "00002", Name: "บ้านพัก", Required: entities.Baht(100), Amount: entities.Baht(100)},
+		}, report.Allocations)
+		assert.Equal(t, entities.Baht(900), report.UnallocatedSurplus)
+	})
+
+	t.Run("เงินเหลือไม่พอ จ่ายเป้าหมายที่ใกล้ถึงกำหนดก่อน", func(t *testing.T) {
+		user := readinessUser()
+		user.RetirementPlan.MonthlyIncome = entities.Baht(30000)
+		user.RetirementPlan.MonthlyExpenses = entities.Baht(25000)
+
+		report, err := utils.CalculateReadiness(user, readinessLoans(), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.38, report.Affordability)
+		assert.Equal(t, 26, report.Score)
+		assert.Equal(t, entities.Baht(8100), report.MonthlyShortfall)
+		assert.Equal(t, entities.Baht(2000), report.Allocations[0].Amount)
+		assert.Equal(t, entities.Baht(1000), report.Allocations[1].Amount)
+		assert.Equal(t, entities.Baht(2000), report.Allocations[2].Amount)
+		assert.Equal(t, entities.Money(0), report.Allocations[3].Amount)
+		assert.Equal(t, entities.Money(0), report.UnallocatedSurplus)
+	})
+
+	t.Run("ไม่มีเงินเหลือ", func(t *testing.T) {
+		user := readinessUser()
+		user.RetirementPlan.MonthlyExpenses = entities.Baht(60000)
+
+		report, err := utils.CalculateReadiness(user, nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, report.Affordability)
+		assert.Equal(t, entities.Money(0), report.UnallocatedSurplus)
+		for _, allocation := range report.Allocations {
+			assert.Equal(t, entities.Money(0), allocation.Amount)
+		}
+	})
+
+	t.Run("วันเกิดไม่ถูกต้อง", func(t *testing.T) {
+		user := readinessUser()
+		user.RetirementPlan.BirthDate = "1980-01-15"
+
+		report, err := utils.CalculateReadiness(user, nil, now)
+
+		assert.Nil(t, report)
+		assert.EqualError(t, err, "invalid BirthDate format, expected DD-MM-YYYY")
+	})
+}
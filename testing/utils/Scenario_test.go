@@ -0,0 +1,82 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func summaryUser() *entities.User {
+	return &entities.User{
+		RetirementPlan: entities.RetirementPlan{
+			BirthDate:               "15-01-1980",
+			RetirementAge:           60,
+			ExpectLifespan:          62,
+			CurrentSavings:          entities.Baht(100000),
+			CurrentTotalInvestment:  entities.Baht(50000),
+			ExpectedMonthlyExpenses: entities.Baht(10000),
+			ExpectedInflation:       3,
+		},
+		House: entities.SelectedHouse{
+			NursingHouseID: "00002",
+			CurrentMoney:   entities.Baht(4000),
+			NursingHouse:   entities.NursingHouse{ID: "00002", Price: entities.Baht(1000)},
+		},
+		Assets: []entities.Asset{
+			{Name: "รถ", TotalCost: entities.Baht(12000), CurrentMoney: entities.Baht(2000), Status: "In_Progress", EndYear: "2027"},
+			{Name: "ทริป", TotalCost: entities.Baht(5000), CurrentMoney: entities.Baht(1000), Status: "Paused", EndYear: "2027"},
+		},
+	}
+}
+
+func TestCalculatePlanSummary(t *testing.T) {
+	now := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("รวมแผนเกษียณ บ้านพัก และทรัพย์สิน", func(t *testing.T) {
+		user := summaryUser()
+		retirementFunds, _ := utils.CalculateRetirementFunds(&user.RetirementPlan, 46)
+		planMonthly, _ := utils.CalculateMonthlySavings(&user.RetirementPlan, 46, 2026, 1)
+
+		summary, err := utils.CalculatePlanSummary(user, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "00002", summary.NursingHouseID)
+		assert.Equal(t, retirementFunds, summary.RetirementFunds)
+		assert.Equal(t, entities.Baht(24000), summary.NursingHouseCost)
+		assert.Equal(t, entities.Baht(17000), summary.AssetsCost)
+		assert.Equal(t, retirementFunds+entities.Baht(41000), summary.RequiredFunds)
+		assert.Equal(t, entities.Baht(157000), summary.CurrentMoney)
+		assert.Equal(t, summary.RequiredFunds-entities.Baht(157000), summary.Shortfall)
+		assert.Equal(t, planMonthly, summary.PlanMonthlySaving)
+		assert.Equal(t, entities.Baht(118), summary.NursingHouseMonthly)
+		assert.Equal(t, entities.Baht(833), summary.AssetsMonthly)
+		assert.Equal(t, planMonthly+entities.Baht(951), summary.MonthlySaving)
+	})
+
+	t.Run("เงินเกินเป้าหมายไม่นับส่วนเกิน", func(t *testing.T) {
+		user := summaryUser()
+		user.RetirementPlan.CurrentSavings = entities.Baht(100000000)
+		user.House.CurrentMoney = entities.Baht(30000)
+		user.Assets = []entities.Asset{{TotalCost: entities.Baht(12000), CurrentMoney: entities.Baht(12000), Status: "Completed", EndYear: "2027"}}
+
+		summary, err := utils.CalculatePlanSummary(user, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, summary.RequiredFunds, summary.CurrentMoney)
+		assert.Equal(t, entities.Money(0), summary.Shortfall)
+		assert.Equal(t, entities.Money(0), summary.MonthlySaving)
+	})
+
+	t.Run("เกษียณแล้ว", func(t *testing.T) {
+		user := summaryUser()
+		user.RetirementPlan.BirthDate = "15-01-1960"
+
+		summary, err := utils.CalculatePlanSummary(user, now)
+
+		assert.Nil(t, summary)
+		assert.EqualError(t, err, "retirement age must be greater than current age")
+	})
+}
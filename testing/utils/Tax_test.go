@@ -0,0 +1,170 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func taxTable() *entities.TaxTable {
+	return &entities.TaxTable{
+		TaxYear:           2024,
+		Version:           1,
+		ExpenseRate:       50,
+		ExpenseCap:        entities.Baht(100000),
+		PersonalAllowance: entities.Baht(60000),
+		Brackets: []entities.TaxBracket{
+			{Threshold: 0, Rate: 0},
+			{Threshold: entities.Baht(150000), Rate: 5},
+			{Threshold: entities.Baht(300000), Rate: 10},
+			{Threshold: entities.Baht(500000), Rate: 15},
+			{Threshold: entities.Baht(750000), Rate: 20},
+			{Threshold: entities.Baht(1000000), Rate: 25},
+			{Threshold: entities.Baht(2000000), Rate: 30},
+			{Threshold: entities.Baht(5000000), Rate: 35},
+		},
+	}
+}
+
+func taxPlan() *entities.RetirementPlan {
+	return &entities.RetirementPlan{
+		BirthDate:         "15-01-1980",
+		RetirementAge:     60,
+		ExpectLifespan:    80,
+		MonthlyIncome:     entities.Baht(50000),
+		CurrentSavings:    entities.Baht(1000000),
+		LastRequiredFunds: entities.Baht(5000000),
+	}
+}
+
+func TestValidateTaxTable(t *testing.T) {
+	t.Run("เรียงขั้นบันไดภาษี", func(t *testing.T) {
+		table := &entities.TaxTable{
+			TaxYear: 2025,
+			Brackets: []entities.TaxBracket{
+				{Threshold: entities.Baht(300000), Rate: 10},
+				{Threshold: 0, Rate: 0},
+				{Threshold: entities.Baht(150000), Rate: 5},
+			},
+		}
+
+		assert.NoError(t, utils.ValidateTaxTable(table))
+		assert.Equal(t, entities.Money(0), table.Brackets[0].Threshold)
+		assert.Equal(t, entities.Baht(300000), table.Brackets[2].Threshold)
+	})
+
+	t.Run("ขั้นแรกไม่เริ่มที่ศูนย์", func(t *testing.T) {
+		table := &entities.TaxTable{TaxYear: 2025, Brackets: []entities.TaxBracket{{Threshold: entities.Baht(1), Rate: 5}}}
+
+		assert.EqualError(t, utils.ValidateTaxTable(table), "first tax bracket must start at zero")
+	})
+
+	t.Run("ขั้นซ้ำกัน", func(t *testing.T) {
+		table := &entities.TaxTable{TaxYear: 2025, Brackets: []entities.TaxBracket{{Rate: 0}, {Rate: 5}}}
+
+		assert.EqualError(t, utils.ValidateTaxTable(table), "tax bracket thresholds must be unique")
+	})
+
+	t.Run("อัตราภาษีไม่ถูกต้อง", func(t *testing.T) {
+		table := &entities.TaxTable{TaxYear: 2025, Brackets: []entities.TaxBracket{{Rate: 120}}}
+
+		assert.EqualError(t, utils.ValidateTaxTable(table), "tax rate must be between 0 and 100")
+	})
+
+	t.Run("ไม่มีขั้นบันได", func(t *testing.T) {
+		assert.EqualError(t, utils.ValidateTaxTable(&entities.TaxTable{TaxYear: 2025}), "tax table must have at least one bracket")
+	})
+}
+
+func TestIncomeTax(t *testing.T) {
+	table := taxTable()
+
+	assert.Equal(t, entities.Money(0), utils.IncomeTax(table, entities.Baht(150000)))
+	assert.Equal(t, entities.Baht(27500), utils.IncomeTax(table, entities.Baht(500000)))
+	assert.Equal(t, entities.Baht(115000), utils.IncomeTax(table, entities.Baht(1000000)))
+}
+
+func TestEstimateTax(t *testing.T) {
+	estimate := utils.EstimateTax(taxTable(), 2025, entities.Baht(600000), 0, 0)
+
+	assert.Equal(t, 2025, estimate.TaxYear)
+	assert.Equal(t, 2024, estimate.TableYear)
+	assert.Equal(t, entities.Baht(100000), estimate.ExpenseDeduction)
+	assert.Equal(t, entities.Baht(440000), estimate.TaxableIncome)
+	assert.Equal(t, entities.Baht(21500), estimate.Tax)
+	assert.Equal(t, 3.58, estimate.EffectiveRate)
+	assert.Equal(t, 10.0, estimate.MarginalRate)
+}
+
+func TestOptimizeTax(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("ยังไม่มีการลงทุน", func(t *testing.T) {
+		result, err := utils.OptimizeTax(taxTable(), taxPlan(), 2024, 0, nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(21500), result.Estimate.Tax)
+		assert.Len(t, result.Suggestions, 3)
+		assert.Equal(t, entities.VehicleTypeThaiESG, result.Suggestions[0].VehicleType)
+		assert.Equal(t, entities.Baht(180000), result.Suggestions[0].Contribution)
+		assert.Equal(t, entities.Baht(16000), result.Suggestions[0].TaxSaving)
+		assert.Equal(t, entities.Baht(180000), result.Suggestions[1].Room)
+		assert.Equal(t, entities.Baht(110000), result.Suggestions[1].Contribution)
+		assert.Equal(t, entities.Baht(5500), result.Suggestions[1].TaxSaving)
+		assert.Equal(t, entities.Money(0), result.Suggestions[2].Contribution)
+		assert.Equal(t, entities.Baht(290000), result.TotalContribution)
+		assert.Equal(t, entities.Money(0), result.OptimizedTax)
+		assert.Equal(t, entities.Baht(21500), result.TotalSaving)
+
+		assert.Equal(t, entities.Baht(1792), result.Redirect.MonthlySaving)
+		assert.Equal(t, 187, result.Redirect.MonthsToRetirement)
+		assert.Equal(t, entities.Baht(335104), result.Redirect.ProjectedValue)
+		assert.Equal(t, entities.Baht(4000000), result.Redirect.RetirementRemaining)
+		assert.Equal(t, entities.Baht(21390), result.Redirect.RetirementMonthlyRequired)
+		assert.Equal(t, 0.08, result.Redirect.CoveredRatio)
+	})
+
+	t.Run("นับเงินที่ลงทุนแล้วในปีภาษี", func(t *testing.T) {
+		contributions := []entities.History{
+			vehicleDeposit(entities.VehicleTypeRMF, "RMF", 100000, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)),
+			vehicleDeposit(entities.VehicleTypeRMF, "RMF", 100000, time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)),
+			vehicleDeposit(entities.VehicleTypeSSF, "SSF", 50000, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)),
+		}
+
+		result, err := utils.OptimizeTax(taxTable(), taxPlan(), 2024, 0, contributions, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(150000), result.Estimate.VehicleDeductions)
+		assert.Equal(t, entities.Baht(7000), result.Estimate.Tax)
+		assert.Equal(t, entities.Baht(140000), result.Suggestions[0].Contribution)
+		assert.Equal(t, entities.Baht(100000), result.Suggestions[1].Contributed)
+		assert.Equal(t, entities.Baht(80000), result.Suggestions[1].Room)
+		assert.Equal(t, entities.Money(0), result.Suggestions[1].Contribution)
+		assert.Equal(t, entities.Baht(7000), result.TotalSaving)
+	})
+
+	t.Run("หักประกันสังคมไม่เกินเพดาน", func(t *testing.T) {
+		contributions := []entities.History{
+			vehicleDeposit(entities.VehicleTypeSocialSecurity, "ประกันสังคม", 6000, time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)),
+			vehicleDeposit(entities.VehicleTypeSocialSecurity, "ประกันสังคม", 4000, time.Date(2024, time.May, 31, 0, 0, 0, 0, time.UTC)),
+		}
+
+		result, err := utils.OptimizeTax(taxTable(), taxPlan(), 2024, 0, contributions, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(9000), result.Estimate.VehicleDeductions)
+		assert.Equal(t, entities.Baht(20600), result.Estimate.Tax)
+	})
+
+	t.Run("หักลดหย่อนเองจนไม่ต้องเสียภาษี", func(t *testing.T) {
+		result, err := utils.OptimizeTax(taxTable(), taxPlan(), 2024, entities.Baht(300000), nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Money(0), result.Estimate.Tax)
+		assert.Equal(t, entities.Money(0), result.TotalContribution)
+		assert.Equal(t, entities.Money(0), result.Redirect.MonthlySaving)
+	})
+}
@@ -0,0 +1,113 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitionTransaction(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     entities.TransactionStatus
+		to       entities.TransactionStatus
+		expected bool
+	}{
+		{"ถึงกำหนดเป็นค้างชำระ", entities.TransactionDue, entities.TransactionOverdue, true},
+		{"ถึงกำหนดเป็นชำระแล้ว", entities.TransactionDue, entities.TransactionPaid, true},
+		{"ถึงกำหนดเป็นหยุดพัก", entities.TransactionDue, entities.TransactionPaused, true},
+		{"ค้างชำระเป็นชำระแล้ว", entities.TransactionOverdue, entities.TransactionPaid, true},
+		{"หยุดพักกลับมาถึงกำหนด", entities.TransactionPaused, entities.TransactionDue, true},
+		{"ชำระแล้วกลับไปถึงกำหนด", entities.TransactionPaid, entities.TransactionDue, false},
+		{"ค้างชำระเป็นหยุดพัก", entities.TransactionOverdue, entities.TransactionPaused, false},
+		{"หยุดพักเป็นชำระแล้ว", entities.TransactionPaused, entities.TransactionPaid, false},
+		{"สถานะเดิม", entities.TransactionDue, entities.TransactionDue, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := &entities.Transaction{Status: tt.from}
+
+			err := utils.TransitionTransaction(transaction, tt.to)
+
+			if tt.expected {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.to, transaction.Status)
+			} else {
+				assert.EqualError(t, err, "invalid transaction status transition")
+				assert.Equal(t, tt.from, transaction.Status)
+			}
+		})
+	}
+}
+
+func TestTransactionStatusLabel(t *testing.T) {
+	assert.Equal(t, "ค้างชำระ", utils.TransactionStatusLabel(entities.TransactionOverdue, utils.LocaleThai))
+	assert.Equal(t, "Paused", utils.TransactionStatusLabel(entities.TransactionPaused, utils.LocaleEnglish))
+	assert.Equal(t, "ชำระแล้ว", utils.TransactionStatusLabel(entities.TransactionPaid, "fr"))
+}
+
+func TestBillingDueDate(t *testing.T) {
+	assert.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), utils.BillingDueDate(time.Date(2024, time.February, 1, 9, 30, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), utils.BillingDueDate(time.Date(2024, time.December, 31, 23, 0, 0, 0, time.UTC)))
+}
+
+func TestIsOverdue(t *testing.T) {
+	now := time.Date(2024, time.November, 1, 9, 0, 0, 0, time.Local)
+
+	assert.False(t, utils.IsOverdue(&entities.Transaction{DueDate: time.Date(2024, time.November, 17, 0, 0, 0, 0, time.Local)}, now))
+	assert.False(t, utils.IsOverdue(&entities.Transaction{DueDate: time.Date(2024, time.November, 1, 0, 0, 0, 0, time.Local)}, now))
+	assert.True(t, utils.IsOverdue(&entities.Transaction{DueDate: time.Date(2024, time.October, 31, 0, 0, 0, 0, time.Local)}, now))
+}
+
+func TestPaymentStatistics(t *testing.T) {
+	due := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	at := func(days int, hour int) *time.Time {
+		paid := due.AddDate(0, 0, days).Add(time.Duration(hour) * time.Hour)
+		return &paid
+	}
+
+	t.Run("ชำระในวันครบกำหนดถือว่าตรงเวลา", func(t *testing.T) {
+		stats := utils.PaymentStatistics([]entities.Transaction{
+			{Status: entities.TransactionPaid, DueDate: due, PaidAt: at(0, 23), PaidAmount: entities.Baht(1000)},
+			{Status: entities.TransactionPaid, DueDate: due, PaidAt: at(-3, 0), PaidAmount: entities.Baht(1000)},
+		})
+
+		assert.Equal(t, 2, stats.OnTime)
+		assert.Equal(t, 0, stats.Late)
+		assert.Equal(t, 100.0, stats.OnTimeRate)
+		assert.Equal(t, entities.Baht(2000), stats.TotalPaid)
+	})
+
+	t.Run("ชำระล่าช้า", func(t *testing.T) {
+		stats := utils.PaymentStatistics([]entities.Transaction{
+			{Status: entities.TransactionPaid, DueDate: due, PaidAt: at(1, 1)},
+			{Status: entities.TransactionPaid, DueDate: due, PaidAt: at(4, 12)},
+			{Status: entities.TransactionPaid, DueDate: due, PaidAt: at(0, 8)},
+		})
+
+		assert.Equal(t, 1, stats.OnTime)
+		assert.Equal(t, 2, stats.Late)
+		assert.Equal(t, 33.33, stats.OnTimeRate)
+		assert.Equal(t, 2.5, stats.AverageDaysLate)
+	})
+
+	t.Run("ไม่นับรายการที่ไม่มีวันชำระ", func(t *testing.T) {
+		stats := utils.PaymentStatistics([]entities.Transaction{
+			{Status: entities.TransactionPaid, PaidAmount: entities.Baht(500)},
+			{Status: entities.TransactionDue, DueDate: due},
+			{Status: entities.TransactionOverdue, DueDate: due},
+			{Status: entities.TransactionPaused},
+		})
+
+		assert.Equal(t, 1, stats.Paid)
+		assert.Equal(t, 0, stats.OnTime+stats.Late)
+		assert.Equal(t, 1, stats.Due)
+		assert.Equal(t, 1, stats.Overdue)
+		assert.Equal(t, 0.0, stats.OnTimeRate)
+		assert.Equal(t, entities.Baht(500), stats.TotalPaid)
+	})
+}
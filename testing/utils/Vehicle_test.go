@@ -0,0 +1,226 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XzerozZ/Kasian_Phrom_BE/modules/entities"
+	"github.com/XzerozZ/Kasian_Phrom_BE/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func vehiclePlan() *entities.RetirementPlan {
+	return &entities.RetirementPlan{
+		BirthDate:      "15-01-1980",
+		RetirementAge:  60,
+		ExpectLifespan: 80,
+		MonthlyIncome:  entities.Baht(50000),
+	}
+}
+
+func vehicleDeposit(category, name string, money int64, date time.Time) entities.History {
+	return entities.History{Method: "deposit", Type: entities.HistoryTypeVehicle, Category: category, Name: name, Money: entities.Baht(money), TrackDate: date}
+}
+
+func TestGetVehicleRule(t *testing.T) {
+	t.Run("ประเภทที่รองรับ", func(t *testing.T) {
+		rule, err := utils.GetVehicleRule(entities.VehicleTypeSSF)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, rule.LockYears)
+		assert.True(t, rule.SharesRetirementCap)
+		assert.Len(t, utils.VehicleRules(), 5)
+	})
+
+	t.Run("ประเภทไม่ถูกต้อง", func(t *testing.T) {
+		_, err := utils.GetVehicleRule("ltf")
+
+		assert.EqualError(t, err, "invalid vehicle type")
+	})
+}
+
+func TestAnnualContributionLimit(t *testing.T) {
+	income := entities.Baht(600000)
+	rmf, _ := utils.GetVehicleRule(entities.VehicleTypeRMF)
+	provident, _ := utils.GetVehicleRule(entities.VehicleTypeProvidentFund)
+	socialSecurity, _ := utils.GetVehicleRule(entities.VehicleTypeSocialSecurity)
+
+	assert.Equal(t, entities.Baht(180000), utils.AnnualContributionLimit(rmf, income))
+	assert.Equal(t, entities.Baht(90000), utils.AnnualContributionLimit(provident, income))
+	assert.Equal(t, entities.Baht(9000), utils.AnnualContributionLimit(socialSecurity, income))
+	assert.Equal(t, entities.Baht(500000), utils.AnnualContributionLimit(rmf, entities.Baht(5000000)))
+}
+
+func TestCheckVehicleContribution(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+	contributions := []entities.History{
+		vehicleDeposit(entities.VehicleTypeRMF, "RMF", 170000, time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC)),
+		vehicleDeposit(entities.VehicleTypeSSF, "SSF", 200000, time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC)),
+		vehicleDeposit(entities.VehicleTypeRMF, "RMF", 50000, time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)),
+		{Method: "deposit", Type: "saving_money", Category: entities.VehicleTypeRMF, Money: entities.Baht(90000), TrackDate: now},
+	}
+
+	t.Run("ฝากได้พอดีวงเงิน", func(t *testing.T) {
+		vehicle := &entities.SavingsVehicle{Type: entities.VehicleTypeRMF}
+
+		err := utils.CheckVehicleContribution(vehicle, entities.Baht(10000), entities.Baht(600000), contributions, now)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("เกินวงเงินของประเภท", func(t *testing.T) {
+		vehicle := &entities.SavingsVehicle{Type: entities.VehicleTypeRMF}
+
+		err := utils.CheckVehicleContribution(vehicle, entities.Baht(10001), entities.Baht(600000), contributions, now)
+
+		assert.EqualError(t, err, "contribution exceeds annual limit for this vehicle type")
+	})
+
+	t.Run("เกินวงเงินรวมเพื่อการเกษียณ", func(t *testing.T) {
+		vehicle := &entities.SavingsVehicle{Type: entities.VehicleTypeProvidentFund}
+
+		err := utils.CheckVehicleContribution(vehicle, entities.Baht(200000), entities.Baht(2000000), contributions, now)
+
+		assert.EqualError(t, err, "contribution exceeds combined retirement savings limit")
+	})
+
+	t.Run("ThaiESG ไม่นับรวมวงเงินเกษียณ", func(t *testing.T) {
+		vehicle := &entities.SavingsVehicle{Type: entities.VehicleTypeThaiESG}
+
+		err := utils.CheckVehicleContribution(vehicle, entities.Baht(200000), entities.Baht(2000000), contributions, now)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestVehicleWithdrawable(t *testing.T) {
+	now := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("RMF ยังไม่ถึงอายุ 55", func(t *testing.T) {
+		vehicle := &entities.SavingsVehicle{Type: entities.VehicleTypeRMF, CurrentMoney: entities.Baht(100000), OpenedAt: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}
+
+		withdrawable, unlockDate, err := utils.VehicleWithdrawable(vehicle, vehiclePlan(), nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Money(0), withdrawable)
+		assert.Equal(t, time.Date(2035, time.January, 15, 0, 0, 0, 0, time.UTC), unlockDate)
+	})
+
+	t.Run("RMF อายุเกิน 55 แต่ถือไม่ครบ 5 ปี", func(t *testing.T) {
+		plan := vehiclePlan()
+		plan.BirthDate = "15-01-1965"
+		vehicle := &entities.SavingsVehicle{Type: entities.VehicleTypeRMF, CurrentMoney: entities.Baht(100000), OpenedAt: time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)}
+
+		withdrawable, unlockDate, err := utils.VehicleWithdrawable(vehicle, plan, nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Money(0), withdrawable)
+		assert.Equal(t, time.Date(2028, time.March, 1, 0, 0, 0, 0, time.UTC), unlockDate)
+	})
+
+	t.Run("กองทุนสำรองเลี้ยงชีพครบอายุ", func(t *testing.T) {
+		plan := vehiclePlan()
+		plan.BirthDate = "15-01-1965"
+		vehicle := &entities.SavingsVehicle{Type: entities.VehicleTypeProvidentFund, CurrentMoney: entities.Baht(100000)}
+
+		withdrawable, _, err := utils.VehicleWithdrawable(vehicle, plan, nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(100000), withdrawable)
+	})
+
+	t.Run("SSF ล็อกแยกตามงวดที่ซื้อ", func(t *testing.T) {
+		vehicle := &entities.SavingsVehicle{Name: "SSF", Type: entities.VehicleTypeSSF, CurrentMoney: entities.Baht(300000), OpenedAt: time.Date(2014, time.January, 1, 0, 0, 0, 0, time.UTC)}
+		contributions := []entities.History{
+			vehicleDeposit(entities.VehicleTypeSSF, "SSF", 100000, time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)),
+			vehicleDeposit(entities.VehicleTypeSSF, "SSF", 50000, time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)),
+			vehicleDeposit(entities.VehicleTypeSSF, "SSF", 30000, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)),
+			vehicleDeposit(entities.VehicleTypeSSF, "SSF อื่น", 70000, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)),
+		}
+
+		withdrawable, unlockDate, err := utils.VehicleWithdrawable(vehicle, vehiclePlan(), contributions, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Baht(220000), withdrawable)
+		assert.Equal(t, time.Date(2034, time.February, 1, 0, 0, 0, 0, time.UTC), unlockDate)
+	})
+
+	t.Run("ThaiESG เปิดบัญชียังไม่ครบ 5 ปี", func(t *testing.T) {
+		vehicle := &entities.SavingsVehicle{Type: entities.VehicleTypeThaiESG, CurrentMoney: entities.Baht(50000), OpenedAt: time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)}
+
+		withdrawable, unlockDate, err := utils.VehicleWithdrawable(vehicle, vehiclePlan(), nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Money(0), withdrawable)
+		assert.Equal(t, time.Date(2029, time.June, 1, 0, 0, 0, 0, time.UTC), unlockDate)
+	})
+}
+
+func TestProjectVehicle(t *testing.T) {
+	now := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+	retirementMonth := time.Date(2040, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("กองทุนสำรองเลี้ยงชีพจ่ายเงินก้อนเมื่อเกษียณ", func(t *testing.T) {
+		vehicle := &entities.SavingsVehicle{ID: "vehicle-1", Type: entities.VehicleTypeProvidentFund, CurrentMoney: entities.Baht(100000), MonthlyContribution: entities.Baht(1000)}
+
+		projection, err := utils.ProjectVehicle(vehicle, vehiclePlan(), nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, retirementMonth, projection.PayoutMonth)
+		assert.Equal(t, entities.Baht(100000), projection.CurrentMoney)
+		assert.Equal(t, entities.Baht(268000), projection.ProjectedBalance)
+		assert.Equal(t, entities.Baht(268000), projection.TotalPayout)
+		assert.Equal(t, entities.Money(0), projection.MonthlyPension)
+	})
+
+	t.Run("ผลตอบแทนทบต้น", func(t *testing.T) {
+		vehicle := &entities.SavingsVehicle{Type: entities.VehicleTypeRMF, CurrentMoney: entities.Baht(100000), ExpectedReturn: 12, OpenedAt: now}
+
+		projection, err := utils.ProjectVehicle(vehicle, vehiclePlan(), nil, now)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 488711, projection.ProjectedBalance.Float64(), 10)
+	})
+
+	t.Run("ประกันสังคมได้บำนาญรายเดือน", func(t *testing.T) {
+		vehicle := &entities.SavingsVehicle{Type: entities.VehicleTypeSocialSecurity, ContributionMonths: 120, MonthlyContribution: entities.Baht(750), AverageWage: entities.Baht(20000)}
+
+		projection, err := utils.ProjectVehicle(vehicle, vehiclePlan(), nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, retirementMonth, projection.PayoutMonth)
+		assert.Equal(t, entities.Baht(5025), projection.MonthlyPension)
+		assert.Equal(t, entities.Money(0), projection.ProjectedBalance)
+		assert.Equal(t, entities.Baht(5025*240), projection.TotalPayout)
+	})
+
+	t.Run("ประกันสังคมส่งไม่ครบ 180 เดือนได้เงินก้อน", func(t *testing.T) {
+		vehicle := &entities.SavingsVehicle{Type: entities.VehicleTypeSocialSecurity, ContributionMonths: 60, CurrentMoney: entities.Baht(45000), AverageWage: entities.Baht(15000)}
+
+		projection, err := utils.ProjectVehicle(vehicle, vehiclePlan(), nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Money(0), projection.MonthlyPension)
+		assert.Equal(t, entities.Baht(45000), projection.TotalPayout)
+	})
+}
+
+func TestSummarizeVehicle(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+	vehicle := &entities.SavingsVehicle{ID: "vehicle-1", Name: "RMF", Type: entities.VehicleTypeRMF, CurrentMoney: entities.Baht(50000), OpenedAt: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	contributions := []entities.History{
+		vehicleDeposit(entities.VehicleTypeRMF, "RMF", 30000, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)),
+		vehicleDeposit(entities.VehicleTypeRMF, "RMF2", 20000, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)),
+		vehicleDeposit(entities.VehicleTypeRMF, "RMF", 20000, time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	summary, err := utils.SummarizeVehicle(vehicle, vehiclePlan(), contributions, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.Baht(180000), summary.AnnualLimit)
+	assert.Equal(t, entities.Baht(30000), summary.ContributedThisYear)
+	assert.Equal(t, entities.Baht(130000), summary.RemainingLimit)
+	assert.Equal(t, entities.Money(0), summary.Withdrawable)
+	assert.Equal(t, time.Date(2035, time.January, 15, 0, 0, 0, 0, time.UTC), summary.UnlockDate)
+	assert.Equal(t, "vehicle-1", summary.Projection.VehicleID)
+}